@@ -1,10 +1,117 @@
 package api
 
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"defs.dev/schema/core"
+)
+
+// Component defines the interface for components as deployable units.
+// A component bundles the services it provides with its configuration. The
+// services it requires are bound by a ComponentRegistry before it starts.
 type Component interface {
+	// Entity introspection
+	Name() string
+	Schema() core.ComponentSchema
+	Config() map[string]any
+
+	// Services returns the services the component provides, one per service
+	// in Schema().Provides(), matched by name
+	Services() []Service
+
+	// Bind supplies the provider of a required service, named as in Schema().Requires()
+	Bind(required string, provider Service) error
 }
 
-type ComponentSchema interface {
+// ComponentBinding records which service satisfies a requirement of a component.
+type ComponentBinding struct {
+	Component string `json:"component"`
+	Required  string `json:"required"`
+	Provider  string `json:"provider"`
+	Service   string `json:"service"`
 }
 
+// ComponentRegistry defines the interface for registries that wire components
+// together and run them.
 type ComponentRegistry interface {
+	Registry
+
+	// Component registration
+	Register(component Component) error
+	Get(name string) (Component, bool)
+	Unregister(name string) error
+
+	// Wire resolves every required service to a provided one by schema compatibility
+	Wire() ([]ComponentBinding, error)
+
+	// StartOrder returns component names in dependency order, providers first
+	StartOrder() ([]string, error)
+
+	// Lifecycle management: Start binds requirements and starts the provided
+	// services in dependency order, Stop stops them in reverse order
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// ComponentImpl implements api.Component with a fixed set of services.
+type ComponentImpl struct {
+	schema   core.ComponentSchema
+	config   map[string]any
+	services []Service
+
+	mu           sync.RWMutex
+	dependencies map[string]Service
+}
+
+// NewComponent creates a new Component providing the given services.
+func NewComponent(schema core.ComponentSchema, config map[string]any, services ...Service) *ComponentImpl {
+	return &ComponentImpl{
+		schema:       schema,
+		config:       config,
+		services:     services,
+		dependencies: make(map[string]Service),
+	}
+}
+
+// Name returns the component name
+func (c *ComponentImpl) Name() string {
+	return c.schema.Name()
+}
+
+// Schema returns the component schema
+func (c *ComponentImpl) Schema() core.ComponentSchema {
+	return c.schema
+}
+
+// Config returns the component configuration
+func (c *ComponentImpl) Config() map[string]any {
+	return c.config
+}
+
+// Services returns the services the component provides
+func (c *ComponentImpl) Services() []Service {
+	return c.services
+}
+
+// Bind supplies the provider of a required service
+func (c *ComponentImpl) Bind(required string, provider Service) error {
+	for _, service := range c.schema.Requires() {
+		if service.Name() == required {
+			c.mu.Lock()
+			c.dependencies[required] = provider
+			c.mu.Unlock()
+			return nil
+		}
+	}
+	return fmt.Errorf("component %s does not require service %s", c.Name(), required)
+}
+
+// Dependency returns the provider bound to a required service
+func (c *ComponentImpl) Dependency(required string) (Service, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	service, exists := c.dependencies[required]
+	return service, exists
 }
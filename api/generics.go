@@ -46,6 +46,11 @@ type OptionalSchema[T any] interface {
 type ResultBuilder[T, E any] interface {
 	core.Builder[core.Schema]
 	core.MetadataBuilder[ResultBuilder[T, E]]
+
+	Ok(successSchema core.Schema) ResultBuilder[T, E]
+	Err(errorSchema core.Schema) ResultBuilder[T, E]
+	OkExample(value T) ResultBuilder[T, E]
+	ErrExample(err E) ResultBuilder[T, E]
 }
 
 // ResultSchema defines the interface for result schemas (success/failure patterns).
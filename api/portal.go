@@ -118,6 +118,9 @@ type WebSocketPortal interface {
 
 	// ListClients returns all connected client IDs
 	ListClients() []string
+
+	// ApplyTopic makes a topic available for publishing and subscribing by clients
+	ApplyTopic(ctx context.Context, topic Topic) (Address, error)
 }
 
 // TestingPortal defines the interface for testing/mock portals.
@@ -89,6 +89,7 @@ type ServiceMetadata struct {
 type Factory interface {
 	CreateFunctionRegistry() FunctionRegistry
 	CreateServiceRegistry() ServiceRegistry
+	CreateComponentRegistry() ComponentRegistry
 	CreateConsumer() Consumer
 }
 
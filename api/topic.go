@@ -1 +1,86 @@
 package api
+
+import (
+	"context"
+
+	"defs.dev/schema/core"
+)
+
+// Message is a single message published to a topic.
+type Message struct {
+	Key     any            `json:"key,omitempty"`
+	Payload any            `json:"payload"`
+	Headers map[string]any `json:"headers,omitempty"`
+}
+
+// ToMap returns the message in the form validated against its topic schema.
+func (m Message) ToMap() map[string]any {
+	result := map[string]any{"payload": m.Payload}
+	if m.Key != nil {
+		result["key"] = m.Key
+	}
+	if m.Headers != nil {
+		result["headers"] = m.Headers
+	}
+	return result
+}
+
+// OverflowPolicy decides what happens when a subscriber's buffer is full.
+type OverflowPolicy string
+
+const (
+	// OverflowBlock makes publishers wait until the subscriber catches up.
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDropNewest discards the message being published.
+	OverflowDropNewest OverflowPolicy = "drop_newest"
+	// OverflowDropOldest discards the oldest buffered message to make room.
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+)
+
+// SubscribeOptions configures a subscription to a topic.
+type SubscribeOptions struct {
+	// Keys limits delivery to messages with one of these keys. Empty means all messages.
+	Keys []any `json:"keys,omitempty"`
+
+	// BufferSize is the number of undelivered messages held for the subscriber.
+	BufferSize int `json:"bufferSize,omitempty"`
+
+	// Overflow decides what happens when the buffer is full. Defaults to OverflowBlock.
+	Overflow OverflowPolicy `json:"overflow,omitempty"`
+}
+
+// Subscription receives the messages of a topic.
+type Subscription interface {
+	// Messages returns the channel messages are delivered on. It is closed when the subscription ends.
+	Messages() <-chan Message
+
+	// Dropped returns the number of messages discarded because the buffer was full.
+	Dropped() int64
+
+	// Close ends the subscription.
+	Close() error
+}
+
+// Topic defines the interface for topics as entities that messages are published to.
+// This creates symmetry with the Function and Service interfaces.
+type Topic interface {
+	// Entity introspection
+	Name() string
+	Schema() core.TopicSchema
+
+	// Publish validates a message against the topic schema and delivers it to every matching subscription
+	Publish(ctx context.Context, message Message) error
+
+	// Subscribe starts receiving messages until the subscription is closed or ctx is done
+	Subscribe(ctx context.Context, options SubscribeOptions) (Subscription, error)
+}
+
+// TopicBroker defines the interface for brokers that manage topics.
+type TopicBroker interface {
+	Registry
+
+	// Topic management
+	CreateTopic(schema core.TopicSchema) (Topic, error)
+	GetTopic(name string) (Topic, bool)
+	DeleteTopic(name string) error
+}
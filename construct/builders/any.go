@@ -0,0 +1,75 @@
+package builders
+
+import (
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+)
+
+// AnyBuilder provides a fluent interface for building AnySchema instances.
+// It implements core.AnySchemaBuilder interface and returns core.AnySchema.
+type AnyBuilder struct {
+	config schemas.AnySchemaConfig
+}
+
+// Ensure AnyBuilder implements the API interface at compile time
+var _ core.AnySchemaBuilder = (*AnyBuilder)(nil)
+
+// NewAnySchema creates a new AnyBuilder for creating schemas that accept any value.
+func NewAnySchema() core.AnySchemaBuilder {
+	return &AnyBuilder{
+		config: schemas.AnySchemaConfig{
+			Metadata: core.SchemaMetadata{},
+		},
+	}
+}
+
+// Build returns the constructed AnySchema as a core.AnySchema.
+func (b *AnyBuilder) Build() core.AnySchema {
+	return schemas.NewAnySchema(b.config)
+}
+
+// Description sets the description metadata.
+func (b *AnyBuilder) Description(desc string) core.AnySchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Description = desc
+	return clone
+}
+
+// Name sets the name metadata.
+func (b *AnyBuilder) Name(name string) core.AnySchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Name = name
+	return clone
+}
+
+// Tag adds a tag to the metadata.
+func (b *AnyBuilder) Tag(tag string) core.AnySchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Tags = append(clone.config.Metadata.Tags, tag)
+	return clone
+}
+
+// Example adds an example value to the metadata.
+func (b *AnyBuilder) Example(example any) core.AnySchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Examples = append(clone.config.Metadata.Examples, example)
+	return clone
+}
+
+// clone creates a deep copy of the builder to ensure immutability.
+func (b *AnyBuilder) clone() *AnyBuilder {
+	newConfig := b.config
+
+	// Deep copy slices
+	if b.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(b.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, b.config.Metadata.Examples)
+	}
+
+	if b.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(b.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, b.config.Metadata.Tags)
+	}
+
+	return &AnyBuilder{config: newConfig}
+}
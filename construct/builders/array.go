@@ -104,6 +104,21 @@ func (b *ArrayBuilder) Contains(schema core.Schema) core.ArraySchemaBuilder {
 	return clone
 }
 
+// PrefixItems sets the schemas for the leading positions of the array.
+func (b *ArrayBuilder) PrefixItems(schemas ...core.Schema) core.ArraySchemaBuilder {
+	clone := b.clone()
+	clone.config.PrefixItems = make([]core.Schema, len(schemas))
+	copy(clone.config.PrefixItems, schemas)
+	return clone
+}
+
+// Rest sets the schema for items following the prefix items.
+func (b *ArrayBuilder) Rest(schema core.Schema) core.ArraySchemaBuilder {
+	clone := b.clone()
+	clone.config.RestItems = schema
+	return clone
+}
+
 // Length sets both minimum and maximum items to the same value (fixed length).
 func (b *ArrayBuilder) Length(length int) core.ArraySchemaBuilder {
 	clone := b.clone()
@@ -183,6 +198,13 @@ func (b *ArrayBuilder) Tuple(length int) core.ArraySchemaBuilder {
 		Description("Fixed-length tuple")
 }
 
+// TupleOf creates a tuple with one schema per position.
+// The tuple is closed unless a rest schema is added with Rest.
+func (b *ArrayBuilder) TupleOf(schemas ...core.Schema) core.ArraySchemaBuilder {
+	return b.PrefixItems(schemas...).
+		Description("Tuple")
+}
+
 // LimitedList creates a list with reasonable size constraints.
 func (b *ArrayBuilder) LimitedList(maxItems int) core.ArraySchemaBuilder {
 	return b.Range(0, maxItems).
@@ -0,0 +1,103 @@
+package builders
+
+import (
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+)
+
+// ComponentBuilder provides a fluent interface for building ComponentSchema instances.
+// It implements core.ComponentSchemaBuilder interface and returns core.ComponentSchema.
+type ComponentBuilder struct {
+	config schemas.ComponentSchemaConfig
+}
+
+// Ensure ComponentBuilder implements the API interface at compile time
+var _ core.ComponentSchemaBuilder = (*ComponentBuilder)(nil)
+
+// NewComponentSchema creates a new ComponentBuilder for creating component schemas.
+func NewComponentSchema() core.ComponentSchemaBuilder {
+	return &ComponentBuilder{
+		config: schemas.ComponentSchemaConfig{
+			Metadata: core.SchemaMetadata{},
+		},
+	}
+}
+
+// Build returns the constructed ComponentSchema as a core.ComponentSchema.
+func (b *ComponentBuilder) Build() core.ComponentSchema {
+	return schemas.NewComponentSchema(b.config)
+}
+
+// Description sets the description metadata.
+func (b *ComponentBuilder) Description(desc string) core.ComponentSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Description = desc
+	return clone
+}
+
+// Name sets the component name.
+func (b *ComponentBuilder) Name(name string) core.ComponentSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Name = name
+	return clone
+}
+
+// Tag adds a tag to the metadata.
+func (b *ComponentBuilder) Tag(tag string) core.ComponentSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Tags = append(clone.config.Metadata.Tags, tag)
+	return clone
+}
+
+// Provides adds services the component provides.
+func (b *ComponentBuilder) Provides(services ...core.ServiceSchema) core.ComponentSchemaBuilder {
+	clone := b.clone()
+	clone.config.Provides = append(clone.config.Provides, services...)
+	return clone
+}
+
+// Requires adds services the component needs from other components.
+func (b *ComponentBuilder) Requires(services ...core.ServiceSchema) core.ComponentSchemaBuilder {
+	clone := b.clone()
+	clone.config.Requires = append(clone.config.Requires, services...)
+	return clone
+}
+
+// Publishes adds topics the component publishes to.
+func (b *ComponentBuilder) Publishes(topics ...core.TopicSchema) core.ComponentSchemaBuilder {
+	clone := b.clone()
+	clone.config.Publishes = append(clone.config.Publishes, topics...)
+	return clone
+}
+
+// Subscribes adds topics the component subscribes to.
+func (b *ComponentBuilder) Subscribes(topics ...core.TopicSchema) core.ComponentSchemaBuilder {
+	clone := b.clone()
+	clone.config.Subscribes = append(clone.config.Subscribes, topics...)
+	return clone
+}
+
+// Config sets the schema of the component configuration.
+func (b *ComponentBuilder) Config(schema core.Schema) core.ComponentSchemaBuilder {
+	clone := b.clone()
+	clone.config.Config = schema
+	return clone
+}
+
+// clone creates a deep copy of the builder to ensure immutability.
+func (b *ComponentBuilder) clone() *ComponentBuilder {
+	newConfig := b.config
+
+	// Deep copy slices
+	if b.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(b.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, b.config.Metadata.Tags)
+	}
+
+	newConfig.Provides = append([]core.ServiceSchema(nil), b.config.Provides...)
+	newConfig.Requires = append([]core.ServiceSchema(nil), b.config.Requires...)
+	newConfig.Publishes = append([]core.TopicSchema(nil), b.config.Publishes...)
+	newConfig.Subscribes = append([]core.TopicSchema(nil), b.config.Subscribes...)
+
+	return &ComponentBuilder{config: newConfig}
+}
@@ -52,6 +52,15 @@ func (b *FunctionSchemaBuilder) Error(schema core.Schema) core.FunctionSchemaBui
 	return b
 }
 
+// Returns declares a single required "result" output holding the given result
+// schema. Its error branch becomes the function's error schema.
+func (b *FunctionSchemaBuilder) Returns(result core.ResultSchema) core.FunctionSchemaBuilder {
+	arg := schemas.NewArgSchemaWithOptions("result", result, "", false, nil)
+	b.outputs.AddArg(arg)
+	b.errors = result.ErrorSchema()
+	return b
+}
+
 func (b *FunctionSchemaBuilder) RequiredInputs(names ...string) core.FunctionSchemaBuilder {
 	// Mark specified inputs as required
 	for _, name := range names {
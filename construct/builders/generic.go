@@ -0,0 +1,85 @@
+package builders
+
+import (
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+)
+
+// GenericBuilder provides a fluent interface for building GenericSchema instances.
+// It implements core.GenericSchemaBuilder interface and returns core.GenericSchema.
+type GenericBuilder struct {
+	config schemas.GenericSchemaConfig
+}
+
+// Ensure GenericBuilder implements the API interface at compile time
+var _ core.GenericSchemaBuilder = (*GenericBuilder)(nil)
+
+// NewGenericSchema creates a new GenericBuilder for creating schema templates.
+func NewGenericSchema() core.GenericSchemaBuilder {
+	return &GenericBuilder{
+		config: schemas.GenericSchemaConfig{
+			Metadata: core.SchemaMetadata{},
+		},
+	}
+}
+
+// Build returns the constructed GenericSchema as a core.GenericSchema.
+func (b *GenericBuilder) Build() core.GenericSchema {
+	return schemas.NewGenericSchema(b.config)
+}
+
+// Description sets the description metadata.
+func (b *GenericBuilder) Description(desc string) core.GenericSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Description = desc
+	return clone
+}
+
+// Name sets the name metadata.
+func (b *GenericBuilder) Name(name string) core.GenericSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Name = name
+	return clone
+}
+
+// Tag adds a tag to the metadata.
+func (b *GenericBuilder) Tag(tag string) core.GenericSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Tags = append(clone.config.Metadata.Tags, tag)
+	return clone
+}
+
+// TypeParameters sets the parameters of the template, in declaration order.
+func (b *GenericBuilder) TypeParameters(params ...core.TypeParameterSchema) core.GenericSchemaBuilder {
+	clone := b.clone()
+	clone.config.TypeParameters = make([]core.TypeParameterSchema, len(params))
+	copy(clone.config.TypeParameters, params)
+	return clone
+}
+
+// Template sets the schema body that refers to the type parameters.
+func (b *GenericBuilder) Template(template core.Schema) core.GenericSchemaBuilder {
+	clone := b.clone()
+	clone.config.Template = template
+	return clone
+}
+
+// clone creates a deep copy of the builder to ensure immutability.
+func (b *GenericBuilder) clone() *GenericBuilder {
+	newConfig := b.config
+
+	// Deep copy slices
+	if b.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(b.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, b.config.Metadata.Examples)
+	}
+
+	if b.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(b.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, b.config.Metadata.Tags)
+	}
+
+	// Note: TypeParameters is replaced wholesale, and the template is not deeply
+	// cloned as it should be immutable
+	return &GenericBuilder{config: newConfig}
+}
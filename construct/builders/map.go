@@ -0,0 +1,170 @@
+package builders
+
+import (
+	"defs.dev/schema/api"
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+)
+
+// MapBuilder provides a fluent interface for building MapSchema instances.
+// It implements core.MapSchemaBuilder interface and returns core.MapSchema.
+type MapBuilder struct {
+	config schemas.MapSchemaConfig
+}
+
+// Ensure MapBuilder implements the API interface at compile time
+var _ core.MapSchemaBuilder = (*MapBuilder)(nil)
+var _ api.MapBuilder[string, any] = (*TypedMapBuilder[string, any])(nil)
+var _ api.MapSchema[string, any] = (*schemas.MapSchema)(nil)
+
+// NewMapSchema creates a new MapBuilder for creating map schemas.
+func NewMapSchema() core.MapSchemaBuilder {
+	return &MapBuilder{
+		config: schemas.MapSchemaConfig{
+			Metadata: core.SchemaMetadata{},
+		},
+	}
+}
+
+// Build returns the constructed MapSchema as a core.MapSchema.
+func (b *MapBuilder) Build() core.MapSchema {
+	return schemas.NewMapSchema(b.config)
+}
+
+// Description sets the description metadata.
+func (b *MapBuilder) Description(desc string) core.MapSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Description = desc
+	return clone
+}
+
+// Name sets the name metadata.
+func (b *MapBuilder) Name(name string) core.MapSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Name = name
+	return clone
+}
+
+// Tag adds a tag to the metadata.
+func (b *MapBuilder) Tag(tag string) core.MapSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Tags = append(clone.config.Metadata.Tags, tag)
+	return clone
+}
+
+// Keys sets the schema that every key must satisfy.
+func (b *MapBuilder) Keys(keySchema core.Schema) core.MapSchemaBuilder {
+	clone := b.clone()
+	clone.config.KeySchema = keySchema
+	return clone
+}
+
+// Values sets the schema that every value must satisfy.
+func (b *MapBuilder) Values(valueSchema core.Schema) core.MapSchemaBuilder {
+	clone := b.clone()
+	clone.config.ValueSchema = valueSchema
+	return clone
+}
+
+// MinItems sets the minimum number of entries.
+func (b *MapBuilder) MinItems(min int) core.MapSchemaBuilder {
+	clone := b.clone()
+	clone.config.MinItems = &min
+	return clone
+}
+
+// MaxItems sets the maximum number of entries.
+func (b *MapBuilder) MaxItems(max int) core.MapSchemaBuilder {
+	clone := b.clone()
+	clone.config.MaxItems = &max
+	return clone
+}
+
+// Example adds an example value to the metadata.
+func (b *MapBuilder) Example(example map[string]any) core.MapSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Examples = append(clone.config.Metadata.Examples, example)
+	return clone
+}
+
+// KeyPattern requires string keys matching the given regular expression.
+func (b *MapBuilder) KeyPattern(pattern string) core.MapSchemaBuilder {
+	return b.Keys(NewStringSchema().Pattern(pattern).Build())
+}
+
+// KeyEnum restricts keys to the given set of strings.
+func (b *MapBuilder) KeyEnum(values ...string) core.MapSchemaBuilder {
+	return b.Keys(NewStringSchema().Enum(values...).Build())
+}
+
+// IntegerKeys requires keys to be integers (or strings holding integers).
+func (b *MapBuilder) IntegerKeys() core.MapSchemaBuilder {
+	return b.Keys(NewIntegerSchema().Build())
+}
+
+// clone creates a deep copy of the builder to ensure immutability.
+func (b *MapBuilder) clone() *MapBuilder {
+	newConfig := b.config
+
+	// Deep copy slices
+	if b.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(b.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, b.config.Metadata.Examples)
+	}
+
+	if b.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(b.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, b.config.Metadata.Tags)
+	}
+
+	// Note: KeySchema and ValueSchema are not deeply cloned as they should be immutable
+	return &MapBuilder{config: newConfig}
+}
+
+// TypedMapBuilder builds Map[K, V] schemas. The type parameters describe the
+// Go types of the keys and values.
+type TypedMapBuilder[K comparable, V any] struct {
+	builder *MapBuilder
+}
+
+// NewTypedMapSchema creates a new TypedMapBuilder for Map[K, V] schemas whose
+// keys and values satisfy the given schemas. A nil schema leaves keys or
+// values unconstrained.
+func NewTypedMapSchema[K comparable, V any](keySchema, valueSchema core.Schema) api.MapBuilder[K, V] {
+	return &TypedMapBuilder[K, V]{builder: NewMapSchema().Keys(keySchema).Values(valueSchema).(*MapBuilder)}
+}
+
+// Build returns the constructed MapSchema.
+func (b *TypedMapBuilder[K, V]) Build() core.Schema {
+	return b.builder.Build()
+}
+
+// Description sets the description metadata.
+func (b *TypedMapBuilder[K, V]) Description(desc string) api.MapBuilder[K, V] {
+	return b.with(b.builder.Description(desc))
+}
+
+// Name sets the name metadata.
+func (b *TypedMapBuilder[K, V]) Name(name string) api.MapBuilder[K, V] {
+	return b.with(b.builder.Name(name))
+}
+
+// Tag adds a tag to the metadata.
+func (b *TypedMapBuilder[K, V]) Tag(tag string) api.MapBuilder[K, V] {
+	return b.with(b.builder.Tag(tag))
+}
+
+// MinItems sets the minimum number of entries.
+func (b *TypedMapBuilder[K, V]) MinItems(min int) api.MapBuilder[K, V] {
+	return b.with(b.builder.MinItems(min))
+}
+
+// MaxItems sets the maximum number of entries.
+func (b *TypedMapBuilder[K, V]) MaxItems(max int) api.MapBuilder[K, V] {
+	return b.with(b.builder.MaxItems(max))
+}
+
+// with wraps the next untyped builder state.
+func (b *TypedMapBuilder[K, V]) with(next core.MapSchemaBuilder) api.MapBuilder[K, V] {
+	return &TypedMapBuilder[K, V]{builder: next.(*MapBuilder)}
+}
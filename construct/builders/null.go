@@ -0,0 +1,68 @@
+package builders
+
+import (
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+)
+
+// NullBuilder provides a fluent interface for building NullSchema instances.
+// It implements core.NullSchemaBuilder interface and returns core.NullSchema.
+type NullBuilder struct {
+	config schemas.NullSchemaConfig
+}
+
+// Ensure NullBuilder implements the API interface at compile time
+var _ core.NullSchemaBuilder = (*NullBuilder)(nil)
+
+// NewNullSchema creates a new NullBuilder for creating null schemas.
+func NewNullSchema() core.NullSchemaBuilder {
+	return &NullBuilder{
+		config: schemas.NullSchemaConfig{
+			Metadata: core.SchemaMetadata{},
+		},
+	}
+}
+
+// Build returns the constructed NullSchema as a core.NullSchema.
+func (b *NullBuilder) Build() core.NullSchema {
+	return schemas.NewNullSchema(b.config)
+}
+
+// Description sets the description metadata.
+func (b *NullBuilder) Description(desc string) core.NullSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Description = desc
+	return clone
+}
+
+// Name sets the name metadata.
+func (b *NullBuilder) Name(name string) core.NullSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Name = name
+	return clone
+}
+
+// Tag adds a tag to the metadata.
+func (b *NullBuilder) Tag(tag string) core.NullSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Tags = append(clone.config.Metadata.Tags, tag)
+	return clone
+}
+
+// clone creates a deep copy of the builder to ensure immutability.
+func (b *NullBuilder) clone() *NullBuilder {
+	newConfig := b.config
+
+	// Deep copy slices
+	if b.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(b.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, b.config.Metadata.Examples)
+	}
+
+	if b.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(b.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, b.config.Metadata.Tags)
+	}
+
+	return &NullBuilder{config: newConfig}
+}
@@ -8,6 +8,9 @@ import (
 // ObjectBuilder provides a fluent API for building ObjectSchemas.
 type ObjectBuilder struct {
 	config schemas.ObjectSchemaConfig
+
+	// base is the schema being extended, if any
+	base core.ObjectSchema
 }
 
 // Ensure ObjectBuilder implements the API interface at compile time
@@ -32,6 +35,9 @@ func NewObject() core.ObjectSchemaBuilder {
 
 // Build returns the constructed ObjectSchema.
 func (b *ObjectBuilder) Build() core.ObjectSchema {
+	if b.base != nil {
+		return schemas.Extend(b.base, schemas.NewObjectSchema(b.config))
+	}
 	return schemas.NewObjectSchema(b.config)
 }
 
@@ -234,6 +240,14 @@ func (b *ObjectBuilder) DeepPartial() *ObjectBuilder {
 	return clone
 }
 
+// Extend derives the object from base. The built schema holds the properties
+// of base plus those defined on the builder, which take precedence.
+func (b *ObjectBuilder) Extend(base core.ObjectSchema) *ObjectBuilder {
+	clone := b.clone()
+	clone.base = base
+	return clone
+}
+
 // Common domain-specific helper methods
 
 // PersonExample creates a typical person object structure example.
@@ -323,5 +337,5 @@ func (b *ObjectBuilder) clone() *ObjectBuilder {
 		}
 	}
 
-	return &ObjectBuilder{config: newConfig}
+	return &ObjectBuilder{config: newConfig, base: b.base}
 }
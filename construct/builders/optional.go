@@ -0,0 +1,131 @@
+package builders
+
+import (
+	"defs.dev/schema/api"
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+)
+
+// OptionalBuilder provides a fluent interface for building OptionalSchema instances.
+// It implements core.OptionalSchemaBuilder interface and returns core.OptionalSchema.
+type OptionalBuilder struct {
+	config schemas.OptionalSchemaConfig
+}
+
+// Ensure OptionalBuilder implements the API interface at compile time
+var _ core.OptionalSchemaBuilder = (*OptionalBuilder)(nil)
+var _ api.OptionalBuilder[any] = (*TypedOptionalBuilder[any])(nil)
+var _ api.OptionalSchema[any] = (*schemas.OptionalSchema)(nil)
+
+// NewOptionalSchema creates a new OptionalBuilder for creating nullable schemas.
+func NewOptionalSchema() core.OptionalSchemaBuilder {
+	return &OptionalBuilder{
+		config: schemas.OptionalSchemaConfig{
+			Metadata: core.SchemaMetadata{},
+		},
+	}
+}
+
+// Build returns the constructed OptionalSchema as a core.OptionalSchema.
+func (b *OptionalBuilder) Build() core.OptionalSchema {
+	return schemas.NewOptionalSchema(b.config)
+}
+
+// Description sets the description metadata.
+func (b *OptionalBuilder) Description(desc string) core.OptionalSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Description = desc
+	return clone
+}
+
+// Name sets the name metadata.
+func (b *OptionalBuilder) Name(name string) core.OptionalSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Name = name
+	return clone
+}
+
+// Tag adds a tag to the metadata.
+func (b *OptionalBuilder) Tag(tag string) core.OptionalSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Tags = append(clone.config.Metadata.Tags, tag)
+	return clone
+}
+
+// Of sets the wrapped schema that non-null values must satisfy.
+func (b *OptionalBuilder) Of(itemSchema core.Schema) core.OptionalSchemaBuilder {
+	clone := b.clone()
+	clone.config.ItemSchema = itemSchema
+	return clone
+}
+
+// Example adds an example value to the metadata.
+func (b *OptionalBuilder) Example(example any) core.OptionalSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Examples = append(clone.config.Metadata.Examples, example)
+	return clone
+}
+
+// clone creates a deep copy of the builder to ensure immutability.
+func (b *OptionalBuilder) clone() *OptionalBuilder {
+	newConfig := b.config
+
+	// Deep copy slices
+	if b.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(b.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, b.config.Metadata.Examples)
+	}
+
+	if b.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(b.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, b.config.Metadata.Tags)
+	}
+
+	// Note: ItemSchema is not deeply cloned as it should be immutable
+	return &OptionalBuilder{config: newConfig}
+}
+
+// TypedOptionalBuilder builds Optional[T] schemas. The type parameter
+// describes the Go type of non-null values and makes examples type-checked.
+type TypedOptionalBuilder[T any] struct {
+	builder *OptionalBuilder
+}
+
+// NewTypedOptionalSchema creates a new TypedOptionalBuilder for Optional[T]
+// schemas whose non-null values satisfy itemSchema.
+func NewTypedOptionalSchema[T any](itemSchema core.Schema) api.OptionalBuilder[T] {
+	return &TypedOptionalBuilder[T]{builder: NewOptionalSchema().Of(itemSchema).(*OptionalBuilder)}
+}
+
+// Build returns the constructed OptionalSchema.
+func (b *TypedOptionalBuilder[T]) Build() core.Schema {
+	return b.builder.Build()
+}
+
+// Description sets the description metadata.
+func (b *TypedOptionalBuilder[T]) Description(desc string) api.OptionalBuilder[T] {
+	return b.with(b.builder.Description(desc))
+}
+
+// Name sets the name metadata.
+func (b *TypedOptionalBuilder[T]) Name(name string) api.OptionalBuilder[T] {
+	return b.with(b.builder.Name(name))
+}
+
+// Tag adds a tag to the metadata.
+func (b *TypedOptionalBuilder[T]) Tag(tag string) api.OptionalBuilder[T] {
+	return b.with(b.builder.Tag(tag))
+}
+
+// Example adds an example value to the metadata; a nil example is null.
+func (b *TypedOptionalBuilder[T]) Example(example *T) api.OptionalBuilder[T] {
+	if example == nil {
+		return b.with(b.builder.Example(nil))
+	}
+	return b.with(b.builder.Example(*example))
+}
+
+// with wraps the next untyped builder state.
+func (b *TypedOptionalBuilder[T]) with(next core.OptionalSchemaBuilder) api.OptionalBuilder[T] {
+	return &TypedOptionalBuilder[T]{builder: next.(*OptionalBuilder)}
+}
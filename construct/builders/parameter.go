@@ -0,0 +1,75 @@
+package builders
+
+import (
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+)
+
+// TypeParameterBuilder provides a fluent interface for building TypeParameterSchema instances.
+// It implements core.TypeParameterSchemaBuilder interface and returns core.TypeParameterSchema.
+type TypeParameterBuilder struct {
+	config schemas.TypeParameterSchemaConfig
+}
+
+// Ensure TypeParameterBuilder implements the API interface at compile time
+var _ core.TypeParameterSchemaBuilder = (*TypeParameterBuilder)(nil)
+
+// NewTypeParameterSchema creates a new TypeParameterBuilder for the named type parameter.
+func NewTypeParameterSchema(name string) core.TypeParameterSchemaBuilder {
+	return &TypeParameterBuilder{
+		config: schemas.TypeParameterSchemaConfig{
+			Metadata: core.SchemaMetadata{Name: name},
+		},
+	}
+}
+
+// Build returns the constructed TypeParameterSchema as a core.TypeParameterSchema.
+func (b *TypeParameterBuilder) Build() core.TypeParameterSchema {
+	return schemas.NewTypeParameterSchema(b.config)
+}
+
+// Description sets the description metadata.
+func (b *TypeParameterBuilder) Description(desc string) core.TypeParameterSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Description = desc
+	return clone
+}
+
+// Name sets the name metadata, which is also the parameter name.
+func (b *TypeParameterBuilder) Name(name string) core.TypeParameterSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Name = name
+	return clone
+}
+
+// Tag adds a tag to the metadata.
+func (b *TypeParameterBuilder) Tag(tag string) core.TypeParameterSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Tags = append(clone.config.Metadata.Tags, tag)
+	return clone
+}
+
+// Constraint sets the schema that type arguments must conform to.
+func (b *TypeParameterBuilder) Constraint(schema core.Schema) core.TypeParameterSchemaBuilder {
+	clone := b.clone()
+	clone.config.Constraint = schema
+	return clone
+}
+
+// clone creates a deep copy of the builder to ensure immutability.
+func (b *TypeParameterBuilder) clone() *TypeParameterBuilder {
+	newConfig := b.config
+
+	// Deep copy slices
+	if b.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(b.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, b.config.Metadata.Examples)
+	}
+
+	if b.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(b.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, b.config.Metadata.Tags)
+	}
+
+	return &TypeParameterBuilder{config: newConfig}
+}
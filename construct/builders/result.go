@@ -0,0 +1,149 @@
+package builders
+
+import (
+	"defs.dev/schema/api"
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+)
+
+// ResultBuilder provides a fluent interface for building ResultSchema instances.
+// It implements core.ResultSchemaBuilder interface and returns core.ResultSchema.
+type ResultBuilder struct {
+	config schemas.ResultSchemaConfig
+}
+
+// Ensure ResultBuilder implements the API interface at compile time
+var _ core.ResultSchemaBuilder = (*ResultBuilder)(nil)
+var _ api.ResultBuilder[any, error] = (*TypedResultBuilder[any, error])(nil)
+var _ api.ResultSchema[any, error] = (*schemas.ResultSchema)(nil)
+
+// NewResultSchema creates a new ResultBuilder for creating success/failure schemas.
+func NewResultSchema() core.ResultSchemaBuilder {
+	return &ResultBuilder{
+		config: schemas.ResultSchemaConfig{
+			Metadata: core.SchemaMetadata{},
+		},
+	}
+}
+
+// Build returns the constructed ResultSchema as a core.ResultSchema.
+func (b *ResultBuilder) Build() core.ResultSchema {
+	return schemas.NewResultSchema(b.config)
+}
+
+// Description sets the description metadata.
+func (b *ResultBuilder) Description(desc string) core.ResultSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Description = desc
+	return clone
+}
+
+// Name sets the name metadata.
+func (b *ResultBuilder) Name(name string) core.ResultSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Name = name
+	return clone
+}
+
+// Tag adds a tag to the metadata.
+func (b *ResultBuilder) Tag(tag string) core.ResultSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Tags = append(clone.config.Metadata.Tags, tag)
+	return clone
+}
+
+// Ok sets the schema of the success payload.
+func (b *ResultBuilder) Ok(successSchema core.Schema) core.ResultSchemaBuilder {
+	clone := b.clone()
+	clone.config.Success = successSchema
+	return clone
+}
+
+// Err sets the schema of the error payload.
+func (b *ResultBuilder) Err(errorSchema core.Schema) core.ResultSchemaBuilder {
+	clone := b.clone()
+	clone.config.Error = errorSchema
+	return clone
+}
+
+// Example adds an example value to the metadata.
+func (b *ResultBuilder) Example(example map[string]any) core.ResultSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Examples = append(clone.config.Metadata.Examples, example)
+	return clone
+}
+
+// clone creates a deep copy of the builder to ensure immutability.
+func (b *ResultBuilder) clone() *ResultBuilder {
+	newConfig := b.config
+
+	// Deep copy slices
+	if b.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(b.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, b.config.Metadata.Examples)
+	}
+
+	if b.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(b.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, b.config.Metadata.Tags)
+	}
+
+	// Note: Success and Error schemas are not deeply cloned as they should be immutable
+	return &ResultBuilder{config: newConfig}
+}
+
+// TypedResultBuilder builds Result[T, E] schemas. The type parameters describe
+// the Go types of the payloads and make examples type-checked.
+type TypedResultBuilder[T, E any] struct {
+	builder *ResultBuilder
+}
+
+// NewTypedResultSchema creates a new TypedResultBuilder for Result[T, E] schemas.
+func NewTypedResultSchema[T, E any]() api.ResultBuilder[T, E] {
+	return &TypedResultBuilder[T, E]{builder: NewResultSchema().(*ResultBuilder)}
+}
+
+// Build returns the constructed ResultSchema.
+func (b *TypedResultBuilder[T, E]) Build() core.Schema {
+	return b.builder.Build()
+}
+
+// Description sets the description metadata.
+func (b *TypedResultBuilder[T, E]) Description(desc string) api.ResultBuilder[T, E] {
+	return b.with(b.builder.Description(desc))
+}
+
+// Name sets the name metadata.
+func (b *TypedResultBuilder[T, E]) Name(name string) api.ResultBuilder[T, E] {
+	return b.with(b.builder.Name(name))
+}
+
+// Tag adds a tag to the metadata.
+func (b *TypedResultBuilder[T, E]) Tag(tag string) api.ResultBuilder[T, E] {
+	return b.with(b.builder.Tag(tag))
+}
+
+// Ok sets the schema of the success payload.
+func (b *TypedResultBuilder[T, E]) Ok(successSchema core.Schema) api.ResultBuilder[T, E] {
+	return b.with(b.builder.Ok(successSchema))
+}
+
+// Err sets the schema of the error payload.
+func (b *TypedResultBuilder[T, E]) Err(errorSchema core.Schema) api.ResultBuilder[T, E] {
+	return b.with(b.builder.Err(errorSchema))
+}
+
+// OkExample adds an example success value to the metadata.
+func (b *TypedResultBuilder[T, E]) OkExample(value T) api.ResultBuilder[T, E] {
+	return b.with(b.builder.Example(map[string]any{"ok": value}))
+}
+
+// ErrExample adds an example error value to the metadata.
+func (b *TypedResultBuilder[T, E]) ErrExample(err E) api.ResultBuilder[T, E] {
+	return b.with(b.builder.Example(map[string]any{"err": err}))
+}
+
+// with wraps the next untyped builder state.
+func (b *TypedResultBuilder[T, E]) with(next core.ResultSchemaBuilder) api.ResultBuilder[T, E] {
+	return &TypedResultBuilder[T, E]{builder: next.(*ResultBuilder)}
+}
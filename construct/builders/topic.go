@@ -0,0 +1,96 @@
+package builders
+
+import (
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+)
+
+// TopicBuilder provides a fluent interface for building TopicSchema instances.
+// It implements core.TopicSchemaBuilder interface and returns core.TopicSchema.
+type TopicBuilder struct {
+	config schemas.TopicSchemaConfig
+}
+
+// Ensure TopicBuilder implements the API interface at compile time
+var _ core.TopicSchemaBuilder = (*TopicBuilder)(nil)
+
+// NewTopicSchema creates a new TopicBuilder for creating topic schemas.
+func NewTopicSchema() core.TopicSchemaBuilder {
+	return &TopicBuilder{
+		config: schemas.TopicSchemaConfig{
+			Metadata: core.SchemaMetadata{},
+		},
+	}
+}
+
+// Build returns the constructed TopicSchema as a core.TopicSchema.
+func (b *TopicBuilder) Build() core.TopicSchema {
+	return schemas.NewTopicSchema(b.config)
+}
+
+// Description sets the description metadata.
+func (b *TopicBuilder) Description(desc string) core.TopicSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Description = desc
+	return clone
+}
+
+// Name sets the topic name.
+func (b *TopicBuilder) Name(name string) core.TopicSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Name = name
+	return clone
+}
+
+// Tag adds a tag to the metadata.
+func (b *TopicBuilder) Tag(tag string) core.TopicSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Tags = append(clone.config.Metadata.Tags, tag)
+	return clone
+}
+
+// Payload sets the schema of message payloads.
+func (b *TopicBuilder) Payload(schema core.Schema) core.TopicSchemaBuilder {
+	clone := b.clone()
+	clone.config.Payload = schema
+	return clone
+}
+
+// Key sets the schema of message keys.
+func (b *TopicBuilder) Key(schema core.Schema) core.TopicSchemaBuilder {
+	clone := b.clone()
+	clone.config.Key = schema
+	return clone
+}
+
+// Headers sets the schema of message headers, usually an object or a map of strings.
+func (b *TopicBuilder) Headers(schema core.Schema) core.TopicSchemaBuilder {
+	clone := b.clone()
+	clone.config.Headers = schema
+	return clone
+}
+
+// Example adds an example message to the metadata.
+func (b *TopicBuilder) Example(example map[string]any) core.TopicSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Examples = append(clone.config.Metadata.Examples, example)
+	return clone
+}
+
+// clone creates a deep copy of the builder to ensure immutability.
+func (b *TopicBuilder) clone() *TopicBuilder {
+	newConfig := b.config
+
+	// Deep copy slices
+	if b.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(b.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, b.config.Metadata.Examples)
+	}
+
+	if b.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(b.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, b.config.Metadata.Tags)
+	}
+
+	return &TopicBuilder{config: newConfig}
+}
@@ -0,0 +1,111 @@
+package builders
+
+import (
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+)
+
+// UnionBuilder provides a fluent interface for building UnionSchema instances.
+// It implements core.UnionSchemaBuilder interface and returns core.UnionSchema.
+type UnionBuilder struct {
+	config schemas.UnionSchemaConfig
+}
+
+// Ensure UnionBuilder implements the API interface at compile time
+var _ core.UnionSchemaBuilder = (*UnionBuilder)(nil)
+
+// NewUnionSchema creates a new UnionBuilder for creating union schemas.
+// Unions are exclusive (oneOf) by default.
+func NewUnionSchema() core.UnionSchemaBuilder {
+	return &UnionBuilder{
+		config: schemas.UnionSchemaConfig{
+			Metadata: core.SchemaMetadata{},
+			Mode:     core.UnionOneOf,
+		},
+	}
+}
+
+// Build returns the constructed UnionSchema as a core.UnionSchema.
+func (b *UnionBuilder) Build() core.UnionSchema {
+	return schemas.NewUnionSchema(b.config)
+}
+
+// Description sets the description metadata.
+func (b *UnionBuilder) Description(desc string) core.UnionSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Description = desc
+	return clone
+}
+
+// Name sets the name metadata.
+func (b *UnionBuilder) Name(name string) core.UnionSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Name = name
+	return clone
+}
+
+// Tag adds a tag to the metadata.
+func (b *UnionBuilder) Tag(tag string) core.UnionSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Tags = append(clone.config.Metadata.Tags, tag)
+	return clone
+}
+
+// Schemas appends member schemas to the union.
+func (b *UnionBuilder) Schemas(members ...core.Schema) core.UnionSchemaBuilder {
+	clone := b.clone()
+	clone.config.Schemas = append(clone.config.Schemas, members...)
+	return clone
+}
+
+// Discriminator sets the property used to select the branch for object values.
+func (b *UnionBuilder) Discriminator(property string) core.UnionSchemaBuilder {
+	clone := b.clone()
+	clone.config.Discriminator = property
+	return clone
+}
+
+// OneOf requires values to match exactly one member schema.
+func (b *UnionBuilder) OneOf() core.UnionSchemaBuilder {
+	clone := b.clone()
+	clone.config.Mode = core.UnionOneOf
+	return clone
+}
+
+// AnyOf requires values to match at least one member schema.
+func (b *UnionBuilder) AnyOf() core.UnionSchemaBuilder {
+	clone := b.clone()
+	clone.config.Mode = core.UnionAnyOf
+	return clone
+}
+
+// Example adds an example value to the metadata.
+func (b *UnionBuilder) Example(example any) core.UnionSchemaBuilder {
+	clone := b.clone()
+	clone.config.Metadata.Examples = append(clone.config.Metadata.Examples, example)
+	return clone
+}
+
+// clone creates a deep copy of the builder to ensure immutability.
+func (b *UnionBuilder) clone() *UnionBuilder {
+	newConfig := b.config
+
+	// Deep copy slices
+	if b.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(b.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, b.config.Metadata.Examples)
+	}
+
+	if b.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(b.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, b.config.Metadata.Tags)
+	}
+
+	if b.config.Schemas != nil {
+		newConfig.Schemas = make([]core.Schema, len(b.config.Schemas))
+		copy(newConfig.Schemas, b.config.Schemas)
+	}
+
+	// Note: member schemas are not deeply cloned as they should be immutable
+	return &UnionBuilder{config: newConfig}
+}
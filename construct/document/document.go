@@ -0,0 +1,156 @@
+// Package document persists schemas in a native JSON document format.
+//
+// Unlike the JSON Schema exporter, the document format is lossless: every
+// schema type, including functions, services, generics and references, is
+// written with its metadata, annotations and composition provenance, and
+// Unmarshal rebuilds an equivalent schema. Marshal output is canonical, so
+// marshalling a loaded document again yields the same bytes.
+//
+//	data, err := document.Marshal(schema)
+//	...
+//	loaded, err := document.Unmarshal(data)
+package document
+
+import (
+	"encoding/json"
+
+	"defs.dev/schema/core"
+)
+
+// Version is the version of the document format written by Marshal.
+const Version = "1"
+
+// Document is the top-level structure of a schema document. Schemas reached
+// through references are stored once under Definitions, keyed by the name
+// they are referenced by.
+type Document struct {
+	Version     string           `json:"version"`
+	Schema      *Node            `json:"schema"`
+	Definitions map[string]*Node `json:"definitions,omitempty"`
+}
+
+// Node is the document representation of a single schema. Only the fields
+// that apply to the node's type are set.
+type Node struct {
+	Type        core.SchemaType      `json:"type"`
+	Metadata    *core.SchemaMetadata `json:"metadata,omitempty"`
+	Annotations []AnnotationNode     `json:"annotations,omitempty"`
+	Composition *CompositionNode     `json:"composition,omitempty"`
+	Default     json.RawMessage      `json:"default,omitempty"`
+
+	// String
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Format    string   `json:"format,omitempty"`
+	Enum      []string `json:"enum,omitempty"`
+
+	// Number and integer
+	Minimum *json.Number `json:"minimum,omitempty"`
+	Maximum *json.Number `json:"maximum,omitempty"`
+
+	// Boolean
+	CaseInsensitive bool `json:"caseInsensitive,omitempty"`
+
+	// Array, map and optional
+	Items       *Node   `json:"items,omitempty"`
+	MinItems    *int    `json:"minItems,omitempty"`
+	MaxItems    *int    `json:"maxItems,omitempty"`
+	UniqueItems bool    `json:"uniqueItems,omitempty"`
+	Contains    *Node   `json:"contains,omitempty"`
+	PrefixItems []*Node `json:"prefixItems,omitempty"`
+	Rest        *Node   `json:"rest,omitempty"`
+	Key         *Node   `json:"key,omitempty"`
+	Value       *Node   `json:"value,omitempty"`
+
+	// Object
+	Properties           map[string]*Node    `json:"properties,omitempty"`
+	Required             []string            `json:"required,omitempty"`
+	AdditionalProperties *bool               `json:"additionalProperties,omitempty"`
+	MinProperties        *int                `json:"minProperties,omitempty"`
+	MaxProperties        *int                `json:"maxProperties,omitempty"`
+	PatternProperties    map[string]*Node    `json:"patternProperties,omitempty"`
+	Dependencies         map[string][]string `json:"dependencies,omitempty"`
+
+	// Union
+	Members       []*Node        `json:"members,omitempty"`
+	Discriminator string         `json:"discriminator,omitempty"`
+	Mode          core.UnionMode `json:"mode,omitempty"`
+
+	// Result
+	Ok  *Node `json:"ok,omitempty"`
+	Err *Node `json:"err,omitempty"`
+
+	// Reference and generic instance
+	Ref          string  `json:"ref,omitempty"`
+	RefNamespace string  `json:"refNamespace,omitempty"`
+	RefVersion   string  `json:"refVersion,omitempty"`
+	Generic      string  `json:"generic,omitempty"`
+	Arguments    []*Node `json:"arguments,omitempty"`
+
+	// Type parameter and generic
+	Constraint *Node   `json:"constraint,omitempty"`
+	Parameters []*Node `json:"parameters,omitempty"`
+	Template   *Node   `json:"template,omitempty"`
+
+	// Function
+	Inputs            *ArgsNode        `json:"inputs,omitempty"`
+	Outputs           *ArgsNode        `json:"outputs,omitempty"`
+	Errors            *Node            `json:"errors,omitempty"`
+	AdditionalInputs  bool             `json:"additionalInputs,omitempty"`
+	AdditionalOutputs bool             `json:"additionalOutputs,omitempty"`
+	Examples          []map[string]any `json:"examples,omitempty"`
+
+	// Service
+	Service string       `json:"service,omitempty"`
+	Methods []MethodNode `json:"methods,omitempty"`
+
+	// Topic, whose message key uses Key
+	Payload *Node `json:"payload,omitempty"`
+	Headers *Node `json:"headers,omitempty"`
+
+	// Component
+	Provides   []*Node `json:"provides,omitempty"`
+	Requires   []*Node `json:"requires,omitempty"`
+	Publishes  []*Node `json:"publishes,omitempty"`
+	Subscribes []*Node `json:"subscribes,omitempty"`
+	Config     *Node   `json:"config,omitempty"`
+}
+
+// AnnotationNode is the document representation of an annotation.
+type AnnotationNode struct {
+	Name     string                   `json:"name"`
+	Value    any                      `json:"value,omitempty"`
+	Metadata *core.AnnotationMetadata `json:"metadata,omitempty"`
+}
+
+// CompositionNode records the provenance of a composed object schema.
+type CompositionNode struct {
+	Kind    core.CompositionKind `json:"kind"`
+	Sources []*Node              `json:"sources,omitempty"`
+	Keys    []string             `json:"keys,omitempty"`
+}
+
+// ArgsNode is the document representation of function inputs or outputs.
+type ArgsNode struct {
+	Args            []ArgNode `json:"args,omitempty"`
+	AllowAdditional bool      `json:"allowAdditional,omitempty"`
+	Additional      *Node     `json:"additional,omitempty"`
+	Name            string    `json:"name,omitempty"`
+	Description     string    `json:"description,omitempty"`
+}
+
+// ArgNode is the document representation of a single function argument.
+type ArgNode struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Schema      *Node    `json:"schema"`
+	Optional    bool     `json:"optional,omitempty"`
+	Constraints []string `json:"constraints,omitempty"`
+}
+
+// MethodNode is the document representation of a service method.
+type MethodNode struct {
+	Name     string `json:"name"`
+	Function *Node  `json:"function"`
+}
@@ -0,0 +1,596 @@
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/engine"
+)
+
+// Marshal writes a schema and every schema it references as a document.
+func Marshal(schema core.Schema) ([]byte, error) {
+	doc, err := NewDocument(schema)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// NewDocument converts a schema to its document representation. References
+// are followed once per name; targets that cannot be resolved are left out,
+// so the loaded reference stays unresolved as well.
+func NewDocument(schema core.Schema) (*Document, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("schema cannot be nil")
+	}
+
+	e := &encoder{
+		pending:     make(map[string]core.RefSchema),
+		definitions: make(map[string]*Node),
+	}
+	root, err := e.encode(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	for len(e.pending) > 0 {
+		for name, ref := range e.pending {
+			delete(e.pending, name)
+
+			target, err := ref.Resolve()
+			if err != nil {
+				continue
+			}
+			e.definitions[name] = nil // Reserve the name so self references are not queued again
+			node, err := e.encode(target)
+			if err != nil {
+				return nil, fmt.Errorf("definition %s: %w", name, err)
+			}
+			e.definitions[name] = node
+		}
+	}
+
+	doc := &Document{Version: Version, Schema: root}
+	if len(e.definitions) > 0 {
+		doc.Definitions = e.definitions
+	}
+	return doc, nil
+}
+
+// encoder converts schemas to nodes and collects referenced schemas.
+type encoder struct {
+	pending     map[string]core.RefSchema
+	definitions map[string]*Node
+}
+
+func (e *encoder) encode(schema core.Schema) (*Node, error) {
+	if schema == nil {
+		return nil, nil
+	}
+
+	node := &Node{Type: schema.Type()}
+	if metadata := schema.Metadata(); !isZeroMetadata(metadata) {
+		metadata.Composition = nil
+		node.Metadata = &metadata
+	}
+	for _, annotation := range schema.Annotations() {
+		annotationNode := AnnotationNode{Name: annotation.Name(), Value: annotation.Value()}
+		if metadata := annotation.Metadata(); !reflect.DeepEqual(metadata, core.AnnotationMetadata{}) {
+			annotationNode.Metadata = &metadata
+		}
+		node.Annotations = append(node.Annotations, annotationNode)
+	}
+
+	var err error
+	switch schema.Type() {
+	case core.TypeString:
+		err = e.encodeString(node, schema)
+	case core.TypeNumber:
+		err = e.encodeNumber(node, schema)
+	case core.TypeInteger:
+		err = e.encodeInteger(node, schema)
+	case core.TypeBoolean:
+		err = e.encodeBoolean(node, schema)
+	case core.TypeNull, core.TypeAny:
+		// No type-specific fields
+	case core.TypeArray:
+		err = e.encodeArray(node, schema)
+	case core.TypeStructure:
+		err = e.encodeObject(node, schema)
+	case core.TypeMap:
+		err = e.encodeMap(node, schema)
+	case core.TypeOptional:
+		err = e.encodeOptional(node, schema)
+	case core.TypeUnion:
+		err = e.encodeUnion(node, schema)
+	case core.TypeResult:
+		err = e.encodeResult(node, schema)
+	case core.TypeRef:
+		err = e.encodeRef(node, schema)
+	case core.TypeParameter:
+		err = e.encodeParameter(node, schema)
+	case core.TypeGeneric:
+		err = e.encodeGeneric(node, schema)
+	case core.TypeFunction:
+		err = e.encodeFunction(node, schema)
+	case core.TypeService:
+		err = e.encodeService(node, schema)
+	case core.TypeTopic:
+		err = e.encodeTopic(node, schema)
+	case core.TypeComponent:
+		err = e.encodeComponent(node, schema)
+	default:
+		err = fmt.Errorf("unsupported schema type %q", schema.Type())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// encodeAll converts a list of schemas to nodes.
+func (e *encoder) encodeAll(schemas []core.Schema) ([]*Node, error) {
+	var nodes []*Node
+	for i, schema := range schemas {
+		node, err := e.encode(schema)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// encodeSchemaMap converts a map of schemas to nodes.
+func (e *encoder) encodeSchemaMap(schemas map[string]core.Schema) (map[string]*Node, error) {
+	if len(schemas) == 0 {
+		return nil, nil
+	}
+	nodes := make(map[string]*Node, len(schemas))
+	for name, schema := range schemas {
+		node, err := e.encode(schema)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		nodes[name] = node
+	}
+	return nodes, nil
+}
+
+func (e *encoder) encodeString(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.StringSchema)
+	if !ok {
+		return fmt.Errorf("string schema does not implement core.StringSchema")
+	}
+	node.MinLength = s.MinLength()
+	node.MaxLength = s.MaxLength()
+	node.Pattern = s.Pattern()
+	node.Format = s.Format()
+	node.Enum = s.EnumValues()
+	if defaultValue := s.DefaultValue(); defaultValue != nil {
+		return setDefault(node, *defaultValue)
+	}
+	return nil
+}
+
+func (e *encoder) encodeNumber(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.NumberSchema)
+	if !ok {
+		return fmt.Errorf("number schema does not implement core.NumberSchema")
+	}
+	node.Minimum = floatNumber(s.Minimum())
+	node.Maximum = floatNumber(s.Maximum())
+	if d, ok := schema.(interface{ DefaultValue() *float64 }); ok && d.DefaultValue() != nil {
+		return setDefault(node, *d.DefaultValue())
+	}
+	return nil
+}
+
+func (e *encoder) encodeInteger(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.IntegerSchema)
+	if !ok {
+		return fmt.Errorf("integer schema does not implement core.IntegerSchema")
+	}
+	node.Minimum = intNumber(s.Minimum())
+	node.Maximum = intNumber(s.Maximum())
+	if d, ok := schema.(interface{ DefaultValue() *int64 }); ok && d.DefaultValue() != nil {
+		return setDefault(node, *d.DefaultValue())
+	}
+	return nil
+}
+
+func (e *encoder) encodeBoolean(node *Node, schema core.Schema) error {
+	if c, ok := schema.(interface{ CaseInsensitive() bool }); ok {
+		node.CaseInsensitive = c.CaseInsensitive()
+	}
+	if d, ok := schema.(interface{ DefaultValue() *bool }); ok && d.DefaultValue() != nil {
+		return setDefault(node, *d.DefaultValue())
+	}
+	return nil
+}
+
+func (e *encoder) encodeArray(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.ArraySchema)
+	if !ok {
+		return fmt.Errorf("array schema does not implement core.ArraySchema")
+	}
+
+	var err error
+	if node.Items, err = e.encode(s.ItemSchema()); err != nil {
+		return fmt.Errorf("items: %w", err)
+	}
+	if node.Contains, err = e.encode(s.ContainsSchema()); err != nil {
+		return fmt.Errorf("contains: %w", err)
+	}
+	if node.PrefixItems, err = e.encodeAll(s.PrefixItemSchemas()); err != nil {
+		return fmt.Errorf("prefixItems%w", err)
+	}
+	if node.Rest, err = e.encode(s.RestItemSchema()); err != nil {
+		return fmt.Errorf("rest: %w", err)
+	}
+	node.MinItems = s.MinItems()
+	node.MaxItems = s.MaxItems()
+	node.UniqueItems = s.UniqueItemsRequired()
+
+	if d, ok := schema.(interface{ DefaultValue() []any }); ok && d.DefaultValue() != nil {
+		return setDefault(node, d.DefaultValue())
+	}
+	return nil
+}
+
+func (e *encoder) encodeObject(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.ObjectSchema)
+	if !ok {
+		return fmt.Errorf("object schema does not implement core.ObjectSchema")
+	}
+
+	var err error
+	if node.Properties, err = e.encodeSchemaMap(s.Properties()); err != nil {
+		return fmt.Errorf("properties.%w", err)
+	}
+	if required := s.Required(); len(required) > 0 {
+		node.Required = required
+	}
+	additionalProperties := s.AdditionalProperties()
+	node.AdditionalProperties = &additionalProperties
+
+	if o, ok := schema.(interface {
+		MinProperties() *int
+		MaxProperties() *int
+		PatternProperties() map[string]core.Schema
+		PropertyDependencies() map[string][]string
+		DefaultValue() map[string]any
+	}); ok {
+		node.MinProperties = o.MinProperties()
+		node.MaxProperties = o.MaxProperties()
+		if node.PatternProperties, err = e.encodeSchemaMap(o.PatternProperties()); err != nil {
+			return fmt.Errorf("patternProperties.%w", err)
+		}
+		if dependencies := o.PropertyDependencies(); len(dependencies) > 0 {
+			node.Dependencies = dependencies
+		}
+		if defaultValue := o.DefaultValue(); defaultValue != nil {
+			if err := setDefault(node, defaultValue); err != nil {
+				return err
+			}
+		}
+	}
+
+	if composition := s.Metadata().Composition; composition != nil {
+		sources, err := e.encodeAll(composition.Sources)
+		if err != nil {
+			return fmt.Errorf("composition sources%w", err)
+		}
+		node.Composition = &CompositionNode{
+			Kind:    composition.Kind,
+			Sources: sources,
+			Keys:    composition.Keys,
+		}
+	}
+	return nil
+}
+
+func (e *encoder) encodeMap(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.MapSchema)
+	if !ok {
+		return fmt.Errorf("map schema does not implement core.MapSchema")
+	}
+
+	var err error
+	if node.Key, err = e.encode(s.KeySchema()); err != nil {
+		return fmt.Errorf("key: %w", err)
+	}
+	if node.Value, err = e.encode(s.ValueSchema()); err != nil {
+		return fmt.Errorf("value: %w", err)
+	}
+	node.MinItems = s.MinItems()
+	node.MaxItems = s.MaxItems()
+	return nil
+}
+
+func (e *encoder) encodeOptional(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.OptionalSchema)
+	if !ok {
+		return fmt.Errorf("optional schema does not implement core.OptionalSchema")
+	}
+
+	var err error
+	if node.Items, err = e.encode(s.ItemSchema()); err != nil {
+		return fmt.Errorf("items: %w", err)
+	}
+	return nil
+}
+
+func (e *encoder) encodeUnion(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.UnionSchema)
+	if !ok {
+		return fmt.Errorf("union schema does not implement core.UnionSchema")
+	}
+
+	var err error
+	if node.Members, err = e.encodeAll(s.Schemas()); err != nil {
+		return fmt.Errorf("members%w", err)
+	}
+	node.Discriminator = s.Discriminator()
+	node.Mode = s.Mode()
+	return nil
+}
+
+func (e *encoder) encodeResult(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.ResultSchema)
+	if !ok {
+		return fmt.Errorf("result schema does not implement core.ResultSchema")
+	}
+
+	var err error
+	if node.Ok, err = e.encode(s.SuccessSchema()); err != nil {
+		return fmt.Errorf("ok: %w", err)
+	}
+	if node.Err, err = e.encode(s.ErrorSchema()); err != nil {
+		return fmt.Errorf("err: %w", err)
+	}
+	return nil
+}
+
+func (e *encoder) encodeRef(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.RefSchema)
+	if !ok {
+		return fmt.Errorf("reference schema does not implement core.RefSchema")
+	}
+
+	name := s.ReferenceName()
+	if name == "" {
+		return fmt.Errorf("reference schema has no target name")
+	}
+	node.Ref = name
+
+	if ref, ok := schema.(*engine.RefSchema); ok && ref.Reference() != nil {
+		node.RefNamespace = ref.Reference().Namespace()
+		node.RefVersion = ref.Reference().Version()
+	}
+
+	if instance, ok := schema.(core.InstanceSchema); ok {
+		var err error
+		node.Generic = instance.GenericName()
+		if node.Arguments, err = e.encodeAll(instance.TypeArguments()); err != nil {
+			return fmt.Errorf("arguments%w", err)
+		}
+	}
+
+	if _, seen := e.definitions[name]; !seen {
+		e.pending[name] = s
+	}
+	return nil
+}
+
+func (e *encoder) encodeParameter(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.TypeParameterSchema)
+	if !ok {
+		return fmt.Errorf("type parameter schema does not implement core.TypeParameterSchema")
+	}
+
+	var err error
+	if node.Constraint, err = e.encode(s.Constraint()); err != nil {
+		return fmt.Errorf("constraint: %w", err)
+	}
+	return nil
+}
+
+func (e *encoder) encodeGeneric(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.GenericSchema)
+	if !ok {
+		return fmt.Errorf("generic schema does not implement core.GenericSchema")
+	}
+
+	for i, param := range s.TypeParameters() {
+		paramNode, err := e.encode(param)
+		if err != nil {
+			return fmt.Errorf("parameters[%d]: %w", i, err)
+		}
+		node.Parameters = append(node.Parameters, paramNode)
+	}
+
+	var err error
+	if node.Template, err = e.encode(s.Template()); err != nil {
+		return fmt.Errorf("template: %w", err)
+	}
+	return nil
+}
+
+func (e *encoder) encodeFunction(node *Node, schema core.Schema) error {
+	// Service methods report the function type and wrap the actual function
+	if method, ok := schema.(core.ServiceMethodSchema); ok {
+		schema = method.Function()
+	}
+	s, ok := schema.(core.FunctionSchema)
+	if !ok {
+		return fmt.Errorf("function schema does not implement core.FunctionSchema")
+	}
+
+	var err error
+	if node.Inputs, err = e.encodeArgs(s.Inputs()); err != nil {
+		return fmt.Errorf("inputs.%w", err)
+	}
+	if node.Outputs, err = e.encodeArgs(s.Outputs()); err != nil {
+		return fmt.Errorf("outputs.%w", err)
+	}
+	if node.Errors, err = e.encode(s.Errors()); err != nil {
+		return fmt.Errorf("errors: %w", err)
+	}
+
+	if f, ok := schema.(interface {
+		AdditionalInputs() bool
+		AdditionalOutputs() bool
+		Examples() []map[string]any
+	}); ok {
+		node.AdditionalInputs = f.AdditionalInputs()
+		node.AdditionalOutputs = f.AdditionalOutputs()
+		if examples := f.Examples(); len(examples) > 0 {
+			node.Examples = examples
+		}
+	}
+	return nil
+}
+
+func (e *encoder) encodeArgs(args core.ArgSchemas) (*ArgsNode, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	node := &ArgsNode{
+		AllowAdditional: args.AllowAdditional(),
+		Name:            args.CollectionName(),
+		Description:     args.CollectionDescription(),
+	}
+	for _, arg := range args.Args() {
+		schemaNode, err := e.encode(arg.Schema())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", arg.Name(), err)
+		}
+		node.Args = append(node.Args, ArgNode{
+			Name:        arg.Name(),
+			Description: arg.Description(),
+			Schema:      schemaNode,
+			Optional:    arg.Optional(),
+			Constraints: arg.Constraints(),
+		})
+	}
+
+	var err error
+	if node.Additional, err = e.encode(args.AdditionalSchema()); err != nil {
+		return nil, fmt.Errorf("additional: %w", err)
+	}
+	if reflect.DeepEqual(*node, ArgsNode{}) {
+		return nil, nil
+	}
+	return node, nil
+}
+
+func (e *encoder) encodeService(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.ServiceSchema)
+	if !ok {
+		return fmt.Errorf("service schema does not implement core.ServiceSchema")
+	}
+
+	node.Service = s.Name()
+	for _, method := range s.Methods() {
+		functionNode, err := e.encode(method.Function())
+		if err != nil {
+			return fmt.Errorf("methods.%s: %w", method.Name(), err)
+		}
+		node.Methods = append(node.Methods, MethodNode{Name: method.Name(), Function: functionNode})
+	}
+	return nil
+}
+
+func (e *encoder) encodeTopic(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.TopicSchema)
+	if !ok {
+		return fmt.Errorf("topic schema does not implement core.TopicSchema")
+	}
+
+	var err error
+	if node.Payload, err = e.encode(s.Payload()); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+	if node.Key, err = e.encode(s.Key()); err != nil {
+		return fmt.Errorf("key: %w", err)
+	}
+	if node.Headers, err = e.encode(s.Headers()); err != nil {
+		return fmt.Errorf("headers: %w", err)
+	}
+	return nil
+}
+
+func (e *encoder) encodeComponent(node *Node, schema core.Schema) error {
+	s, ok := schema.(core.ComponentSchema)
+	if !ok {
+		return fmt.Errorf("component schema does not implement core.ComponentSchema")
+	}
+
+	var err error
+	if node.Provides, err = encodeEach(e, "provides", s.Provides()); err != nil {
+		return err
+	}
+	if node.Requires, err = encodeEach(e, "requires", s.Requires()); err != nil {
+		return err
+	}
+	if node.Publishes, err = encodeEach(e, "publishes", s.Publishes()); err != nil {
+		return err
+	}
+	if node.Subscribes, err = encodeEach(e, "subscribes", s.Subscribes()); err != nil {
+		return err
+	}
+	if node.Config, err = e.encode(s.Config()); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	return nil
+}
+
+// encodeEach encodes a list of schemas, naming the list and index in errors.
+func encodeEach[T core.Schema](e *encoder, name string, schemas []T) ([]*Node, error) {
+	var nodes []*Node
+	for i, schema := range schemas {
+		node, err := e.encode(schema)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%d: %w", name, i, err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// setDefault stores the default value of a schema.
+func setDefault(node *Node, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("default: %w", err)
+	}
+	node.Default = data
+	return nil
+}
+
+func floatNumber(f *float64) *json.Number {
+	if f == nil {
+		return nil
+	}
+	n := json.Number(strconv.FormatFloat(*f, 'g', -1, 64))
+	return &n
+}
+
+func intNumber(i *int64) *json.Number {
+	if i == nil {
+		return nil
+	}
+	n := json.Number(strconv.FormatInt(*i, 10))
+	return &n
+}
+
+// isZeroMetadata reports whether metadata holds nothing worth writing.
+func isZeroMetadata(metadata core.SchemaMetadata) bool {
+	return metadata.Name == "" && metadata.Version == "" && metadata.Description == "" &&
+		len(metadata.Examples) == 0 && len(metadata.Tags) == 0 && len(metadata.Properties) == 0
+}
@@ -0,0 +1,744 @@
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/annotation"
+	"defs.dev/schema/engine"
+	"defs.dev/schema/schemas"
+)
+
+// UnmarshalOption configures how a document is loaded.
+type UnmarshalOption func(*decoder)
+
+// WithEngine registers the document's definitions with the given engine and
+// resolves references through it. By default a new engine is created.
+func WithEngine(e engine.SchemaEngine) UnmarshalOption {
+	return func(d *decoder) {
+		d.engine = e
+	}
+}
+
+// WithAnnotationRegistry creates annotations through the given registry, so
+// registered annotation types are restored with their schemas. By default
+// annotations are restored as untyped annotations.
+func WithAnnotationRegistry(registry annotation.AnnotationRegistry) UnmarshalOption {
+	return func(d *decoder) {
+		d.annotations = registry
+	}
+}
+
+// Unmarshal loads a schema written by Marshal. Numbers in examples, defaults
+// and annotations keep the Go type of the schema they belong to.
+func Unmarshal(data []byte, options ...UnmarshalOption) (core.Schema, error) {
+	var doc Document
+	if err := unmarshalNumbers(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid schema document: %w", err)
+	}
+	return doc.Load(options...)
+}
+
+// Load rebuilds the schema held by a document.
+func (doc *Document) Load(options ...UnmarshalOption) (core.Schema, error) {
+	if doc.Version != Version {
+		return nil, fmt.Errorf("unsupported schema document version %q", doc.Version)
+	}
+	if doc.Schema == nil {
+		return nil, fmt.Errorf("schema document has no schema")
+	}
+
+	d := &decoder{definitions: doc.Definitions}
+	for _, option := range options {
+		option(d)
+	}
+	if d.engine == nil {
+		d.engine = engine.NewSchemaEngine()
+	}
+	if d.annotations == nil {
+		d.annotations = annotation.NewRegistry()
+	}
+
+	// Definitions are registered first; references only resolve on demand
+	names := make([]string, 0, len(doc.Definitions))
+	for name := range doc.Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		definition, err := d.decode(doc.Definitions[name])
+		if err != nil {
+			return nil, fmt.Errorf("definition %s: %w", name, err)
+		}
+		if err := d.engine.RegisterSchema(name, definition); err != nil {
+			return nil, fmt.Errorf("definition %s: %w", name, err)
+		}
+	}
+
+	return d.decode(doc.Schema)
+}
+
+// decoder builds schemas from nodes.
+type decoder struct {
+	engine      engine.SchemaEngine
+	annotations annotation.AnnotationRegistry
+	definitions map[string]*Node
+}
+
+func (d *decoder) decode(node *Node) (core.Schema, error) {
+	if node == nil {
+		return nil, nil
+	}
+
+	var metadata core.SchemaMetadata
+	if node.Metadata != nil {
+		metadata = *node.Metadata
+		metadata.Examples = d.values(node, metadata.Examples)
+	}
+	annotations, err := d.decodeAnnotations(node.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node.Type {
+	case core.TypeString:
+		return d.decodeString(node, metadata, annotations)
+	case core.TypeNumber:
+		return d.decodeNumber(node, metadata, annotations)
+	case core.TypeInteger:
+		return d.decodeInteger(node, metadata, annotations)
+	case core.TypeBoolean:
+		config := schemas.BooleanSchemaConfig{Metadata: metadata, Annotations: annotations, CaseInsensitive: node.CaseInsensitive}
+		if err := decodeDefault(d, node, &config.DefaultVal); err != nil {
+			return nil, err
+		}
+		return schemas.NewBooleanSchema(config), nil
+	case core.TypeNull:
+		return schemas.NewNullSchema(schemas.NullSchemaConfig{Metadata: metadata, Annotations: annotations}), nil
+	case core.TypeAny:
+		return schemas.NewAnySchema(schemas.AnySchemaConfig{Metadata: metadata, Annotations: annotations}), nil
+	case core.TypeArray:
+		return d.decodeArray(node, metadata, annotations)
+	case core.TypeStructure:
+		return d.decodeObject(node, metadata, annotations)
+	case core.TypeMap:
+		return d.decodeMap(node, metadata, annotations)
+	case core.TypeOptional:
+		item, err := d.decode(node.Items)
+		if err != nil {
+			return nil, fmt.Errorf("items: %w", err)
+		}
+		return schemas.NewOptionalSchema(schemas.OptionalSchemaConfig{Metadata: metadata, Annotations: annotations, ItemSchema: item}), nil
+	case core.TypeUnion:
+		members, err := d.decodeAll(node.Members)
+		if err != nil {
+			return nil, fmt.Errorf("members%w", err)
+		}
+		return schemas.NewUnionSchema(schemas.UnionSchemaConfig{
+			Metadata:      metadata,
+			Annotations:   annotations,
+			Schemas:       members,
+			Discriminator: node.Discriminator,
+			Mode:          node.Mode,
+		}), nil
+	case core.TypeResult:
+		return d.decodeResult(node, metadata, annotations)
+	case core.TypeRef:
+		return d.decodeRef(node, metadata, annotations)
+	case core.TypeParameter:
+		constraint, err := d.decode(node.Constraint)
+		if err != nil {
+			return nil, fmt.Errorf("constraint: %w", err)
+		}
+		return schemas.NewTypeParameterSchema(schemas.TypeParameterSchemaConfig{Metadata: metadata, Annotations: annotations, Constraint: constraint}), nil
+	case core.TypeGeneric:
+		return d.decodeGeneric(node, metadata, annotations)
+	case core.TypeFunction:
+		return d.decodeFunction(node, metadata)
+	case core.TypeService:
+		return d.decodeService(node, metadata)
+	case core.TypeTopic:
+		return d.decodeTopic(node, metadata, annotations)
+	case core.TypeComponent:
+		return d.decodeComponent(node, metadata, annotations)
+	default:
+		return nil, fmt.Errorf("unsupported schema type %q", node.Type)
+	}
+}
+
+// decodeAll builds a list of schemas from nodes.
+func (d *decoder) decodeAll(nodes []*Node) ([]core.Schema, error) {
+	var result []core.Schema
+	for i, node := range nodes {
+		schema, err := d.decode(node)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		result = append(result, schema)
+	}
+	return result, nil
+}
+
+// decodeSchemaMap builds a map of schemas from nodes.
+func (d *decoder) decodeSchemaMap(nodes map[string]*Node) (map[string]core.Schema, error) {
+	if nodes == nil {
+		return nil, nil
+	}
+	result := make(map[string]core.Schema, len(nodes))
+	for name, node := range nodes {
+		schema, err := d.decode(node)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		result[name] = schema
+	}
+	return result, nil
+}
+
+func (d *decoder) decodeAnnotations(nodes []AnnotationNode) ([]core.Annotation, error) {
+	var result []core.Annotation
+	for _, node := range nodes {
+		var metadata core.AnnotationMetadata
+		if node.Metadata != nil {
+			metadata = *node.Metadata
+		}
+		a, err := d.annotations.CreateWithMetadata(node.Name, d.value(nil, node.Value), metadata)
+		if err != nil {
+			return nil, fmt.Errorf("annotation %s: %w", node.Name, err)
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
+
+func (d *decoder) decodeString(node *Node, metadata core.SchemaMetadata, annotations []core.Annotation) (core.Schema, error) {
+	config := schemas.StringSchemaConfig{
+		Metadata:    metadata,
+		Annotations: annotations,
+		MinLength:   node.MinLength,
+		MaxLength:   node.MaxLength,
+		Format:      node.Format,
+		EnumValues:  node.Enum,
+	}
+	if node.Pattern != "" {
+		pattern, err := regexp.Compile(node.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("pattern: %w", err)
+		}
+		config.Pattern = pattern
+	}
+	if err := decodeDefault(d, node, &config.DefaultVal); err != nil {
+		return nil, err
+	}
+	return schemas.NewStringSchema(config), nil
+}
+
+func (d *decoder) decodeNumber(node *Node, metadata core.SchemaMetadata, annotations []core.Annotation) (core.Schema, error) {
+	config := schemas.NumberSchemaConfig{Metadata: metadata, Annotations: annotations}
+
+	var err error
+	if config.Minimum, err = parseFloat(node.Minimum); err != nil {
+		return nil, fmt.Errorf("minimum: %w", err)
+	}
+	if config.Maximum, err = parseFloat(node.Maximum); err != nil {
+		return nil, fmt.Errorf("maximum: %w", err)
+	}
+	if err := decodeDefault(d, node, &config.DefaultVal); err != nil {
+		return nil, err
+	}
+	return schemas.NewNumberSchema(config), nil
+}
+
+func (d *decoder) decodeInteger(node *Node, metadata core.SchemaMetadata, annotations []core.Annotation) (core.Schema, error) {
+	config := schemas.IntegerSchemaConfig{Metadata: metadata, Annotations: annotations}
+
+	var err error
+	if config.Minimum, err = parseInt(node.Minimum); err != nil {
+		return nil, fmt.Errorf("minimum: %w", err)
+	}
+	if config.Maximum, err = parseInt(node.Maximum); err != nil {
+		return nil, fmt.Errorf("maximum: %w", err)
+	}
+	if err := decodeDefault(d, node, &config.DefaultVal); err != nil {
+		return nil, err
+	}
+	return schemas.NewIntegerSchema(config), nil
+}
+
+func (d *decoder) decodeArray(node *Node, metadata core.SchemaMetadata, annotations []core.Annotation) (core.Schema, error) {
+	config := schemas.ArraySchemaConfig{
+		Metadata:    metadata,
+		Annotations: annotations,
+		MinItems:    node.MinItems,
+		MaxItems:    node.MaxItems,
+		UniqueItems: node.UniqueItems,
+	}
+
+	var err error
+	if config.ItemSchema, err = d.decode(node.Items); err != nil {
+		return nil, fmt.Errorf("items: %w", err)
+	}
+	if config.ContainsSchema, err = d.decode(node.Contains); err != nil {
+		return nil, fmt.Errorf("contains: %w", err)
+	}
+	if config.PrefixItems, err = d.decodeAll(node.PrefixItems); err != nil {
+		return nil, fmt.Errorf("prefixItems%w", err)
+	}
+	if config.RestItems, err = d.decode(node.Rest); err != nil {
+		return nil, fmt.Errorf("rest: %w", err)
+	}
+	if err := decodeDefault(d, node, &config.DefaultVal); err != nil {
+		return nil, err
+	}
+	return schemas.NewArraySchema(config), nil
+}
+
+func (d *decoder) decodeObject(node *Node, metadata core.SchemaMetadata, annotations []core.Annotation) (core.Schema, error) {
+	config := schemas.ObjectSchemaConfig{
+		Metadata:             metadata,
+		Annotations:          annotations,
+		Required:             node.Required,
+		MinProperties:        node.MinProperties,
+		MaxProperties:        node.MaxProperties,
+		PropertyDependencies: node.Dependencies,
+	}
+	if node.AdditionalProperties != nil {
+		config.AdditionalProperties = *node.AdditionalProperties
+	}
+
+	var err error
+	if config.Properties, err = d.decodeSchemaMap(node.Properties); err != nil {
+		return nil, fmt.Errorf("properties.%w", err)
+	}
+	if config.Properties == nil {
+		config.Properties = make(map[string]core.Schema)
+	}
+	if config.PatternProperties, err = d.decodeSchemaMap(node.PatternProperties); err != nil {
+		return nil, fmt.Errorf("patternProperties.%w", err)
+	}
+	if err := decodeDefault(d, node, &config.DefaultVal); err != nil {
+		return nil, err
+	}
+
+	if node.Composition != nil {
+		sources, err := d.decodeAll(node.Composition.Sources)
+		if err != nil {
+			return nil, fmt.Errorf("composition sources%w", err)
+		}
+		config.Metadata.Composition = &core.Composition{
+			Kind:    node.Composition.Kind,
+			Sources: sources,
+			Keys:    node.Composition.Keys,
+		}
+	}
+	return schemas.NewObjectSchema(config), nil
+}
+
+func (d *decoder) decodeMap(node *Node, metadata core.SchemaMetadata, annotations []core.Annotation) (core.Schema, error) {
+	config := schemas.MapSchemaConfig{
+		Metadata:    metadata,
+		Annotations: annotations,
+		MinItems:    node.MinItems,
+		MaxItems:    node.MaxItems,
+	}
+
+	var err error
+	if config.KeySchema, err = d.decode(node.Key); err != nil {
+		return nil, fmt.Errorf("key: %w", err)
+	}
+	if config.ValueSchema, err = d.decode(node.Value); err != nil {
+		return nil, fmt.Errorf("value: %w", err)
+	}
+	return schemas.NewMapSchema(config), nil
+}
+
+func (d *decoder) decodeResult(node *Node, metadata core.SchemaMetadata, annotations []core.Annotation) (core.Schema, error) {
+	config := schemas.ResultSchemaConfig{Metadata: metadata, Annotations: annotations}
+
+	var err error
+	if config.Success, err = d.decode(node.Ok); err != nil {
+		return nil, fmt.Errorf("ok: %w", err)
+	}
+	if config.Error, err = d.decode(node.Err); err != nil {
+		return nil, fmt.Errorf("err: %w", err)
+	}
+	return schemas.NewResultSchema(config), nil
+}
+
+func (d *decoder) decodeRef(node *Node, metadata core.SchemaMetadata, annotations []core.Annotation) (core.Schema, error) {
+	if node.Ref == "" {
+		return nil, fmt.Errorf("reference has no target name")
+	}
+
+	if node.Generic != "" {
+		arguments, err := d.decodeAll(node.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("arguments%w", err)
+		}
+		return engine.NewInstanceSchema(engine.InstanceSchemaConfig{
+			Metadata:    metadata,
+			Annotations: annotations,
+			GenericName: node.Generic,
+			Arguments:   arguments,
+			Name:        node.Ref,
+			Engine:      d.engine,
+		}), nil
+	}
+
+	return engine.NewRefSchema(engine.RefSchemaConfig{
+		Metadata:    metadata,
+		Annotations: annotations,
+		Reference:   engine.NewVersionedReference(node.RefNamespace, node.Ref, node.RefVersion),
+		Engine:      d.engine,
+	}), nil
+}
+
+func (d *decoder) decodeGeneric(node *Node, metadata core.SchemaMetadata, annotations []core.Annotation) (core.Schema, error) {
+	config := schemas.GenericSchemaConfig{Metadata: metadata, Annotations: annotations}
+
+	for i, paramNode := range node.Parameters {
+		param, err := d.decode(paramNode)
+		if err != nil {
+			return nil, fmt.Errorf("parameters[%d]: %w", i, err)
+		}
+		typeParam, ok := param.(core.TypeParameterSchema)
+		if !ok {
+			return nil, fmt.Errorf("parameters[%d]: expected type parameter, got %s", i, param.Type())
+		}
+		config.TypeParameters = append(config.TypeParameters, typeParam)
+	}
+
+	var err error
+	if config.Template, err = d.decode(node.Template); err != nil {
+		return nil, fmt.Errorf("template: %w", err)
+	}
+	return schemas.NewGenericSchema(config), nil
+}
+
+func (d *decoder) decodeFunction(node *Node, metadata core.SchemaMetadata) (core.Schema, error) {
+	inputs, err := d.decodeArgs(node.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("inputs.%w", err)
+	}
+	outputs, err := d.decodeArgs(node.Outputs)
+	if err != nil {
+		return nil, fmt.Errorf("outputs.%w", err)
+	}
+	errorSchema, err := d.decode(node.Errors)
+	if err != nil {
+		return nil, fmt.Errorf("errors: %w", err)
+	}
+
+	function := schemas.NewFunctionSchema(inputs, outputs).
+		WithAdditionalInputs(node.AdditionalInputs).
+		WithAdditionalOutputs(node.AdditionalOutputs)
+	if errorSchema != nil {
+		function = function.WithError(errorSchema)
+	}
+	for _, example := range node.Examples {
+		function = function.WithExample(d.example(node, example))
+	}
+	return function.WithMetadata(metadata), nil
+}
+
+func (d *decoder) decodeArgs(node *ArgsNode) (schemas.ArgSchemas, error) {
+	args := schemas.NewArgSchemas()
+	if node == nil {
+		return args, nil
+	}
+
+	for _, argNode := range node.Args {
+		schema, err := d.decode(argNode.Schema)
+		if err != nil {
+			return args, fmt.Errorf("%s: %w", argNode.Name, err)
+		}
+		args.AddArg(schemas.NewArgSchemaWithOptions(argNode.Name, schema, argNode.Description, argNode.Optional, argNode.Constraints))
+	}
+
+	additional, err := d.decode(node.Additional)
+	if err != nil {
+		return args, fmt.Errorf("additional: %w", err)
+	}
+	args.SetAllowAdditional(node.AllowAdditional)
+	args.SetAdditionalSchema(additional)
+	args.SetCollectionMetadata(node.Name, node.Description)
+	return args, nil
+}
+
+func (d *decoder) decodeService(node *Node, metadata core.SchemaMetadata) (core.Schema, error) {
+	service := schemas.NewServiceSchema(node.Service)
+	for _, method := range node.Methods {
+		schema, err := d.decode(method.Function)
+		if err != nil {
+			return nil, fmt.Errorf("methods.%s: %w", method.Name, err)
+		}
+		function, ok := schema.(core.FunctionSchema)
+		if !ok {
+			return nil, fmt.Errorf("methods.%s: expected function schema", method.Name)
+		}
+		service = service.WithMethod(method.Name, function)
+	}
+	return service.WithMetadata(metadata), nil
+}
+
+func (d *decoder) decodeTopic(node *Node, metadata core.SchemaMetadata, annotations []core.Annotation) (core.Schema, error) {
+	config := schemas.TopicSchemaConfig{Metadata: metadata, Annotations: annotations}
+
+	var err error
+	if config.Payload, err = d.decode(node.Payload); err != nil {
+		return nil, fmt.Errorf("payload: %w", err)
+	}
+	if config.Key, err = d.decode(node.Key); err != nil {
+		return nil, fmt.Errorf("key: %w", err)
+	}
+	if config.Headers, err = d.decode(node.Headers); err != nil {
+		return nil, fmt.Errorf("headers: %w", err)
+	}
+	return schemas.NewTopicSchema(config), nil
+}
+
+func (d *decoder) decodeComponent(node *Node, metadata core.SchemaMetadata, annotations []core.Annotation) (core.Schema, error) {
+	config := schemas.ComponentSchemaConfig{Metadata: metadata, Annotations: annotations}
+
+	var err error
+	if config.Provides, err = decodeEach[core.ServiceSchema](d, "provides", node.Provides); err != nil {
+		return nil, err
+	}
+	if config.Requires, err = decodeEach[core.ServiceSchema](d, "requires", node.Requires); err != nil {
+		return nil, err
+	}
+	if config.Publishes, err = decodeEach[core.TopicSchema](d, "publishes", node.Publishes); err != nil {
+		return nil, err
+	}
+	if config.Subscribes, err = decodeEach[core.TopicSchema](d, "subscribes", node.Subscribes); err != nil {
+		return nil, err
+	}
+	if config.Config, err = d.decode(node.Config); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	return schemas.NewComponentSchema(config), nil
+}
+
+// decodeEach decodes a list of nodes that must all decode to schemas of type T.
+func decodeEach[T core.Schema](d *decoder, name string, nodes []*Node) ([]T, error) {
+	var result []T
+	for i, node := range nodes {
+		schema, err := d.decode(node)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%d: %w", name, i, err)
+		}
+		if schema == nil {
+			return nil, fmt.Errorf("%s.%d: missing schema", name, i)
+		}
+		typed, ok := schema.(T)
+		if !ok {
+			return nil, fmt.Errorf("%s.%d: unexpected %s schema", name, i, schema.Type())
+		}
+		result = append(result, typed)
+	}
+	return result, nil
+}
+
+// decodeDefault reads the default value of a node into target.
+func decodeDefault[T any](d *decoder, node *Node, target *T) error {
+	if len(node.Default) == 0 {
+		return nil
+	}
+	if err := unmarshalNumbers(node.Default, target); err != nil {
+		return fmt.Errorf("default: %w", err)
+	}
+	if value, ok := d.value(node, *target).(T); ok {
+		*target = value
+	}
+	return nil
+}
+
+// unmarshalNumbers is json.Unmarshal, but decodes numbers held by any as
+// json.Number so that value can convert them by their schema.
+func unmarshalNumbers(data []byte, target any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(target); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("invalid data after top-level value")
+	}
+	return nil
+}
+
+// value converts a decoded value to the Go types of the schema node
+// describes: numbers become int64 for integer schemas and float64 otherwise,
+// as they are decoded without a schema, and arrays and objects are converted
+// element by element.
+func (d *decoder) value(node *Node, value any) any {
+	node = d.valueNode(node, value)
+	switch v := value.(type) {
+	case json.Number:
+		if node != nil && node.Type == core.TypeInteger {
+			if i, err := v.Int64(); err == nil {
+				return i
+			}
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case float64:
+		// Documents decoded by callers hold float64 numbers
+		if node != nil && node.Type == core.TypeInteger && v == float64(int64(v)) {
+			return int64(v)
+		}
+	case []any:
+		converted := make([]any, len(v))
+		for i, item := range v {
+			converted[i] = d.value(elementNode(node, i), item)
+		}
+		return converted
+	case map[string]any:
+		converted := make(map[string]any, len(v))
+		for key, item := range v {
+			converted[key] = d.value(fieldNode(node, key), item)
+		}
+		return converted
+	}
+	return value
+}
+
+// values converts a list of values of the schema node describes.
+func (d *decoder) values(node *Node, values []any) []any {
+	if values == nil {
+		return nil
+	}
+	converted := make([]any, len(values))
+	for i, value := range values {
+		converted[i] = d.value(node, value)
+	}
+	return converted
+}
+
+// example converts a function example by the schemas of the arguments it sets.
+func (d *decoder) example(node *Node, example map[string]any) map[string]any {
+	converted := make(map[string]any, len(example))
+	for name, value := range example {
+		schema := argNode(node.Inputs, name)
+		if schema == nil {
+			schema = argNode(node.Outputs, name)
+		}
+		converted[name] = d.value(schema, value)
+	}
+	return converted
+}
+
+// argNode returns the schema node of the named argument, if there is one.
+func argNode(args *ArgsNode, name string) *Node {
+	if args == nil {
+		return nil
+	}
+	for _, arg := range args.Args {
+		if arg.Name == name {
+			return arg.Schema
+		}
+	}
+	return nil
+}
+
+// valueNode returns the node of the concrete schema a value of node is
+// described by, following references, optionals and the first union member
+// matching the kind of value. It returns nil where that schema is not known.
+func (d *decoder) valueNode(node *Node, value any) *Node {
+	seen := map[*Node]bool{}
+	for node != nil && !seen[node] {
+		seen[node] = true
+		switch node.Type {
+		case core.TypeRef:
+			if node.Generic != "" {
+				return nil
+			}
+			node = d.definitions[node.Ref]
+		case core.TypeOptional:
+			node = node.Items
+		case core.TypeUnion:
+			var member *Node
+			for _, candidate := range node.Members {
+				if resolved := d.valueNode(candidate, value); resolved != nil && matchesKind(resolved.Type, value) {
+					member = resolved
+					break
+				}
+			}
+			return member
+		default:
+			return node
+		}
+	}
+	return nil
+}
+
+// matchesKind reports whether a schema type describes values of the JSON
+// kind of value.
+func matchesKind(schemaType core.SchemaType, value any) bool {
+	switch value.(type) {
+	case json.Number, float64:
+		return schemaType == core.TypeInteger || schemaType == core.TypeNumber
+	case []any:
+		return schemaType == core.TypeArray
+	case map[string]any:
+		return schemaType == core.TypeStructure || schemaType == core.TypeMap
+	}
+	return false
+}
+
+// elementNode returns the node describing the i-th element of an array.
+func elementNode(node *Node, i int) *Node {
+	if node == nil || node.Type != core.TypeArray {
+		return nil
+	}
+	if len(node.PrefixItems) > 0 {
+		if i < len(node.PrefixItems) {
+			return node.PrefixItems[i]
+		}
+		return node.Rest
+	}
+	return node.Items
+}
+
+// fieldNode returns the node describing the value of key in an object or map.
+func fieldNode(node *Node, key string) *Node {
+	if node == nil {
+		return nil
+	}
+	switch node.Type {
+	case core.TypeStructure:
+		return node.Properties[key]
+	case core.TypeMap:
+		return node.Value
+	}
+	return nil
+}
+
+func parseFloat(n *json.Number) (*float64, error) {
+	if n == nil {
+		return nil, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func parseInt(n *json.Number) (*int64, error) {
+	if n == nil {
+		return nil, nil
+	}
+	i, err := n.Int64()
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
@@ -0,0 +1,253 @@
+// Package jsonschema imports JSON Schema documents (draft-07 through
+// draft-2020-12) as core.Schema trees. It is the inverse of the JSON Schema
+// exporter in visit/export/json.
+//
+// Named definitions under $defs or definitions are registered with a
+// SchemaEngine and referenced through engine.RefSchema, so recursive documents
+// import without special handling. Keywords that have no counterpart in the
+// schema model are collected in a Report rather than silently dropped.
+//
+//	e := engine.NewSchemaEngine()
+//	schema, report, err := jsonschema.Import(data, e)
+//	for _, keyword := range report.Unsupported {
+//		log.Printf("%s: %s not imported", keyword.Path, keyword.Keyword)
+//	}
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/jsonpointer"
+	"defs.dev/schema/engine"
+	"defs.dev/schema/schemas"
+)
+
+// UnsupportedKeyword is a keyword the importer could not represent.
+type UnsupportedKeyword struct {
+	// Path is the JSON Pointer of the schema holding the keyword, as a URI fragment.
+	Path    string `json:"path"`
+	Keyword string `json:"keyword"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// Report lists what an import could not carry over.
+type Report struct {
+	Unsupported []UnsupportedKeyword `json:"unsupported,omitempty"`
+}
+
+// Empty reports whether the import was complete.
+func (r *Report) Empty() bool {
+	return len(r.Unsupported) == 0
+}
+
+// Add records a keyword at the given JSON Pointer that was not imported.
+func (r *Report) Add(pointer, keyword, reason string) {
+	r.Unsupported = append(r.Unsupported, UnsupportedKeyword{
+		Path:    "#" + pointer,
+		Keyword: keyword,
+		Reason:  reason,
+	})
+}
+
+// Keywords returns the distinct unsupported keywords, sorted.
+func (r *Report) Keywords() []string {
+	seen := make(map[string]bool)
+	var keywords []string
+	for _, u := range r.Unsupported {
+		if !seen[u.Keyword] {
+			seen[u.Keyword] = true
+			keywords = append(keywords, u.Keyword)
+		}
+	}
+	sort.Strings(keywords)
+	return keywords
+}
+
+// Option configures an Importer.
+type Option func(*Importer)
+
+// WithDefinitionsPath adds a JSON Pointer under which named definitions are
+// found, such as "/components/schemas" for OpenAPI documents. "/$defs" and
+// "/definitions" are always searched.
+func WithDefinitionsPath(pointer string) Option {
+	return func(imp *Importer) {
+		imp.definitionPaths = append(imp.definitionPaths, strings.TrimSuffix(pointer, "/"))
+	}
+}
+
+// Importer converts JSON Schema documents to schemas.
+type Importer struct {
+	engine          engine.SchemaEngine
+	definitionPaths []string
+	report          Report
+
+	root        any
+	definitions map[string]*definition
+	inline      map[string]bool
+
+	// parameters collects the type parameters met while importing a generic template
+	parameters map[string]core.TypeParameterSchema
+}
+
+// definition is a named schema found under a definitions path.
+type definition struct {
+	pointer string
+	node    any
+	schema  core.Schema
+	loading bool
+}
+
+// NewImporter creates an importer that registers definitions with the given engine.
+func NewImporter(e engine.SchemaEngine, options ...Option) *Importer {
+	imp := &Importer{
+		engine:          e,
+		definitionPaths: []string{"/$defs", "/definitions"},
+	}
+	for _, option := range options {
+		option(imp)
+	}
+	return imp
+}
+
+// Import parses a JSON Schema document, registers its definitions with the
+// engine and returns the root schema along with a report of the keywords that
+// were not imported.
+func Import(data []byte, e engine.SchemaEngine) (core.Schema, *Report, error) {
+	imp := NewImporter(e)
+	schema, err := imp.Import(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return schema, imp.Report(), nil
+}
+
+// Report returns the keywords not imported so far.
+func (imp *Importer) Report() *Report {
+	return &imp.report
+}
+
+// Import parses a JSON Schema document and imports its root schema.
+func (imp *Importer) Import(data []byte) (core.Schema, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema document: %w", err)
+	}
+	return imp.ImportDocument(doc)
+}
+
+// ImportDocument imports a decoded JSON Schema document.
+func (imp *Importer) ImportDocument(doc any) (core.Schema, error) {
+	if err := imp.LoadDefinitions(doc); err != nil {
+		return nil, err
+	}
+	return imp.ImportSchema(doc, "")
+}
+
+// LoadDefinitions makes doc the document that references are resolved
+// against, and imports and registers every named definition it holds.
+func (imp *Importer) LoadDefinitions(doc any) error {
+	imp.root = doc
+	imp.definitions = make(map[string]*definition)
+	imp.inline = make(map[string]bool)
+
+	for _, path := range imp.definitionPaths {
+		container, ok := jsonpointer.Lookup(doc, path).(map[string]any)
+		if !ok {
+			continue
+		}
+		for name, node := range container {
+			if _, exists := imp.definitions[name]; exists {
+				return fmt.Errorf("definition %s is defined more than once", name)
+			}
+			imp.definitions[name] = &definition{pointer: path + "/" + jsonpointer.Escape(name), node: node}
+		}
+	}
+
+	names := make([]string, 0, len(imp.definitions))
+	for name := range imp.definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		schema, err := imp.definition(name)
+		if err != nil {
+			return err
+		}
+		if err := imp.engine.RegisterSchema(name, schema); err != nil {
+			return fmt.Errorf("definition %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ImportSchema imports a single schema found at the given JSON Pointer of the
+// document passed to LoadDefinitions.
+func (imp *Importer) ImportSchema(node any, pointer string) (core.Schema, error) {
+	if imp.definitions == nil {
+		imp.definitions = make(map[string]*definition)
+		imp.inline = make(map[string]bool)
+	}
+	return imp.importNode(node, pointer)
+}
+
+// definition imports a named definition once. While a definition is being
+// imported, references to it are only resolved lazily.
+func (imp *Importer) definition(name string) (core.Schema, error) {
+	def := imp.definitions[name]
+	if def.schema != nil || def.loading {
+		return def.schema, nil
+	}
+
+	def.loading = true
+	schema, err := imp.importNode(def.node, def.pointer)
+	def.loading = false
+	if err != nil {
+		return nil, fmt.Errorf("definition %s: %w", name, err)
+	}
+
+	metadata := schema.Metadata()
+	metadata.Name = name
+	def.schema = schemas.WithMetadata(schema, metadata)
+	return def.schema, nil
+}
+
+// unsupported records a keyword that was not imported.
+func (imp *Importer) unsupported(pointer, keyword, reason string) {
+	imp.report.Add(pointer, keyword, reason)
+}
+
+// resolveRef resolves a $ref. References to named definitions become
+// engine references; other local references are imported inline.
+func (imp *Importer) resolveRef(ref string, pointer string) (core.Schema, error) {
+	if !strings.HasPrefix(ref, "#") {
+		imp.unsupported(pointer, "$ref", fmt.Sprintf("remote reference %s", ref))
+		return schemas.NewAnySchema(schemas.AnySchemaConfig{}), nil
+	}
+
+	target, err := url.PathUnescape(strings.TrimPrefix(ref, "#"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid $ref %s: %w", ref, err)
+	}
+	for name, def := range imp.definitions {
+		if def.pointer == target {
+			return engine.NewRef(imp.engine, name), nil
+		}
+	}
+
+	node := jsonpointer.Lookup(imp.root, target)
+	if node == nil {
+		return nil, fmt.Errorf("unresolved $ref %s", ref)
+	}
+	if imp.inline[target] {
+		imp.unsupported(pointer, "$ref", fmt.Sprintf("recursive reference %s outside of the definitions", ref))
+		return schemas.NewAnySchema(schemas.AnySchemaConfig{}), nil
+	}
+	imp.inline[target] = true
+	defer delete(imp.inline, target)
+	return imp.importNode(node, target)
+}
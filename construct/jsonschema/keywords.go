@@ -0,0 +1,768 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/jsonpointer"
+	"defs.dev/schema/schemas"
+)
+
+// ignoredKeywords identify or document a schema without constraining values,
+// so leaving them out loses nothing.
+var ignoredKeywords = map[string]bool{
+	"$schema":  true,
+	"$id":      true,
+	"$comment": true,
+}
+
+// node is a JSON Schema object being imported. It tracks which keywords were
+// read, so the rest can be reported.
+type node struct {
+	values  map[string]any
+	pointer string
+	used    map[string]bool
+}
+
+func (n *node) get(keyword string) (any, bool) {
+	value, ok := n.values[keyword]
+	if ok {
+		n.used[keyword] = true
+	}
+	return value, ok
+}
+
+func (n *node) has(keyword string) bool {
+	_, ok := n.values[keyword]
+	return ok
+}
+
+// without returns a copy of the node's values minus the given keywords.
+func (n *node) without(keywords ...string) map[string]any {
+	values := make(map[string]any, len(n.values))
+	for k, v := range n.values {
+		values[k] = v
+	}
+	for _, keyword := range keywords {
+		delete(values, keyword)
+	}
+	return values
+}
+
+// consumeAll marks every keyword as read, for nodes imported through a copy.
+func (n *node) consumeAll() {
+	for keyword := range n.values {
+		n.used[keyword] = true
+	}
+}
+
+// child returns the pointer of a keyword below this node.
+func (n *node) child(tokens ...string) string {
+	pointer := n.pointer
+	for _, token := range tokens {
+		pointer += "/" + jsonpointer.Escape(token)
+	}
+	return pointer
+}
+
+// importNode converts a JSON Schema value to a schema.
+func (imp *Importer) importNode(value any, pointer string) (core.Schema, error) {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return schemas.NewAnySchema(schemas.AnySchemaConfig{}), nil
+		}
+		imp.unsupported(pointer, "false", "schema rejecting every value")
+		return schemas.NewAnySchema(schemas.AnySchemaConfig{}), nil
+	case map[string]any:
+		n := &node{values: v, pointer: pointer, used: make(map[string]bool)}
+		schema, err := imp.importObjectNode(n)
+		if err != nil {
+			return nil, err
+		}
+
+		keywords := make([]string, 0, len(v))
+		for keyword := range v {
+			if !n.used[keyword] && !ignoredKeywords[keyword] {
+				keywords = append(keywords, keyword)
+			}
+		}
+		sort.Strings(keywords)
+		for _, keyword := range keywords {
+			imp.unsupported(pointer, keyword, "")
+		}
+		return schema, nil
+	default:
+		return nil, fmt.Errorf("#%s: expected schema object, got %T", pointer, value)
+	}
+}
+
+func (imp *Importer) importObjectNode(n *node) (core.Schema, error) {
+	metadata := imp.metadata(n)
+
+	// Definitions are imported separately
+	if n.pointer == "" {
+		for _, path := range imp.definitionPaths {
+			n.get(strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0])
+		}
+	}
+
+	if ref, ok := n.get("$ref"); ok {
+		refString, ok := ref.(string)
+		if !ok {
+			return nil, fmt.Errorf("#%s: $ref must be a string", n.pointer)
+		}
+		schema, err := imp.resolveRef(refString, n.pointer)
+		if err != nil {
+			return nil, err
+		}
+		if metadata.Description != "" || len(metadata.Examples) > 0 {
+			metadata.Name = schema.Metadata().Name
+			schema = schemas.WithMetadata(schema, metadata)
+		}
+		return schema, nil
+	}
+
+	if _, ok := n.get("x-type-parameters"); ok {
+		return imp.importGeneric(n, metadata)
+	}
+	if name, ok := n.get("x-type-parameter"); ok {
+		return imp.importParameter(n, name, metadata)
+	}
+	if n.has("allOf") {
+		return imp.importAllOf(n, metadata)
+	}
+	if n.has("oneOf") || n.has("anyOf") {
+		return imp.importUnion(n, metadata)
+	}
+
+	typeValue, hasType := n.get("type")
+	if types, ok := typeValue.([]any); ok {
+		return imp.importTypeList(n, types, metadata)
+	}
+
+	schemaType, _ := typeValue.(string)
+	if !hasType {
+		schemaType = inferType(n)
+	}
+
+	switch schemaType {
+	case "string":
+		return imp.importString(n, metadata)
+	case "integer":
+		return imp.importInteger(n, metadata)
+	case "number":
+		return imp.importNumber(n, metadata)
+	case "boolean":
+		config := schemas.BooleanSchemaConfig{Metadata: metadata}
+		if err := imp.defaultValue(n, &config.DefaultVal); err != nil {
+			return nil, err
+		}
+		return schemas.NewBooleanSchema(config), nil
+	case "null":
+		return schemas.NewNullSchema(schemas.NullSchemaConfig{Metadata: metadata}), nil
+	case "array":
+		return imp.importArray(n, metadata)
+	case "object":
+		return imp.importObject(n, metadata)
+	case "":
+		return schemas.NewAnySchema(schemas.AnySchemaConfig{Metadata: metadata}), nil
+	default:
+		return nil, fmt.Errorf("#%s: unknown type %q", n.pointer, schemaType)
+	}
+}
+
+// inferType derives the type of a schema without a type keyword from the
+// keywords it uses.
+func inferType(n *node) string {
+	switch {
+	case n.has("properties") || n.has("additionalProperties") || n.has("patternProperties") || n.has("required"):
+		return "object"
+	case n.has("items") || n.has("prefixItems"):
+		return "array"
+	case n.has("enum") || n.has("const"):
+		if stringValues(n.values["enum"]) != nil || isString(n.values["const"]) {
+			return "string"
+		}
+	}
+	return ""
+}
+
+// metadata reads the annotation keywords shared by every schema.
+func (imp *Importer) metadata(n *node) core.SchemaMetadata {
+	var metadata core.SchemaMetadata
+	if title, ok := n.get("title"); ok {
+		metadata.Name, _ = title.(string)
+	}
+	if description, ok := n.get("description"); ok {
+		metadata.Description, _ = description.(string)
+	}
+	if examples, ok := n.get("examples"); ok {
+		metadata.Examples, _ = examples.([]any)
+	}
+	return metadata
+}
+
+// defaultValue reads the default keyword into target, reporting values of the wrong type.
+func (imp *Importer) defaultValue(n *node, target any) error {
+	value, ok := n.values["default"]
+	if !ok {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return nil // Left unread, so it is reported
+	}
+	n.used["default"] = true
+	return nil
+}
+
+func (imp *Importer) importString(n *node, metadata core.SchemaMetadata) (core.Schema, error) {
+	config := schemas.StringSchemaConfig{
+		Metadata:  metadata,
+		MinLength: intKeyword(n, "minLength"),
+		MaxLength: intKeyword(n, "maxLength"),
+	}
+	if pattern, ok := n.values["pattern"].(string); ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			imp.unsupported(n.pointer, "pattern", fmt.Sprintf("pattern is not a valid Go regular expression: %v", err))
+		} else {
+			config.Pattern = compiled
+		}
+		n.used["pattern"] = true
+	}
+	if format, ok := n.values["format"].(string); ok {
+		config.Format = format
+		n.used["format"] = true
+	}
+	if enum := stringValues(n.values["enum"]); enum != nil {
+		config.EnumValues = enum
+		n.used["enum"] = true
+	}
+	if constant, ok := n.values["const"].(string); ok && !n.has("enum") {
+		config.EnumValues = []string{constant}
+		n.used["const"] = true
+	}
+	if err := imp.defaultValue(n, &config.DefaultVal); err != nil {
+		return nil, err
+	}
+	return schemas.NewStringSchema(config), nil
+}
+
+func (imp *Importer) importInteger(n *node, metadata core.SchemaMetadata) (core.Schema, error) {
+	config := schemas.IntegerSchemaConfig{Metadata: metadata}
+
+	bound := func(keyword string, offset float64) *int64 {
+		value, ok := n.values[keyword].(float64)
+		if !ok || value != math.Trunc(value) {
+			return nil
+		}
+		n.used[keyword] = true
+		i := int64(value + offset)
+		return &i
+	}
+	config.Minimum = bound("minimum", 0)
+	if exclusive := bound("exclusiveMinimum", 1); exclusive != nil && (config.Minimum == nil || *exclusive > *config.Minimum) {
+		config.Minimum = exclusive
+	}
+	config.Maximum = bound("maximum", 0)
+	if exclusive := bound("exclusiveMaximum", -1); exclusive != nil && (config.Maximum == nil || *exclusive < *config.Maximum) {
+		config.Maximum = exclusive
+	}
+
+	if err := imp.defaultValue(n, &config.DefaultVal); err != nil {
+		return nil, err
+	}
+	return schemas.NewIntegerSchema(config), nil
+}
+
+func (imp *Importer) importNumber(n *node, metadata core.SchemaMetadata) (core.Schema, error) {
+	config := schemas.NumberSchemaConfig{Metadata: metadata}
+	if minimum, ok := n.values["minimum"].(float64); ok {
+		config.Minimum = &minimum
+		n.used["minimum"] = true
+	}
+	if maximum, ok := n.values["maximum"].(float64); ok {
+		config.Maximum = &maximum
+		n.used["maximum"] = true
+	}
+	if err := imp.defaultValue(n, &config.DefaultVal); err != nil {
+		return nil, err
+	}
+	return schemas.NewNumberSchema(config), nil
+}
+
+func (imp *Importer) importArray(n *node, metadata core.SchemaMetadata) (core.Schema, error) {
+	config := schemas.ArraySchemaConfig{
+		Metadata: metadata,
+		MinItems: intKeyword(n, "minItems"),
+		MaxItems: intKeyword(n, "maxItems"),
+	}
+	if unique, ok := n.values["uniqueItems"].(bool); ok {
+		config.UniqueItems = unique
+		n.used["uniqueItems"] = true
+	}
+
+	var err error
+	if contains, ok := n.get("contains"); ok {
+		if config.ContainsSchema, err = imp.importNode(contains, n.child("contains")); err != nil {
+			return nil, err
+		}
+	}
+
+	// Tuples are prefixItems plus items (2020-12) or an items array plus additionalItems (draft-07)
+	prefixItems, restKeyword := n.values["prefixItems"], "items"
+	prefixKeyword := "prefixItems"
+	if list, ok := n.values["items"].([]any); ok {
+		prefixItems, prefixKeyword, restKeyword = list, "items", "additionalItems"
+	}
+
+	if list, ok := prefixItems.([]any); ok {
+		n.used[prefixKeyword] = true
+		for i, item := range list {
+			schema, err := imp.importNode(item, n.child(prefixKeyword, fmt.Sprint(i)))
+			if err != nil {
+				return nil, err
+			}
+			config.PrefixItems = append(config.PrefixItems, schema)
+		}
+
+		rest, hasRest := n.get(restKeyword)
+		switch {
+		case !hasRest || rest == true:
+			config.RestItems = schemas.NewAnySchema(schemas.AnySchemaConfig{})
+		case rest == false:
+			// Closed tuple
+		default:
+			if config.RestItems, err = imp.importNode(rest, n.child(restKeyword)); err != nil {
+				return nil, err
+			}
+		}
+	} else if items, ok := n.get("items"); ok {
+		if config.ItemSchema, err = imp.importNode(items, n.child("items")); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := imp.defaultValue(n, &config.DefaultVal); err != nil {
+		return nil, err
+	}
+	return schemas.NewArraySchema(config), nil
+}
+
+func (imp *Importer) importObject(n *node, metadata core.SchemaMetadata) (core.Schema, error) {
+	additional, hasAdditional := n.values["additionalProperties"]
+	_, additionalIsSchema := additional.(map[string]any)
+
+	// An object described only by its values is a map
+	if additionalIsSchema && !n.has("properties") && !n.has("patternProperties") {
+		return imp.importMap(n, metadata)
+	}
+
+	config := schemas.ObjectSchemaConfig{
+		Metadata:             metadata,
+		Properties:           make(map[string]core.Schema),
+		AdditionalProperties: true,
+		MinProperties:        intKeyword(n, "minProperties"),
+		MaxProperties:        intKeyword(n, "maxProperties"),
+	}
+
+	if properties, ok := n.get("properties"); ok {
+		propertyMap, ok := properties.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("#%s: properties must be an object", n.pointer)
+		}
+		for name, prop := range propertyMap {
+			schema, err := imp.importNode(prop, n.child("properties", name))
+			if err != nil {
+				return nil, err
+			}
+			config.Properties[name] = schema
+		}
+	}
+	if required := stringValues(n.values["required"]); required != nil {
+		config.Required = required
+		n.used["required"] = true
+	}
+	if allowed, ok := additional.(bool); hasAdditional && ok {
+		config.AdditionalProperties = allowed
+		n.used["additionalProperties"] = true
+	}
+
+	if patterns, ok := n.values["patternProperties"].(map[string]any); ok {
+		n.used["patternProperties"] = true
+		config.PatternProperties = make(map[string]core.Schema)
+		for pattern, prop := range patterns {
+			schema, err := imp.importNode(prop, n.child("patternProperties", pattern))
+			if err != nil {
+				return nil, err
+			}
+			config.PatternProperties[pattern] = schema
+		}
+	}
+
+	// dependentRequired (2019-09+) and the array form of dependencies (draft-07)
+	for _, keyword := range []string{"dependentRequired", "dependencies"} {
+		dependencies, ok := n.values[keyword].(map[string]any)
+		if !ok {
+			continue
+		}
+		complete := true
+		for name, value := range dependencies {
+			required := stringValues(value)
+			if required == nil {
+				complete = false
+				continue
+			}
+			if config.PropertyDependencies == nil {
+				config.PropertyDependencies = make(map[string][]string)
+			}
+			config.PropertyDependencies[name] = required
+		}
+		if complete {
+			n.used[keyword] = true
+		} else {
+			imp.unsupported(n.pointer, keyword, "schema dependencies are not supported")
+			n.used[keyword] = true
+		}
+	}
+
+	if err := imp.defaultValue(n, &config.DefaultVal); err != nil {
+		return nil, err
+	}
+	return schemas.NewObjectSchema(config), nil
+}
+
+func (imp *Importer) importMap(n *node, metadata core.SchemaMetadata) (core.Schema, error) {
+	config := schemas.MapSchemaConfig{
+		Metadata: metadata,
+		MinItems: intKeyword(n, "minProperties"),
+		MaxItems: intKeyword(n, "maxProperties"),
+	}
+
+	var err error
+	values, _ := n.get("additionalProperties")
+	if config.ValueSchema, err = imp.importNode(values, n.child("additionalProperties")); err != nil {
+		return nil, err
+	}
+	if keys, ok := n.get("propertyNames"); ok {
+		if config.KeySchema, err = imp.importNode(keys, n.child("propertyNames")); err != nil {
+			return nil, err
+		}
+	} else {
+		config.KeySchema = schemas.NewStringSchema(schemas.StringSchemaConfig{})
+	}
+	return schemas.NewMapSchema(config), nil
+}
+
+// importTypeList imports a schema with several types. A type widened with
+// null is optional; other combinations become a union of the types.
+func (imp *Importer) importTypeList(n *node, types []any, metadata core.SchemaMetadata) (core.Schema, error) {
+	var nonNull []string
+	nullable := false
+	for _, t := range types {
+		switch t {
+		case "null":
+			nullable = true
+		default:
+			if s, ok := t.(string); ok {
+				nonNull = append(nonNull, s)
+			}
+		}
+	}
+
+	// Each type is imported from a copy of the node, which reports its own leftovers
+	n.consumeAll()
+	base := n.without("type", "title", "description", "examples")
+
+	var members []core.Schema
+	for _, t := range nonNull {
+		values := make(map[string]any, len(base)+1)
+		for k, v := range base {
+			values[k] = v
+		}
+		values["type"] = t
+		member, err := imp.importNode(values, n.pointer)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	var schema core.Schema
+	switch len(members) {
+	case 0:
+		schema = schemas.NewNullSchema(schemas.NullSchemaConfig{})
+		nullable = false
+	case 1:
+		schema = members[0]
+	default:
+		schema = schemas.NewUnionSchema(schemas.UnionSchemaConfig{Schemas: members, Mode: core.UnionAnyOf})
+	}
+
+	if nullable {
+		return schemas.NewOptionalSchema(schemas.OptionalSchemaConfig{Metadata: metadata, ItemSchema: schema}), nil
+	}
+	return schemas.WithMetadata(schema, metadata), nil
+}
+
+// importUnion imports oneOf and anyOf. An anyOf of a schema and null is
+// optional, and a oneOf of closed {"ok"} and {"err"} objects is a result.
+func (imp *Importer) importUnion(n *node, metadata core.SchemaMetadata) (core.Schema, error) {
+	keyword, mode := "oneOf", core.UnionOneOf
+	if !n.has("oneOf") {
+		keyword, mode = "anyOf", core.UnionAnyOf
+	}
+	branches, _ := n.get(keyword)
+	list, ok := branches.([]any)
+	if !ok {
+		return nil, fmt.Errorf("#%s: %s must be an array", n.pointer, keyword)
+	}
+
+	if result, ok := resultBranches(list); ok {
+		config := schemas.ResultSchemaConfig{Metadata: metadata}
+		var err error
+		if config.Success, err = imp.importPayload(result[0], n.child(keyword, "0", "properties", "ok")); err != nil {
+			return nil, err
+		}
+		if config.Error, err = imp.importPayload(result[1], n.child(keyword, "1", "properties", "err")); err != nil {
+			return nil, err
+		}
+		return schemas.NewResultSchema(config), nil
+	}
+
+	var members []core.Schema
+	nullIndex := -1
+	for i, branch := range list {
+		if isNullSchema(branch) {
+			nullIndex = i
+		}
+		member, err := imp.importNode(branch, n.child(keyword, fmt.Sprint(i)))
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	if len(members) == 2 && nullIndex >= 0 {
+		return schemas.NewOptionalSchema(schemas.OptionalSchemaConfig{
+			Metadata:   metadata,
+			ItemSchema: members[1-nullIndex],
+		}), nil
+	}
+
+	config := schemas.UnionSchemaConfig{Metadata: metadata, Schemas: members, Mode: mode}
+	if discriminator, ok := n.values["discriminator"].(map[string]any); ok {
+		if property, ok := discriminator["propertyName"].(string); ok {
+			config.Discriminator = property
+			n.used["discriminator"] = true
+		}
+	}
+	return schemas.NewUnionSchema(config), nil
+}
+
+// importPayload imports one side of a result; the empty schema means no payload schema.
+func (imp *Importer) importPayload(value any, pointer string) (core.Schema, error) {
+	if m, ok := value.(map[string]any); ok && len(m) == 0 {
+		return nil, nil
+	}
+	return imp.importNode(value, pointer)
+}
+
+// importAllOf imports an intersection of object schemas. Keywords next to
+// allOf form one more member.
+func (imp *Importer) importAllOf(n *node, metadata core.SchemaMetadata) (core.Schema, error) {
+	value, _ := n.get("allOf")
+	list, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("#%s: allOf must be an array", n.pointer)
+	}
+
+	var members []core.Schema
+	for i, member := range list {
+		schema, err := imp.importNode(member, n.child("allOf", fmt.Sprint(i)))
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, schema)
+	}
+
+	siblings := n.without("allOf", "title", "description", "examples")
+	for keyword := range ignoredKeywords {
+		delete(siblings, keyword)
+	}
+	if len(siblings) > 0 {
+		n.consumeAll()
+		schema, err := imp.importNode(siblings, n.pointer)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, schema)
+	}
+
+	if len(members) == 1 {
+		if metadata.Name == "" {
+			metadata.Name = members[0].Metadata().Name
+		}
+		return schemas.WithMetadata(members[0], metadata), nil
+	}
+
+	objects := make([]core.ObjectSchema, 0, len(members))
+	for _, member := range members {
+		object, ok := imp.objectSchema(member)
+		if !ok {
+			imp.unsupported(n.pointer, "allOf", "only intersections of object schemas are supported")
+			return schemas.WithMetadata(members[0], metadata), nil
+		}
+		objects = append(objects, object)
+	}
+
+	intersection, err := schemas.AllOf(objects...)
+	if err != nil {
+		imp.unsupported(n.pointer, "allOf", err.Error())
+		return schemas.WithMetadata(members[0], metadata), nil
+	}
+	metadata.Composition = intersection.Metadata().Composition
+	return schemas.WithMetadata(intersection, metadata), nil
+}
+
+// objectSchema returns the object a member of allOf stands for, following
+// references to definitions.
+func (imp *Importer) objectSchema(schema core.Schema) (core.ObjectSchema, bool) {
+	if ref, ok := schema.(core.RefSchema); ok {
+		if _, defined := imp.definitions[ref.ReferenceName()]; !defined {
+			return nil, false
+		}
+		target, err := imp.definition(ref.ReferenceName())
+		if err != nil || target == nil {
+			return nil, false
+		}
+		schema = target
+	}
+	object, ok := schema.(core.ObjectSchema)
+	return object, ok
+}
+
+// importParameter imports a type parameter exported with x-type-parameter.
+// The remaining keywords are its constraint.
+func (imp *Importer) importParameter(n *node, name any, metadata core.SchemaMetadata) (core.Schema, error) {
+	paramName, ok := name.(string)
+	if !ok {
+		return nil, fmt.Errorf("#%s: x-type-parameter must be a string", n.pointer)
+	}
+
+	config := schemas.TypeParameterSchemaConfig{Metadata: core.SchemaMetadata{Name: paramName, Description: metadata.Description}}
+	constraint := n.without("x-type-parameter", "description")
+	if len(constraint) > 0 {
+		n.consumeAll()
+		schema, err := imp.importNode(constraint, n.pointer)
+		if err != nil {
+			return nil, err
+		}
+		config.Constraint = schema
+	}
+
+	param := schemas.NewTypeParameterSchema(config)
+	if imp.parameters != nil {
+		imp.parameters[paramName] = param
+	}
+	return param, nil
+}
+
+// importGeneric imports a generic template exported with x-type-parameters.
+func (imp *Importer) importGeneric(n *node, metadata core.SchemaMetadata) (core.Schema, error) {
+	value, _ := n.get("x-type-parameters")
+	names := stringValues(value)
+	if names == nil {
+		return nil, fmt.Errorf("#%s: x-type-parameters must be an array of strings", n.pointer)
+	}
+
+	outer := imp.parameters
+	imp.parameters = make(map[string]core.TypeParameterSchema)
+	defer func() { imp.parameters = outer }()
+
+	n.consumeAll()
+	template, err := imp.importNode(n.without("x-type-parameters", "title", "description", "examples"), n.pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	config := schemas.GenericSchemaConfig{Metadata: metadata, Template: template}
+	for _, name := range names {
+		param, ok := imp.parameters[name]
+		if !ok {
+			param = schemas.NewTypeParameterSchema(schemas.TypeParameterSchemaConfig{Metadata: core.SchemaMetadata{Name: name}})
+		}
+		config.TypeParameters = append(config.TypeParameters, param)
+	}
+	return schemas.NewGenericSchema(config), nil
+}
+
+// resultBranches reports whether branches are the closed {"ok"} and {"err"}
+// objects the exporter writes for a result, and returns their payload schemas.
+func resultBranches(branches []any) ([2]any, bool) {
+	var payloads [2]any
+	if len(branches) != 2 {
+		return payloads, false
+	}
+	for i, key := range []string{"ok", "err"} {
+		branch, ok := branches[i].(map[string]any)
+		if !ok || branch["additionalProperties"] != false {
+			return payloads, false
+		}
+		properties, ok := branch["properties"].(map[string]any)
+		required := stringValues(branch["required"])
+		if !ok || len(properties) != 1 || len(required) != 1 || required[0] != key || properties[key] == nil {
+			return payloads, false
+		}
+		payloads[i] = properties[key]
+	}
+	return payloads, true
+}
+
+func isNullSchema(value any) bool {
+	m, ok := value.(map[string]any)
+	return ok && len(m) == 1 && m["type"] == "null"
+}
+
+func isString(value any) bool {
+	_, ok := value.(string)
+	return ok
+}
+
+// stringValues returns value as a list of strings, or nil if it is not one.
+func stringValues(value any) []string {
+	list, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+// intKeyword reads a non-negative integer keyword.
+func intKeyword(n *node, keyword string) *int {
+	value, ok := n.values[keyword].(float64)
+	if !ok || value < 0 || value != math.Trunc(value) {
+		return nil
+	}
+	n.used[keyword] = true
+	i := int(value)
+	return &i
+}
@@ -3,12 +3,14 @@ package native
 import (
 	"defs.dev/schema/construct/builders"
 	"defs.dev/schema/core/annotation"
+	"defs.dev/schema/engine"
 	"defs.dev/schema/runtime/registry"
 	"fmt"
 	"reflect"
 	"sync"
 
 	"defs.dev/schema/core"
+	"defs.dev/schema/core/structure"
 )
 
 // DefaultTypeConverter implements TypeConverter with annotation support.
@@ -17,8 +19,15 @@ type DefaultTypeConverter struct {
 	validatorRegistry  registry.ValidatorRegistry
 	tagParser          TagParser
 	config             ConverterConfig
+	schemaEngine       engine.SchemaEngine
 	cache              map[reflect.Type]core.Schema
 	mu                 sync.RWMutex
+
+	// Recursive struct tracking. Conversions are serialized by convertMu so the
+	// in-progress set always describes a single conversion.
+	convertMu  sync.Mutex
+	inProgress map[reflect.Type]bool
+	recursive  map[reflect.Type]bool
 }
 
 // NewDefaultTypeConverter creates a new type converter with default configuration.
@@ -30,6 +39,8 @@ func NewDefaultTypeConverter(
 		annotationRegistry: annotationRegistry,
 		validatorRegistry:  validatorRegistry,
 		cache:              make(map[reflect.Type]core.Schema),
+		inProgress:         make(map[reflect.Type]bool),
+		recursive:          make(map[reflect.Type]bool),
 		config: ConverterConfig{
 			StrictMode:          false,
 			DefaultAnnotations:  true,
@@ -73,7 +84,9 @@ func (c *DefaultTypeConverter) FromTypeWithAnnotations(t reflect.Type, annotatio
 		c.mu.RUnlock()
 	}
 
+	c.convertMu.Lock()
 	schema, err := c.convertType(t, annotations, 0)
+	c.convertMu.Unlock()
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +162,25 @@ func (c *DefaultTypeConverter) SetStrictMode(strict bool) {
 	}
 }
 
+// SetSchemaEngine implements TypeConverter.
+// Recursive structs are registered with this engine and referenced by name.
+func (c *DefaultTypeConverter) SetSchemaEngine(engine engine.SchemaEngine) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.schemaEngine = engine
+}
+
+// GetSchemaEngine implements TypeConverter.
+// An engine is created on first use if none has been set.
+func (c *DefaultTypeConverter) GetSchemaEngine() engine.SchemaEngine {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.schemaEngine == nil {
+		c.schemaEngine = engine.NewSchemaEngine()
+	}
+	return c.schemaEngine
+}
+
 // GetSupportedTags implements TypeConverter.
 func (c *DefaultTypeConverter) GetSupportedTags() []string {
 	if c.tagParser != nil {
@@ -272,14 +304,17 @@ func (c *DefaultTypeConverter) convertArray(t reflect.Type, annotations []annota
 }
 
 func (c *DefaultTypeConverter) convertMap(t reflect.Type, annotations []annotation.Annotation, depth int) (core.Schema, error) {
-	// For maps, we create an object schema with dictionary-like behavior
-	valueType := t.Elem()
-	valueSchema, err := c.convertType(valueType, nil, depth+1)
+	keySchema, err := c.convertType(t.Key(), nil, depth+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert map key type: %v", err)
+	}
+
+	valueSchema, err := c.convertType(t.Elem(), nil, depth+1)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert map value type: %v", err)
 	}
 
-	builder := builders.NewObjectSchema().Dict(valueSchema)
+	builder := builders.NewMapSchema().Keys(keySchema).Values(valueSchema)
 
 	// Apply annotations
 	for _, ann := range annotations {
@@ -292,6 +327,18 @@ func (c *DefaultTypeConverter) convertMap(t reflect.Type, annotations []annotati
 }
 
 func (c *DefaultTypeConverter) convertStruct(t reflect.Type, annotations []annotation.Annotation, depth int) (core.Schema, error) {
+	// A struct that is already being converted refers to itself; emit a
+	// reference to it instead of expanding it again
+	if t.Name() != "" {
+		if c.inProgress[t] {
+			c.recursive[t] = true
+			return engine.NewRef(c.GetSchemaEngine(), t.Name()), nil
+		}
+		c.inProgress[t] = true
+		defer delete(c.inProgress, t)
+		defer delete(c.recursive, t)
+	}
+
 	builder := builders.NewObjectSchema()
 
 	// Process struct fields
@@ -357,18 +404,41 @@ func (c *DefaultTypeConverter) convertStruct(t reflect.Type, annotations []annot
 		}
 	}
 
-	return builder.Build(), nil
+	if !c.recursive[t] {
+		return builder.Build(), nil
+	}
+
+	// Recursive structs are named and registered so their references resolve.
+	// A different schema registered under the name, such as that of another
+	// type of the same name, would be what the references resolve to.
+	schema := builder.Name(t.Name()).Build()
+	schemaEngine := c.GetSchemaEngine()
+	if existing, err := schemaEngine.ResolveSchema(t.Name()); err == nil {
+		if !structure.Equal(existing, schema) {
+			return nil, fmt.Errorf("recursive type %s conflicts with a different schema registered as %s", t, t.Name())
+		}
+		return schema, nil
+	}
+	if err := schemaEngine.RegisterSchema(t.Name(), schema); err != nil {
+		return nil, fmt.Errorf("failed to register recursive type %s: %v", t.Name(), err)
+	}
+
+	return schema, nil
 }
 
 func (c *DefaultTypeConverter) convertPointer(t reflect.Type, annotations []annotation.Annotation, depth int) (core.Schema, error) {
-	// For pointers, convert the pointed-to type
-	elemType := t.Elem()
-	return c.convertType(elemType, annotations, depth+1)
+	// Pointers may be nil, so the pointed-to type is wrapped in an optional schema
+	elemSchema, err := c.convertType(t.Elem(), annotations, depth+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return builders.NewOptionalSchema().Of(elemSchema).Build(), nil
 }
 
 func (c *DefaultTypeConverter) convertInterface(t reflect.Type, annotations []annotation.Annotation) (core.Schema, error) {
-	// For interfaces, we create a flexible schema
-	builder := builders.NewObjectSchema().Flexible()
+	// Interfaces can hold any value, including scalars and nil
+	builder := builders.NewAnySchema()
 
 	// Apply annotations
 	for _, ann := range annotations {
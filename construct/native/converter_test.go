@@ -25,7 +25,8 @@ func TestDefaultTypeConverter_BasicTypes(t *testing.T) {
 		{"float64", 3.14, core.TypeNumber},
 		{"bool", true, core.TypeBoolean},
 		{"slice", []string{"a", "b"}, core.TypeArray},
-		{"map", map[string]int{"key": 1}, core.TypeStructure},
+		{"map", map[string]int{"key": 1}, core.TypeMap},
+		{"pointer", new(string), core.TypeOptional},
 	}
 
 	for _, tt := range tests {
@@ -125,3 +126,99 @@ func TestDefaultTypeConverter_Configuration(t *testing.T) {
 		t.Error("GetSupportedTags() returned empty slice")
 	}
 }
+
+func TestDefaultTypeConverter_RecursiveStruct(t *testing.T) {
+	// Setup
+	annotationReg := annotation.NewRegistry()
+	validatorReg := registry.NewDefaultValidatorRegistry(annotationReg)
+	converter := NewDefaultTypeConverter(annotationReg, validatorReg)
+
+	type TreeNode struct {
+		Value    string      `json:"value"`
+		Children []*TreeNode `json:"children"`
+	}
+
+	schema, err := converter.FromType(reflect.TypeOf(TreeNode{}))
+	if err != nil {
+		t.Fatalf("FromType() error = %v", err)
+	}
+	if schema.Type() != core.TypeStructure {
+		t.Fatalf("FromType() type = %v, want object", schema.Type())
+	}
+
+	// The recursive field refers back to the struct through a reference
+	children := schema.(core.ObjectSchema).Properties()["children"].(core.ArraySchema)
+	item := children.ItemSchema().(core.OptionalSchema).ItemSchema()
+	ref, ok := item.(core.RefSchema)
+	if !ok {
+		t.Fatalf("children item type = %v, want ref", item.Type())
+	}
+	if ref.ReferenceName() != "TreeNode" {
+		t.Errorf("ReferenceName() = %v, want TreeNode", ref.ReferenceName())
+	}
+
+	resolved, err := ref.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if resolved.Metadata().Name != "TreeNode" {
+		t.Errorf("resolved schema name = %v, want TreeNode", resolved.Metadata().Name)
+	}
+	if !converter.GetSchemaEngine().HasSchema("TreeNode") {
+		t.Error("recursive type was not registered with the schema engine")
+	}
+}
+
+func TestDefaultTypeConverter_InterfaceField(t *testing.T) {
+	// Setup
+	annotationReg := annotation.NewRegistry()
+	validatorReg := registry.NewDefaultValidatorRegistry(annotationReg)
+	converter := NewDefaultTypeConverter(annotationReg, validatorReg)
+
+	type Event struct {
+		Payload interface{} `json:"payload"`
+	}
+
+	schema, err := converter.FromType(reflect.TypeOf(Event{}))
+	if err != nil {
+		t.Fatalf("FromType() error = %v", err)
+	}
+
+	payload := schema.(core.ObjectSchema).Properties()["payload"]
+	if payload.Type() != core.TypeAny {
+		t.Errorf("payload type = %v, want %v", payload.Type(), core.TypeAny)
+	}
+}
+
+func TestDefaultTypeConverter_RecursiveStructNameConflict(t *testing.T) {
+	// Setup
+	annotationReg := annotation.NewRegistry()
+	validatorReg := registry.NewDefaultValidatorRegistry(annotationReg)
+	converter := NewDefaultTypeConverter(annotationReg, validatorReg)
+
+	type TreeNode struct {
+		Value    string      `json:"value"`
+		Children []*TreeNode `json:"children"`
+	}
+	if _, err := converter.FromType(reflect.TypeOf(TreeNode{})); err != nil {
+		t.Fatalf("FromType() error = %v", err)
+	}
+	// Converting the same type with another converter of the engine reuses its registration
+	again := NewDefaultTypeConverter(annotationReg, validatorReg)
+	again.SetSchemaEngine(converter.GetSchemaEngine())
+	if _, err := again.FromType(reflect.TypeOf(TreeNode{})); err != nil {
+		t.Fatalf("FromType() error = %v", err)
+	}
+
+	// Another type of the same name must not share the registered schema
+	other := func() reflect.Type {
+		type TreeNode struct {
+			Count    int         `json:"count"`
+			Children []*TreeNode `json:"children"`
+		}
+		return reflect.TypeOf(TreeNode{})
+	}()
+	if _, err := converter.FromType(other); err == nil {
+		t.Error("FromType() expected an error for a conflicting type name")
+	}
+}
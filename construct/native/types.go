@@ -5,6 +5,7 @@ package native
 
 import (
 	"defs.dev/schema/core/annotation"
+	"defs.dev/schema/engine"
 	"defs.dev/schema/runtime/registry"
 	"reflect"
 
@@ -30,10 +31,12 @@ type TypeConverter interface {
 	SetAnnotationRegistry(registry annotation.AnnotationRegistry)
 	SetValidatorRegistry(registry registry.ValidatorRegistry)
 	SetStrictMode(strict bool)
+	SetSchemaEngine(engine engine.SchemaEngine)
 
 	// Metadata
 	GetSupportedTags() []string
 	GetConfiguration() ConverterConfig
+	GetSchemaEngine() engine.SchemaEngine
 }
 
 // ServiceDiscovery analyzes Go types for service/function definitions.
@@ -0,0 +1,157 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"defs.dev/schema/api"
+	"defs.dev/schema/core"
+	"defs.dev/schema/runtime/portal"
+)
+
+// ResponseError is returned by operation functions when the endpoint responds
+// with a status outside 2xx.
+type ResponseError struct {
+	Operation  string
+	StatusCode int
+
+	// Body is the decoded JSON response body, or the raw text if it is not JSON.
+	Body any
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%s: unexpected status %d", e.Operation, e.StatusCode)
+}
+
+// Functions returns a function for every operation that calls its endpoint at
+// BaseURL through the given HTTP portal.
+func (a *API) Functions(p *portal.HTTPPortal) []api.Function {
+	functions := make([]api.Function, len(a.Operations))
+	for i, op := range a.Operations {
+		functions[i] = &operationFunction{operation: op, baseURL: a.BaseURL, portal: p}
+	}
+	return functions
+}
+
+// Register registers the functions of every operation with a function registry.
+func (a *API) Register(registry api.FunctionRegistry, p *portal.HTTPPortal) error {
+	for _, fn := range a.Functions(p) {
+		if err := registry.Register(fn.Name(), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// operationFunction calls an operation's endpoint.
+type operationFunction struct {
+	operation *Operation
+	baseURL   string
+	portal    *portal.HTTPPortal
+}
+
+var _ api.Function = (*operationFunction)(nil)
+
+func (f *operationFunction) Name() string {
+	return f.operation.Name
+}
+
+func (f *operationFunction) Schema() core.FunctionSchema {
+	return f.operation.Function
+}
+
+// Call sends the request described by the operation and returns the decoded
+// response body as the "result" output.
+func (f *operationFunction) Call(ctx context.Context, params api.FunctionData) (api.FunctionData, error) {
+	req, err := f.request(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := f.portal.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.operation.Name, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: reading response: %w", f.operation.Name, err)
+	}
+
+	var body any
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &body); err != nil {
+			body = string(data)
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &ResponseError{Operation: f.operation.Name, StatusCode: resp.StatusCode, Body: body}
+	}
+	if len(f.operation.Function.Outputs().Args()) == 0 {
+		return api.NewFunctionData(nil), nil
+	}
+	return api.NewFunctionData(map[string]any{"result": body}), nil
+}
+
+// request builds the HTTP request for the given inputs.
+func (f *operationFunction) request(ctx context.Context, params api.FunctionData) (*http.Request, error) {
+	path := f.operation.Path
+	query := url.Values{}
+	header := http.Header{}
+	var body io.Reader
+
+	for _, param := range f.operation.Parameters {
+		value, ok := params.Get(param.Name)
+		if !ok || value == nil {
+			if param.Required {
+				return nil, fmt.Errorf("%s: missing required input %s", f.operation.Name, param.Name)
+			}
+			continue
+		}
+
+		switch param.In {
+		case InPath:
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", url.PathEscape(fmt.Sprint(value)))
+		case InQuery:
+			if list, ok := value.([]any); ok {
+				for _, item := range list {
+					query.Add(param.Name, fmt.Sprint(item))
+				}
+			} else {
+				query.Set(param.Name, fmt.Sprint(value))
+			}
+		case InHeader:
+			header.Set(param.Name, fmt.Sprint(value))
+		case InBody:
+			data, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: encoding body: %w", f.operation.Name, err)
+			}
+			body = bytes.NewReader(data)
+			header.Set("Content-Type", "application/json")
+		}
+	}
+
+	target := strings.TrimSuffix(f.baseURL, "/") + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, f.operation.Method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.operation.Name, err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
@@ -0,0 +1,381 @@
+// Package openapi imports OpenAPI 3.1 documents as service schemas.
+//
+// Component schemas under /components/schemas are imported with the JSON
+// Schema importer and registered with a SchemaEngine. Every operation becomes
+// a method of one core.ServiceSchema, taking its path, query and header
+// parameters plus a "body" input for the JSON request body, and returning the
+// JSON response as its "result" output.
+//
+// Imported operations can be called through an HTTP portal:
+//
+//	spec, err := openapi.Import(data, engine.NewSchemaEngine())
+//	for _, fn := range spec.Functions(httpPortal) {
+//		registry.Register(fn.Name(), fn)
+//	}
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"defs.dev/schema/construct/jsonschema"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/jsonpointer"
+	"defs.dev/schema/engine"
+	"defs.dev/schema/schemas"
+)
+
+// methods are the operations of a path item, in document order.
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// ParameterLocation is where an operation parameter is sent.
+type ParameterLocation string
+
+const (
+	InPath   ParameterLocation = "path"
+	InQuery  ParameterLocation = "query"
+	InHeader ParameterLocation = "header"
+	InBody   ParameterLocation = "body"
+)
+
+// Parameter is an input of an operation and where it goes in the request.
+type Parameter struct {
+	Name     string
+	In       ParameterLocation
+	Required bool
+}
+
+// Operation is an imported API operation.
+type Operation struct {
+	// Name is the operationId, or a name derived from the method and path.
+	Name       string
+	Method     string
+	Path       string
+	Parameters []Parameter
+
+	// Function describes the operation's inputs, its "result" output and its error response.
+	Function core.FunctionSchema
+}
+
+// API is an imported OpenAPI document.
+type API struct {
+	// BaseURL is the URL of the first server, used by Functions. It may be changed before calling it.
+	BaseURL    string
+	Service    core.ServiceSchema
+	Operations []*Operation
+
+	// Report lists the schema keywords and OpenAPI features that were not imported.
+	Report *jsonschema.Report
+}
+
+// Operation returns the operation with the given name.
+func (a *API) Operation(name string) (*Operation, bool) {
+	for _, op := range a.Operations {
+		if op.Name == name {
+			return op, true
+		}
+	}
+	return nil, false
+}
+
+// Import parses an OpenAPI 3.1 document in JSON, registers its component
+// schemas with the engine and returns its operations.
+func Import(data []byte, e engine.SchemaEngine) (*API, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	return ImportDocument(doc, e)
+}
+
+// ImportDocument imports a decoded OpenAPI document.
+func ImportDocument(doc map[string]any, e engine.SchemaEngine) (*API, error) {
+	version, _ := doc["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q", version)
+	}
+
+	imp := &importer{
+		doc:    doc,
+		schema: jsonschema.NewImporter(e, jsonschema.WithDefinitionsPath("/components/schemas")),
+	}
+	if err := imp.schema.LoadDefinitions(doc); err != nil {
+		return nil, err
+	}
+
+	info, _ := doc["info"].(map[string]any)
+	title, _ := info["title"].(string)
+	description, _ := info["description"].(string)
+	infoVersion, _ := info["version"].(string)
+
+	service := schemas.NewServiceSchema(title)
+	result := &API{Report: imp.schema.Report()}
+	if servers, ok := doc["servers"].([]any); ok && len(servers) > 0 {
+		if server, ok := servers[0].(map[string]any); ok {
+			result.BaseURL, _ = server["url"].(string)
+		}
+	}
+
+	paths, _ := doc["paths"].(map[string]any)
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	for _, path := range pathNames {
+		item, ok := imp.resolve(paths[path]).(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, method := range methods {
+			operation, ok := item[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			op, err := imp.operation(path, method, item, operation)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			if _, exists := result.Operation(op.Name); exists {
+				return nil, fmt.Errorf("operation %s is defined more than once", op.Name)
+			}
+			result.Operations = append(result.Operations, op)
+			service = service.WithMethod(op.Name, op.Function)
+		}
+	}
+
+	result.Service = service.WithMetadata(core.SchemaMetadata{
+		Name:        title,
+		Description: description,
+		Version:     infoVersion,
+	})
+	return result, nil
+}
+
+// importer holds the state of one document import.
+type importer struct {
+	doc    map[string]any
+	schema *jsonschema.Importer
+}
+
+// operation imports one operation of a path item.
+func (imp *importer) operation(path, method string, item, operation map[string]any) (*Operation, error) {
+	pointer := "/paths/" + jsonpointer.Escape(path) + "/" + method
+
+	op := &Operation{Method: strings.ToUpper(method), Path: path}
+	op.Name, _ = operation["operationId"].(string)
+	if op.Name == "" {
+		op.Name = operationName(method, path)
+	}
+
+	inputs := schemas.NewArgSchemas()
+	outputs := schemas.NewArgSchemas()
+
+	parameters, err := imp.parameters(pointer, item, operation)
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range parameters {
+		op.Parameters = append(op.Parameters, param.Parameter)
+		inputs.AddArg(schemas.NewArgSchemaWithOptions(param.Name, param.schema, param.description, !param.Required, nil))
+	}
+
+	if body, ok := imp.resolve(operation["requestBody"]).(map[string]any); ok {
+		schema, err := imp.content(pointer+"/requestBody", body)
+		if err != nil {
+			return nil, err
+		}
+		if schema != nil {
+			required, _ := body["required"].(bool)
+			description, _ := body["description"].(string)
+			op.Parameters = append(op.Parameters, Parameter{Name: "body", In: InBody, Required: required})
+			inputs.AddArg(schemas.NewArgSchemaWithOptions("body", schema, description, !required, nil))
+		}
+	}
+
+	var errorSchema core.Schema
+	responses, _ := operation["responses"].(map[string]any)
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	success := false
+	for _, code := range codes {
+		response, ok := imp.resolve(responses[code]).(map[string]any)
+		if !ok {
+			continue
+		}
+		isSuccess := strings.HasPrefix(code, "2")
+		if (isSuccess && success) || (!isSuccess && errorSchema != nil) {
+			continue
+		}
+		schema, err := imp.content(pointer+"/responses/"+jsonpointer.Escape(code), response)
+		if err != nil {
+			return nil, err
+		}
+		if isSuccess {
+			success = true
+			if schema != nil {
+				description, _ := response["description"].(string)
+				outputs.AddArg(schemas.NewArgSchemaWithOptions("result", schema, description, false, nil))
+			}
+		} else if schema != nil {
+			errorSchema = schema
+		}
+	}
+
+	fn := schemas.NewFunctionSchema(inputs, outputs)
+	if errorSchema != nil {
+		fn = fn.WithError(errorSchema)
+	}
+
+	metadata := core.SchemaMetadata{Name: op.Name}
+	metadata.Description, _ = operation["summary"].(string)
+	if description, ok := operation["description"].(string); ok && description != "" {
+		metadata.Description = description
+	}
+	if tags, ok := operation["tags"].([]any); ok {
+		for _, tag := range tags {
+			if s, ok := tag.(string); ok {
+				metadata.Tags = append(metadata.Tags, s)
+			}
+		}
+	}
+	op.Function = fn.WithMetadata(metadata)
+	return op, nil
+}
+
+// parameter is an imported parameter together with its schema.
+type parameter struct {
+	Parameter
+	schema      core.Schema
+	description string
+}
+
+// parameters imports the parameters of an operation. Operation parameters
+// override path item parameters with the same name and location.
+func (imp *importer) parameters(pointer string, item, operation map[string]any) ([]parameter, error) {
+	type source struct {
+		list    []any
+		pointer string
+	}
+	itemParams, _ := item["parameters"].([]any)
+	operationParams, _ := operation["parameters"].([]any)
+	sources := []source{
+		{itemParams, pointer[:strings.LastIndex(pointer, "/")] + "/parameters"},
+		{operationParams, pointer + "/parameters"},
+	}
+
+	var result []parameter
+	index := make(map[string]int)
+	for _, src := range sources {
+		for i, raw := range src.list {
+			paramPointer := fmt.Sprintf("%s/%d", src.pointer, i)
+			param, ok := imp.resolve(raw).(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("#%s: invalid parameter", paramPointer)
+			}
+
+			name, _ := param["name"].(string)
+			in, _ := param["in"].(string)
+			location := ParameterLocation(in)
+			switch location {
+			case InPath, InQuery, InHeader:
+			default:
+				imp.schema.Report().Add(paramPointer, "in", fmt.Sprintf("%s parameters are not supported", in))
+				continue
+			}
+
+			var schema core.Schema
+			if raw, ok := param["schema"]; ok {
+				var err error
+				if schema, err = imp.schema.ImportSchema(raw, paramPointer+"/schema"); err != nil {
+					return nil, err
+				}
+			} else {
+				schema = schemas.NewAnySchema(schemas.AnySchemaConfig{})
+			}
+
+			required, _ := param["required"].(bool)
+			description, _ := param["description"].(string)
+			p := parameter{
+				Parameter:   Parameter{Name: name, In: location, Required: required || location == InPath},
+				schema:      schema,
+				description: description,
+			}
+
+			key := in + ":" + name
+			if existing, ok := index[key]; ok {
+				result[existing] = p
+				continue
+			}
+			for _, other := range result {
+				if other.Name == name {
+					return nil, fmt.Errorf("parameter %s is both in %s and %s", name, other.In, in)
+				}
+			}
+			index[key] = len(result)
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// content imports the JSON schema of a request body or response. It returns
+// nil when there is no JSON content.
+func (imp *importer) content(pointer string, holder map[string]any) (core.Schema, error) {
+	content, _ := holder["content"].(map[string]any)
+	types := make([]string, 0, len(content))
+	for mediaType := range content {
+		types = append(types, mediaType)
+	}
+	sort.Strings(types)
+
+	for _, mediaType := range types {
+		if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+			continue
+		}
+		media, _ := content[mediaType].(map[string]any)
+		raw, ok := media["schema"]
+		if !ok {
+			return schemas.NewAnySchema(schemas.AnySchemaConfig{}), nil
+		}
+		return imp.schema.ImportSchema(raw, pointer+"/content/"+jsonpointer.Escape(mediaType)+"/schema")
+	}
+	return nil, nil
+}
+
+// resolve follows a $ref to a reusable component, such as a parameter or a
+// response. Schema references are left to the schema importer.
+func (imp *importer) resolve(value any) any {
+	for range 16 {
+		node, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		ref, ok := node["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return value
+		}
+		value = jsonpointer.Lookup(imp.doc, strings.TrimPrefix(ref, "#"))
+	}
+	return nil
+}
+
+// operationName derives an operation name from the method and path, such as
+// getPetsPetId for GET /pets/{petId}.
+func operationName(method, path string) string {
+	var b strings.Builder
+	b.WriteString(method)
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == '-' || r == '_' || r == '.'
+	}) {
+		b.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,252 @@
+// Package coercion converts loosely typed values to the types of their schemas
+// using the consumer framework.
+//
+// Query strings, form posts, environment variables and CSV cells arrive as
+// strings. Coercion walks a value alongside its schema and converts it to the
+// schema's types before validation: strings to integers, numbers and
+// booleans, comma separated lists to arrays and RFC 3339 strings to
+// time.Time for date-time strings. Every conversion performed is reported:
+//
+//	result, err := coercion.Coerce(schema, value, coercion.Options{Mode: coercion.ModeLenient})
+//	for _, c := range result.Coercions {
+//		log.Printf("%s: %v -> %v", c.Path, c.From, c.To)
+//	}
+//
+// ModeStrict only converts canonical representations, such as "42" and
+// "true", and fails for strings that cannot be converted to the number,
+// integer or boolean they stand for.
+// ModeLenient also accepts common variations, such as " 42 ", "3.0" for
+// integers and "yes" for booleans, and leaves values it cannot convert for
+// validation to report. The input value is never modified.
+package coercion
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"defs.dev/schema/consume/transform"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+	"defs.dev/schema/core/jsonpointer"
+)
+
+// Mode selects how permissive coercion is.
+type Mode string
+
+const (
+	// ModeStrict converts canonical representations only and fails for
+	// strings that cannot be converted.
+	ModeStrict Mode = "strict"
+	// ModeLenient also converts common variations and leaves values that
+	// cannot be converted unchanged.
+	ModeLenient Mode = "lenient"
+)
+
+// DefaultSeparator separates the items of lists coerced to arrays
+const DefaultSeparator = ","
+
+// Options configures coercion.
+type Options struct {
+	// Mode defaults to ModeStrict
+	Mode Mode `json:"mode,omitempty"`
+
+	// UseNumber converts numeric strings to json.Number instead of int64 or
+	// float64, which preserves their exact representation
+	UseNumber bool `json:"useNumber,omitempty"`
+
+	// Separator splits strings coerced to arrays; defaults to DefaultSeparator
+	Separator string `json:"separator,omitempty"`
+}
+
+// Coercion records a conversion performed on a value.
+type Coercion struct {
+	Path string          `json:"path"` // JSON Pointer to the value
+	Type core.SchemaType `json:"type"` // type converted to
+	From any             `json:"from"`
+	To   any             `json:"to"`
+}
+
+// Result holds a coerced value and the conversions performed.
+type Result struct {
+	Value     any        `json:"value"`
+	Coercions []Coercion `json:"coercions"`
+}
+
+// Failure describes a value that could not be converted in ModeStrict.
+type Failure struct {
+	Path    string          `json:"path"`
+	Type    core.SchemaType `json:"type"`
+	Value   any             `json:"value"`
+	Message string          `json:"message"`
+}
+
+// Error is returned in ModeStrict for values that could not be converted.
+type Error struct {
+	Failures []Failure
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		messages[i] = f.Message
+		if f.Path != "" {
+			messages[i] = f.Path + ": " + f.Message
+		}
+	}
+	return "coercion failed: " + strings.Join(messages, "; ")
+}
+
+// optionCoercer is the Options key under which the coercion run is passed to consumers
+const optionCoercer = "coercer"
+
+// kind is the kind of the results of coercion consumers
+const kind transform.Kind = "coercion"
+
+// coercer holds the state of a coercion run.
+type coercer struct {
+	registry  consumer.Registry
+	options   Options
+	coercions []Coercion
+	failures  []Failure
+}
+
+// Coerce converts a value to the types of a schema with the built-in coercion consumers.
+func Coerce(schema core.Schema, value any, options Options) (Result, error) {
+	return CoerceWithRegistry(NewCoercionRegistry(), schema, value, options)
+}
+
+// CoerceWithRegistry converts a value using the transform consumers of a registry.
+// In ModeStrict the result holds the partially coerced value along with an *Error.
+func CoerceWithRegistry(registry consumer.Registry, schema core.Schema, value any, options Options) (Result, error) {
+	if schema == nil {
+		return Result{}, fmt.Errorf("schema cannot be nil")
+	}
+	if options.Mode == "" {
+		options.Mode = ModeStrict
+	}
+	if options.Separator == "" {
+		options.Separator = DefaultSeparator
+	}
+
+	c := &coercer{registry: registry, options: options}
+	coerced, err := c.coerce(schema, value, []string{}, nil, 0)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{Value: coerced, Coercions: c.coercions}
+	if result.Coercions == nil {
+		result.Coercions = []Coercion{}
+	}
+	if len(c.failures) > 0 {
+		return result, &Error{Failures: c.failures}
+	}
+	return result, nil
+}
+
+// CoerceChild coerces the value of a child schema of the schema being
+// processed. Custom coercion consumers use it to recurse.
+func CoerceChild(ctx consumer.ProcessingContext, segment string, schema core.Schema, value any) (any, error) {
+	c, ok := ctx.Options[optionCoercer].(*coercer)
+	if !ok {
+		return nil, fmt.Errorf("coercion consumers must be run by Coerce")
+	}
+	return c.coerce(schema, value, transform.AppendPath(ctx.Path, segment), ctx.Schema, transform.Depth(ctx)+1)
+}
+
+// OptionsFrom returns the options of the coercion run processing a context.
+func OptionsFrom(ctx consumer.ProcessingContext) Options {
+	if c, ok := ctx.Options[optionCoercer].(*coercer); ok {
+		return c.options
+	}
+	return Options{Mode: ModeStrict, Separator: DefaultSeparator}
+}
+
+// Coerced records that the value being processed was converted to value,
+// and returns value.
+func Coerced(ctx consumer.ProcessingContext, value any) any {
+	if c, ok := ctx.Options[optionCoercer].(*coercer); ok {
+		c.coercions = append(c.coercions, Coercion{
+			Path: jsonpointer.Format(ctx.Path),
+			Type: ctx.Schema.Type(),
+			From: transform.Input(ctx),
+			To:   value,
+		})
+	}
+	return value
+}
+
+// Failed records that the value being processed needs converting but cannot
+// be converted, and returns it unchanged. Failures are only reported in
+// ModeStrict.
+func Failed(ctx consumer.ProcessingContext, format string, args ...any) any {
+	if c, ok := ctx.Options[optionCoercer].(*coercer); ok && c.options.Mode == ModeStrict {
+		c.failures = append(c.failures, Failure{
+			Path:    jsonpointer.Format(ctx.Path),
+			Type:    ctx.Schema.Type(),
+			Value:   transform.Input(ctx),
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+	return transform.Input(ctx)
+}
+
+func (c *coercer) coerce(schema core.Schema, value any, path []string, parent core.Schema, level int) (any, error) {
+	if schema == nil || value == nil {
+		return value, nil
+	}
+	// Only schemas that reference themselves get this deep
+	if level > transform.MaxDepth {
+		return nil, fmt.Errorf("maximum coercion depth exceeded at %s", jsonpointer.Format(path))
+	}
+
+	consumers := c.registry.GetApplicableSchemaConsumersByPurpose(schema, consumer.PurposeTransform)
+	if len(consumers) == 0 {
+		return value, nil
+	}
+	result, err := consumers[0].ProcessSchema(transform.Context(schema, parent, path, value, level,
+		map[string]any{optionCoercer: c}))
+	if err != nil {
+		return nil, err
+	}
+	return result.Value(), nil
+}
+
+// NewCoercionRegistry creates a new consumer registry with coercion consumers registered.
+func NewCoercionRegistry() consumer.Registry {
+	registry := consumer.NewRegistry()
+
+	// Register coercion consumers
+	RegisterCoercionConsumers(registry)
+
+	return registry
+}
+
+// RegisterCoercionConsumers registers all coercion consumers with a registry.
+func RegisterCoercionConsumers(registry consumer.Registry) {
+	registry.RegisterSchemaConsumer(&StringCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&NumberCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&IntegerCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&BooleanCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&ArrayCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&ObjectCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&MapCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&OptionalCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&UnionCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&ResultCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&RefCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&GenericCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&FunctionCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&TopicCoercionConsumer{})
+	registry.RegisterSchemaConsumer(&ComponentCoercionConsumer{})
+}
+
+// lenient reports whether a consumer may convert common variations.
+func lenient(ctx consumer.ProcessingContext) bool {
+	return OptionsFrom(ctx).Mode == ModeLenient
+}
+
+// index returns the path segment of an array item.
+func index(i int) string {
+	return strconv.Itoa(i)
+}
@@ -0,0 +1,500 @@
+package coercion
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"defs.dev/schema/consume/transform"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// ArrayCoercionConsumer converts lists to arrays and coerces array items
+type ArrayCoercionConsumer struct{}
+
+func (c *ArrayCoercionConsumer) Name() string {
+	return "array_coercer"
+}
+
+func (c *ArrayCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *ArrayCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeArray)
+}
+
+func (c *ArrayCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.ArraySchema)
+	if !ok {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+
+	var items []any
+	switch v := transform.Input(ctx).(type) {
+	case []any:
+		items = v
+	case string:
+		items = Coerced(ctx, c.split(ctx, v)).([]any)
+	case map[string]any:
+		return kind.Result(v), nil
+	default:
+		// A single value stands for a list of one, as in repeated query parameters
+		if !lenient(ctx) {
+			return kind.Result(v), nil
+		}
+		items = Coerced(ctx, []any{v}).([]any)
+	}
+
+	prefix := s.PrefixItemSchemas()
+	coerced := make([]any, len(items))
+	for i, item := range items {
+		schema := s.ItemSchema()
+		if len(prefix) > 0 {
+			schema = s.RestItemSchema()
+			if i < len(prefix) {
+				schema = prefix[i]
+			}
+		}
+		value, err := CoerceChild(ctx, index(i), schema, item)
+		if err != nil {
+			return nil, err
+		}
+		coerced[i] = value
+	}
+	return kind.Result(coerced), nil
+}
+
+// split splits a separated list. Leniently, items are trimmed, empty items
+// are dropped and JSON arrays are decoded.
+func (c *ArrayCoercionConsumer) split(ctx consumer.ProcessingContext, list string) []any {
+	if lenient(ctx) {
+		if decoded, ok := decodeJSON(list).([]any); ok {
+			return decoded
+		}
+	}
+	items := []any{}
+	if list == "" {
+		return items
+	}
+	for _, item := range strings.Split(list, OptionsFrom(ctx).Separator) {
+		if lenient(ctx) {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (c *ArrayCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Converts lists to arrays and coerces array items", "array")
+}
+
+// ObjectCoercionConsumer coerces object properties
+type ObjectCoercionConsumer struct{}
+
+func (c *ObjectCoercionConsumer) Name() string {
+	return "object_coercer"
+}
+
+func (c *ObjectCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *ObjectCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeStructure)
+}
+
+func (c *ObjectCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.ObjectSchema)
+	object, isObject := decoded(ctx).(map[string]any)
+	if !ok || !isObject {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	coerced, err := entries(ctx, object, s.Properties())
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(coerced), nil
+}
+
+func (c *ObjectCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Coerces object properties", "object")
+}
+
+// MapCoercionConsumer coerces map values
+type MapCoercionConsumer struct{}
+
+func (c *MapCoercionConsumer) Name() string {
+	return "map_coercer"
+}
+
+func (c *MapCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *MapCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeMap)
+}
+
+func (c *MapCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.MapSchema)
+	values, isMap := decoded(ctx).(map[string]any)
+	if !ok || !isMap {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	schemas := make(map[string]core.Schema, len(values))
+	for key := range values {
+		schemas[key] = s.ValueSchema()
+	}
+	coerced, err := entries(ctx, values, schemas)
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(coerced), nil
+}
+
+func (c *MapCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Coerces map values", "map")
+}
+
+// OptionalCoercionConsumer coerces present optional values
+type OptionalCoercionConsumer struct{}
+
+func (c *OptionalCoercionConsumer) Name() string {
+	return "optional_coercer"
+}
+
+func (c *OptionalCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *OptionalCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeOptional)
+}
+
+func (c *OptionalCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.OptionalSchema)
+	if !ok {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	// Empty form fields and "null" stand for absent non-string values
+	if text, isString := transform.Input(ctx).(string); isString && lenient(ctx) && s.ItemSchema().Type() != core.TypeString {
+		if text = strings.TrimSpace(text); text == "" || text == "null" {
+			return kind.Result(Coerced(ctx, nil)), nil
+		}
+	}
+	value, err := CoerceChild(ctx, "", s.ItemSchema(), transform.Input(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(value), nil
+}
+
+func (c *OptionalCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Coerces present optional values", "optional")
+}
+
+// UnionCoercionConsumer coerces values to the first union branch they can be converted to
+type UnionCoercionConsumer struct{}
+
+func (c *UnionCoercionConsumer) Name() string {
+	return "union_coercer"
+}
+
+func (c *UnionCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *UnionCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeUnion)
+}
+
+func (c *UnionCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.UnionSchema)
+	run, isRun := ctx.Options[optionCoercer].(*coercer)
+	value := transform.Input(ctx)
+	if !ok || !isRun || validation.ValidateValue(s, value).Valid {
+		return kind.Result(value), nil
+	}
+
+	// Try the branches in order, keeping the coercions of the one that works
+	for _, branch := range s.Schemas() {
+		attempt := &coercer{registry: run.registry, options: run.options}
+		coerced, err := attempt.coerce(branch, value, ctx.Path, ctx.Schema, transform.Depth(ctx)+1)
+		if err != nil {
+			return nil, err
+		}
+		if len(attempt.failures) == 0 && validation.ValidateValue(branch, coerced).Valid {
+			run.coercions = append(run.coercions, attempt.coercions...)
+			return kind.Result(coerced), nil
+		}
+	}
+	if _, isString := value.(string); isString {
+		return kind.Result(Failed(ctx, "cannot coerce %q to any union branch", value)), nil
+	}
+	return kind.Result(value), nil
+}
+
+func (c *UnionCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Coerces values to the first union branch they can be converted to", "union")
+}
+
+// ResultCoercionConsumer coerces the success or failure of a result
+type ResultCoercionConsumer struct{}
+
+func (c *ResultCoercionConsumer) Name() string {
+	return "result_coercer"
+}
+
+func (c *ResultCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *ResultCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeResult)
+}
+
+func (c *ResultCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.ResultSchema)
+	branches, isMap := transform.Input(ctx).(map[string]any)
+	if !ok || !isMap {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	coerced, err := entries(ctx, branches, map[string]core.Schema{
+		"ok":  s.SuccessSchema(),
+		"err": s.ErrorSchema(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(coerced), nil
+}
+
+func (c *ResultCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Coerces the success or failure of a result", "result")
+}
+
+// RefCoercionConsumer coerces values to the referenced schema
+type RefCoercionConsumer struct{}
+
+func (c *RefCoercionConsumer) Name() string {
+	return "ref_coercer"
+}
+
+func (c *RefCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *RefCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeRef)
+}
+
+func (c *RefCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.RefSchema)
+	if !ok {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	target, err := s.Resolve()
+	if err != nil {
+		return nil, fmt.Errorf("resolving reference %s: %w", s.ReferenceName(), err)
+	}
+	value, err := CoerceChild(ctx, "", target, transform.Input(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(value), nil
+}
+
+func (c *RefCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Coerces values to the referenced schema", "ref")
+}
+
+// GenericCoercionConsumer coerces values to the generic template
+type GenericCoercionConsumer struct{}
+
+func (c *GenericCoercionConsumer) Name() string {
+	return "generic_coercer"
+}
+
+func (c *GenericCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *GenericCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeGeneric)
+}
+
+func (c *GenericCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.GenericSchema)
+	if !ok {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	value, err := CoerceChild(ctx, "", s.Template(), transform.Input(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(value), nil
+}
+
+func (c *GenericCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Coerces values to the generic template", "generic")
+}
+
+// FunctionCoercionConsumer coerces function inputs
+type FunctionCoercionConsumer struct{}
+
+func (c *FunctionCoercionConsumer) Name() string {
+	return "function_coercer"
+}
+
+func (c *FunctionCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *FunctionCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeFunction)
+}
+
+func (c *FunctionCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.FunctionSchema)
+	inputs, isMap := transform.Input(ctx).(map[string]any)
+	if !ok || !isMap || s.Inputs() == nil {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	args := make(map[string]core.Schema)
+	for _, arg := range s.Inputs().Args() {
+		args[arg.Name()] = arg.Schema()
+	}
+	coerced, err := entries(ctx, inputs, args)
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(coerced), nil
+}
+
+func (c *FunctionCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Coerces function inputs", "function")
+}
+
+// TopicCoercionConsumer coerces the key, payload and headers of a topic message
+type TopicCoercionConsumer struct{}
+
+func (c *TopicCoercionConsumer) Name() string {
+	return "topic_coercer"
+}
+
+func (c *TopicCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *TopicCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeTopic)
+}
+
+func (c *TopicCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.TopicSchema)
+	message, isMap := transform.Input(ctx).(map[string]any)
+	if !ok || !isMap {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	coerced, err := entries(ctx, message, map[string]core.Schema{
+		"key":     s.Key(),
+		"payload": s.Payload(),
+		"headers": s.Headers(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(coerced), nil
+}
+
+func (c *TopicCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Coerces the key, payload and headers of a topic message", "topic")
+}
+
+// ComponentCoercionConsumer coerces component configurations
+type ComponentCoercionConsumer struct{}
+
+func (c *ComponentCoercionConsumer) Name() string {
+	return "component_coercer"
+}
+
+func (c *ComponentCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *ComponentCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeComponent)
+}
+
+func (c *ComponentCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.ComponentSchema)
+	if !ok || s.Config() == nil {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	value, err := CoerceChild(ctx, "", s.Config(), transform.Input(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(value), nil
+}
+
+func (c *ComponentCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Coerces component configurations", "component")
+}
+
+// entries copies a map, coercing the entries that have a schema in key order.
+func entries(ctx consumer.ProcessingContext, values map[string]any, schemas map[string]core.Schema) (map[string]any, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	coerced := make(map[string]any, len(values))
+	for _, key := range keys {
+		schema := schemas[key]
+		if schema == nil {
+			coerced[key] = values[key]
+			continue
+		}
+		value, err := CoerceChild(ctx, key, schema, values[key])
+		if err != nil {
+			return nil, err
+		}
+		coerced[key] = value
+	}
+	return coerced, nil
+}
+
+// decoded returns the value being processed, with JSON object strings
+// decoded in ModeLenient, as environment variables carry them.
+func decoded(ctx consumer.ProcessingContext) any {
+	text, ok := transform.Input(ctx).(string)
+	if !ok || !lenient(ctx) {
+		return transform.Input(ctx)
+	}
+	if object, ok := decodeJSON(text).(map[string]any); ok {
+		return Coerced(ctx, object)
+	}
+	return text
+}
+
+// decodeJSON decodes a JSON array or object, or returns nil.
+func decodeJSON(text string) any {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "[") && !strings.HasPrefix(text, "{") {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return nil
+	}
+	return value
+}
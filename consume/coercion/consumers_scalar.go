@@ -0,0 +1,295 @@
+package coercion
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"defs.dev/schema/consume/transform"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// Canonical JSON representations accepted in ModeStrict
+var (
+	integerPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)$`)
+	numberPattern  = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+)
+
+// IntegerCoercionConsumer converts strings and json.Number values to integers
+type IntegerCoercionConsumer struct{}
+
+func (c *IntegerCoercionConsumer) Name() string {
+	return "integer_coercer"
+}
+
+func (c *IntegerCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *IntegerCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeInteger)
+}
+
+func (c *IntegerCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	options := OptionsFrom(ctx)
+	switch v := transform.Input(ctx).(type) {
+	case string:
+		text := v
+		if lenient(ctx) {
+			text = strings.TrimSpace(text)
+		}
+		n, ok := parseInteger(text, lenient(ctx))
+		if !ok {
+			return kind.Result(Failed(ctx, "cannot coerce %q to integer", v)), nil
+		}
+		if options.UseNumber {
+			return kind.Result(Coerced(ctx, json.Number(strconv.FormatInt(n, 10)))), nil
+		}
+		return kind.Result(Coerced(ctx, n)), nil
+	case json.Number:
+		if options.UseNumber {
+			return kind.Result(v), nil
+		}
+		n, ok := parseInteger(string(v), lenient(ctx))
+		if !ok {
+			return kind.Result(Failed(ctx, "cannot coerce %s to integer", v)), nil
+		}
+		return kind.Result(Coerced(ctx, n)), nil
+	case float64:
+		if n, ok := wholeNumber(v); ok && lenient(ctx) {
+			return kind.Result(Coerced(ctx, n)), nil
+		}
+	}
+	return kind.Result(transform.Input(ctx)), nil
+}
+
+func (c *IntegerCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Converts strings and json.Number values to integers", "integer")
+}
+
+// NumberCoercionConsumer converts strings and json.Number values to numbers
+type NumberCoercionConsumer struct{}
+
+func (c *NumberCoercionConsumer) Name() string {
+	return "number_coercer"
+}
+
+func (c *NumberCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *NumberCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeNumber)
+}
+
+func (c *NumberCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	options := OptionsFrom(ctx)
+	switch v := transform.Input(ctx).(type) {
+	case string:
+		text := v
+		if lenient(ctx) {
+			text = strings.TrimSpace(text)
+		}
+		f, ok := parseNumber(text, lenient(ctx))
+		if !ok {
+			return kind.Result(Failed(ctx, "cannot coerce %q to number", v)), nil
+		}
+		if options.UseNumber {
+			// Keep the digits as written, which float64 may not represent
+			if !numberPattern.MatchString(text) {
+				text = strconv.FormatFloat(f, 'g', -1, 64)
+			}
+			return kind.Result(Coerced(ctx, json.Number(text))), nil
+		}
+		return kind.Result(Coerced(ctx, f)), nil
+	case json.Number:
+		if options.UseNumber {
+			return kind.Result(v), nil
+		}
+		f, ok := parseNumber(string(v), lenient(ctx))
+		if !ok {
+			return kind.Result(Failed(ctx, "cannot coerce %s to number", v)), nil
+		}
+		return kind.Result(Coerced(ctx, f)), nil
+	}
+	return kind.Result(transform.Input(ctx)), nil
+}
+
+func (c *NumberCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Converts strings and json.Number values to numbers", "number")
+}
+
+// BooleanCoercionConsumer converts strings to booleans
+type BooleanCoercionConsumer struct{}
+
+func (c *BooleanCoercionConsumer) Name() string {
+	return "boolean_coercer"
+}
+
+func (c *BooleanCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *BooleanCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeBoolean)
+}
+
+func (c *BooleanCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	switch v := transform.Input(ctx).(type) {
+	case string:
+		b, ok := parseBoolean(v, lenient(ctx))
+		if !ok {
+			return kind.Result(Failed(ctx, "cannot coerce %q to boolean", v)), nil
+		}
+		return kind.Result(Coerced(ctx, b)), nil
+	case float64, int, int64, json.Number:
+		// Numeric flags, as sent by some JSON producers
+		if lenient(ctx) {
+			switch formatScalar(v) {
+			case "1":
+				return kind.Result(Coerced(ctx, true)), nil
+			case "0":
+				return kind.Result(Coerced(ctx, false)), nil
+			}
+		}
+	}
+	return kind.Result(transform.Input(ctx)), nil
+}
+
+func (c *BooleanCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Converts strings to booleans", "boolean")
+}
+
+// StringCoercionConsumer converts date-time strings to time.Time and, in
+// ModeLenient, scalars to strings
+type StringCoercionConsumer struct{}
+
+func (c *StringCoercionConsumer) Name() string {
+	return "string_coercer"
+}
+
+func (c *StringCoercionConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *StringCoercionConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeString)
+}
+
+func (c *StringCoercionConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	var format string
+	if s, ok := ctx.Schema.(core.StringSchema); ok {
+		format = s.Format()
+	}
+
+	switch v := transform.Input(ctx).(type) {
+	case string:
+		if format != "date-time" {
+			break
+		}
+		text := v
+		if lenient(ctx) {
+			text = strings.TrimSpace(text)
+		}
+		// Unparseable date-times are still strings, which validation reports
+		if t, err := time.Parse(time.RFC3339Nano, text); err == nil {
+			return kind.Result(Coerced(ctx, t)), nil
+		}
+	case time.Time:
+		if format != "date-time" && lenient(ctx) {
+			return kind.Result(Coerced(ctx, v.Format(time.RFC3339Nano))), nil
+		}
+	case bool, int, int64, float64, json.Number:
+		if lenient(ctx) {
+			return kind.Result(Coerced(ctx, formatScalar(v))), nil
+		}
+	}
+	return kind.Result(transform.Input(ctx)), nil
+}
+
+func (c *StringCoercionConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Converts date-time strings to time.Time and, in lenient mode, scalars to strings", "string")
+}
+
+// parseInteger parses a base 10 integer. Leniently, whole numbers written
+// as decimals, such as "3.0" and "1e3", and leading signs and zeros are
+// accepted too.
+func parseInteger(text string, lenient bool) (int64, bool) {
+	if integerPattern.MatchString(text) {
+		n, err := strconv.ParseInt(text, 10, 64)
+		return n, err == nil
+	}
+	if !lenient {
+		return 0, false
+	}
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return n, true
+	}
+	if f, ok := parseNumber(text, true); ok {
+		return wholeNumber(f)
+	}
+	return 0, false
+}
+
+// parseNumber parses a finite number. Leniently, any representation
+// strconv.ParseFloat accepts is allowed, such as "+1.5" and ".5".
+func parseNumber(text string, lenient bool) (float64, bool) {
+	if !lenient && !numberPattern.MatchString(text) {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0, false
+	}
+	return f, true
+}
+
+// parseBoolean parses "true" and "false". Leniently, case variations and
+// common flag values such as "yes", "on" and "1" are accepted too.
+func parseBoolean(text string, lenient bool) (bool, bool) {
+	switch text {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	}
+	if !lenient {
+		return false, false
+	}
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "true", "1", "yes", "on", "y", "t":
+		return true, true
+	case "false", "0", "no", "off", "n", "f":
+		return false, true
+	}
+	return false, false
+}
+
+// wholeNumber converts a float without fractional part that fits in an int64.
+func wholeNumber(f float64) (int64, bool) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// formatScalar formats a boolean or number the way JSON writes it.
+func formatScalar(value any) string {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return string(v)
+	}
+	return ""
+}
@@ -0,0 +1,405 @@
+package defaults
+
+import (
+	"fmt"
+
+	"defs.dev/schema/consume/transform"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// ObjectDefaultsConsumer fills absent object properties with their defaults
+type ObjectDefaultsConsumer struct{}
+
+func (c *ObjectDefaultsConsumer) Name() string {
+	return "object_defaults"
+}
+
+func (c *ObjectDefaultsConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *ObjectDefaultsConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeStructure)
+}
+
+func (c *ObjectDefaultsConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.ObjectSchema)
+	object, isObject := transform.Input(ctx).(map[string]any)
+	if !ok || !isObject {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+
+	filled, err := fill(ctx, object, s.Properties())
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(filled), nil
+}
+
+func (c *ObjectDefaultsConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Fills absent object properties with their defaults", "object")
+}
+
+// ArrayDefaultsConsumer applies defaults to array items
+type ArrayDefaultsConsumer struct{}
+
+func (c *ArrayDefaultsConsumer) Name() string {
+	return "array_defaults"
+}
+
+func (c *ArrayDefaultsConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *ArrayDefaultsConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeArray)
+}
+
+func (c *ArrayDefaultsConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.ArraySchema)
+	items, isArray := transform.Input(ctx).([]any)
+	if !ok || !isArray {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+
+	prefix := s.PrefixItemSchemas()
+	filled := make([]any, len(items))
+	for i, item := range items {
+		schema := s.ItemSchema()
+		if len(prefix) > 0 {
+			schema = s.RestItemSchema()
+			if i < len(prefix) {
+				schema = prefix[i]
+			}
+		}
+		value, err := ApplyChild(ctx, fmt.Sprintf("[%d]", i), schema, item)
+		if err != nil {
+			return nil, err
+		}
+		filled[i] = value
+	}
+	return kind.Result(filled), nil
+}
+
+func (c *ArrayDefaultsConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Applies defaults to array items", "array")
+}
+
+// MapDefaultsConsumer applies defaults to map values
+type MapDefaultsConsumer struct{}
+
+func (c *MapDefaultsConsumer) Name() string {
+	return "map_defaults"
+}
+
+func (c *MapDefaultsConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *MapDefaultsConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeMap)
+}
+
+func (c *MapDefaultsConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.MapSchema)
+	entries, isMap := transform.Input(ctx).(map[string]any)
+	if !ok || !isMap {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+
+	filled := make(map[string]any, len(entries))
+	for key, entry := range entries {
+		value, err := ApplyChild(ctx, key, s.ValueSchema(), entry)
+		if err != nil {
+			return nil, err
+		}
+		filled[key] = value
+	}
+	return kind.Result(filled), nil
+}
+
+func (c *MapDefaultsConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Applies defaults to map values", "map")
+}
+
+// OptionalDefaultsConsumer applies defaults to present optional values
+type OptionalDefaultsConsumer struct{}
+
+func (c *OptionalDefaultsConsumer) Name() string {
+	return "optional_defaults"
+}
+
+func (c *OptionalDefaultsConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *OptionalDefaultsConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeOptional)
+}
+
+func (c *OptionalDefaultsConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.OptionalSchema)
+	if !ok {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	value, err := ApplyChild(ctx, "", s.ItemSchema(), transform.Input(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(value), nil
+}
+
+func (c *OptionalDefaultsConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Applies defaults to present optional values", "optional")
+}
+
+// ResultDefaultsConsumer applies defaults to the success or failure of a result
+type ResultDefaultsConsumer struct{}
+
+func (c *ResultDefaultsConsumer) Name() string {
+	return "result_defaults"
+}
+
+func (c *ResultDefaultsConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *ResultDefaultsConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeResult)
+}
+
+func (c *ResultDefaultsConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.ResultSchema)
+	branches, isMap := transform.Input(ctx).(map[string]any)
+	if !ok || !isMap {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+
+	filled, err := parts(ctx, branches, map[string]core.Schema{
+		"ok":  s.SuccessSchema(),
+		"err": s.ErrorSchema(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(filled), nil
+}
+
+func (c *ResultDefaultsConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Applies defaults to the success or failure of a result", "result")
+}
+
+// RefDefaultsConsumer applies the defaults of the referenced schema
+type RefDefaultsConsumer struct{}
+
+func (c *RefDefaultsConsumer) Name() string {
+	return "ref_defaults"
+}
+
+func (c *RefDefaultsConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *RefDefaultsConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeRef)
+}
+
+func (c *RefDefaultsConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.RefSchema)
+	if !ok {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	target, err := s.Resolve()
+	if err != nil {
+		return nil, fmt.Errorf("resolving reference %s: %w", s.ReferenceName(), err)
+	}
+	value, err := ApplyChild(ctx, "", target, transform.Input(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(value), nil
+}
+
+func (c *RefDefaultsConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Applies the defaults of the referenced schema", "ref")
+}
+
+// GenericDefaultsConsumer applies the defaults of the generic template
+type GenericDefaultsConsumer struct{}
+
+func (c *GenericDefaultsConsumer) Name() string {
+	return "generic_defaults"
+}
+
+func (c *GenericDefaultsConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *GenericDefaultsConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeGeneric)
+}
+
+func (c *GenericDefaultsConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.GenericSchema)
+	if !ok {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	value, err := ApplyChild(ctx, "", s.Template(), transform.Input(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(value), nil
+}
+
+func (c *GenericDefaultsConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Applies the defaults of the generic template", "generic")
+}
+
+// FunctionDefaultsConsumer fills absent function inputs with their defaults
+type FunctionDefaultsConsumer struct{}
+
+func (c *FunctionDefaultsConsumer) Name() string {
+	return "function_defaults"
+}
+
+func (c *FunctionDefaultsConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *FunctionDefaultsConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeFunction)
+}
+
+func (c *FunctionDefaultsConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.FunctionSchema)
+	inputs, isMap := transform.Input(ctx).(map[string]any)
+	if !ok || !isMap || s.Inputs() == nil {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+
+	args := make(map[string]core.Schema)
+	for _, arg := range s.Inputs().Args() {
+		args[arg.Name()] = arg.Schema()
+	}
+	filled, err := fill(ctx, inputs, args)
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(filled), nil
+}
+
+func (c *FunctionDefaultsConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Fills absent function inputs with their defaults", "function")
+}
+
+// TopicDefaultsConsumer applies defaults to the key, payload and headers of a topic message
+type TopicDefaultsConsumer struct{}
+
+func (c *TopicDefaultsConsumer) Name() string {
+	return "topic_defaults"
+}
+
+func (c *TopicDefaultsConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *TopicDefaultsConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeTopic)
+}
+
+func (c *TopicDefaultsConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.TopicSchema)
+	message, isMap := transform.Input(ctx).(map[string]any)
+	if !ok || !isMap {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+
+	filled, err := parts(ctx, message, map[string]core.Schema{
+		"key":     s.Key(),
+		"payload": s.Payload(),
+		"headers": s.Headers(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(filled), nil
+}
+
+func (c *TopicDefaultsConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Applies defaults to the key, payload and headers of a topic message", "topic")
+}
+
+// ComponentDefaultsConsumer applies the defaults of a component's configuration
+type ComponentDefaultsConsumer struct{}
+
+func (c *ComponentDefaultsConsumer) Name() string {
+	return "component_defaults"
+}
+
+func (c *ComponentDefaultsConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeTransform
+}
+
+func (c *ComponentDefaultsConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeComponent)
+}
+
+func (c *ComponentDefaultsConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	s, ok := ctx.Schema.(core.ComponentSchema)
+	if !ok || s.Config() == nil {
+		return kind.Result(transform.Input(ctx)), nil
+	}
+	value, err := ApplyChild(ctx, "config", s.Config(), transform.Input(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return kind.Result(value), nil
+}
+
+func (c *ComponentDefaultsConsumer) Metadata() consumer.ConsumerMetadata {
+	return kind.Metadata(c.Name(), "Applies the defaults of a component's configuration", "component")
+}
+
+// fill copies a map, filling absent entries that have a default and applying
+// defaults to every entry with a schema. Entries without a schema are copied.
+func fill(ctx consumer.ProcessingContext, values map[string]any, schemas map[string]core.Schema) (map[string]any, error) {
+	filled, err := parts(ctx, values, schemas)
+	if err != nil {
+		return nil, err
+	}
+	for name, schema := range schemas {
+		if _, exists := values[name]; exists {
+			continue
+		}
+		def, ok := Default(schema)
+		if !ok {
+			continue
+		}
+		// Defaults may themselves lack nested defaults
+		value, err := ApplyChild(ctx, name, schema, def)
+		if err != nil {
+			return nil, err
+		}
+		filled[name] = value
+	}
+	return filled, nil
+}
+
+// parts copies a map, applying defaults to the entries that have a schema.
+func parts(ctx consumer.ProcessingContext, values map[string]any, schemas map[string]core.Schema) (map[string]any, error) {
+	filled := make(map[string]any, len(values))
+	for name, entry := range values {
+		schema, ok := schemas[name]
+		if !ok || schema == nil {
+			filled[name] = entry
+			continue
+		}
+		value, err := ApplyChild(ctx, name, schema, entry)
+		if err != nil {
+			return nil, err
+		}
+		filled[name] = value
+	}
+	return filled, nil
+}
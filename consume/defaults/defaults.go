@@ -0,0 +1,174 @@
+// Package defaults fills schema defaults into values using the consumer framework.
+//
+// Defaults are applied by consumers with the "transform" purpose, one per
+// container schema type. Applying walks a value alongside its schema and
+// returns a new value in which absent object properties and function inputs
+// with a default are filled in, recursing through nested objects, arrays,
+// maps and the other container types:
+//
+//	value, err := defaults.Apply(schema, input)
+//
+// The input value is never modified and defaults are copied, so values
+// returned by Apply can be changed freely. Properties that are present with
+// a null value are kept as they are; only absent ones receive defaults.
+// Union values are left as they are, as their branch is ambiguous.
+package defaults
+
+import (
+	"fmt"
+	"strings"
+
+	"defs.dev/schema/consume/transform"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// optionApplier is the Options key under which the applier is passed to consumers
+const optionApplier = "defaults"
+
+// kind is the kind of the results of defaults consumers
+const kind transform.Kind = "defaults"
+
+// applier applies defaults with the consumers of a registry.
+type applier struct {
+	registry consumer.Registry
+}
+
+// Apply returns a copy of value with the defaults of schema filled in, using
+// the built-in defaults consumers. A nil value is replaced by the schema's
+// own default, if it has one.
+func Apply(schema core.Schema, value any) (any, error) {
+	return ApplyWithRegistry(NewDefaultsRegistry(), schema, value)
+}
+
+// ApplyWithRegistry applies defaults using the transform consumers of a registry.
+func ApplyWithRegistry(registry consumer.Registry, schema core.Schema, value any) (any, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("schema cannot be nil")
+	}
+	a := &applier{registry: registry}
+	return a.apply(schema, value, []string{}, nil, 0)
+}
+
+// ApplyChild applies defaults to the value of a child schema of the schema
+// being processed. Custom defaults consumers use it to recurse.
+func ApplyChild(ctx consumer.ProcessingContext, segment string, schema core.Schema, value any) (any, error) {
+	a, ok := ctx.Options[optionApplier].(*applier)
+	if !ok {
+		return nil, fmt.Errorf("defaults consumers must be run by Apply")
+	}
+	return a.apply(schema, value, transform.AppendPath(ctx.Path, segment), ctx.Schema, transform.Depth(ctx)+1)
+}
+
+// Default returns a copy of the default value of a schema. Optional schemas
+// and references have the default of the schema they wrap.
+func Default(schema core.Schema) (any, bool) {
+	switch s := schema.(type) {
+	case interface{ DefaultValue() *string }:
+		if v := s.DefaultValue(); v != nil {
+			return *v, true
+		}
+	case interface{ DefaultValue() *float64 }:
+		if v := s.DefaultValue(); v != nil {
+			return *v, true
+		}
+	case interface{ DefaultValue() *int64 }:
+		if v := s.DefaultValue(); v != nil {
+			return *v, true
+		}
+	case interface{ DefaultValue() *bool }:
+		if v := s.DefaultValue(); v != nil {
+			return *v, true
+		}
+	case interface{ DefaultValue() []any }:
+		if v := s.DefaultValue(); v != nil {
+			return clone(v), true
+		}
+	case interface{ DefaultValue() map[string]any }:
+		if v := s.DefaultValue(); v != nil {
+			return clone(v), true
+		}
+	case core.OptionalSchema:
+		return Default(s.ItemSchema())
+	case core.RefSchema:
+		if target, err := s.Resolve(); err == nil {
+			return Default(target)
+		}
+	}
+	return nil, false
+}
+
+func (a *applier) apply(schema core.Schema, value any, path []string, parent core.Schema, level int) (any, error) {
+	if schema == nil {
+		return value, nil
+	}
+	// Only schemas that reference themselves get this deep
+	if level > transform.MaxDepth {
+		return nil, fmt.Errorf("maximum defaults depth exceeded at /%s", strings.Join(path, "/"))
+	}
+	if value == nil {
+		if level > 0 {
+			return nil, nil
+		}
+		def, ok := Default(schema)
+		if !ok {
+			return nil, nil
+		}
+		value = def
+	}
+
+	consumers := a.registry.GetApplicableSchemaConsumersByPurpose(schema, consumer.PurposeTransform)
+	if len(consumers) == 0 {
+		// Scalars have nothing to fill in
+		return value, nil
+	}
+	result, err := consumers[0].ProcessSchema(transform.Context(schema, parent, path, value, level,
+		map[string]any{optionApplier: a}))
+	if err != nil {
+		return nil, err
+	}
+	return result.Value(), nil
+}
+
+// NewDefaultsRegistry creates a new consumer registry with defaults consumers registered.
+func NewDefaultsRegistry() consumer.Registry {
+	registry := consumer.NewRegistry()
+
+	// Register defaults consumers
+	RegisterDefaultsConsumers(registry)
+
+	return registry
+}
+
+// RegisterDefaultsConsumers registers all defaults consumers with a registry.
+func RegisterDefaultsConsumers(registry consumer.Registry) {
+	registry.RegisterSchemaConsumer(&ObjectDefaultsConsumer{})
+	registry.RegisterSchemaConsumer(&ArrayDefaultsConsumer{})
+	registry.RegisterSchemaConsumer(&MapDefaultsConsumer{})
+	registry.RegisterSchemaConsumer(&OptionalDefaultsConsumer{})
+	registry.RegisterSchemaConsumer(&ResultDefaultsConsumer{})
+	registry.RegisterSchemaConsumer(&RefDefaultsConsumer{})
+	registry.RegisterSchemaConsumer(&GenericDefaultsConsumer{})
+	registry.RegisterSchemaConsumer(&FunctionDefaultsConsumer{})
+	registry.RegisterSchemaConsumer(&TopicDefaultsConsumer{})
+	registry.RegisterSchemaConsumer(&ComponentDefaultsConsumer{})
+}
+
+// clone deep-copies maps and slices of a value.
+func clone(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			result[k] = clone(item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = clone(item)
+		}
+		return result
+	}
+	return value
+}
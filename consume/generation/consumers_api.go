@@ -0,0 +1,257 @@
+package generation
+
+import (
+	"errors"
+	"fmt"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// FunctionGenerationConsumer generates function inputs
+type FunctionGenerationConsumer struct{}
+
+func (c *FunctionGenerationConsumer) Name() string {
+	return "function_generator"
+}
+
+func (c *FunctionGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *FunctionGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeFunction)
+}
+
+func (c *FunctionGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		s, ok := ctx.Schema.(core.FunctionSchema)
+		if !ok {
+			return nil, fmt.Errorf("expected function schema, got %T", ctx.Schema)
+		}
+		if WantsInvalid(ctx) {
+			return c.invalid(ctx, g, s)
+		}
+		return inputs(ctx, g, s)
+	})
+}
+
+func (c *FunctionGenerationConsumer) invalid(ctx consumer.ProcessingContext, g *Generator, s core.FunctionSchema) (any, error) {
+	args := s.Inputs().Args()
+	candidates := []func() (any, error){
+		func() (any, error) { return []any{}, nil },
+		func() (any, error) {
+			// Make one input invalid, trying them in random order
+			for _, i := range g.rand.Perm(len(args)) {
+				value, err := g.InvalidValue(ctx, args[i].Name(), args[i].Schema())
+				if errors.Is(err, ErrNoInvalidValue) {
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+				values, err := inputs(ctx, g, s)
+				if err != nil {
+					return nil, err
+				}
+				values[args[i].Name()] = value
+				return values, nil
+			}
+			return nil, ErrNoInvalidValue
+		},
+	}
+	if required := s.RequiredInputs(); len(required) > 0 {
+		candidates = append(candidates, func() (any, error) {
+			values, err := inputs(ctx, g, s)
+			if err != nil {
+				return nil, err
+			}
+			delete(values, required[g.rand.Intn(len(required))])
+			return values, nil
+		})
+	}
+	return g.oneOf(candidates...)
+}
+
+func (c *FunctionGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates function inputs", "function")
+}
+
+// inputs generates the required and some optional inputs of a function.
+func inputs(ctx consumer.ProcessingContext, g *Generator, s core.FunctionSchema) (map[string]any, error) {
+	allOptional := g.rand.Intn(2) == 0
+	values := make(map[string]any)
+	for _, arg := range s.Inputs().Args() {
+		if arg.Optional() {
+			if g.Deep(ctx) {
+				continue
+			}
+			if g.Boundary() && !allOptional || !g.Boundary() && g.rand.Intn(2) == 0 {
+				continue
+			}
+		}
+		value, err := g.Value(ctx, arg.Name(), arg.Schema())
+		if err != nil {
+			return nil, err
+		}
+		values[arg.Name()] = value
+	}
+	return values, nil
+}
+
+// ServiceGenerationConsumer generates inputs for every method of a service, by method name
+type ServiceGenerationConsumer struct{}
+
+func (c *ServiceGenerationConsumer) Name() string {
+	return "service_generator"
+}
+
+func (c *ServiceGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *ServiceGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeService)
+}
+
+func (c *ServiceGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		// Services accept any value
+		if WantsInvalid(ctx) {
+			return nil, ErrNoInvalidValue
+		}
+		s, ok := ctx.Schema.(core.ServiceSchema)
+		if !ok {
+			return map[string]any{}, nil
+		}
+		calls := make(map[string]any)
+		for _, method := range s.Methods() {
+			value, err := g.Value(ctx, method.Name(), method.Function())
+			if err != nil {
+				return nil, err
+			}
+			calls[method.Name()] = value
+		}
+		return calls, nil
+	})
+}
+
+func (c *ServiceGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates inputs for every method of a service, by method name", "service")
+}
+
+// TopicGenerationConsumer generates topic messages
+type TopicGenerationConsumer struct{}
+
+func (c *TopicGenerationConsumer) Name() string {
+	return "topic_generator"
+}
+
+func (c *TopicGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *TopicGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeTopic)
+}
+
+func (c *TopicGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		s, ok := ctx.Schema.(core.TopicSchema)
+		if !ok {
+			return nil, fmt.Errorf("expected topic schema, got %T", ctx.Schema)
+		}
+		if WantsInvalid(ctx) {
+			return g.oneOf(
+				func() (any, error) { return g.text(1, defaultStringLength), nil },
+				func() (any, error) { return map[string]any{}, nil },
+				func() (any, error) {
+					message, err := c.message(ctx, g, s)
+					if err != nil {
+						return nil, err
+					}
+					message[words[g.rand.Intn(len(words))]] = g.scalar()
+					return message, nil
+				},
+				func() (any, error) {
+					payload, err := g.InvalidValue(ctx, "payload", s.Payload())
+					if err != nil {
+						return nil, err
+					}
+					message, err := c.message(ctx, g, s)
+					if err != nil {
+						return nil, err
+					}
+					message["payload"] = payload
+					return message, nil
+				},
+			)
+		}
+		return c.message(ctx, g, s)
+	})
+}
+
+// message generates a message with a payload and the key and headers the topic has.
+func (c *TopicGenerationConsumer) message(ctx consumer.ProcessingContext, g *Generator, s core.TopicSchema) (map[string]any, error) {
+	message := make(map[string]any)
+	for _, part := range []struct {
+		name   string
+		schema core.Schema
+	}{
+		{"key", s.Key()},
+		{"payload", s.Payload()},
+		{"headers", s.Headers()},
+	} {
+		if part.schema == nil {
+			if part.name == "payload" {
+				message[part.name] = g.scalar()
+			}
+			continue
+		}
+		value, err := g.Value(ctx, part.name, part.schema)
+		if err != nil {
+			return nil, err
+		}
+		message[part.name] = value
+	}
+	return message, nil
+}
+
+func (c *TopicGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates topic messages", "topic")
+}
+
+// ComponentGenerationConsumer generates component configurations
+type ComponentGenerationConsumer struct{}
+
+func (c *ComponentGenerationConsumer) Name() string {
+	return "component_generator"
+}
+
+func (c *ComponentGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *ComponentGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeComponent)
+}
+
+func (c *ComponentGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		s, ok := ctx.Schema.(core.ComponentSchema)
+		if !ok || s.Config() == nil {
+			if WantsInvalid(ctx) {
+				return nil, ErrNoInvalidValue
+			}
+			return nil, nil
+		}
+		if WantsInvalid(ctx) {
+			return g.InvalidValue(ctx, "config", s.Config())
+		}
+		return g.Value(ctx, "config", s.Config())
+	})
+}
+
+func (c *ComponentGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates component configurations", "component")
+}
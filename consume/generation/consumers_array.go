@@ -0,0 +1,183 @@
+package generation
+
+import (
+	"fmt"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// ArrayGenerationConsumer generates arrays honoring item bounds, uniqueness, contains and tuples
+type ArrayGenerationConsumer struct{}
+
+func (c *ArrayGenerationConsumer) Name() string {
+	return "array_generator"
+}
+
+func (c *ArrayGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *ArrayGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeArray)
+}
+
+func (c *ArrayGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		s, ok := ctx.Schema.(core.ArraySchema)
+		if !ok {
+			if WantsInvalid(ctx) {
+				return map[string]any{}, nil
+			}
+			return []any{}, nil
+		}
+		if WantsInvalid(ctx) {
+			return c.invalid(ctx, g, s)
+		}
+
+		lo, hi := c.countRange(g, s)
+		if !g.Boundary() && lo == 0 && hi > 0 {
+			lo = 1
+		}
+		count := g.Between(lo, hi)
+		if g.Deep(ctx) {
+			count = lo
+		}
+		if s.ContainsSchema() != nil && count == 0 && hi > 0 {
+			count = 1
+		}
+		return c.items(ctx, g, s, count)
+	})
+}
+
+// countRange returns the number of items allowed by an array schema.
+func (c *ArrayGenerationConsumer) countRange(g *Generator, s core.ArraySchema) (int, int) {
+	prefix := len(s.PrefixItemSchemas())
+	if prefix > 0 && s.RestItemSchema() == nil {
+		return prefix, prefix
+	}
+
+	lo := prefix
+	if s.MinItems() != nil {
+		lo = max(lo, *s.MinItems())
+	}
+	hi := lo + g.options.MaxItems
+	if s.MaxItems() != nil {
+		hi = *s.MaxItems()
+	}
+	return lo, hi
+}
+
+// itemSchema returns the schema of the item at a position.
+func (c *ArrayGenerationConsumer) itemSchema(s core.ArraySchema, i int) core.Schema {
+	if prefix := s.PrefixItemSchemas(); len(prefix) > 0 {
+		if i < len(prefix) {
+			return prefix[i]
+		}
+		return s.RestItemSchema()
+	}
+	return s.ItemSchema()
+}
+
+// items generates count valid items.
+func (c *ArrayGenerationConsumer) items(ctx consumer.ProcessingContext, g *Generator, s core.ArraySchema, count int) ([]any, error) {
+	items := make([]any, 0, count)
+	seen := make(map[string]bool)
+	for i := 0; i < count; i++ {
+		segment := fmt.Sprintf("[%d]", i)
+		schema := c.itemSchema(s, i)
+
+		var item any
+		for attempt := 0; ; attempt++ {
+			var err error
+			generate := func() error {
+				if schema == nil {
+					item = g.scalar()
+					return nil
+				}
+				item, err = g.Value(ctx, segment, schema)
+				return err
+			}
+			// Boundary values repeat, so fall back to random ones for unique items
+			if attempt > 1 && g.Boundary() {
+				err = g.withMode(ModeValid, generate)
+			} else {
+				err = generate()
+			}
+			if err != nil {
+				return nil, err
+			}
+			// Items are compared the way validation compares them
+			if key := fmt.Sprintf("%v", item); !s.UniqueItemsRequired() || !seen[key] {
+				seen[key] = true
+				break
+			}
+			if attempt == maxAttempts {
+				return nil, fmt.Errorf("cannot generate %d unique items at /%s", count, joinPath(ctx.Path))
+			}
+		}
+		items = append(items, item)
+	}
+
+	// Put a matching item at one of the positions after the tuple prefix
+	if contains := s.ContainsSchema(); contains != nil {
+		if free := len(items) - len(s.PrefixItemSchemas()); free > 0 {
+			i := len(s.PrefixItemSchemas()) + g.rand.Intn(free)
+			item, err := g.Value(ctx, fmt.Sprintf("[%d]", i), contains)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+	}
+	return items, nil
+}
+
+func (c *ArrayGenerationConsumer) invalid(ctx consumer.ProcessingContext, g *Generator, s core.ArraySchema) (any, error) {
+	lo, hi := c.countRange(g, s)
+	candidates := []func() (any, error){
+		func() (any, error) { return map[string]any{}, nil },
+		func() (any, error) {
+			// Replace an item with an invalid one
+			if hi == 0 {
+				return nil, ErrNoInvalidValue
+			}
+			count := g.Between(max(lo, 1), hi)
+			items, err := c.items(ctx, g, s, count)
+			if err != nil {
+				return nil, err
+			}
+			i := g.rand.Intn(count)
+			if items[i], err = g.InvalidValue(ctx, fmt.Sprintf("[%d]", i), c.itemSchema(s, i)); err != nil {
+				return nil, err
+			}
+			return items, nil
+		},
+	}
+	if lo > 0 {
+		candidates = append(candidates, func() (any, error) { return c.items(ctx, g, s, lo-1) })
+	}
+	if s.MaxItems() != nil || (len(s.PrefixItemSchemas()) > 0 && s.RestItemSchema() == nil) {
+		candidates = append(candidates, func() (any, error) {
+			items, err := c.items(ctx, g, s, hi)
+			if err != nil {
+				return nil, err
+			}
+			return append(items, g.scalar()), nil
+		})
+	}
+	if s.UniqueItemsRequired() && len(s.PrefixItemSchemas()) == 0 {
+		candidates = append(candidates, func() (any, error) {
+			items, err := c.items(ctx, g, s, 1)
+			if err != nil {
+				return nil, err
+			}
+			return append(items, items[0]), nil
+		})
+	}
+	return g.oneOf(candidates...)
+}
+
+func (c *ArrayGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates arrays honoring item bounds, uniqueness, contains and tuples", "array")
+}
@@ -0,0 +1,102 @@
+package generation
+
+import (
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// BooleanGenerationConsumer generates booleans
+type BooleanGenerationConsumer struct{}
+
+func (c *BooleanGenerationConsumer) Name() string {
+	return "boolean_generator"
+}
+
+func (c *BooleanGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *BooleanGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeBoolean)
+}
+
+func (c *BooleanGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		if WantsInvalid(ctx) {
+			return []any{"true", int64(1)}[g.rand.Intn(2)], nil
+		}
+		return g.rand.Intn(2) == 1, nil
+	})
+}
+
+func (c *BooleanGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates booleans", "boolean")
+}
+
+// NullGenerationConsumer generates null
+type NullGenerationConsumer struct{}
+
+func (c *NullGenerationConsumer) Name() string {
+	return "null_generator"
+}
+
+func (c *NullGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *NullGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeNull)
+}
+
+func (c *NullGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		if WantsInvalid(ctx) {
+			return g.text(1, defaultStringLength), nil
+		}
+		return nil, nil
+	})
+}
+
+func (c *NullGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates null", "null")
+}
+
+// AnyGenerationConsumer generates scalars of a random type
+type AnyGenerationConsumer struct{}
+
+func (c *AnyGenerationConsumer) Name() string {
+	return "any_generator"
+}
+
+func (c *AnyGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *AnyGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeAny)
+}
+
+func (c *AnyGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		if WantsInvalid(ctx) {
+			return nil, ErrNoInvalidValue
+		}
+		return g.scalar(), nil
+	})
+}
+
+func (c *AnyGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates scalars of a random type", "any")
+}
+
+// scalar returns a string, integer or boolean.
+func (g *Generator) scalar() any {
+	switch g.rand.Intn(3) {
+	case 0:
+		return g.text(1, defaultStringLength)
+	case 1:
+		return int64(g.rand.Intn(defaultNumberRange))
+	default:
+		return g.rand.Intn(2) == 1
+	}
+}
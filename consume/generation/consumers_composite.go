@@ -0,0 +1,260 @@
+package generation
+
+import (
+	"fmt"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// OptionalGenerationConsumer generates null or a value of the wrapped schema
+type OptionalGenerationConsumer struct{}
+
+func (c *OptionalGenerationConsumer) Name() string {
+	return "optional_generator"
+}
+
+func (c *OptionalGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *OptionalGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeOptional)
+}
+
+func (c *OptionalGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		s, ok := ctx.Schema.(core.OptionalSchema)
+		if !ok {
+			if WantsInvalid(ctx) {
+				return nil, ErrNoInvalidValue
+			}
+			return nil, nil
+		}
+		if WantsInvalid(ctx) {
+			return g.InvalidValue(ctx, "", s.ItemSchema())
+		}
+		// Boundary values are null half of the time, random ones a quarter
+		if g.Deep(ctx) || g.Boundary() && g.rand.Intn(2) == 0 || !g.Boundary() && g.rand.Intn(4) == 0 {
+			return nil, nil
+		}
+		return g.Value(ctx, "", s.ItemSchema())
+	})
+}
+
+func (c *OptionalGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates null or a value of the wrapped schema", "optional")
+}
+
+// UnionGenerationConsumer generates a value of one of the union's branches
+type UnionGenerationConsumer struct{}
+
+func (c *UnionGenerationConsumer) Name() string {
+	return "union_generator"
+}
+
+func (c *UnionGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *UnionGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeUnion)
+}
+
+func (c *UnionGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		s, ok := ctx.Schema.(core.UnionSchema)
+		if !ok || len(s.Schemas()) == 0 {
+			if WantsInvalid(ctx) {
+				return nil, ErrNoInvalidValue
+			}
+			return g.scalar(), nil
+		}
+		branches := s.Schemas()
+
+		if WantsInvalid(ctx) {
+			// A value of another type, or one breaking a branch, may still be
+			// accepted by another branch; the generator retries those
+			candidates := []func() (any, error){
+				func() (any, error) { return g.scalar(), nil },
+				func() (any, error) { return []any{g.scalar()}, nil },
+				func() (any, error) { return map[string]any{words[g.rand.Intn(len(words))]: g.scalar()}, nil },
+			}
+			for i, branch := range branches {
+				candidates = append(candidates, func() (any, error) {
+					return g.InvalidValue(ctx, fmt.Sprintf("[%d]", i), branch)
+				})
+			}
+			return g.oneOf(candidates...)
+		}
+
+		i := g.rand.Intn(len(branches))
+		return g.Value(ctx, fmt.Sprintf("[%d]", i), branches[i])
+	})
+}
+
+func (c *UnionGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates a value of one of the union's branches", "union")
+}
+
+// ResultGenerationConsumer generates success or failure results
+type ResultGenerationConsumer struct{}
+
+func (c *ResultGenerationConsumer) Name() string {
+	return "result_generator"
+}
+
+func (c *ResultGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *ResultGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeResult)
+}
+
+func (c *ResultGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		var ok, failure core.Schema
+		if s, isResult := ctx.Schema.(core.ResultSchema); isResult {
+			ok, failure = s.SuccessSchema(), s.ErrorSchema()
+		}
+
+		branch := func(name string, schema core.Schema, invalid bool) (any, error) {
+			var value any
+			var err error
+			switch {
+			case invalid:
+				value, err = g.InvalidValue(ctx, name, schema)
+			case schema == nil:
+				value = g.scalar()
+			default:
+				value, err = g.Value(ctx, name, schema)
+			}
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{name: value}, nil
+		}
+
+		if WantsInvalid(ctx) {
+			return g.oneOf(
+				func() (any, error) { return g.text(1, defaultStringLength), nil },
+				func() (any, error) { return map[string]any{}, nil },
+				func() (any, error) { return map[string]any{"ok": g.scalar(), "err": g.scalar()}, nil },
+				func() (any, error) { return branch("ok", ok, true) },
+				func() (any, error) { return branch("err", failure, true) },
+			)
+		}
+		if g.rand.Intn(2) == 0 {
+			return branch("ok", ok, false)
+		}
+		return branch("err", failure, false)
+	})
+}
+
+func (c *ResultGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates success or failure results", "result")
+}
+
+// RefGenerationConsumer generates a value of the referenced schema
+type RefGenerationConsumer struct{}
+
+func (c *RefGenerationConsumer) Name() string {
+	return "ref_generator"
+}
+
+func (c *RefGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *RefGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeRef)
+}
+
+func (c *RefGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		s, ok := ctx.Schema.(core.RefSchema)
+		if !ok {
+			return nil, fmt.Errorf("expected reference schema, got %T", ctx.Schema)
+		}
+		target, err := s.Resolve()
+		if err != nil {
+			return nil, fmt.Errorf("resolving reference %s: %w", s.ReferenceName(), err)
+		}
+		if WantsInvalid(ctx) {
+			return g.InvalidValue(ctx, "", target)
+		}
+		return g.Value(ctx, "", target)
+	})
+}
+
+func (c *RefGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates a value of the referenced schema", "ref")
+}
+
+// TypeParameterGenerationConsumer generates a value of the parameter's constraint
+type TypeParameterGenerationConsumer struct{}
+
+func (c *TypeParameterGenerationConsumer) Name() string {
+	return "type_parameter_generator"
+}
+
+func (c *TypeParameterGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *TypeParameterGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeParameter)
+}
+
+func (c *TypeParameterGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		var constraint core.Schema
+		if s, ok := ctx.Schema.(core.TypeParameterSchema); ok {
+			constraint = s.Constraint()
+		}
+		switch {
+		case WantsInvalid(ctx):
+			return g.InvalidValue(ctx, "", constraint)
+		case constraint == nil:
+			return g.scalar(), nil
+		}
+		return g.Value(ctx, "", constraint)
+	})
+}
+
+func (c *TypeParameterGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates a value of the parameter's constraint", "generic")
+}
+
+// GenericGenerationConsumer generates a value of the generic template
+type GenericGenerationConsumer struct{}
+
+func (c *GenericGenerationConsumer) Name() string {
+	return "generic_generator"
+}
+
+func (c *GenericGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *GenericGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeGeneric)
+}
+
+func (c *GenericGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		s, ok := ctx.Schema.(core.GenericSchema)
+		if !ok {
+			return nil, fmt.Errorf("expected generic schema, got %T", ctx.Schema)
+		}
+		if WantsInvalid(ctx) {
+			return g.InvalidValue(ctx, "", s.Template())
+		}
+		return g.Value(ctx, "", s.Template())
+	})
+}
+
+func (c *GenericGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates a value of the generic template", "generic")
+}
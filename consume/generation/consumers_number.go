@@ -0,0 +1,146 @@
+package generation
+
+import (
+	"math"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// defaultNumberRange is the width of the range used for numbers with at most one bound
+const defaultNumberRange = 1000
+
+// NumberGenerationConsumer generates numbers within the minimum and maximum
+type NumberGenerationConsumer struct{}
+
+func (c *NumberGenerationConsumer) Name() string {
+	return "number_generator"
+}
+
+func (c *NumberGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *NumberGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeNumber)
+}
+
+func (c *NumberGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		var minimum, maximum *float64
+		if s, ok := ctx.Schema.(core.NumberSchema); ok {
+			minimum, maximum = s.Minimum(), s.Maximum()
+		}
+
+		if WantsInvalid(ctx) {
+			candidates := []func() (any, error){
+				func() (any, error) { return "42", nil },
+			}
+			if minimum != nil {
+				candidates = append(candidates, func() (any, error) { return *minimum - 1 - math.Round(g.rand.Float64()*100)/100, nil })
+			}
+			if maximum != nil {
+				candidates = append(candidates, func() (any, error) { return *maximum + 1 + math.Round(g.rand.Float64()*100)/100, nil })
+			}
+			return g.oneOf(candidates...)
+		}
+
+		lo, hi := 0.0, float64(defaultNumberRange)
+		switch {
+		case minimum != nil && maximum != nil:
+			lo, hi = *minimum, *maximum
+		case minimum != nil:
+			lo, hi = *minimum, *minimum+defaultNumberRange
+		case maximum != nil:
+			lo, hi = *maximum-defaultNumberRange, *maximum
+		}
+		if hi <= lo {
+			return lo, nil
+		}
+		if g.Boundary() {
+			return []float64{lo, hi}[g.rand.Intn(2)], nil
+		}
+
+		// Two decimals look like real data; stay within bounds after rounding
+		value := math.Round((lo+g.rand.Float64()*(hi-lo))*100) / 100
+		return math.Min(math.Max(value, lo), hi), nil
+	})
+}
+
+func (c *NumberGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates numbers within the minimum and maximum", "number")
+}
+
+// IntegerGenerationConsumer generates integers within the minimum and maximum
+type IntegerGenerationConsumer struct{}
+
+func (c *IntegerGenerationConsumer) Name() string {
+	return "integer_generator"
+}
+
+func (c *IntegerGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *IntegerGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeInteger)
+}
+
+func (c *IntegerGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		var minimum, maximum *int64
+		if s, ok := ctx.Schema.(core.IntegerSchema); ok {
+			minimum, maximum = s.Minimum(), s.Maximum()
+		}
+
+		if WantsInvalid(ctx) {
+			candidates := []func() (any, error){
+				func() (any, error) { return "42", nil },
+				func() (any, error) { return float64(g.rand.Intn(100)) + 0.5, nil },
+			}
+			if minimum != nil && *minimum > math.MinInt64 {
+				candidates = append(candidates, func() (any, error) { return *minimum - 1, nil })
+			}
+			if maximum != nil && *maximum < math.MaxInt64 {
+				candidates = append(candidates, func() (any, error) { return *maximum + 1, nil })
+			}
+			return g.oneOf(candidates...)
+		}
+
+		lo, hi := int64(0), int64(defaultNumberRange)
+		switch {
+		case minimum != nil && maximum != nil:
+			lo, hi = *minimum, *maximum
+		case minimum != nil:
+			lo, hi = *minimum, saturatingAdd(*minimum, defaultNumberRange)
+		case maximum != nil:
+			lo, hi = saturatingAdd(*maximum, -defaultNumberRange), *maximum
+		}
+		if hi <= lo {
+			return lo, nil
+		}
+		if g.Boundary() {
+			return []int64{lo, hi}[g.rand.Intn(2)], nil
+		}
+
+		span := uint64(hi) - uint64(lo)
+		if span == math.MaxUint64 {
+			return int64(g.rand.Uint64()), nil
+		}
+		return int64(uint64(lo) + g.rand.Uint64()%(span+1)), nil
+	})
+}
+
+func (c *IntegerGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates integers within the minimum and maximum", "integer")
+}
+
+func saturatingAdd(value, delta int64) int64 {
+	switch {
+	case delta > 0 && value > math.MaxInt64-delta:
+		return math.MaxInt64
+	case delta < 0 && value < math.MinInt64-delta:
+		return math.MinInt64
+	}
+	return value + delta
+}
@@ -0,0 +1,254 @@
+package generation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// ObjectGenerationConsumer generates objects with all required and some optional properties
+type ObjectGenerationConsumer struct{}
+
+func (c *ObjectGenerationConsumer) Name() string {
+	return "object_generator"
+}
+
+func (c *ObjectGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *ObjectGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeStructure)
+}
+
+func (c *ObjectGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		s, ok := ctx.Schema.(core.ObjectSchema)
+		if !ok {
+			if WantsInvalid(ctx) {
+				return []any{}, nil
+			}
+			return map[string]any{}, nil
+		}
+		if WantsInvalid(ctx) {
+			return c.invalid(ctx, g, s)
+		}
+		return c.valid(ctx, g, s)
+	})
+}
+
+func (c *ObjectGenerationConsumer) valid(ctx consumer.ProcessingContext, g *Generator, s core.ObjectSchema) (map[string]any, error) {
+	properties := s.Properties()
+	required := make(map[string]bool)
+	for _, name := range s.Required() {
+		required[name] = true
+	}
+
+	// In boundary mode an object has either all or none of its optional properties
+	allOptional := g.rand.Intn(2) == 0
+	object := make(map[string]any)
+	for _, name := range sortedNames(properties) {
+		if !required[name] {
+			if g.Deep(ctx) {
+				continue
+			}
+			if g.Boundary() && !allOptional || !g.Boundary() && g.rand.Intn(2) == 0 {
+				continue
+			}
+		}
+		value, err := g.Value(ctx, name, properties[name])
+		if err != nil {
+			return nil, err
+		}
+		object[name] = value
+	}
+
+	// Required properties without a schema accept anything
+	for _, name := range s.Required() {
+		if _, ok := object[name]; !ok {
+			object[name] = g.scalar()
+		}
+	}
+	return object, nil
+}
+
+func (c *ObjectGenerationConsumer) invalid(ctx consumer.ProcessingContext, g *Generator, s core.ObjectSchema) (any, error) {
+	properties := s.Properties()
+	candidates := []func() (any, error){
+		func() (any, error) { return []any{}, nil },
+		func() (any, error) {
+			// Make one property invalid, trying them in random order
+			names := sortedNames(properties)
+			for _, i := range g.rand.Perm(len(names)) {
+				value, err := g.InvalidValue(ctx, names[i], properties[names[i]])
+				if errors.Is(err, ErrNoInvalidValue) {
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+				object, err := c.valid(ctx, g, s)
+				if err != nil {
+					return nil, err
+				}
+				object[names[i]] = value
+				return object, nil
+			}
+			return nil, ErrNoInvalidValue
+		},
+	}
+	if required := s.Required(); len(required) > 0 {
+		candidates = append(candidates, func() (any, error) {
+			object, err := c.valid(ctx, g, s)
+			if err != nil {
+				return nil, err
+			}
+			delete(object, required[g.rand.Intn(len(required))])
+			return object, nil
+		})
+	}
+	if !s.AdditionalProperties() {
+		candidates = append(candidates, func() (any, error) {
+			object, err := c.valid(ctx, g, s)
+			if err != nil {
+				return nil, err
+			}
+			name := words[g.rand.Intn(len(words))]
+			for _, declared := properties[name]; declared; _, declared = properties[name] {
+				name += "_"
+			}
+			object[name] = g.scalar()
+			return object, nil
+		})
+	}
+	return g.oneOf(candidates...)
+}
+
+func (c *ObjectGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates objects with all required and some optional properties", "object")
+}
+
+// MapGenerationConsumer generates maps honoring key and value schemas and entry bounds
+type MapGenerationConsumer struct{}
+
+func (c *MapGenerationConsumer) Name() string {
+	return "map_generator"
+}
+
+func (c *MapGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *MapGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeMap)
+}
+
+func (c *MapGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		s, ok := ctx.Schema.(core.MapSchema)
+		if !ok {
+			if WantsInvalid(ctx) {
+				return []any{}, nil
+			}
+			return map[string]any{}, nil
+		}
+
+		lo, hi := 0, g.options.MaxItems
+		if s.MinItems() != nil {
+			lo = *s.MinItems()
+			hi = lo + g.options.MaxItems
+		}
+		if s.MaxItems() != nil {
+			hi = *s.MaxItems()
+		}
+
+		if WantsInvalid(ctx) {
+			candidates := []func() (any, error){
+				func() (any, error) { return []any{}, nil },
+				func() (any, error) {
+					if hi == 0 {
+						return nil, ErrNoInvalidValue
+					}
+					entries, err := c.entries(ctx, g, s, g.Between(max(lo, 1), hi))
+					if err != nil {
+						return nil, err
+					}
+					key := sortedNames(entries)[0]
+					if entries[key], err = g.InvalidValue(ctx, key, s.ValueSchema()); err != nil {
+						return nil, err
+					}
+					return entries, nil
+				},
+			}
+			if lo > 0 {
+				candidates = append(candidates, func() (any, error) { return c.entries(ctx, g, s, lo-1) })
+			}
+			if s.MaxItems() != nil {
+				candidates = append(candidates, func() (any, error) { return c.entries(ctx, g, s, hi+1) })
+			}
+			return g.oneOf(candidates...)
+		}
+
+		if !g.Boundary() && lo == 0 && hi > 0 {
+			lo = 1
+		}
+		count := g.Between(lo, hi)
+		if g.Deep(ctx) {
+			count = lo
+		}
+		return c.entries(ctx, g, s, count)
+	})
+}
+
+// entries generates count entries with distinct keys.
+func (c *MapGenerationConsumer) entries(ctx consumer.ProcessingContext, g *Generator, s core.MapSchema, count int) (map[string]any, error) {
+	entries := make(map[string]any, count)
+	for len(entries) < count {
+		var key string
+		for attempt := 0; ; attempt++ {
+			if s.KeySchema() == nil {
+				key = g.text(3, 10)
+			} else {
+				generated, err := g.Value(ctx, "key", s.KeySchema())
+				if err != nil {
+					return nil, err
+				}
+				key = fmt.Sprint(generated)
+			}
+			if _, exists := entries[key]; !exists {
+				break
+			}
+			if attempt == maxAttempts {
+				return nil, fmt.Errorf("cannot generate %d distinct keys at /%s", count, joinPath(ctx.Path))
+			}
+		}
+
+		var value any
+		if s.ValueSchema() == nil {
+			value = g.scalar()
+		} else {
+			var err error
+			if value, err = g.Value(ctx, key, s.ValueSchema()); err != nil {
+				return nil, err
+			}
+		}
+		entries[key] = value
+	}
+	return entries, nil
+}
+
+func (c *MapGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates maps honoring key and value schemas and entry bounds", "map")
+}
+
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,281 @@
+package generation
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"time"
+	"unicode"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// words are used to build realistic looking strings
+var words = []string{
+	"alpha", "amber", "river", "stone", "maple", "cloud", "harbor", "lumen",
+	"north", "orbit", "pixel", "quartz", "sierra", "tango", "velvet", "willow",
+}
+
+// defaultStringLength is the length range added to minLength for strings without maxLength
+const defaultStringLength = 12
+
+// StringGenerationConsumer generates strings honoring length, enum, format and pattern constraints
+type StringGenerationConsumer struct{}
+
+func (c *StringGenerationConsumer) Name() string {
+	return "string_generator"
+}
+
+func (c *StringGenerationConsumer) Purpose() consumer.ConsumerPurpose {
+	return consumer.PurposeGeneration
+}
+
+func (c *StringGenerationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeString)
+}
+
+func (c *StringGenerationConsumer) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	return process(ctx, func(g *Generator) (any, error) {
+		s, ok := ctx.Schema.(core.StringSchema)
+		if !ok {
+			if WantsInvalid(ctx) {
+				return int64(g.rand.Intn(1000)), nil
+			}
+			return g.text(1, defaultStringLength), nil
+		}
+		if WantsInvalid(ctx) {
+			return c.invalid(g, s)
+		}
+		return c.valid(g, s)
+	})
+}
+
+func (c *StringGenerationConsumer) valid(g *Generator, s core.StringSchema) (any, error) {
+	if enum := s.EnumValues(); len(enum) > 0 {
+		return enum[g.rand.Intn(len(enum))], nil
+	}
+	if pattern := s.Pattern(); pattern != "" {
+		return g.matching(pattern, s.MinLength(), s.MaxLength())
+	}
+	if value, ok := g.formatted(s.Format()); ok {
+		return value, nil
+	}
+
+	min, max := lengthRange(s)
+	if min == 0 && max > 0 && !g.Boundary() {
+		min = 1
+	}
+	return g.text(min, max), nil
+}
+
+func (c *StringGenerationConsumer) invalid(g *Generator, s core.StringSchema) (any, error) {
+	candidates := []func() (any, error){
+		func() (any, error) { return int64(g.rand.Intn(1000)), nil },
+	}
+	if enum := s.EnumValues(); len(enum) > 0 {
+		candidates = append(candidates, func() (any, error) {
+			value := enum[0]
+			for contains(enum, value) {
+				value = g.text(len(value)+1, len(value)+4)
+			}
+			return value, nil
+		})
+	}
+	if min := s.MinLength(); min != nil && *min > 0 {
+		candidates = append(candidates, func() (any, error) { return g.text(*min-1, *min-1), nil })
+	}
+	if max := s.MaxLength(); max != nil {
+		candidates = append(candidates, func() (any, error) { return g.text(*max+1, *max+1), nil })
+	}
+	if pattern := s.Pattern(); pattern != "" {
+		candidates = append(candidates, func() (any, error) {
+			re := regexp.MustCompile(pattern)
+			for attempt := 0; attempt < maxAttempts; attempt++ {
+				if value := g.text(1, defaultStringLength) + "!"; !re.MatchString(value) {
+					return value, nil
+				}
+			}
+			return nil, ErrNoInvalidValue
+		})
+	}
+	switch s.Format() {
+	case "email":
+		candidates = append(candidates, func() (any, error) { return g.text(4, 10), nil })
+	case "uuid":
+		candidates = append(candidates, func() (any, error) { return "not-a-uuid", nil })
+	case "url":
+		candidates = append(candidates, func() (any, error) { return "http://[" + g.text(3, 6), nil })
+	}
+	return g.oneOf(candidates...)
+}
+
+func (c *StringGenerationConsumer) Metadata() consumer.ConsumerMetadata {
+	return metadata(c.Name(), "Generates strings honoring length, enum, format and pattern constraints", "string")
+}
+
+// lengthRange returns the length range allowed by a string schema.
+func lengthRange(s core.StringSchema) (int, int) {
+	min, max := 0, defaultStringLength
+	if s.MinLength() != nil {
+		min = *s.MinLength()
+		max = min + defaultStringLength
+	}
+	if s.MaxLength() != nil {
+		max = *s.MaxLength()
+	}
+	return min, max
+}
+
+// text returns words of a length between min and max.
+func (g *Generator) text(min, max int) string {
+	length := g.Between(min, max)
+	var b strings.Builder
+	for b.Len() < length {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(words[g.rand.Intn(len(words))])
+	}
+	text := []byte(b.String()[:length])
+	// Avoid leading and trailing spaces
+	if length > 0 && text[length-1] == ' ' {
+		text[length-1] = 'x'
+	}
+	return string(text)
+}
+
+// formatted returns a value for the well-known formats.
+func (g *Generator) formatted(format string) (string, bool) {
+	switch format {
+	case "email":
+		return fmt.Sprintf("%s.%s%d@example.com", words[g.rand.Intn(len(words))], words[g.rand.Intn(len(words))], g.rand.Intn(100)), true
+	case "uuid":
+		b := make([]byte, 16)
+		g.rand.Read(b)
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), true
+	case "url", "uri":
+		return fmt.Sprintf("https://%s.example.com/%s", words[g.rand.Intn(len(words))], words[g.rand.Intn(len(words))]), true
+	case "hostname":
+		return words[g.rand.Intn(len(words))] + ".example.com", true
+	case "ipv4":
+		return fmt.Sprintf("192.168.%d.%d", g.rand.Intn(256), 1+g.rand.Intn(254)), true
+	case "date-time", "date", "time":
+		start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		t := start.Add(time.Duration(g.rand.Int63n(int64(30 * 365 * 24 * time.Hour)))).Truncate(time.Second)
+		switch format {
+		case "date":
+			return t.Format(time.DateOnly), true
+		case "time":
+			return t.Format(time.TimeOnly), true
+		}
+		return t.Format(time.RFC3339), true
+	}
+	return "", false
+}
+
+// matching synthesizes a string matching a regular expression, preferring
+// one whose length is within the given bounds.
+func (g *Generator) matching(pattern string, minLength, maxLength *int) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", err
+	}
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+	parsed = parsed.Simplify()
+
+	var found string
+	matched := false
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		var b strings.Builder
+		g.synthesize(&b, parsed)
+		value := b.String()
+		if !re.MatchString(value) {
+			continue
+		}
+		if (minLength == nil || len(value) >= *minLength) && (maxLength == nil || len(value) <= *maxLength) {
+			return value, nil
+		}
+		found, matched = value, true
+	}
+	if !matched {
+		return "", fmt.Errorf("cannot synthesize a string matching %q", pattern)
+	}
+	return found, nil
+}
+
+// synthesize writes a string matched by a parsed regular expression.
+func (g *Generator) synthesize(b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && g.rand.Intn(2) == 0 {
+				r = unicode.SimpleFold(r)
+			}
+			b.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		b.WriteRune(g.classRune(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteByte(byte('a' + g.rand.Intn(26)))
+	case syntax.OpCapture:
+		g.synthesize(b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.synthesize(b, sub)
+		}
+	case syntax.OpAlternate:
+		g.synthesize(b, re.Sub[g.rand.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max := 0, g.options.MaxItems
+		switch re.Op {
+		case syntax.OpPlus:
+			min, max = 1, 1+g.options.MaxItems
+		case syntax.OpQuest:
+			max = 1
+		case syntax.OpRepeat:
+			min, max = re.Min, re.Max
+			if max < 0 {
+				max = min + g.options.MaxItems
+			}
+		}
+		for i := g.Between(min, max); i > 0; i-- {
+			g.synthesize(b, re.Sub[0])
+		}
+	}
+	// Anchors, word boundaries and empty matches produce no characters
+}
+
+// classRune picks a rune from a character class given as ranges, preferring
+// printable ASCII for negated classes, which span all of Unicode.
+func (g *Generator) classRune(ranges []rune) rune {
+	if len(ranges) == 0 {
+		return 'x'
+	}
+	var printable []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := max(ranges[i], '!'); r <= min(ranges[i+1], '~'); r++ {
+			printable = append(printable, r)
+		}
+	}
+	if len(printable) > 0 {
+		return printable[g.rand.Intn(len(printable))]
+	}
+	i := 2 * g.rand.Intn(len(ranges)/2)
+	return ranges[i] + rune(g.rand.Intn(int(ranges[i+1]-ranges[i]+1)))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
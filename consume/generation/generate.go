@@ -0,0 +1,361 @@
+// Package generation produces example values for schemas using the consumer framework.
+//
+// Values are generated by consumers with the "generation" purpose, one per
+// schema type. Generation is driven by a seed, so the same seed and schema
+// always produce the same value:
+//
+//	value, err := generation.Generate(schema, generation.Options{Seed: 42})
+//
+// Besides random valid values, the generator can produce values at the limits
+// of a schema's constraints (ModeBoundary) and values that violate the schema
+// (ModeInvalid) for negative testing.
+package generation
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// Mode selects the kind of values to generate.
+type Mode string
+
+const (
+	// ModeValid generates random values that satisfy the schema.
+	ModeValid Mode = "valid"
+	// ModeBoundary generates values at the limits of the schema's constraints,
+	// such as strings of exactly minLength or maxLength characters.
+	ModeBoundary Mode = "boundary"
+	// ModeInvalid generates values that violate the schema.
+	ModeInvalid Mode = "invalid"
+)
+
+// ErrNoInvalidValue is returned in ModeInvalid for schemas that accept every value.
+var ErrNoInvalidValue = errors.New("schema accepts every value")
+
+// Default limits
+const (
+	DefaultMaxItems = 3
+	DefaultMaxDepth = 6
+
+	// maxAttempts bounds retries, e.g. for unique items or invalid values
+	maxAttempts = 10
+)
+
+// Options configures generation.
+type Options struct {
+	// Seed makes generation reproducible
+	Seed int64 `json:"seed"`
+
+	// Mode defaults to ModeValid
+	Mode Mode `json:"mode,omitempty"`
+
+	// MaxItems limits arrays and maps without a maximum; defaults to DefaultMaxItems
+	MaxItems int `json:"maxItems,omitempty"`
+
+	// MaxDepth is the nesting depth after which only required content is
+	// generated, which ends recursive schemas; defaults to DefaultMaxDepth
+	MaxDepth int `json:"maxDepth,omitempty"`
+}
+
+// Options keys under which generation state is passed to consumers
+const (
+	optionGenerator = "generator"
+	optionInvalid   = "invalid"
+	optionDepth     = "depth"
+)
+
+// Generator generates values. It holds the random source of a generation
+// run and is not safe for concurrent use.
+type Generator struct {
+	registry consumer.Registry
+	options  Options
+	rand     *rand.Rand
+}
+
+// Generate produces a value for a schema with the built-in generation consumers.
+func Generate(schema core.Schema, options Options) (any, error) {
+	return NewGenerator(options).Generate(schema)
+}
+
+// NewGenerator creates a generator with the built-in generation consumers.
+func NewGenerator(options Options) *Generator {
+	return NewGeneratorWithRegistry(NewGenerationRegistry(), options)
+}
+
+// NewGeneratorWithRegistry creates a generator using the generation consumers of a registry.
+func NewGeneratorWithRegistry(registry consumer.Registry, options Options) *Generator {
+	if options.Mode == "" {
+		options.Mode = ModeValid
+	}
+	if options.MaxItems <= 0 {
+		options.MaxItems = DefaultMaxItems
+	}
+	if options.MaxDepth <= 0 {
+		options.MaxDepth = DefaultMaxDepth
+	}
+	return &Generator{
+		registry: registry,
+		options:  options,
+		rand:     rand.New(rand.NewSource(options.Seed)),
+	}
+}
+
+// Generate produces the next value for a schema.
+func (g *Generator) Generate(schema core.Schema) (any, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("schema cannot be nil")
+	}
+	value, err := g.generate(schema, []string{}, nil, g.options.Mode == ModeInvalid, 0)
+	if errors.Is(err, ErrNoInvalidValue) {
+		return nil, fmt.Errorf("no invalid value for %s schema: %w", schema.Type(), err)
+	}
+	return value, err
+}
+
+// Options returns the options of the generator.
+func (g *Generator) Options() Options {
+	return g.options
+}
+
+// Rand returns the random source of the generator.
+func (g *Generator) Rand() *rand.Rand {
+	return g.rand
+}
+
+// Boundary reports whether values at the limits of constraints are wanted.
+func (g *Generator) Boundary() bool {
+	return g.options.Mode == ModeBoundary
+}
+
+// Between returns a number from min to max inclusive; in ModeBoundary it
+// returns either min or max.
+func (g *Generator) Between(min, max int) int {
+	if max <= min {
+		return min
+	}
+	if g.Boundary() {
+		return g.pick(min, max)
+	}
+	return min + g.rand.Intn(max-min+1)
+}
+
+// Value generates a valid value for a child schema of the schema being processed.
+func (g *Generator) Value(ctx consumer.ProcessingContext, segment string, schema core.Schema) (any, error) {
+	return g.generate(schema, appendPath(ctx.Path, segment), ctx.Schema, false, depth(ctx)+1)
+}
+
+// InvalidValue generates a value for a child schema that violates it.
+func (g *Generator) InvalidValue(ctx consumer.ProcessingContext, segment string, schema core.Schema) (any, error) {
+	return g.generate(schema, appendPath(ctx.Path, segment), ctx.Schema, true, depth(ctx)+1)
+}
+
+// Deep reports whether the maximum depth has been reached, after which
+// consumers only generate required content.
+func (g *Generator) Deep(ctx consumer.ProcessingContext) bool {
+	return depth(ctx) >= g.options.MaxDepth
+}
+
+// GeneratorFrom returns the generator of a processing context created by a Generator.
+func GeneratorFrom(ctx consumer.ProcessingContext) (*Generator, bool) {
+	g, ok := ctx.Options[optionGenerator].(*Generator)
+	return g, ok
+}
+
+// WantsInvalid reports whether the consumer should generate a value that
+// violates the schema being processed.
+func WantsInvalid(ctx consumer.ProcessingContext) bool {
+	invalid, _ := ctx.Options[optionInvalid].(bool)
+	return invalid
+}
+
+func (g *Generator) generate(schema core.Schema, path []string, parent core.Schema, invalid bool, level int) (any, error) {
+	if schema == nil {
+		if invalid {
+			return nil, ErrNoInvalidValue
+		}
+		return nil, nil
+	}
+	// Only schemas that require themselves get this deep
+	if level > g.options.MaxDepth+32 {
+		return nil, fmt.Errorf("maximum generation depth exceeded at /%s", joinPath(path))
+	}
+
+	consumers := g.registry.GetApplicableSchemaConsumersByPurpose(schema, consumer.PurposeGeneration)
+	if len(consumers) == 0 {
+		return nil, fmt.Errorf("no generator for %s schema at /%s", schema.Type(), joinPath(path))
+	}
+	ctx := consumer.ProcessingContext{
+		Schema: schema,
+		Path:   path,
+		Parent: parent,
+		Options: map[string]any{
+			optionGenerator: g,
+			optionInvalid:   invalid,
+			optionDepth:     level,
+		},
+	}
+
+	if !invalid {
+		// Valid examples make for the most realistic values
+		if !g.Boundary() && g.rand.Intn(2) == 0 {
+			if example, ok := g.example(schema); ok {
+				return example, nil
+			}
+		}
+		result, err := consumers[0].ProcessSchema(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return result.Value(), nil
+	}
+
+	// Consumers pick one of several ways to break a schema, not all of which
+	// are guaranteed to, e.g. for unions; keep the first value that fails
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		result, err := consumers[0].ProcessSchema(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !accepts(schema, result.Value()) {
+			return result.Value(), nil
+		}
+	}
+	return nil, ErrNoInvalidValue
+}
+
+// accepts reports whether a value validates against a schema. Component
+// values are their configuration.
+func accepts(schema core.Schema, value any) bool {
+	if component, ok := schema.(core.ComponentSchema); ok {
+		if component.Config() == nil {
+			return true
+		}
+		schema = component.Config()
+	}
+	return validation.ValidateValue(schema, value).Valid
+}
+
+// example picks one of the examples of a schema that validate against it.
+func (g *Generator) example(schema core.Schema) (any, bool) {
+	switch schema.Type() {
+	case core.TypeFunction, core.TypeService, core.TypeComponent:
+		return nil, false
+	}
+	var valid []any
+	for _, example := range schema.Metadata().Examples {
+		if accepts(schema, example) {
+			valid = append(valid, example)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, false
+	}
+	return valid[g.rand.Intn(len(valid))], true
+}
+
+// withMode runs fn with another generation mode.
+func (g *Generator) withMode(mode Mode, fn func() error) error {
+	previous := g.options.Mode
+	g.options.Mode = mode
+	defer func() { g.options.Mode = previous }()
+	return fn()
+}
+
+// pick returns one of its arguments at random.
+func (g *Generator) pick(values ...int) int {
+	return values[g.rand.Intn(len(values))]
+}
+
+// oneOf tries the candidates in random order and returns the first value
+// generated. Candidates returning ErrNoInvalidValue are skipped.
+func (g *Generator) oneOf(candidates ...func() (any, error)) (any, error) {
+	for _, i := range g.rand.Perm(len(candidates)) {
+		value, err := candidates[i]()
+		if errors.Is(err, ErrNoInvalidValue) {
+			continue
+		}
+		return value, err
+	}
+	return nil, ErrNoInvalidValue
+}
+
+// NewGenerationRegistry creates a new consumer registry with generation consumers registered.
+func NewGenerationRegistry() consumer.Registry {
+	registry := consumer.NewRegistry()
+
+	// Register generation consumers
+	RegisterGenerationConsumers(registry)
+
+	return registry
+}
+
+// RegisterGenerationConsumers registers all generation consumers with a registry.
+func RegisterGenerationConsumers(registry consumer.Registry) {
+	registry.RegisterSchemaConsumer(&StringGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&NumberGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&IntegerGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&BooleanGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&NullGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&AnyGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&ArrayGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&ObjectGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&MapGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&OptionalGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&UnionGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&ResultGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&RefGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&TypeParameterGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&GenericGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&FunctionGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&ServiceGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&TopicGenerationConsumer{})
+	registry.RegisterSchemaConsumer(&ComponentGenerationConsumer{})
+}
+
+func depth(ctx consumer.ProcessingContext) int {
+	level, _ := ctx.Options[optionDepth].(int)
+	return level
+}
+
+func appendPath(path []string, segment string) []string {
+	if segment == "" {
+		return path
+	}
+	return append(append(make([]string, 0, len(path)+1), path...), segment)
+}
+
+func joinPath(path []string) string {
+	return strings.Join(path, "/")
+}
+
+// process runs a generation function for a consumer and wraps its value.
+func process(ctx consumer.ProcessingContext, generate func(g *Generator) (any, error)) (consumer.ConsumerResult, error) {
+	g, ok := GeneratorFrom(ctx)
+	if !ok {
+		return nil, fmt.Errorf("generation consumers must be run by a Generator")
+	}
+	value, err := generate(g)
+	if err != nil {
+		return nil, err
+	}
+	return consumer.NewResult("generation", value), nil
+}
+
+// metadata describes a built-in generation consumer.
+func metadata(name, description string, tags ...string) consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         name,
+		Purpose:      consumer.PurposeGeneration,
+		Description:  description,
+		Version:      "1.0.0",
+		Tags:         append([]string{"generation"}, tags...),
+		ResultKind:   "generation",
+		ResultGoType: "any",
+	}
+}
@@ -0,0 +1,169 @@
+// Package lint checks schemas for mistakes using the consumer framework.
+//
+// Each rule is a schema consumer with the "lint" purpose. The linter walks a
+// schema tree and runs every applicable rule on every schema in it, reporting
+// issues with the JSON Pointer path of the schema they were found in:
+//
+//	result := lint.Lint(schema, lint.Config{
+//		Severities: map[string]lint.Severity{"unnamed-nested-object": lint.SeverityOff},
+//	})
+//	for _, issue := range result.Errors() {
+//		fmt.Println(issue)
+//	}
+package lint
+
+import (
+	"fmt"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+	"defs.dev/schema/core/jsonpointer"
+	"defs.dev/schema/visit/walker"
+)
+
+// Severity is how serious a lint issue is.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	// SeverityOff disables a rule.
+	SeverityOff Severity = "off"
+)
+
+// Rule is implemented by lint consumers. Their ProcessSchema returns a
+// consumer.NewResult("lint", []string{...}) holding one message per issue
+// found in the schema of the processing context.
+type Rule interface {
+	consumer.AnnotationConsumer
+
+	// DefaultSeverity is the severity of the rule's issues unless configured otherwise.
+	DefaultSeverity() Severity
+}
+
+// Issue is a problem found by a rule.
+type Issue struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path"` // JSON Pointer, "" = root
+	Message  string   `json:"message"`
+}
+
+func (i Issue) String() string {
+	path := i.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s: %s (%s, %s)", path, i.Message, i.Rule, i.Severity)
+}
+
+// Result holds the issues found in a schema, in walk order.
+type Result struct {
+	Issues []Issue `json:"issues,omitempty"`
+}
+
+// Errors returns the issues with error severity.
+func (r Result) Errors() []Issue {
+	return r.WithSeverity(SeverityError)
+}
+
+// WithSeverity returns the issues with the given severity.
+func (r Result) WithSeverity(severity Severity) []Issue {
+	var issues []Issue
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// HasErrors reports whether any issue has error severity.
+func (r Result) HasErrors() bool {
+	return len(r.Errors()) > 0
+}
+
+// Config configures the linter.
+type Config struct {
+	// Severities overrides the default severity of rules by name.
+	// SeverityOff disables a rule.
+	Severities map[string]Severity `json:"severities,omitempty"`
+}
+
+// Lint checks a schema with the built-in rules.
+func Lint(schema core.Schema, config Config) Result {
+	return LintWithRegistry(NewLintRegistry(), schema, config)
+}
+
+// LintWithRegistry checks a schema with the lint consumers of a registry.
+// Consumers that do not implement Rule report warnings by default.
+func LintWithRegistry(registry consumer.Registry, schema core.Schema, config Config) Result {
+	var result Result
+	walker.Walk(schema, walker.Hooks{Pre: func(node *walker.Node) error {
+		ctx := consumer.ProcessingContext{
+			Schema: node.Schema,
+			Path:   jsonpointer.Parse(node.Path),
+		}
+		if node.Parent != nil {
+			ctx.Parent = node.Parent.Schema
+		}
+
+		for _, rule := range registry.GetApplicableSchemaConsumersByPurpose(node.Schema, consumer.PurposeLint) {
+			severity := SeverityWarning
+			if r, ok := rule.(Rule); ok {
+				severity = r.DefaultSeverity()
+			}
+			if configured, ok := config.Severities[rule.Name()]; ok {
+				severity = configured
+			}
+			if severity == SeverityOff {
+				continue
+			}
+
+			processed, err := rule.ProcessSchema(ctx)
+			if err != nil {
+				result.Issues = append(result.Issues, Issue{
+					Rule:     rule.Name(),
+					Severity: SeverityError,
+					Path:     node.Path,
+					Message:  fmt.Sprintf("rule failed: %v", err),
+				})
+				continue
+			}
+			messages, _ := processed.Value().([]string)
+			for _, message := range messages {
+				result.Issues = append(result.Issues, Issue{
+					Rule:     rule.Name(),
+					Severity: severity,
+					Path:     node.Path,
+					Message:  message,
+				})
+			}
+		}
+		return nil
+	}})
+	return result
+}
+
+// NewLintRegistry creates a new consumer registry with the built-in rules registered.
+func NewLintRegistry() consumer.Registry {
+	registry := consumer.NewRegistry()
+
+	// Register lint rules
+	RegisterLintRules(registry)
+
+	return registry
+}
+
+// RegisterLintRules registers all built-in lint rules with a registry.
+func RegisterLintRules(registry consumer.Registry) {
+	registry.RegisterSchemaConsumer(&LengthBoundsRule{})
+	registry.RegisterSchemaConsumer(&InvalidPatternRule{})
+	registry.RegisterSchemaConsumer(&UndeclaredRequiredRule{})
+	registry.RegisterSchemaConsumer(&InvalidDefaultRule{})
+	registry.RegisterSchemaConsumer(&InvalidExampleRule{})
+	registry.RegisterSchemaConsumer(&UnnamedNestedObjectRule{})
+	registry.RegisterSchemaConsumer(&FunctionDescriptionRule{})
+	registry.RegisterSchemaConsumer(&DuplicateEnumRule{})
+}
@@ -0,0 +1,316 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// LengthBoundsRule reports string schemas whose minimum length exceeds their maximum length.
+type LengthBoundsRule struct{}
+
+func (r *LengthBoundsRule) Name() string                      { return "min-max-length" }
+func (r *LengthBoundsRule) Purpose() consumer.ConsumerPurpose { return consumer.PurposeLint }
+func (r *LengthBoundsRule) DefaultSeverity() Severity         { return SeverityError }
+
+func (r *LengthBoundsRule) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeString)
+}
+
+func (r *LengthBoundsRule) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	var messages []string
+	if s, ok := ctx.Schema.(core.StringSchema); ok {
+		if minLength, maxLength := s.MinLength(), s.MaxLength(); minLength != nil && maxLength != nil && *minLength > *maxLength {
+			messages = append(messages, fmt.Sprintf("minLength %d is greater than maxLength %d", *minLength, *maxLength))
+		}
+	}
+	return consumer.NewResult("lint", messages), nil
+}
+
+func (r *LengthBoundsRule) Metadata() consumer.ConsumerMetadata {
+	return ruleMetadata(r, "Reports string schemas that no string can satisfy because minLength exceeds maxLength")
+}
+
+// InvalidPatternRule reports regular expressions that do not compile, in
+// pattern properties and pattern annotations.
+type InvalidPatternRule struct{}
+
+func (r *InvalidPatternRule) Name() string                      { return "invalid-pattern" }
+func (r *InvalidPatternRule) Purpose() consumer.ConsumerPurpose { return consumer.PurposeLint }
+func (r *InvalidPatternRule) DefaultSeverity() Severity         { return SeverityError }
+
+func (r *InvalidPatternRule) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Or(consumer.Type(core.TypeStructure), consumer.HasAnnotation("pattern"))
+}
+
+func (r *InvalidPatternRule) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	var messages []string
+	if s, ok := ctx.Schema.(interface {
+		PatternProperties() map[string]core.Schema
+	}); ok {
+		for _, pattern := range sortedKeys(s.PatternProperties()) {
+			// "*" is the wildcard used for additional property schemas
+			if pattern == "*" {
+				continue
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				messages = append(messages, fmt.Sprintf("pattern property %q does not compile: %v", pattern, err))
+			}
+		}
+	}
+	for _, annotation := range ctx.Schema.Annotations() {
+		if annotation.Name() != "pattern" {
+			continue
+		}
+		if pattern, ok := annotation.Value().(string); ok {
+			if _, err := regexp.Compile(pattern); err != nil {
+				messages = append(messages, fmt.Sprintf("pattern %q does not compile: %v", pattern, err))
+			}
+		}
+	}
+	return consumer.NewResult("lint", messages), nil
+}
+
+func (r *InvalidPatternRule) Metadata() consumer.ConsumerMetadata {
+	return ruleMetadata(r, "Reports regular expressions that do not compile")
+}
+
+// UndeclaredRequiredRule reports required properties that an object does not declare.
+type UndeclaredRequiredRule struct{}
+
+func (r *UndeclaredRequiredRule) Name() string                      { return "undeclared-required" }
+func (r *UndeclaredRequiredRule) Purpose() consumer.ConsumerPurpose { return consumer.PurposeLint }
+func (r *UndeclaredRequiredRule) DefaultSeverity() Severity         { return SeverityError }
+
+func (r *UndeclaredRequiredRule) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeStructure)
+}
+
+func (r *UndeclaredRequiredRule) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	var messages []string
+	if s, ok := ctx.Schema.(core.ObjectSchema); ok {
+		properties := s.Properties()
+		for _, name := range s.Required() {
+			if _, declared := properties[name]; !declared {
+				messages = append(messages, fmt.Sprintf("required property %q is not declared", name))
+			}
+		}
+	}
+	return consumer.NewResult("lint", messages), nil
+}
+
+func (r *UndeclaredRequiredRule) Metadata() consumer.ConsumerMetadata {
+	return ruleMetadata(r, "Reports required properties missing from the properties of an object")
+}
+
+// InvalidDefaultRule reports default values that do not validate against their own schema.
+type InvalidDefaultRule struct{}
+
+func (r *InvalidDefaultRule) Name() string                      { return "invalid-default" }
+func (r *InvalidDefaultRule) Purpose() consumer.ConsumerPurpose { return consumer.PurposeLint }
+func (r *InvalidDefaultRule) DefaultSeverity() Severity         { return SeverityError }
+
+func (r *InvalidDefaultRule) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Or(
+		consumer.Type(core.TypeString),
+		consumer.Type(core.TypeNumber),
+		consumer.Type(core.TypeInteger),
+		consumer.Type(core.TypeBoolean),
+		consumer.Type(core.TypeArray),
+		consumer.Type(core.TypeStructure),
+	)
+}
+
+func (r *InvalidDefaultRule) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	var messages []string
+	if value, ok := defaultValue(ctx.Schema); ok {
+		if problems := violations(ctx.Schema, value); problems != "" {
+			messages = append(messages, fmt.Sprintf("default value %v is invalid: %s", value, problems))
+		}
+	}
+	return consumer.NewResult("lint", messages), nil
+}
+
+func (r *InvalidDefaultRule) Metadata() consumer.ConsumerMetadata {
+	return ruleMetadata(r, "Reports default values that violate their own schema")
+}
+
+// InvalidExampleRule reports examples that do not validate against their own schema.
+type InvalidExampleRule struct{}
+
+func (r *InvalidExampleRule) Name() string                      { return "invalid-example" }
+func (r *InvalidExampleRule) Purpose() consumer.ConsumerPurpose { return consumer.PurposeLint }
+func (r *InvalidExampleRule) DefaultSeverity() Severity         { return SeverityWarning }
+
+func (r *InvalidExampleRule) ApplicableSchemas() consumer.SchemaCondition {
+	// Examples of functions, services and components describe calls, not values
+	return consumer.Not(consumer.Or(
+		consumer.Type(core.TypeFunction),
+		consumer.Type(core.TypeService),
+		consumer.Type(core.TypeComponent),
+	))
+}
+
+func (r *InvalidExampleRule) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	var messages []string
+	for i, example := range ctx.Schema.Metadata().Examples {
+		if problems := violations(ctx.Schema, example); problems != "" {
+			messages = append(messages, fmt.Sprintf("example %d (%v) is invalid: %s", i, example, problems))
+		}
+	}
+	return consumer.NewResult("lint", messages), nil
+}
+
+func (r *InvalidExampleRule) Metadata() consumer.ConsumerMetadata {
+	return ruleMetadata(r, "Reports examples that violate their own schema")
+}
+
+// UnnamedNestedObjectRule reports objects nested in other schemas that have
+// no name, which code generators then have to make one up for.
+type UnnamedNestedObjectRule struct{}
+
+func (r *UnnamedNestedObjectRule) Name() string                      { return "unnamed-nested-object" }
+func (r *UnnamedNestedObjectRule) Purpose() consumer.ConsumerPurpose { return consumer.PurposeLint }
+func (r *UnnamedNestedObjectRule) DefaultSeverity() Severity         { return SeverityInfo }
+
+func (r *UnnamedNestedObjectRule) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeStructure)
+}
+
+func (r *UnnamedNestedObjectRule) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	var messages []string
+	if ctx.Parent != nil && ctx.Schema.Metadata().Name == "" {
+		messages = append(messages, "nested object has no name")
+	}
+	return consumer.NewResult("lint", messages), nil
+}
+
+func (r *UnnamedNestedObjectRule) Metadata() consumer.ConsumerMetadata {
+	return ruleMetadata(r, "Reports nested objects without a name")
+}
+
+// FunctionDescriptionRule reports functions without a description.
+type FunctionDescriptionRule struct{}
+
+func (r *FunctionDescriptionRule) Name() string                      { return "function-description" }
+func (r *FunctionDescriptionRule) Purpose() consumer.ConsumerPurpose { return consumer.PurposeLint }
+func (r *FunctionDescriptionRule) DefaultSeverity() Severity         { return SeverityWarning }
+
+func (r *FunctionDescriptionRule) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeFunction)
+}
+
+func (r *FunctionDescriptionRule) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	var messages []string
+	if strings.TrimSpace(ctx.Schema.Metadata().Description) == "" {
+		messages = append(messages, "function has no description")
+	}
+	return consumer.NewResult("lint", messages), nil
+}
+
+func (r *FunctionDescriptionRule) Metadata() consumer.ConsumerMetadata {
+	return ruleMetadata(r, "Reports functions without a description")
+}
+
+// DuplicateEnumRule reports enum values that appear more than once.
+type DuplicateEnumRule struct{}
+
+func (r *DuplicateEnumRule) Name() string                      { return "duplicate-enum" }
+func (r *DuplicateEnumRule) Purpose() consumer.ConsumerPurpose { return consumer.PurposeLint }
+func (r *DuplicateEnumRule) DefaultSeverity() Severity         { return SeverityWarning }
+
+func (r *DuplicateEnumRule) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeString)
+}
+
+func (r *DuplicateEnumRule) ProcessSchema(ctx consumer.ProcessingContext) (consumer.ConsumerResult, error) {
+	var messages []string
+	if s, ok := ctx.Schema.(core.StringSchema); ok {
+		seen := make(map[string]bool)
+		for _, value := range s.EnumValues() {
+			if seen[value] {
+				messages = append(messages, fmt.Sprintf("enum value %q is listed more than once", value))
+			}
+			seen[value] = true
+		}
+	}
+	return consumer.NewResult("lint", messages), nil
+}
+
+func (r *DuplicateEnumRule) Metadata() consumer.ConsumerMetadata {
+	return ruleMetadata(r, "Reports duplicate enum values")
+}
+
+func ruleMetadata(rule Rule, description string) consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         rule.Name(),
+		Purpose:      consumer.PurposeLint,
+		Description:  description,
+		Version:      "1.0.0",
+		Tags:         []string{"lint", string(rule.DefaultSeverity())},
+		ResultKind:   "lint",
+		ResultGoType: "[]string",
+	}
+}
+
+// defaultValue returns the default value of a schema, if it has one.
+func defaultValue(schema core.Schema) (any, bool) {
+	switch s := schema.(type) {
+	case interface{ DefaultValue() *string }:
+		if v := s.DefaultValue(); v != nil {
+			return *v, true
+		}
+	case interface{ DefaultValue() *float64 }:
+		if v := s.DefaultValue(); v != nil {
+			return *v, true
+		}
+	case interface{ DefaultValue() *int64 }:
+		if v := s.DefaultValue(); v != nil {
+			return *v, true
+		}
+	case interface{ DefaultValue() *bool }:
+		if v := s.DefaultValue(); v != nil {
+			return *v, true
+		}
+	case interface{ DefaultValue() []any }:
+		if v := s.DefaultValue(); v != nil {
+			return v, true
+		}
+	case interface{ DefaultValue() map[string]any }:
+		if v := s.DefaultValue(); v != nil {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// violations validates a value and joins the validation errors, or returns
+// "" if the value is valid.
+func violations(schema core.Schema, value any) string {
+	result := validation.ValidateValue(schema, value)
+	if result.Valid {
+		return ""
+	}
+	messages := make([]string, len(result.Errors))
+	for i, issue := range result.Errors {
+		messages[i] = issue.Message
+		if len(issue.Path) > 0 {
+			messages[i] = strings.Join(issue.Path, ".") + ": " + issue.Message
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
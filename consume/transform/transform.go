@@ -0,0 +1,81 @@
+// Package transform holds what the consumers that transform values, such as
+// those of the defaults and coercion packages, share.
+//
+// A transformation walks a value alongside its schema. The value being
+// processed is passed to consumers as a core.Value and its nesting depth in
+// the Options of the context; consumers return the transformed value as a
+// result of the transformation's Kind.
+package transform
+
+import (
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// OptionDepth is the Options key under which the nesting depth of the value
+// being processed is passed to consumers.
+const OptionDepth = "depth"
+
+// MaxDepth ends transformations for values nested deeper than any real value
+const MaxDepth = 256
+
+// Kind names what a transformation produces, such as "defaults". It is the
+// kind of the results of its consumers.
+type Kind string
+
+// Result wraps a transformed value.
+func (k Kind) Result(value any) consumer.ConsumerResult {
+	return consumer.NewResult(string(k), value)
+}
+
+// Metadata describes a built-in consumer of the transformation.
+func (k Kind) Metadata(name, description string, tags ...string) consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         name,
+		Purpose:      consumer.PurposeTransform,
+		Description:  description,
+		Version:      "1.0.0",
+		Tags:         append([]string{string(k)}, tags...),
+		ResultKind:   string(k),
+		ResultGoType: "any",
+	}
+}
+
+// Context returns the context in which a consumer transforms a value at
+// path, nested level deep, with the given options.
+func Context(schema, parent core.Schema, path []string, value any, level int, options map[string]any) consumer.ProcessingContext {
+	opts := map[string]any{OptionDepth: level}
+	for key, option := range options {
+		opts[key] = option
+	}
+	return consumer.ProcessingContext{
+		Schema:  schema,
+		Path:    path,
+		Parent:  parent,
+		Value:   core.ValueOf(value),
+		Options: opts,
+	}
+}
+
+// Input returns the value being processed by a consumer.
+func Input(ctx consumer.ProcessingContext) any {
+	if ctx.Value == nil {
+		return nil
+	}
+	return ctx.Value.Value()
+}
+
+// Depth returns the nesting depth of the value being processed.
+func Depth(ctx consumer.ProcessingContext) int {
+	level, _ := ctx.Options[OptionDepth].(int)
+	return level
+}
+
+// AppendPath returns the path of a child value; an empty segment stands for
+// the value itself.
+func AppendPath(path []string, segment string) []string {
+	if segment == "" {
+		return path
+	}
+	return append(append(make([]string, 0, len(path)+1), path...), segment)
+}
@@ -0,0 +1,311 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+	"defs.dev/schema/core/structure"
+)
+
+// optionValidator is the Options key under which the running Validator is
+// passed to consumers, which use it to validate nested values.
+const optionValidator = "validator"
+
+// Validator validates values against a compiled schema. The validation
+// consumers of every schema in the tree, including the targets of
+// references, are resolved once, string patterns are compiled once and the
+// properties and pattern properties of objects are read once, so validating
+// a value only runs the checks. Issues are located in the schema along the
+// path by which validation reached them, so a schema shared within the tree
+// is located where it was applied. A Validator is immutable and safe for concurrent use; compile a schema once
+// and keep the Validator for as long as the schema is in use.
+type Validator struct {
+	schema    core.Schema
+	consumers []consumer.ValueConsumer // validation consumers of the registry
+	plans     map[core.Schema]*plan
+	patterns  map[string]*regexp.Regexp
+	options   map[string]any
+}
+
+// plan is what a Validator compiled for a schema.
+type plan struct {
+	consumers  []consumer.ValueConsumer // applicable consumers, in registration order
+	properties map[string]core.Schema   // properties of an object schema
+	required   []string                 // required properties of an object schema
+	patterns   []patternProperty        // pattern properties of an object schema
+}
+
+// Compile compiles a schema with the built-in validation consumers.
+func Compile(schema core.Schema) *Validator {
+	return CompileWithRegistry(NewValidationRegistry(), schema)
+}
+
+// CompileWithRegistry compiles a schema with the validation consumers of a
+// registry. Consumers registered later are not used by the Validator.
+func CompileWithRegistry(registry consumer.Registry, schema core.Schema) *Validator {
+	v := &Validator{
+		schema:   schema,
+		plans:    make(map[core.Schema]*plan),
+		patterns: make(map[string]*regexp.Regexp),
+	}
+	v.options = map[string]any{optionValidator: v}
+
+	_, names := registry.ListByPurpose("validation")
+	for _, name := range names {
+		if c, ok := registry.GetValueConsumer(name); ok {
+			v.consumers = append(v.consumers, c)
+		}
+	}
+
+	if schema != nil {
+		v.compile(schema)
+	}
+	return v
+}
+
+// Schema returns the compiled schema.
+func (v *Validator) Schema() core.Schema {
+	return v.schema
+}
+
+// Validate validates a value against the compiled schema.
+func (v *Validator) Validate(value any) ValidationResult {
+	if v.schema == nil {
+		return NewValidationError([]string{}, "validation_error", "schema cannot be nil")
+	}
+	result := v.validate(&valueNode{raw: value, schema: v.schema})
+	point(result.Errors, nil)
+	point(result.Warnings, nil)
+	return result
+}
+
+// validate validates a node of a value against its schema.
+func (v *Validator) validate(node *valueNode) ValidationResult {
+	if node.schema == nil {
+		return NewValidationResult()
+	}
+	var consumers []consumer.ValueConsumer
+	if cacheable(node.schema) {
+		node.plan = v.plans[node.schema]
+	}
+	if node.plan != nil {
+		consumers = node.plan.consumers
+	} else {
+		consumers = v.applicable(node.schema)
+	}
+	if len(consumers) == 0 {
+		return NewValidationError([]string{}, "validation_error", "no applicable value consumers found for purpose validation")
+	}
+
+	ctx := consumer.ProcessingContext{
+		Schema:  node.schema,
+		Path:    []string{},
+		Value:   node,
+		Options: v.options,
+	}
+
+	aggregated := ValidationResult{Valid: true}
+	var errors []error
+	for _, c := range consumers {
+		result, err := c.ProcessValue(ctx, node)
+		if err != nil {
+			errors = append(errors, consumer.NewConsumerError(c.Name(), "validation", ctx.Path, err))
+			continue
+		}
+		if validationResult, ok := result.Value().(ValidationResult); ok {
+			aggregated.Merge(validationResult)
+		}
+	}
+	if len(errors) > 0 {
+		return NewValidationError([]string{}, "validation_error", fmt.Sprintf("some consumers failed: %v", errors))
+	}
+	locate(aggregated.Errors, node.location)
+	locate(aggregated.Warnings, node.location)
+	fillValues(aggregated.Errors, node.raw)
+	fillValues(aggregated.Warnings, node.raw)
+	return aggregated
+}
+
+// locate fills in the schema locations of the issues reported for the schema
+// at location itself. Issues of nested schemas have been located when their
+// values were validated.
+func locate(issues []ValidationIssue, location string) {
+	for i := range issues {
+		if issues[i].SchemaLocation != "" {
+			continue
+		}
+		issues[i].SchemaLocation = location
+		if issues[i].Keyword != "" {
+			issues[i].SchemaLocation += "/" + issues[i].Keyword
+		}
+	}
+}
+
+// fillValues fills in the value of the issues reported for the value itself.
+func fillValues(issues []ValidationIssue, value any) {
+	for i := range issues {
+		if len(issues[i].Path) == 0 && issues[i].Value == nil {
+			issues[i].Value = value
+		}
+	}
+}
+
+// point fills in the JSON Pointers of issues. Consumers report the paths of
+// causes relative to their issue; they are made relative to the root as well.
+func point(issues []ValidationIssue, parent []string) {
+	for i := range issues {
+		issues[i].Path = append(append([]string{}, parent...), issues[i].Path...)
+		issues[i].Pointer = JSONPointer(issues[i].Path)
+		point(issues[i].Causes, issues[i].Path)
+	}
+}
+
+// compile resolves the consumers and compiles the patterns of every schema
+// reachable from schema, including the targets of references. Schemas
+// reached again, such as recursive references, are compiled once.
+func (v *Validator) compile(schema core.Schema) {
+	pending := []core.Schema{schema}
+	for len(pending) > 0 {
+		s := pending[0]
+		pending = pending[1:]
+		if !cacheable(s) {
+			continue
+		}
+		if _, compiled := v.plans[s]; compiled {
+			continue
+		}
+		p := &plan{consumers: v.applicable(s)}
+		if object, ok := s.(core.ObjectSchema); ok {
+			p.properties = object.Properties()
+			p.required = object.Required()
+			p.patterns = patternProperties(object)
+		}
+		v.plans[s] = p
+		v.compilePatterns(s)
+		pending = append(pending, children(s)...)
+	}
+}
+
+// applicable returns the consumers that apply to a schema, in registration order.
+func (v *Validator) applicable(schema core.Schema) []consumer.ValueConsumer {
+	var applicable []consumer.ValueConsumer
+	for _, c := range v.consumers {
+		if c.ApplicableSchemas().Matches(schema) {
+			applicable = append(applicable, c)
+		}
+	}
+	return applicable
+}
+
+func (v *Validator) compilePatterns(schema core.Schema) {
+	sources := []string{}
+	if s, ok := schema.(core.StringSchema); ok && s.Pattern() != "" {
+		sources = append(sources, s.Pattern())
+	}
+	for _, annotation := range schema.Annotations() {
+		if source, ok := annotation.Value().(string); ok && annotation.Name() == "pattern" {
+			sources = append(sources, source)
+		}
+	}
+
+	// Invalid patterns are reported when values are validated against them
+	for _, source := range sources {
+		if re, err := regexp.Compile(source); err == nil {
+			v.patterns[source] = re
+		}
+	}
+}
+
+// children returns the schemas nested in a schema that values are validated
+// against, which include the targets of references.
+func children(schema core.Schema) []core.Schema {
+	if ref, ok := schema.(core.RefSchema); ok {
+		if target, err := ref.Resolve(); err == nil {
+			return []core.Schema{target}
+		}
+		return nil
+	}
+	var nested []core.Schema
+	for _, child := range structure.Children(schema) {
+		nested = append(nested, child.Schema)
+	}
+	return nested
+}
+
+// cacheable reports whether a schema can key the plans of a Validator.
+func cacheable(schema core.Schema) bool {
+	return schema != nil && reflect.TypeOf(schema).Comparable()
+}
+
+// validateChild validates a value against a schema nested in the schema
+// being processed, with the Validator running ctx when there is one. segment
+// is the path fragment of the value within the value being processed, or ""
+// when the value is validated in its place; location is the JSON Pointer of
+// the schema within the schema being processed, or "" for the same schema.
+func validateChild(ctx consumer.ProcessingContext, segment, location string, schema core.Schema, value any) ValidationResult {
+	parent, _ := ctx.Value.(*valueNode)
+	node := &valueNode{raw: value, schema: schema, parent: parent, segment: segment}
+	if parent != nil {
+		node.location = parent.location
+		node.registry = parent.registry
+	}
+	if location != "" {
+		node.location += "/" + location
+	}
+	if node.recurs() {
+		return NewValidationError([]string{}, "circular_reference",
+			fmt.Sprintf("circular reference: %s schema is applied to the same value again", schema.Type()))
+	}
+	if v, ok := ctx.Options[optionValidator].(*Validator); ok {
+		return v.validate(node)
+	}
+	return validateNode(node)
+}
+
+// sameValue reports whether a and b are the same Go value: equal scalars, or
+// maps, pointers and slices sharing their storage.
+func sameValue(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() {
+		return !va.IsValid() && !vb.IsValid()
+	}
+	if va.Type() != vb.Type() {
+		return false
+	}
+	switch va.Kind() {
+	case reflect.Map, reflect.Pointer:
+		return va.UnsafePointer() == vb.UnsafePointer()
+	case reflect.Slice:
+		return va.UnsafePointer() == vb.UnsafePointer() && va.Len() == vb.Len()
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return a == b
+	}
+	return false
+}
+
+// objectShape returns the properties, required properties and pattern
+// properties of the object schema being processed, as compiled by the
+// Validator running ctx when there is one.
+func objectShape(ctx consumer.ProcessingContext, schema core.ObjectSchema) (map[string]core.Schema, []string, []patternProperty) {
+	if node, ok := ctx.Value.(*valueNode); ok && node.plan != nil && node.plan.properties != nil {
+		return node.plan.properties, node.plan.required, node.plan.patterns
+	}
+	return schema.Properties(), schema.Required(), patternProperties(schema)
+}
+
+// compiledPattern returns a pattern compiled by the Validator running ctx, or
+// compiles it.
+func compiledPattern(ctx consumer.ProcessingContext, source string) (*regexp.Regexp, error) {
+	if v, ok := ctx.Options[optionValidator].(*Validator); ok {
+		if re, ok := v.patterns[source]; ok {
+			return re, nil
+		}
+	}
+	return regexp.Compile(source)
+}
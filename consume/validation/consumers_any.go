@@ -0,0 +1,42 @@
+package validation
+
+import (
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// AnyValidationConsumer accepts every value, including null.
+type AnyValidationConsumer struct{}
+
+func (c *AnyValidationConsumer) Name() string {
+	return "any_validator"
+}
+
+func (c *AnyValidationConsumer) Purpose() consumer.ConsumerPurpose {
+	return "validation"
+}
+
+func (c *AnyValidationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeAny)
+}
+
+func (c *AnyValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, value core.Value[any]) (consumer.ConsumerResult, error) {
+	result := ValidationResult{
+		Valid:  true,
+		Errors: []ValidationIssue{},
+	}
+
+	return consumer.NewResult("validation", result), nil
+}
+
+func (c *AnyValidationConsumer) Metadata() consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         "any_validator",
+		Purpose:      "validation",
+		Description:  "Accepts every value",
+		Version:      "1.0.0",
+		Tags:         []string{"validation", "any"},
+		ResultKind:   "validation",
+		ResultGoType: "*validation.ValidationResult",
+	}
+}
@@ -3,6 +3,7 @@ package validation
 import (
 	"defs.dev/schema/core/consumer"
 	"fmt"
+	"strconv"
 
 	"defs.dev/schema/core"
 )
@@ -62,6 +63,8 @@ func (c *ArrayValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, v
 			Path:    ctx.Path,
 			Message: fmt.Sprintf("expected array, got %T", actualValue),
 			Code:    "type_mismatch",
+			Keyword: "type",
+			Params:  map[string]any{"expected": "array"},
 		})
 		return consumer.NewResult("validation", result), nil
 	}
@@ -81,6 +84,8 @@ func (c *ArrayValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, v
 				Path:    ctx.Path,
 				Message: fmt.Sprintf("array has %d items, minimum required is %d", len(arrayItems), *minItems),
 				Code:    "min_items_violation",
+				Keyword: "minItems",
+				Params:  map[string]any{"min": *minItems},
 			})
 		}
 	}
@@ -92,6 +97,8 @@ func (c *ArrayValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, v
 				Path:    ctx.Path,
 				Message: fmt.Sprintf("array has %d items, maximum allowed is %d", len(arrayItems), *maxItems),
 				Code:    "max_items_violation",
+				Keyword: "maxItems",
+				Params:  map[string]any{"max": *maxItems},
 			})
 		}
 	}
@@ -107,24 +114,31 @@ func (c *ArrayValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, v
 					Path:    append(ctx.Path, fmt.Sprintf("[%d]", i)),
 					Message: fmt.Sprintf("duplicate item found: %v", item),
 					Code:    "unique_items_violation",
+					Keyword: "uniqueItems",
+					Value:   item,
 				})
 			}
 			seen[itemStr] = true
 		}
 	}
 
-	// Validate each item against the item schema
-	if itemSchema := arraySchema.ItemSchema(); itemSchema != nil {
+	// Tuples validate each position against its own schema
+	if prefixItems := arraySchema.PrefixItemSchemas(); len(prefixItems) > 0 {
+		for _, issue := range c.validateTuple(ctx, arraySchema, prefixItems, arrayItems) {
+			result.Valid = false
+			result.Errors = append(result.Errors, issue)
+		}
+	} else if itemSchema := arraySchema.ItemSchema(); itemSchema != nil {
+		// Validate each item against the item schema
 		for i, item := range arrayItems {
-			itemPath := append(ctx.Path, fmt.Sprintf("[%d]", i))
-
 			// Use recursive validation for the item
-			itemResult := ValidateWithRegistry(itemSchema, item)
+			itemResult := validateChild(ctx, strconv.Itoa(i), "items", itemSchema, item)
 			if !itemResult.Valid {
 				result.Valid = false
 				// Add path context to item errors
+				itemPath := append(append([]string(nil), ctx.Path...), fmt.Sprintf("[%d]", i))
 				for _, err := range itemResult.Errors {
-					err.Path = itemPath
+					err.Path = append(append([]string(nil), itemPath...), err.Path...)
 					result.Errors = append(result.Errors, err)
 				}
 			}
@@ -134,8 +148,8 @@ func (c *ArrayValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, v
 	// Validate contains constraint
 	if containsSchema := arraySchema.ContainsSchema(); containsSchema != nil {
 		containsMatched := false
-		for _, item := range arrayItems {
-			itemResult := ValidateWithRegistry(containsSchema, item)
+		for i, item := range arrayItems {
+			itemResult := validateChild(ctx, strconv.Itoa(i), "contains", containsSchema, item)
 			if itemResult.Valid {
 				containsMatched = true
 				break
@@ -147,6 +161,7 @@ func (c *ArrayValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, v
 				Path:    ctx.Path,
 				Message: "array does not contain any item matching the contains schema",
 				Code:    "contains_constraint_violation",
+				Keyword: "contains",
 			})
 		}
 	}
@@ -154,10 +169,53 @@ func (c *ArrayValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, v
 	return consumer.NewResult("validation", result), nil
 }
 
+// validateTuple validates items against their positional schemas. Items past
+// the prefix are validated against the rest schema, or rejected if the tuple
+// is closed.
+func (c *ArrayValidationConsumer) validateTuple(ctx consumer.ProcessingContext, arraySchema core.ArraySchema, prefixItems []core.Schema, items []any) []ValidationIssue {
+	var issues []ValidationIssue
+
+	restSchema := arraySchema.RestItemSchema()
+	if len(items) < len(prefixItems) || (restSchema == nil && len(items) > len(prefixItems)) {
+		message := fmt.Sprintf("tuple has %d items, expected %d", len(items), len(prefixItems))
+		if restSchema != nil {
+			message = fmt.Sprintf("tuple has %d items, expected at least %d", len(items), len(prefixItems))
+		}
+		issues = append(issues, ValidationIssue{
+			Path:    ctx.Path,
+			Message: message,
+			Code:    "tuple_length_violation",
+			Keyword: "prefixItems",
+			Params:  map[string]any{"items": len(prefixItems), "closed": restSchema == nil},
+		})
+	}
+
+	for i, item := range items {
+		itemSchema, location := restSchema, "items"
+		if i < len(prefixItems) {
+			itemSchema, location = prefixItems[i], fmt.Sprintf("prefixItems/%d", i)
+		}
+		if itemSchema == nil {
+			continue
+		}
+
+		itemResult := validateChild(ctx, strconv.Itoa(i), location, itemSchema, item)
+		if !itemResult.Valid {
+			itemPath := append(append([]string(nil), ctx.Path...), fmt.Sprintf("[%d]", i))
+			for _, err := range itemResult.Errors {
+				err.Path = append(append([]string(nil), itemPath...), err.Path...)
+				issues = append(issues, err)
+			}
+		}
+	}
+
+	return issues
+}
+
 func (c *ArrayValidationConsumer) validateItem(ctx consumer.ProcessingContext, value core.Value[any]) ValidationResult {
 	// Use recursive validation instead of simplified validation
 	actualValue := value.Value()
-	return ValidateWithRegistry(ctx.Schema, actualValue)
+	return validateChild(ctx, "", "", ctx.Schema, actualValue)
 }
 
 func (c *ArrayValidationConsumer) Metadata() consumer.ConsumerMetadata {
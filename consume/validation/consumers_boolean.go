@@ -4,7 +4,6 @@ package validation
 import (
 	"defs.dev/schema/core/consumer"
 	"fmt"
-	"strings"
 
 	"defs.dev/schema/core"
 )
@@ -33,7 +32,7 @@ func (c *BooleanValidationConsumer) ProcessValue(ctx consumer.ProcessingContext,
 	// Get the actual value
 	actualValue := value.Value()
 
-	// Try to cast to BooleanSchema to check for string conversion
+	// Strings such as "yes" are converted by the coercion package, not here
 	if _, ok := ctx.Schema.(core.BooleanSchema); ok {
 		// Check if it's a boolean first
 		if _, ok := actualValue.(bool); ok {
@@ -46,6 +45,8 @@ func (c *BooleanValidationConsumer) ProcessValue(ctx consumer.ProcessingContext,
 			Path:    ctx.Path,
 			Message: fmt.Sprintf("expected boolean, got %T", actualValue),
 			Code:    "type_mismatch",
+			Keyword: "type",
+			Params:  map[string]any{"expected": "boolean"},
 		})
 		return consumer.NewResult("validation", result), nil
 	}
@@ -58,44 +59,14 @@ func (c *BooleanValidationConsumer) ProcessValue(ctx consumer.ProcessingContext,
 			Path:    ctx.Path,
 			Message: fmt.Sprintf("expected boolean, got %T", actualValue),
 			Code:    "type_mismatch",
+			Keyword: "type",
+			Params:  map[string]any{"expected": "boolean"},
 		})
 	}
 
 	return consumer.NewResult("validation", result), nil
 }
 
-func (c *BooleanValidationConsumer) convertStringToBool(str string, caseInsensitive bool) (bool, error) {
-	// Always check exact matches first
-	switch str {
-	case "true", "1", "yes", "on", "y", "t":
-		return true, nil
-	case "false", "0", "no", "off", "n", "f":
-		return false, nil
-	}
-
-	// For string conversion, be reasonably permissive with common case variations
-	// even if CaseInsensitive is not explicitly enabled
-	lowerStr := strings.ToLower(str)
-	switch lowerStr {
-	case "true":
-		return true, nil
-	case "false":
-		return false, nil
-	}
-
-	// If case insensitive is enabled, also check additional patterns
-	if caseInsensitive {
-		switch lowerStr {
-		case "yes", "on", "y", "t", "1":
-			return true, nil
-		case "no", "off", "n", "f", "0":
-			return false, nil
-		}
-	}
-
-	return false, fmt.Errorf("invalid boolean string: %s", str)
-}
-
 func (c *BooleanValidationConsumer) Metadata() consumer.ConsumerMetadata {
 	return consumer.ConsumerMetadata{
 		Name:         "boolean_validator",
@@ -5,6 +5,7 @@ import (
 
 	"defs.dev/schema/core"
 	"defs.dev/schema/core/consumer"
+	"defs.dev/schema/core/jsonpointer"
 )
 
 // FunctionValidationConsumer validates function input/output values
@@ -42,6 +43,8 @@ func (c *FunctionValidationConsumer) ProcessValue(ctx consumer.ProcessingContext
 			Path:    ctx.Path,
 			Message: fmt.Sprintf("expected function input map, got %T", actualValue),
 			Code:    "type_mismatch",
+			Keyword: "type",
+			Params:  map[string]any{"expected": "object"},
 		})
 		return consumer.NewResult("validation", result), nil
 	}
@@ -59,6 +62,8 @@ func (c *FunctionValidationConsumer) ProcessValue(ctx consumer.ProcessingContext
 				Path:    append(ctx.Path, inputName),
 				Message: fmt.Sprintf("required input '%s' is missing", inputName),
 				Code:    "missing_required_input",
+				Keyword: "required",
+				Params:  map[string]any{"input": inputName},
 			})
 			continue
 		}
@@ -69,7 +74,7 @@ func (c *FunctionValidationConsumer) ProcessValue(ctx consumer.ProcessingContext
 			inputPath := append(ctx.Path, inputName)
 
 			// Use recursive validation for the input value
-			inputResult := ValidateWithRegistry(inputSchema, inputValue)
+			inputResult := validateChild(ctx, inputName, "inputs/"+jsonpointer.Escape(inputName), inputSchema, inputValue)
 			if !inputResult.Valid {
 				result.Valid = false
 				// Add path context to input errors
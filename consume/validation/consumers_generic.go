@@ -0,0 +1,107 @@
+package validation
+
+import (
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// TypeParameterValidationConsumer validates values against an unbound type
+// parameter. Any value is accepted unless the parameter has a constraint.
+type TypeParameterValidationConsumer struct{}
+
+func (c *TypeParameterValidationConsumer) Name() string {
+	return "parameter_validator"
+}
+
+func (c *TypeParameterValidationConsumer) Purpose() consumer.ConsumerPurpose {
+	return "validation"
+}
+
+func (c *TypeParameterValidationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeParameter)
+}
+
+func (c *TypeParameterValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, value core.Value[any]) (consumer.ConsumerResult, error) {
+	result := ValidationResult{
+		Valid:  true,
+		Errors: []ValidationIssue{},
+	}
+
+	paramSchema, ok := ctx.Schema.(core.TypeParameterSchema)
+	if !ok || paramSchema.Constraint() == nil {
+		return consumer.NewResult("validation", result), nil
+	}
+
+	return consumer.NewResult("validation", validateNested(ctx, "constraint", paramSchema.Constraint(), value.Value())), nil
+}
+
+func (c *TypeParameterValidationConsumer) Metadata() consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         "parameter_validator",
+		Purpose:      "validation",
+		Description:  "Validates values against the constraint of an unbound type parameter",
+		Version:      "1.0.0",
+		Tags:         []string{"validation", "generic", "parameter"},
+		ResultKind:   "validation",
+		ResultGoType: "*validation.ValidationResult",
+	}
+}
+
+// GenericValidationConsumer validates values against an uninstantiated generic
+// schema by validating against its template, with parameters left unbound.
+type GenericValidationConsumer struct{}
+
+func (c *GenericValidationConsumer) Name() string {
+	return "generic_validator"
+}
+
+func (c *GenericValidationConsumer) Purpose() consumer.ConsumerPurpose {
+	return "validation"
+}
+
+func (c *GenericValidationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeGeneric)
+}
+
+func (c *GenericValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, value core.Value[any]) (consumer.ConsumerResult, error) {
+	result := ValidationResult{
+		Valid:  true,
+		Errors: []ValidationIssue{},
+	}
+
+	genericSchema, ok := ctx.Schema.(core.GenericSchema)
+	if !ok || genericSchema.Template() == nil {
+		return consumer.NewResult("validation", result), nil
+	}
+
+	return consumer.NewResult("validation", validateNested(ctx, "template", genericSchema.Template(), value.Value())), nil
+}
+
+func (c *GenericValidationConsumer) Metadata() consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         "generic_validator",
+		Purpose:      "validation",
+		Description:  "Validates values against the template of a generic schema",
+		Version:      "1.0.0",
+		Tags:         []string{"validation", "generic"},
+		ResultKind:   "validation",
+		ResultGoType: "*validation.ValidationResult",
+	}
+}
+
+// validateNested validates a value against a schema on behalf of a wrapping
+// schema, prefixing error paths with the current path. location is the
+// location of the schema within the wrapping schema.
+func validateNested(ctx consumer.ProcessingContext, location string, schema core.Schema, value any) ValidationResult {
+	nested := validateChild(ctx, "", location, schema, value)
+	result := ValidationResult{
+		Valid:    nested.Valid,
+		Errors:   []ValidationIssue{},
+		Warnings: nested.Warnings,
+	}
+	for _, err := range nested.Errors {
+		err.Path = append(append([]string(nil), ctx.Path...), err.Path...)
+		result.Errors = append(result.Errors, err)
+	}
+	return result
+}
@@ -0,0 +1,183 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// MapValidationConsumer validates map values
+type MapValidationConsumer struct{}
+
+func (c *MapValidationConsumer) Name() string {
+	return "map_validator"
+}
+
+func (c *MapValidationConsumer) Purpose() consumer.ConsumerPurpose {
+	return "validation"
+}
+
+func (c *MapValidationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeMap)
+}
+
+func (c *MapValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, value core.Value[any]) (consumer.ConsumerResult, error) {
+	result := ValidationResult{
+		Valid:  true,
+		Errors: []ValidationIssue{},
+	}
+
+	// Get the actual map value
+	actualValue := value.Value()
+
+	entries, ok := c.convertToEntries(actualValue)
+	if !ok {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationIssue{
+			Path:    ctx.Path,
+			Message: fmt.Sprintf("expected map, got %T", actualValue),
+			Code:    "type_mismatch",
+			Keyword: "type",
+			Params:  map[string]any{"expected": "object"},
+		})
+		return consumer.NewResult("validation", result), nil
+	}
+
+	// Cast to MapSchema to access properties
+	mapSchema, ok := ctx.Schema.(core.MapSchema)
+	if !ok {
+		// Fallback validation - just check it's a map
+		return consumer.NewResult("validation", result), nil
+	}
+
+	// Validate entry count constraints
+	if minItems := mapSchema.MinItems(); minItems != nil && len(entries) < *minItems {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationIssue{
+			Path:    ctx.Path,
+			Message: fmt.Sprintf("map has %d entries, minimum required is %d", len(entries), *minItems),
+			Code:    "min_items_violation",
+			Keyword: "minProperties",
+			Params:  map[string]any{"min": *minItems},
+		})
+	}
+
+	if maxItems := mapSchema.MaxItems(); maxItems != nil && len(entries) > *maxItems {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationIssue{
+			Path:    ctx.Path,
+			Message: fmt.Sprintf("map has %d entries, maximum allowed is %d", len(entries), *maxItems),
+			Code:    "max_items_violation",
+			Keyword: "maxProperties",
+			Params:  map[string]any{"max": *maxItems},
+		})
+	}
+
+	keySchema := mapSchema.KeySchema()
+	valueSchema := mapSchema.ValueSchema()
+
+	for _, entry := range entries {
+		entryPath := append(append([]string(nil), ctx.Path...), entry.name)
+
+		// Validate the key against the key schema
+		if keySchema != nil {
+			keyResult := validateChild(ctx, "", "propertyNames", keySchema, c.keyValue(keySchema, entry.key))
+			if !keyResult.Valid {
+				result.Valid = false
+				for _, err := range keyResult.Errors {
+					result.Errors = append(result.Errors, ValidationIssue{
+						Path:    entryPath,
+						Message: fmt.Sprintf("invalid key '%s': %s", entry.name, err.Message),
+						Code:    "invalid_map_key",
+						Keyword: "propertyNames",
+						Value:   entry.key,
+						Causes:  []ValidationIssue{err},
+					})
+				}
+			}
+		}
+
+		// Validate the value against the value schema
+		if valueSchema != nil {
+			valueResult := validateChild(ctx, entry.name, "additionalProperties", valueSchema, entry.value)
+			if !valueResult.Valid {
+				result.Valid = false
+				for _, err := range valueResult.Errors {
+					err.Path = append(entryPath, err.Path...)
+					result.Errors = append(result.Errors, err)
+				}
+			}
+		}
+	}
+
+	return consumer.NewResult("validation", result), nil
+}
+
+// mapEntry is a single key/value pair of a map value.
+type mapEntry struct {
+	name  string
+	key   any
+	value any
+}
+
+// convertToEntries converts map-like values to a slice of entries sorted by key name,
+// so that validation errors are reported in a stable order.
+func (c *MapValidationConsumer) convertToEntries(value any) ([]mapEntry, bool) {
+	if value == nil {
+		return nil, false
+	}
+
+	var entries []mapEntry
+	if m, ok := value.(map[string]any); ok {
+		entries = make([]mapEntry, 0, len(m))
+		for k, v := range m {
+			entries = append(entries, mapEntry{name: k, key: k, value: v})
+		}
+	} else {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Map {
+			return nil, false
+		}
+		entries = make([]mapEntry, 0, rv.Len())
+		for _, key := range rv.MapKeys() {
+			k := key.Interface()
+			entries = append(entries, mapEntry{name: fmt.Sprintf("%v", k), key: k, value: rv.MapIndex(key).Interface()})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	return entries, true
+}
+
+// keyValue adapts a map key to the key schema. Integer key schemas accept
+// string keys holding integers, since JSON object keys are always strings.
+func (c *MapValidationConsumer) keyValue(keySchema core.Schema, key any) any {
+	if keySchema.Type() != core.TypeInteger {
+		return key
+	}
+	if s, ok := key.(string); ok {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+	}
+	return key
+}
+
+func (c *MapValidationConsumer) Metadata() consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         "map_validator",
+		Purpose:      "validation",
+		Description:  "Validates map keys and values against map schema constraints",
+		Version:      "1.0.0",
+		Tags:         []string{"validation", "map", "constraints"},
+		ResultKind:   "validation",
+		ResultGoType: "*validation.ValidationResult",
+	}
+}
@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// NullValidationConsumer validates that values are null.
+// Typed nil pointers, maps, slices and interfaces count as null.
+type NullValidationConsumer struct{}
+
+func (c *NullValidationConsumer) Name() string {
+	return "null_validator"
+}
+
+func (c *NullValidationConsumer) Purpose() consumer.ConsumerPurpose {
+	return "validation"
+}
+
+func (c *NullValidationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeNull)
+}
+
+func (c *NullValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, value core.Value[any]) (consumer.ConsumerResult, error) {
+	result := ValidationResult{
+		Valid:  true,
+		Errors: []ValidationIssue{},
+	}
+
+	actualValue := value.Value()
+	if !c.isNull(actualValue) {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationIssue{
+			Path:    ctx.Path,
+			Message: fmt.Sprintf("expected null, got %T", actualValue),
+			Code:    "type_mismatch",
+			Keyword: "type",
+			Params:  map[string]any{"expected": "null"},
+		})
+	}
+
+	return consumer.NewResult("validation", result), nil
+}
+
+// isNull reports whether the value is nil or a typed nil.
+func (c *NullValidationConsumer) isNull(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func (c *NullValidationConsumer) Metadata() consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         "null_validator",
+		Purpose:      "validation",
+		Description:  "Accepts only null values",
+		Version:      "1.0.0",
+		Tags:         []string{"validation", "null"},
+		ResultKind:   "validation",
+		ResultGoType: "*validation.ValidationResult",
+	}
+}
@@ -2,7 +2,9 @@
 package validation
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"defs.dev/schema/core"
 	"defs.dev/schema/core/consumer"
@@ -78,12 +80,18 @@ func (c *NumberValidationConsumer) ProcessValue(ctx consumer.ProcessingContext,
 	case float32:
 		numValue = float64(v)
 		ok = true
+	case json.Number:
+		f, err := v.Float64()
+		numValue = f
+		ok = err == nil
 	default:
 		result.Valid = false
 		result.Errors = append(result.Errors, ValidationIssue{
 			Path:    ctx.Path,
 			Message: "Expected number",
 			Code:    "type_mismatch",
+			Keyword: "type",
+			Params:  map[string]any{"expected": "number"},
 		})
 		return consumer.NewResult("validation", result), nil
 	}
@@ -221,6 +229,8 @@ func (c *NumberValidationConsumer) validateIntegerValue(integerSchema core.Integ
 				Path:    path,
 				Message: "expected integer value",
 				Code:    "not_integer",
+				Keyword: "type",
+				Params:  map[string]any{"expected": "integer"},
 			})
 			return consumer.NewResult("validation", result), nil
 		}
@@ -235,15 +245,29 @@ func (c *NumberValidationConsumer) validateIntegerValue(integerSchema core.Integ
 				Path:    path,
 				Message: "expected integer value",
 				Code:    "not_integer",
+				Keyword: "type",
+				Params:  map[string]any{"expected": "integer"},
 			})
 			return consumer.NewResult("validation", result), nil
 		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			intValue = n
+			isSignedInt = true
+			isValidInteger = true
+		} else if n, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			uintValue = n
+			isUnsignedInt = true
+			isValidInteger = true
+		}
 	default:
 		result.Valid = false
 		result.Errors = append(result.Errors, ValidationIssue{
 			Path:    path,
 			Message: fmt.Sprintf("expected integer, got %T", actualValue),
 			Code:    "type_mismatch",
+			Keyword: "type",
+			Params:  map[string]any{"expected": "integer"},
 		})
 		return consumer.NewResult("validation", result), nil
 	}
@@ -266,6 +290,8 @@ func (c *NumberValidationConsumer) validateIntegerValue(integerSchema core.Integ
 				result.Errors = append(result.Errors, ValidationIssue{
 					Path:    path,
 					Code:    "number_too_small",
+					Keyword: "minimum",
+					Params:  map[string]any{"min": *min},
 					Message: fmt.Sprintf("value %d is less than minimum %d", intValue, *min),
 				})
 			}
@@ -277,6 +303,8 @@ func (c *NumberValidationConsumer) validateIntegerValue(integerSchema core.Integ
 				result.Errors = append(result.Errors, ValidationIssue{
 					Path:    path,
 					Code:    "number_too_small",
+					Keyword: "minimum",
+					Params:  map[string]any{"min": *min},
 					Message: fmt.Sprintf("value %d is less than minimum %d", uintValue, *min),
 				})
 			}
@@ -290,6 +318,8 @@ func (c *NumberValidationConsumer) validateIntegerValue(integerSchema core.Integ
 				result.Errors = append(result.Errors, ValidationIssue{
 					Path:    path,
 					Code:    "number_too_large",
+					Keyword: "maximum",
+					Params:  map[string]any{"max": *max},
 					Message: fmt.Sprintf("value %d exceeds maximum %d", intValue, *max),
 				})
 			}
@@ -301,6 +331,8 @@ func (c *NumberValidationConsumer) validateIntegerValue(integerSchema core.Integ
 				result.Errors = append(result.Errors, ValidationIssue{
 					Path:    path,
 					Code:    "number_too_large",
+					Keyword: "maximum",
+					Params:  map[string]any{"max": *max},
 					Message: fmt.Sprintf("value %d exceeds maximum %d", uintValue, *max),
 				})
 			} else if uintValue > uint64(*max) {
@@ -308,6 +340,8 @@ func (c *NumberValidationConsumer) validateIntegerValue(integerSchema core.Integ
 				result.Errors = append(result.Errors, ValidationIssue{
 					Path:    path,
 					Code:    "number_too_large",
+					Keyword: "maximum",
+					Params:  map[string]any{"max": *max},
 					Message: fmt.Sprintf("value %d exceeds maximum %d", uintValue, *max),
 				})
 			}
@@ -335,6 +369,8 @@ func (c *NumberValidationConsumer) validateMin(value float64, min any, path []st
 		return &ValidationIssue{
 			Path:    path,
 			Code:    "number_too_small",
+			Keyword: "minimum",
+			Params:  map[string]any{"min": minVal},
 			Message: fmt.Sprintf("value %g is less than minimum %g", value, minVal),
 		}
 	}
@@ -359,6 +395,8 @@ func (c *NumberValidationConsumer) validateMax(value float64, max any, path []st
 		return &ValidationIssue{
 			Path:    path,
 			Code:    "number_too_large",
+			Keyword: "maximum",
+			Params:  map[string]any{"max": maxVal},
 			Message: fmt.Sprintf("value %g exceeds maximum %g", value, maxVal),
 		}
 	}
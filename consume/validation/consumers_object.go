@@ -3,9 +3,12 @@ package validation
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
 
 	"defs.dev/schema/core"
 	"defs.dev/schema/core/consumer"
+	"defs.dev/schema/core/jsonpointer"
 )
 
 // ObjectValidationConsumer validates object values
@@ -43,6 +46,8 @@ func (c *ObjectValidationConsumer) ProcessValue(ctx consumer.ProcessingContext,
 			Path:    ctx.Path,
 			Message: "Expected object or map",
 			Code:    "type_mismatch",
+			Keyword: "type",
+			Params:  map[string]any{"expected": "object"},
 		})
 		return consumer.NewResult("validation", result), nil
 	default:
@@ -54,6 +59,8 @@ func (c *ObjectValidationConsumer) ProcessValue(ctx consumer.ProcessingContext,
 				Path:    ctx.Path,
 				Message: "Expected object or map",
 				Code:    "type_mismatch",
+				Keyword: "type",
+				Params:  map[string]any{"expected": "object"},
 			})
 			return consumer.NewResult("validation", result), nil
 		}
@@ -67,8 +74,9 @@ func (c *ObjectValidationConsumer) ProcessValue(ctx consumer.ProcessingContext,
 		return consumer.NewResult("validation", result), nil
 	}
 
+	properties, required, patterns := objectShape(ctx, objectSchema)
+
 	// Validate required properties
-	required := objectSchema.Required()
 	for _, requiredProp := range required {
 		if _, exists := objectMap[requiredProp]; !exists {
 			result.Valid = false
@@ -76,43 +84,77 @@ func (c *ObjectValidationConsumer) ProcessValue(ctx consumer.ProcessingContext,
 				Path:    append(ctx.Path, requiredProp),
 				Message: fmt.Sprintf("Missing required property '%s'", requiredProp),
 				Code:    "missing_required_property",
+				Keyword: "required",
+				Params:  map[string]any{"property": requiredProp},
+			})
+		}
+	}
+
+	// Patterns that do not compile are reported once per object
+	for _, pattern := range patterns {
+		if pattern.err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, ValidationIssue{
+				Path:    ctx.Path,
+				Code:    "invalid_regex",
+				Keyword: "patternProperties",
+				Params:  map[string]any{"pattern": pattern.pattern},
+				Message: "invalid regular expression: " + pattern.err.Error(),
 			})
 		}
 	}
 
 	// Validate properties against their schemas
-	properties := objectSchema.Properties()
 	for propName, propValue := range objectMap {
+		// Properties are validated against every pattern their name matches
+		matched := false
+		for _, pattern := range patterns {
+			if pattern.matches(propName) {
+				matched = true
+				c.validateProperty(ctx, &result, propName, "patternProperties/"+jsonpointer.Escape(pattern.pattern), pattern.schema, propValue)
+			}
+		}
+
 		propSchema, exists := properties[propName]
 		if !exists {
 			// Check if additional properties are allowed
-			if !objectSchema.AdditionalProperties() {
+			if !matched && !objectSchema.AdditionalProperties() {
 				result.Valid = false
 				result.Errors = append(result.Errors, ValidationIssue{
 					Path:    append(ctx.Path, propName),
 					Message: fmt.Sprintf("Additional property '%s' is not allowed", propName),
 					Code:    "additional_property_not_allowed",
+					Keyword: "additionalProperties",
+					Params:  map[string]any{"property": propName},
+					Value:   propValue,
 				})
 			}
 			continue
 		}
 
 		// Validate the property value against its schema using recursive validation
-		propPath := append(ctx.Path, propName)
-		propResult := ValidateWithRegistry(propSchema, propValue)
-		if !propResult.Valid {
-			result.Valid = false
-			// Add path context to property errors
-			for _, err := range propResult.Errors {
-				err.Path = append(propPath, err.Path...)
-				result.Errors = append(result.Errors, err)
-			}
-		}
+		c.validateProperty(ctx, &result, propName, "properties/"+jsonpointer.Escape(propName), propSchema, propValue)
 	}
 
 	return consumer.NewResult("validation", result), nil
 }
 
+// validateProperty validates the value of a property against a schema and
+// adds the issues to result. location is the location of the schema within
+// the object schema.
+func (c *ObjectValidationConsumer) validateProperty(ctx consumer.ProcessingContext, result *ValidationResult, propName, location string, propSchema core.Schema, propValue any) {
+	propResult := validateChild(ctx, propName, location, propSchema, propValue)
+	if !propResult.Valid {
+		result.Valid = false
+		// Add path context to property errors
+		propPath := append(append([]string(nil), ctx.Path...), propName)
+		for _, err := range propResult.Errors {
+			err.Path = append(append([]string(nil), propPath...), err.Path...)
+			result.Errors = append(result.Errors, err)
+		}
+	}
+}
+
 // convertToMap converts various object-like types to map[string]any.
 func (c *ObjectValidationConsumer) convertToMap(value any) (map[string]any, bool) {
 	if value == nil {
@@ -185,3 +227,43 @@ func (c *ObjectValidationConsumer) Metadata() consumer.ConsumerMetadata {
 		ResultGoType: "*validation.ValidationResult",
 	}
 }
+
+// patternProperty is the schema of the properties whose names match a
+// pattern. The pattern "*" matches every name.
+type patternProperty struct {
+	pattern string
+	re      *regexp.Regexp
+	err     error // why the pattern does not compile
+	schema  core.Schema
+}
+
+// patternProperties returns the pattern properties of an object schema,
+// sorted by pattern, with their patterns compiled.
+func patternProperties(schema core.ObjectSchema) []patternProperty {
+	s, ok := schema.(interface {
+		PatternProperties() map[string]core.Schema
+	})
+	if !ok {
+		return nil
+	}
+	var properties []patternProperty
+	for pattern, propSchema := range s.PatternProperties() {
+		property := patternProperty{pattern: pattern, schema: propSchema}
+		if pattern != "*" {
+			property.re, property.err = regexp.Compile(pattern)
+		}
+		properties = append(properties, property)
+	}
+	sort.Slice(properties, func(i, j int) bool {
+		return properties[i].pattern < properties[j].pattern
+	})
+	return properties
+}
+
+// matches reports whether a property name matches the pattern.
+func (p patternProperty) matches(name string) bool {
+	if p.pattern == "*" {
+		return true
+	}
+	return p.re != nil && p.re.MatchString(name)
+}
@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"reflect"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// OptionalValidationConsumer validates values against optional (nullable) schemas.
+// Null is accepted here and only here; unwrapped schemas reject nil values.
+type OptionalValidationConsumer struct{}
+
+func (c *OptionalValidationConsumer) Name() string {
+	return "optional_validator"
+}
+
+func (c *OptionalValidationConsumer) Purpose() consumer.ConsumerPurpose {
+	return "validation"
+}
+
+func (c *OptionalValidationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeOptional)
+}
+
+func (c *OptionalValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, value core.Value[any]) (consumer.ConsumerResult, error) {
+	result := ValidationResult{
+		Valid:  true,
+		Errors: []ValidationIssue{},
+	}
+
+	optionalSchema, ok := ctx.Schema.(core.OptionalSchema)
+	if !ok {
+		return consumer.NewResult("validation", result), nil
+	}
+
+	// Null (including typed nil pointers) is always accepted
+	actualValue, isNull := c.unwrap(value.Value())
+	if isNull {
+		return consumer.NewResult("validation", result), nil
+	}
+
+	itemSchema := optionalSchema.ItemSchema()
+	if itemSchema == nil {
+		return consumer.NewResult("validation", result), nil
+	}
+
+	// Non-null values must satisfy the wrapped schema
+	itemResult := validateChild(ctx, "", "anyOf/0", itemSchema, actualValue)
+	if !itemResult.Valid {
+		result.Valid = false
+		for _, err := range itemResult.Errors {
+			err.Path = append(append([]string(nil), ctx.Path...), err.Path...)
+			result.Errors = append(result.Errors, err)
+		}
+	}
+	result.Warnings = append(result.Warnings, itemResult.Warnings...)
+
+	return consumer.NewResult("validation", result), nil
+}
+
+// unwrap dereferences pointers and reports whether the value is null.
+func (c *OptionalValidationConsumer) unwrap(value any) (any, bool) {
+	if value == nil {
+		return nil, true
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, true
+		}
+		rv = rv.Elem()
+	}
+	return rv.Interface(), false
+}
+
+func (c *OptionalValidationConsumer) Metadata() consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         "optional_validator",
+		Purpose:      "validation",
+		Description:  "Accepts null and validates non-null values against the wrapped schema",
+		Version:      "1.0.0",
+		Tags:         []string{"validation", "optional", "nullable"},
+		ResultKind:   "validation",
+		ResultGoType: "*validation.ValidationResult",
+	}
+}
@@ -0,0 +1,108 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// RefValidationConsumer validates values against reference schemas by resolving
+// the target lazily. Recursion is driven by the value, so recursive schemas
+// terminate once the value has been fully traversed.
+type RefValidationConsumer struct{}
+
+func (c *RefValidationConsumer) Name() string {
+	return "ref_validator"
+}
+
+func (c *RefValidationConsumer) Purpose() consumer.ConsumerPurpose {
+	return "validation"
+}
+
+func (c *RefValidationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeRef)
+}
+
+func (c *RefValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, value core.Value[any]) (consumer.ConsumerResult, error) {
+	result := ValidationResult{
+		Valid:  true,
+		Errors: []ValidationIssue{},
+	}
+
+	refSchema, ok := ctx.Schema.(core.RefSchema)
+	if !ok {
+		return consumer.NewResult("validation", result), nil
+	}
+
+	target, issue := c.resolve(refSchema)
+	if issue != nil {
+		issue.Path = ctx.Path
+		result.Valid = false
+		result.Errors = append(result.Errors, *issue)
+		return consumer.NewResult("validation", result), nil
+	}
+
+	// Validate against the resolved target
+	targetResult := validateChild(ctx, "", "$ref", target, value.Value())
+	if !targetResult.Valid {
+		result.Valid = false
+		for _, err := range targetResult.Errors {
+			err.Path = append(append([]string(nil), ctx.Path...), err.Path...)
+			result.Errors = append(result.Errors, err)
+		}
+	}
+	result.Warnings = append(result.Warnings, targetResult.Warnings...)
+
+	return consumer.NewResult("validation", result), nil
+}
+
+// resolve follows a chain of references until it reaches a concrete schema.
+// A chain that returns to a reference it has already seen can never produce
+// a concrete schema and is reported as circular.
+func (c *RefValidationConsumer) resolve(ref core.RefSchema) (core.Schema, *ValidationIssue) {
+	seen := map[string]bool{}
+	chain := []string{}
+
+	var current core.Schema = ref
+	for current != nil && current.Type() == core.TypeRef {
+		r, ok := current.(core.RefSchema)
+		if !ok {
+			break
+		}
+
+		name := r.ReferenceName()
+		chain = append(chain, name)
+		if seen[name] {
+			return nil, &ValidationIssue{
+				Code:    "circular_reference",
+				Message: fmt.Sprintf("circular reference: %s", strings.Join(chain, " -> ")),
+			}
+		}
+		seen[name] = true
+
+		target, err := r.Resolve()
+		if err != nil {
+			return nil, &ValidationIssue{
+				Code:    "unresolved_reference",
+				Message: fmt.Sprintf("cannot resolve reference %s: %v", name, err),
+			}
+		}
+		current = target
+	}
+
+	return current, nil
+}
+
+func (c *RefValidationConsumer) Metadata() consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         "ref_validator",
+		Purpose:      "validation",
+		Description:  "Resolves referenced schemas and validates values against them",
+		Version:      "1.0.0",
+		Tags:         []string{"validation", "ref", "reference"},
+		ResultKind:   "validation",
+		ResultGoType: "*validation.ValidationResult",
+	}
+}
@@ -0,0 +1,124 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// ResultValidationConsumer validates values against result schemas.
+// A valid value is an object holding exactly one of "ok" or "err".
+type ResultValidationConsumer struct{}
+
+func (c *ResultValidationConsumer) Name() string {
+	return "result_validator"
+}
+
+func (c *ResultValidationConsumer) Purpose() consumer.ConsumerPurpose {
+	return "validation"
+}
+
+func (c *ResultValidationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeResult)
+}
+
+func (c *ResultValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, value core.Value[any]) (consumer.ConsumerResult, error) {
+	result := ValidationResult{
+		Valid:  true,
+		Errors: []ValidationIssue{},
+	}
+
+	resultSchema, ok := ctx.Schema.(core.ResultSchema)
+	if !ok {
+		return consumer.NewResult("validation", result), nil
+	}
+
+	actualValue := value.Value()
+	valueMap, ok := (&ObjectValidationConsumer{}).convertToMap(actualValue)
+	if !ok {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationIssue{
+			Path:    ctx.Path,
+			Message: fmt.Sprintf("expected result object with 'ok' or 'err', got %T", actualValue),
+			Code:    "type_mismatch",
+			Keyword: "type",
+			Params:  map[string]any{"expected": "object"},
+		})
+		return consumer.NewResult("validation", result), nil
+	}
+
+	// Exactly one of ok/err, and nothing else
+	var unexpected []string
+	for key := range valueMap {
+		if key != "ok" && key != "err" {
+			unexpected = append(unexpected, key)
+		}
+	}
+	sort.Strings(unexpected)
+
+	okValue, hasOk := valueMap["ok"]
+	errValue, hasErr := valueMap["err"]
+
+	switch {
+	case len(unexpected) > 0:
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationIssue{
+			Path:    ctx.Path,
+			Message: fmt.Sprintf("unexpected result properties: %s", strings.Join(unexpected, ", ")),
+			Code:    "invalid_result",
+		})
+		return consumer.NewResult("validation", result), nil
+	case hasOk && hasErr:
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationIssue{
+			Path:    ctx.Path,
+			Message: "result must hold either 'ok' or 'err', not both",
+			Code:    "invalid_result",
+		})
+		return consumer.NewResult("validation", result), nil
+	case !hasOk && !hasErr:
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationIssue{
+			Path:    ctx.Path,
+			Message: "result must hold either 'ok' or 'err'",
+			Code:    "invalid_result",
+		})
+		return consumer.NewResult("validation", result), nil
+	}
+
+	// Validate the payload against its branch schema
+	branch, location, branchSchema, branchValue := "ok", "success", resultSchema.SuccessSchema(), okValue
+	if hasErr {
+		branch, location, branchSchema, branchValue = "err", "error", resultSchema.ErrorSchema(), errValue
+	}
+	if branchSchema == nil {
+		return consumer.NewResult("validation", result), nil
+	}
+
+	branchResult := validateChild(ctx, branch, location, branchSchema, branchValue)
+	if !branchResult.Valid {
+		result.Valid = false
+		for _, err := range branchResult.Errors {
+			err.Path = append(append(append([]string(nil), ctx.Path...), branch), err.Path...)
+			result.Errors = append(result.Errors, err)
+		}
+	}
+	result.Warnings = append(result.Warnings, branchResult.Warnings...)
+
+	return consumer.NewResult("validation", result), nil
+}
+
+func (c *ResultValidationConsumer) Metadata() consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         "result_validator",
+		Purpose:      "validation",
+		Description:  "Validates success/failure result payloads holding exactly one of 'ok' or 'err'",
+		Version:      "1.0.0",
+		Tags:         []string{"validation", "result"},
+		ResultKind:   "validation",
+		ResultGoType: "*validation.ValidationResult",
+	}
+}
@@ -7,11 +7,15 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"defs.dev/schema/core"
 	"defs.dev/schema/core/consumer"
 )
 
+// uuidPattern matches UUIDs in lower case
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
 // StringValidationConsumer validates string values against string schema constraints
 type StringValidationConsumer struct{}
 
@@ -36,12 +40,21 @@ func (c *StringValidationConsumer) ProcessValue(ctx consumer.ProcessingContext,
 	// Get the actual string value
 	actualValue := value.Value()
 	str, ok := actualValue.(string)
+
+	// Coerced date-times are validated in their RFC 3339 form
+	if t, isTime := actualValue.(time.Time); isTime {
+		if stringSchema, isString := ctx.Schema.(core.StringSchema); isString && stringSchema.Format() == "date-time" {
+			str, ok = t.Format(time.RFC3339Nano), true
+		}
+	}
 	if !ok {
 		result.Valid = false
 		result.Errors = append(result.Errors, ValidationIssue{
 			Path:    ctx.Path,
 			Message: fmt.Sprintf("expected string, got %T", actualValue),
 			Code:    "type_mismatch",
+			Keyword: "type",
+			Params:  map[string]any{"expected": "string"},
 		})
 		return consumer.NewResult("validation", result), nil
 	}
@@ -58,7 +71,7 @@ func (c *StringValidationConsumer) ProcessValue(ctx consumer.ProcessingContext,
 					result.Errors = append(result.Errors, *err)
 				}
 			case "pattern":
-				if err := c.validatePattern(str, annotation.Value(), ctx.Path); err != nil {
+				if err := c.validatePattern(ctx, str, annotation.Value()); err != nil {
 					result.Valid = false
 					result.Errors = append(result.Errors, *err)
 				}
@@ -93,7 +106,7 @@ func (c *StringValidationConsumer) ProcessValue(ctx consumer.ProcessingContext,
 	}
 
 	if pattern := stringSchema.Pattern(); pattern != "" {
-		if err := c.validatePattern(str, pattern, ctx.Path); err != nil {
+		if err := c.validatePattern(ctx, str, pattern); err != nil {
 			result.Valid = false
 			result.Errors = append(result.Errors, *err)
 		}
@@ -120,6 +133,8 @@ func (c *StringValidationConsumer) ProcessValue(ctx consumer.ProcessingContext,
 			result.Errors = append(result.Errors, ValidationIssue{
 				Path:    ctx.Path,
 				Code:    "enum_mismatch",
+				Keyword: "enum",
+				Params:  map[string]any{"allowed": enumValues},
 				Message: fmt.Sprintf("value '%s' is not one of the allowed values", str),
 			})
 		}
@@ -144,6 +159,8 @@ func (c *StringValidationConsumer) validateFormat(value string, format any, path
 			return &ValidationIssue{
 				Path:    path,
 				Code:    "invalid_email",
+				Keyword: "format",
+				Params:  map[string]any{"format": formatStr},
 				Message: "invalid email format",
 			}
 		}
@@ -152,15 +169,18 @@ func (c *StringValidationConsumer) validateFormat(value string, format any, path
 			return &ValidationIssue{
 				Path:    path,
 				Code:    "invalid_url",
+				Keyword: "format",
+				Params:  map[string]any{"format": formatStr},
 				Message: "invalid URL format",
 			}
 		}
 	case "uuid":
-		uuidPattern := `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`
-		if matched, _ := regexp.MatchString(uuidPattern, strings.ToLower(value)); !matched {
+		if !uuidPattern.MatchString(strings.ToLower(value)) {
 			return &ValidationIssue{
 				Path:    path,
 				Code:    "invalid_uuid",
+				Keyword: "format",
+				Params:  map[string]any{"format": formatStr},
 				Message: "invalid UUID format",
 			}
 		}
@@ -168,7 +188,8 @@ func (c *StringValidationConsumer) validateFormat(value string, format any, path
 	return nil
 }
 
-func (c *StringValidationConsumer) validatePattern(value string, pattern any, path []string) *ValidationIssue {
+func (c *StringValidationConsumer) validatePattern(ctx consumer.ProcessingContext, value string, pattern any) *ValidationIssue {
+	path := ctx.Path
 	patternStr, ok := pattern.(string)
 	if !ok {
 		return &ValidationIssue{
@@ -178,16 +199,20 @@ func (c *StringValidationConsumer) validatePattern(value string, pattern any, pa
 		}
 	}
 
-	if matched, err := regexp.MatchString(patternStr, value); err != nil {
+	if re, err := compiledPattern(ctx, patternStr); err != nil {
 		return &ValidationIssue{
 			Path:    path,
 			Code:    "invalid_regex",
+			Keyword: "pattern",
+			Params:  map[string]any{"pattern": patternStr},
 			Message: "invalid regular expression: " + err.Error(),
 		}
-	} else if !matched {
+	} else if !re.MatchString(value) {
 		return &ValidationIssue{
 			Path:    path,
 			Code:    "pattern_mismatch",
+			Keyword: "pattern",
+			Params:  map[string]any{"pattern": patternStr},
 			Message: fmt.Sprintf("value does not match pattern: %s", patternStr),
 		}
 	}
@@ -212,6 +237,8 @@ func (c *StringValidationConsumer) validateMinLength(value string, minLen any, p
 		return &ValidationIssue{
 			Path:    path,
 			Code:    "string_too_short",
+			Keyword: "minLength",
+			Params:  map[string]any{"min": min},
 			Message: fmt.Sprintf("string length %d is less than minimum %d", len(value), min),
 		}
 	}
@@ -236,6 +263,8 @@ func (c *StringValidationConsumer) validateMaxLength(value string, maxLen any, p
 		return &ValidationIssue{
 			Path:    path,
 			Code:    "string_too_long",
+			Keyword: "maxLength",
+			Params:  map[string]any{"max": max},
 			Message: fmt.Sprintf("string length %d exceeds maximum %d", len(value), max),
 		}
 	}
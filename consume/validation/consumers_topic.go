@@ -0,0 +1,117 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// TopicValidationConsumer validates messages against topic schemas.
+// A message is an object holding a "payload" and optionally a "key" and "headers".
+type TopicValidationConsumer struct{}
+
+func (c *TopicValidationConsumer) Name() string {
+	return "topic_validator"
+}
+
+func (c *TopicValidationConsumer) Purpose() consumer.ConsumerPurpose {
+	return "validation"
+}
+
+func (c *TopicValidationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeTopic)
+}
+
+func (c *TopicValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, value core.Value[any]) (consumer.ConsumerResult, error) {
+	result := ValidationResult{
+		Valid:  true,
+		Errors: []ValidationIssue{},
+	}
+
+	topicSchema, ok := ctx.Schema.(core.TopicSchema)
+	if !ok {
+		return consumer.NewResult("validation", result), nil
+	}
+
+	actualValue := value.Value()
+	valueMap, ok := (&ObjectValidationConsumer{}).convertToMap(actualValue)
+	if !ok {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationIssue{
+			Path:    ctx.Path,
+			Message: fmt.Sprintf("expected message object with 'payload', got %T", actualValue),
+			Code:    "type_mismatch",
+			Keyword: "type",
+			Params:  map[string]any{"expected": "object"},
+		})
+		return consumer.NewResult("validation", result), nil
+	}
+
+	var unexpected []string
+	for key := range valueMap {
+		if key != "key" && key != "payload" && key != "headers" {
+			unexpected = append(unexpected, key)
+		}
+	}
+	sort.Strings(unexpected)
+	if len(unexpected) > 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationIssue{
+			Path:    ctx.Path,
+			Message: fmt.Sprintf("unexpected message properties: %s", strings.Join(unexpected, ", ")),
+			Code:    "invalid_message",
+		})
+		return consumer.NewResult("validation", result), nil
+	}
+	if _, ok := valueMap["payload"]; !ok {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationIssue{
+			Path:    ctx.Path,
+			Message: "message must hold a 'payload'",
+			Code:    "invalid_message",
+		})
+		return consumer.NewResult("validation", result), nil
+	}
+
+	// Validate each part that is present against its schema
+	parts := []struct {
+		name   string
+		schema core.Schema
+	}{
+		{"key", topicSchema.Key()},
+		{"payload", topicSchema.Payload()},
+		{"headers", topicSchema.Headers()},
+	}
+	for _, part := range parts {
+		partValue, present := valueMap[part.name]
+		if !present || part.schema == nil {
+			continue
+		}
+		partResult := validateChild(ctx, part.name, part.name, part.schema, partValue)
+		if !partResult.Valid {
+			result.Valid = false
+			for _, err := range partResult.Errors {
+				err.Path = append(append(append([]string(nil), ctx.Path...), part.name), err.Path...)
+				result.Errors = append(result.Errors, err)
+			}
+		}
+		result.Warnings = append(result.Warnings, partResult.Warnings...)
+	}
+
+	return consumer.NewResult("validation", result), nil
+}
+
+func (c *TopicValidationConsumer) Metadata() consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         "topic_validator",
+		Purpose:      "validation",
+		Description:  "Validates topic messages holding a payload and optionally a key and headers",
+		Version:      "1.0.0",
+		Tags:         []string{"validation", "topic"},
+		ResultKind:   "validation",
+		ResultGoType: "*validation.ValidationResult",
+	}
+}
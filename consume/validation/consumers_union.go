@@ -0,0 +1,210 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+// UnionValidationConsumer validates values against union schemas
+type UnionValidationConsumer struct{}
+
+func (c *UnionValidationConsumer) Name() string {
+	return "union_validator"
+}
+
+func (c *UnionValidationConsumer) Purpose() consumer.ConsumerPurpose {
+	return "validation"
+}
+
+func (c *UnionValidationConsumer) ApplicableSchemas() consumer.SchemaCondition {
+	return consumer.Type(core.TypeUnion)
+}
+
+func (c *UnionValidationConsumer) ProcessValue(ctx consumer.ProcessingContext, value core.Value[any]) (consumer.ConsumerResult, error) {
+	result := ValidationResult{
+		Valid:  true,
+		Errors: []ValidationIssue{},
+	}
+
+	unionSchema, ok := ctx.Schema.(core.UnionSchema)
+	if !ok {
+		return consumer.NewResult("validation", result), nil
+	}
+
+	members := unionSchema.Schemas()
+	if len(members) == 0 {
+		result.AddError(ctx.Path, "union_no_branches", "union schema has no member schemas")
+		return consumer.NewResult("validation", result), nil
+	}
+
+	actualValue := value.Value()
+
+	// A discriminator selects the branch directly for object values
+	if discriminator := unionSchema.Discriminator(); discriminator != "" {
+		c.validateDiscriminated(ctx, unionSchema, discriminator, actualValue, &result)
+		return consumer.NewResult("validation", result), nil
+	}
+
+	var matched []int
+	branchResults := make([]ValidationResult, len(members))
+	for i, member := range members {
+		branchResults[i] = validateChild(ctx, "", branchLocation(unionSchema, i), member, actualValue)
+		if branchResults[i].Valid {
+			matched = append(matched, i)
+		}
+	}
+
+	keyword := "anyOf"
+	if unionSchema.Mode() == core.UnionOneOf {
+		keyword = "oneOf"
+	}
+
+	switch {
+	case len(matched) == 0:
+		var branches ValidationResult
+		for i, branch := range branchResults {
+			c.appendBranchErrors(ctx, &branches, i, members[i], branch)
+		}
+		noMatch := ValidationIssue{
+			Path:    append([]string(nil), ctx.Path...),
+			Code:    "union_no_match",
+			Message: fmt.Sprintf("value does not match any of the %d union branches", len(members)),
+			Keyword: keyword,
+			Params:  map[string]any{"branches": len(members)},
+		}
+		for _, cause := range branches.Errors {
+			cause.Path = cause.Path[len(ctx.Path):]
+			noMatch.Causes = append(noMatch.Causes, cause)
+		}
+
+		// The branch errors are only reported as causes
+		result.Valid = false
+		result.Errors = append(result.Errors, noMatch)
+	case len(matched) > 1 && unionSchema.Mode() == core.UnionOneOf:
+		labels := make([]string, len(matched))
+		for i, idx := range matched {
+			labels[i] = branchLabel(idx, members[idx])
+		}
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationIssue{
+			Path:    append([]string(nil), ctx.Path...),
+			Code:    "union_multiple_matches",
+			Message: fmt.Sprintf("value matches %d branches of a oneOf union: %s", len(matched), strings.Join(labels, ", ")),
+			Keyword: keyword,
+			Params:  map[string]any{"matches": matched},
+		})
+	}
+
+	return consumer.NewResult("validation", result), nil
+}
+
+// validateDiscriminated validates an object value against the branch selected by its discriminator property.
+func (c *UnionValidationConsumer) validateDiscriminated(ctx consumer.ProcessingContext, schema core.UnionSchema, discriminator string, value any, result *ValidationResult) {
+	objectMap, ok := (&ObjectValidationConsumer{}).convertToMap(value)
+	if !ok {
+		result.AddError(ctx.Path, "type_mismatch",
+			fmt.Sprintf("discriminated union expects an object, got %T", value))
+		return
+	}
+
+	discriminatorPath := append(append([]string(nil), ctx.Path...), discriminator)
+	rawTag, exists := objectMap[discriminator]
+	if !exists {
+		result.AddError(discriminatorPath, "missing_discriminator",
+			fmt.Sprintf("Missing discriminator property '%s'", discriminator))
+		return
+	}
+
+	tag, ok := rawTag.(string)
+	if !ok {
+		result.AddError(discriminatorPath, "type_mismatch",
+			fmt.Sprintf("discriminator property '%s' must be a string, got %T", discriminator, rawTag))
+		return
+	}
+
+	members := schema.Schemas()
+	for i, member := range members {
+		if !matchesDiscriminator(member, discriminator, tag) {
+			continue
+		}
+		c.appendBranchErrors(ctx, result, i, member, validateChild(ctx, "", branchLocation(schema, i), member, value))
+		return
+	}
+
+	result.AddError(discriminatorPath, "unknown_discriminator",
+		fmt.Sprintf("discriminator value '%s' does not select any union branch", tag))
+}
+
+// appendBranchErrors copies a branch's errors into the result, labelling them with the branch that produced them.
+func (c *UnionValidationConsumer) appendBranchErrors(ctx consumer.ProcessingContext, result *ValidationResult, index int, member core.Schema, branch ValidationResult) {
+	if branch.Valid {
+		return
+	}
+	result.Valid = false
+	label := branchLabel(index, member)
+	for _, err := range branch.Errors {
+		err.Path = append(append([]string(nil), ctx.Path...), err.Path...)
+		err.Message = fmt.Sprintf("branch %s: %s", label, err.Message)
+		result.Errors = append(result.Errors, err)
+	}
+}
+
+// matchesDiscriminator reports whether a member schema is selected by the given discriminator value.
+// A member matches if its discriminator property enumerates the value, or if the member is named after it.
+// Referenced members are resolved first.
+func matchesDiscriminator(member core.Schema, discriminator, tag string) bool {
+	if ref, ok := member.(core.RefSchema); ok {
+		if ref.ReferenceName() == tag {
+			return true
+		}
+		resolved, err := ref.Resolve()
+		if err != nil {
+			return false
+		}
+		member = resolved
+	}
+	if objectSchema, ok := member.(core.ObjectSchema); ok {
+		if propSchema, exists := objectSchema.Properties()[discriminator]; exists {
+			if stringSchema, ok := propSchema.(core.StringSchema); ok && len(stringSchema.EnumValues()) > 0 {
+				for _, v := range stringSchema.EnumValues() {
+					if v == tag {
+						return true
+					}
+				}
+				return false
+			}
+		}
+	}
+	return member.Metadata().Name == tag
+}
+
+// branchLocation returns the location of a union branch within the union schema.
+func branchLocation(schema core.UnionSchema, index int) string {
+	if schema.Mode() == core.UnionOneOf {
+		return fmt.Sprintf("oneOf/%d", index)
+	}
+	return fmt.Sprintf("anyOf/%d", index)
+}
+
+// branchLabel returns a human-readable label for a union branch.
+func branchLabel(index int, member core.Schema) string {
+	if name := member.Metadata().Name; name != "" {
+		return fmt.Sprintf("%d (%s)", index, name)
+	}
+	return fmt.Sprintf("%d (%s)", index, member.Type())
+}
+
+func (c *UnionValidationConsumer) Metadata() consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{
+		Name:         "union_validator",
+		Purpose:      "validation",
+		Description:  "Validates values against union schema branches",
+		Version:      "1.0.0",
+		Tags:         []string{"validation", "union", "oneOf", "anyOf"},
+		ResultKind:   "validation",
+		ResultGoType: "*validation.ValidationResult",
+	}
+}
@@ -7,25 +7,36 @@ import (
 )
 
 // ValidateWithRegistry validates a value against a schema using validation consumers.
+// Nothing is kept between calls; use Compile to validate many values against
+// the same schema.
 func ValidateWithRegistry(schema core.Schema, value any) ValidationResult {
-	// Create a consumer registry with validation consumers
-	registry := NewValidationRegistry()
+	if schema == nil {
+		return NewValidationError([]string{}, "validation_error", "schema cannot be nil")
+	}
+	result := validateNode(&valueNode{raw: value, schema: schema})
+	point(result.Errors, nil)
+	point(result.Warnings, nil)
+	return result
+}
+
+// validateNode validates a node of a value against its schema with a
+// registry of validation consumers, created for the root of the value.
+func validateNode(node *valueNode) ValidationResult {
+	if node.schema == nil {
+		return NewValidationResult()
+	}
 
-	// Create a simple value wrapper
-	valueWrapper := &simpleValue{value: value}
+	// Create a consumer registry with validation consumers
+	if node.registry == nil {
+		node.registry = NewValidationRegistry()
+	}
+	registry := node.registry
 
 	// Process with validation purpose
-	results, err := registry.ProcessValueAllWithPurpose("validation", schema, valueWrapper)
+	results, err := registry.ProcessValueAllWithPurpose("validation", node.schema, node)
 	if err != nil {
 		// If processing failed, return an error result
-		return ValidationResult{
-			Valid: false,
-			Errors: []ValidationIssue{{
-				Path:    []string{},
-				Code:    "validation_error",
-				Message: err.Error(),
-			}},
-		}
+		return NewValidationError([]string{}, "validation_error", err.Error())
 	}
 
 	// Aggregate all validation results
@@ -37,6 +48,10 @@ func ValidateWithRegistry(schema core.Schema, value any) ValidationResult {
 		}
 	}
 
+	locate(aggregated.Errors, node.location)
+	locate(aggregated.Warnings, node.location)
+	fillValues(aggregated.Errors, node.raw)
+	fillValues(aggregated.Warnings, node.raw)
 	return aggregated
 }
 
@@ -55,9 +70,19 @@ func RegisterValidationConsumers(registry consumer.Registry) {
 	// Register all validation consumers
 	registry.RegisterValueConsumer(&StringValidationConsumer{})
 	registry.RegisterValueConsumer(&BooleanValidationConsumer{})
+	registry.RegisterValueConsumer(&NullValidationConsumer{})
+	registry.RegisterValueConsumer(&AnyValidationConsumer{})
 	registry.RegisterValueConsumer(&NumberValidationConsumer{})
 	registry.RegisterValueConsumer(&FunctionValidationConsumer{})
 	registry.RegisterValueConsumer(&ArrayValidationConsumer{})
 	registry.RegisterValueConsumer(&ObjectValidationConsumer{})
+	registry.RegisterValueConsumer(&MapValidationConsumer{})
+	registry.RegisterValueConsumer(&OptionalValidationConsumer{})
 	registry.RegisterValueConsumer(&ServiceValidationConsumer{})
+	registry.RegisterValueConsumer(&TopicValidationConsumer{})
+	registry.RegisterValueConsumer(&UnionValidationConsumer{})
+	registry.RegisterValueConsumer(&RefValidationConsumer{})
+	registry.RegisterValueConsumer(&ResultValidationConsumer{})
+	registry.RegisterValueConsumer(&TypeParameterValidationConsumer{})
+	registry.RegisterValueConsumer(&GenericValidationConsumer{})
 }
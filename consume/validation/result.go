@@ -1,5 +1,12 @@
 package validation
 
+import (
+	"fmt"
+	"strings"
+
+	"defs.dev/schema/core/jsonpointer"
+)
+
 // ValidationResult represents the result of a validation operation.
 // This is the canonical result type that both schema-level and value-level
 // validators should return via consumer.NewResult("validation", ValidationResult{...}).
@@ -10,10 +17,36 @@ type ValidationResult struct {
 }
 
 // ValidationIssue represents a single validation error or warning.
+//
+// Consumers report the Path, Code and Message of an issue, and the keyword
+// and parameters of the constraint it violates. A Validator fills in the
+// JSON Pointer of the value, the location of the keyword in the schema and
+// the offending value.
 type ValidationIssue struct {
 	Path    []string `json:"path"` // empty = root
 	Code    string   `json:"code"`
 	Message string   `json:"message"`
+
+	Pointer        string            `json:"pointer,omitempty"`        // RFC 6901 JSON Pointer to the value
+	SchemaLocation string            `json:"schemaLocation,omitempty"` // JSON Pointer to the keyword in the schema
+	Keyword        string            `json:"keyword,omitempty"`        // violated keyword, e.g. "minLength"
+	Params         map[string]any    `json:"params,omitempty"`         // parameters of the keyword, e.g. {"min": 3}
+	Value          any               `json:"value,omitempty"`          // offending value, unless redacted
+	Causes         []ValidationIssue `json:"causes,omitempty"`         // issues that caused this one, e.g. of union branches
+}
+
+// ProblemContentType is the media type of RFC 7807 problem documents.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem document describing a failed
+// validation. The issues are carried in the errors extension member.
+type ProblemDetails struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Errors   []ValidationIssue `json:"errors,omitempty"`
 }
 
 // NewValidationResult creates a valid ValidationResult.
@@ -72,3 +105,76 @@ func (r *ValidationResult) Merge(other ValidationResult) {
 	r.Errors = append(r.Errors, other.Errors...)
 	r.Warnings = append(r.Warnings, other.Warnings...)
 }
+
+// GroupByPath groups the errors of the result by the JSON Pointer of their
+// values, keeping their order within each group.
+func (r ValidationResult) GroupByPath() map[string][]ValidationIssue {
+	groups := make(map[string][]ValidationIssue)
+	for _, issue := range r.Errors {
+		pointer := JSONPointer(issue.Path)
+		groups[pointer] = append(groups[pointer], issue)
+	}
+	return groups
+}
+
+// Redacted returns a copy of the result without the offending values of its
+// issues, for reporting to clients that must not see them. Messages are kept
+// as they are.
+func (r ValidationResult) Redacted() ValidationResult {
+	r.Errors = redact(r.Errors)
+	r.Warnings = redact(r.Warnings)
+	return r
+}
+
+// Problem renders the result as an RFC 7807 problem document with the
+// status 422 Unprocessable Entity. Callers may set the Type and Instance of
+// the document before sending it as ProblemContentType.
+func (r ValidationResult) Problem() ProblemDetails {
+	detail := fmt.Sprintf("%d validation errors", len(r.Errors))
+	if len(r.Errors) == 1 {
+		detail = r.Errors[0].Message
+	}
+	return ProblemDetails{
+		Type:   "about:blank",
+		Title:  "Unprocessable Entity",
+		Status: 422,
+		Detail: detail,
+		Errors: r.Errors,
+	}
+}
+
+// JSONPointer returns the RFC 6901 JSON Pointer of a path. Array indices,
+// which consumers report as "[n]", become plain reference tokens.
+func JSONPointer(path []string) string {
+	var b strings.Builder
+	for _, segment := range path {
+		if len(segment) > 2 && segment[0] == '[' && segment[len(segment)-1] == ']' && isIndex(segment[1:len(segment)-1]) {
+			segment = segment[1 : len(segment)-1]
+		}
+		b.WriteByte('/')
+		b.WriteString(jsonpointer.Escape(segment))
+	}
+	return b.String()
+}
+
+func isIndex(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func redact(issues []ValidationIssue) []ValidationIssue {
+	if issues == nil {
+		return nil
+	}
+	redacted := make([]ValidationIssue, len(issues))
+	for i, issue := range issues {
+		issue.Value = nil
+		issue.Causes = redact(issue.Causes)
+		redacted[i] = issue
+	}
+	return redacted
+}
@@ -0,0 +1,100 @@
+package validation
+
+import (
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+)
+
+var _ core.Value[any] = (*valueNode)(nil)
+var _ core.ValueAccepter = (*valueNode)(nil)
+var _ core.PathAware = (*valueNode)(nil)
+
+// valueNode is a value being validated against a schema, at a position
+// within the value validated at the root. Consumers read the Go value; the
+// typed value tree of the core package is only built for consumers that
+// visit or copy the value.
+type valueNode struct {
+	raw      any
+	schema   core.Schema
+	parent   *valueNode
+	segment  string // path fragment within the parent, or "" for the same value
+	location string // JSON Pointer of the schema within the schema validated at the root
+	plan     *plan  // compiled for the schema, if any
+	typed    core.Value[any]
+
+	registry consumer.Registry // consumers validating without a Validator, shared with the parent
+}
+
+func (n *valueNode) Value() any {
+	return n.raw
+}
+
+func (n *valueNode) Copy() any {
+	return n.tree().Copy()
+}
+
+func (n *valueNode) String() string {
+	return n.tree().String()
+}
+
+func (n *valueNode) IsNull() bool {
+	return n.tree().IsNull()
+}
+
+func (n *valueNode) IsComposite() bool {
+	return n.tree().IsComposite()
+}
+
+// Path returns the path of the value within the value validated at the root.
+func (n *valueNode) Path() []string {
+	var path []string
+	for node := n; node != nil; node = node.parent {
+		if node.segment != "" {
+			path = append(path, node.segment)
+		}
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	if path == nil {
+		return []string{}
+	}
+	return path
+}
+
+// recurs reports whether the schema of the node is already being applied to
+// the same value further up. Schemas that refer back to themselves without
+// descending into the value, such as U = anyOf[Ref(U), integer], would
+// otherwise recurse forever.
+func (n *valueNode) recurs() bool {
+	if !cacheable(n.schema) {
+		return false
+	}
+	for node, p := n, n.parent; p != nil && sameValue(p.raw, node.raw); node, p = p, p.parent {
+		if cacheable(p.schema) && p.schema == n.schema {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptValue dispatches to the visitor method of the typed value.
+func (n *valueNode) AcceptValue(visitor core.ValueVisitor) error {
+	if accepter, ok := n.tree().(core.ValueAccepter); ok {
+		return accepter.AcceptValue(visitor)
+	}
+	return nil
+}
+
+// tree builds the typed value tree, by Go types where the value does not
+// fit the schema.
+func (n *valueNode) tree() core.Value[any] {
+	if n.typed == nil {
+		typed, err := core.NewValue(n.schema, n.raw)
+		if err != nil {
+			typed = core.ValueOf(n.raw)
+		}
+		n.typed = typed
+	}
+	return n.typed
+}
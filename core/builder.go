@@ -100,6 +100,8 @@ type ArraySchemaBuilder interface {
 	Contains(schema Schema) ArraySchemaBuilder
 	Length(length int) ArraySchemaBuilder
 	Range(min, max int) ArraySchemaBuilder
+	PrefixItems(schemas ...Schema) ArraySchemaBuilder
+	Rest(schema Schema) ArraySchemaBuilder
 
 	// Common array helpers
 	NonEmpty() ArraySchemaBuilder
@@ -110,6 +112,7 @@ type ArraySchemaBuilder interface {
 	List() ArraySchemaBuilder
 	Set() ArraySchemaBuilder
 	Tuple(length int) ArraySchemaBuilder
+	TupleOf(schemas ...Schema) ArraySchemaBuilder
 	LimitedList(maxItems int) ArraySchemaBuilder
 }
 
@@ -124,6 +127,74 @@ type ObjectSchemaBuilder interface {
 	Example(example map[string]any) ObjectSchemaBuilder
 }
 
+// MapSchemaBuilder defines the interface for building map schemas.
+type MapSchemaBuilder interface {
+	Builder[MapSchema]
+	MetadataBuilder[MapSchemaBuilder]
+
+	Keys(keySchema Schema) MapSchemaBuilder
+	Values(valueSchema Schema) MapSchemaBuilder
+	MinItems(min int) MapSchemaBuilder
+	MaxItems(max int) MapSchemaBuilder
+	Example(example map[string]any) MapSchemaBuilder
+
+	// Common key constraints
+	KeyPattern(pattern string) MapSchemaBuilder
+	KeyEnum(values ...string) MapSchemaBuilder
+	IntegerKeys() MapSchemaBuilder
+}
+
+// OptionalSchemaBuilder defines the interface for building optional (nullable) schemas.
+type OptionalSchemaBuilder interface {
+	Builder[OptionalSchema]
+	MetadataBuilder[OptionalSchemaBuilder]
+
+	Of(itemSchema Schema) OptionalSchemaBuilder
+	Example(example any) OptionalSchemaBuilder
+}
+
+// NullSchemaBuilder defines the interface for building null schemas.
+type NullSchemaBuilder interface {
+	Builder[NullSchema]
+	MetadataBuilder[NullSchemaBuilder]
+}
+
+// AnySchemaBuilder defines the interface for building schemas that accept any value.
+type AnySchemaBuilder interface {
+	Builder[AnySchema]
+	MetadataBuilder[AnySchemaBuilder]
+
+	Example(example any) AnySchemaBuilder
+}
+
+// ResultSchemaBuilder defines the interface for building result (success/failure) schemas.
+type ResultSchemaBuilder interface {
+	Builder[ResultSchema]
+	MetadataBuilder[ResultSchemaBuilder]
+
+	Ok(successSchema Schema) ResultSchemaBuilder
+	Err(errorSchema Schema) ResultSchemaBuilder
+	Example(example map[string]any) ResultSchemaBuilder
+}
+
+// TypeParameterSchemaBuilder defines the interface for building type parameters.
+// The parameter is identified by its name metadata.
+type TypeParameterSchemaBuilder interface {
+	Builder[TypeParameterSchema]
+	MetadataBuilder[TypeParameterSchemaBuilder]
+
+	Constraint(schema Schema) TypeParameterSchemaBuilder
+}
+
+// GenericSchemaBuilder defines the interface for building generic schema templates.
+type GenericSchemaBuilder interface {
+	Builder[GenericSchema]
+	MetadataBuilder[GenericSchemaBuilder]
+
+	TypeParameters(params ...TypeParameterSchema) GenericSchemaBuilder
+	Template(template Schema) GenericSchemaBuilder
+}
+
 // FunctionSchemaBuilder defines the interface for building function schemas.
 type FunctionSchemaBuilder interface {
 	Builder[FunctionSchema]
@@ -132,6 +203,7 @@ type FunctionSchemaBuilder interface {
 	Input(name string, schema Schema) FunctionSchemaBuilder
 	Output(name string, schema Schema) FunctionSchemaBuilder
 	Error(schema Schema) FunctionSchemaBuilder
+	Returns(result ResultSchema) FunctionSchemaBuilder
 	RequiredInputs(names ...string) FunctionSchemaBuilder
 	RequiredOutputs(names ...string) FunctionSchemaBuilder
 	Example(example map[string]any) FunctionSchemaBuilder
@@ -147,10 +219,39 @@ type ServiceSchemaBuilder interface {
 	Example(example map[string]any) ServiceSchemaBuilder
 }
 
+// TopicSchemaBuilder defines the interface for building topic schemas.
+// The topic is identified by its name metadata.
+type TopicSchemaBuilder interface {
+	Builder[TopicSchema]
+	MetadataBuilder[TopicSchemaBuilder]
+
+	Payload(schema Schema) TopicSchemaBuilder
+	Key(schema Schema) TopicSchemaBuilder
+	Headers(schema Schema) TopicSchemaBuilder
+	Example(example map[string]any) TopicSchemaBuilder
+}
+
+// ComponentSchemaBuilder defines the interface for building component schemas.
+// The component is identified by its name metadata.
+type ComponentSchemaBuilder interface {
+	Builder[ComponentSchema]
+	MetadataBuilder[ComponentSchemaBuilder]
+
+	Provides(services ...ServiceSchema) ComponentSchemaBuilder
+	Requires(services ...ServiceSchema) ComponentSchemaBuilder
+	Publishes(topics ...TopicSchema) ComponentSchemaBuilder
+	Subscribes(topics ...TopicSchema) ComponentSchemaBuilder
+	Config(schema Schema) ComponentSchemaBuilder
+}
+
 // UnionSchemaBuilder defines the interface for building union schemas.
 type UnionSchemaBuilder interface {
 	Builder[UnionSchema]
 	MetadataBuilder[UnionSchemaBuilder]
 
 	Schemas(schemas ...Schema) UnionSchemaBuilder
+	Discriminator(property string) UnionSchemaBuilder
+	OneOf() UnionSchemaBuilder
+	AnyOf() UnionSchemaBuilder
+	Example(example any) UnionSchemaBuilder
 }
@@ -2,10 +2,12 @@ package consumer
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
 	"time"
 
 	"defs.dev/schema/core"
+	"defs.dev/schema/core/structure"
 )
 
 // Registry manages both AnnotationConsumers and ValueConsumers with purpose-based selection.
@@ -56,12 +58,18 @@ type RegistryImpl struct {
 	valueConsumers  map[string]ValueConsumer
 	schemaByPurpose map[ConsumerPurpose][]AnnotationConsumer
 	valueByPurpose  map[ConsumerPurpose][]ValueConsumer
-	conditionCache  map[cacheKey]bool // cache for condition matching
+	conditionCache  map[cacheKey]bool      // cache for condition matching
+	fingerprints    map[core.Schema]string // fingerprints of comparable schema instances
+	cacheMu         sync.RWMutex
 }
 
+// maxCacheSize bounds the condition cache and the fingerprint memo; a full
+// cache is cleared.
+const maxCacheSize = 4096
+
 type cacheKey struct {
 	consumerName string
-	schemaHash   string // simplified, could use better hashing
+	fingerprint  string // structural fingerprint of the schema
 }
 
 // NewRegistry creates a new consumer registry.
@@ -72,6 +80,7 @@ func NewRegistry() Registry {
 		schemaByPurpose: make(map[ConsumerPurpose][]AnnotationConsumer),
 		valueByPurpose:  make(map[ConsumerPurpose][]ValueConsumer),
 		conditionCache:  make(map[cacheKey]bool),
+		fingerprints:    make(map[core.Schema]string),
 	}
 }
 
@@ -187,12 +196,13 @@ func (r *RegistryImpl) ListByPurpose(purpose ConsumerPurpose) ([]string, []strin
 
 // GetApplicableSchemaConsumers finds all schema consumers that match the schema.
 func (r *RegistryImpl) GetApplicableSchemaConsumers(schema core.Schema) []AnnotationConsumer {
+	fingerprint := r.fingerprint(schema)
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var applicable []AnnotationConsumer
 	for _, consumer := range r.schemaConsumers {
-		if r.checkCondition(consumer.Name(), schema, consumer.ApplicableSchemas()) {
+		if r.checkCondition(consumer.Name(), fingerprint, schema, consumer.ApplicableSchemas()) {
 			applicable = append(applicable, consumer)
 		}
 	}
@@ -201,13 +211,14 @@ func (r *RegistryImpl) GetApplicableSchemaConsumers(schema core.Schema) []Annota
 
 // GetApplicableSchemaConsumersByPurpose finds schema consumers that match schema and purpose.
 func (r *RegistryImpl) GetApplicableSchemaConsumersByPurpose(schema core.Schema, purpose ConsumerPurpose) []AnnotationConsumer {
+	fingerprint := r.fingerprint(schema)
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var applicable []AnnotationConsumer
 	if consumers, ok := r.schemaByPurpose[purpose]; ok {
 		for _, consumer := range consumers {
-			if r.checkCondition(consumer.Name(), schema, consumer.ApplicableSchemas()) {
+			if r.checkCondition(consumer.Name(), fingerprint, schema, consumer.ApplicableSchemas()) {
 				applicable = append(applicable, consumer)
 			}
 		}
@@ -217,12 +228,13 @@ func (r *RegistryImpl) GetApplicableSchemaConsumersByPurpose(schema core.Schema,
 
 // GetApplicableValueConsumers finds all value consumers that match the schema.
 func (r *RegistryImpl) GetApplicableValueConsumers(schema core.Schema) []ValueConsumer {
+	fingerprint := r.fingerprint(schema)
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var applicable []ValueConsumer
 	for _, consumer := range r.valueConsumers {
-		if r.checkCondition(consumer.Name(), schema, consumer.ApplicableSchemas()) {
+		if r.checkCondition(consumer.Name(), fingerprint, schema, consumer.ApplicableSchemas()) {
 			applicable = append(applicable, consumer)
 		}
 	}
@@ -231,13 +243,14 @@ func (r *RegistryImpl) GetApplicableValueConsumers(schema core.Schema) []ValueCo
 
 // GetApplicableValueConsumersByPurpose finds value consumers that match schema and purpose.
 func (r *RegistryImpl) GetApplicableValueConsumersByPurpose(schema core.Schema, purpose ConsumerPurpose) []ValueConsumer {
+	fingerprint := r.fingerprint(schema)
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var applicable []ValueConsumer
 	if consumers, ok := r.valueByPurpose[purpose]; ok {
 		for _, consumer := range consumers {
-			if r.checkCondition(consumer.Name(), schema, consumer.ApplicableSchemas()) {
+			if r.checkCondition(consumer.Name(), fingerprint, schema, consumer.ApplicableSchemas()) {
 				applicable = append(applicable, consumer)
 			}
 		}
@@ -451,19 +464,48 @@ func (r *RegistryImpl) ProcessWithContext(ctx ProcessingContext) (ProcessingResu
 	return result, nil
 }
 
-// checkCondition checks if a schema matches a condition, with caching.
-func (r *RegistryImpl) checkCondition(consumerName string, schema core.Schema, condition SchemaCondition) bool {
-	// Simple cache key (could be improved with better hashing)
-	key := cacheKey{
-		consumerName: consumerName,
-		schemaHash:   fmt.Sprintf("%p", schema), // simplified hash
-	}
+// checkCondition checks if a schema matches a condition, with caching by
+// the schema's fingerprint.
+func (r *RegistryImpl) checkCondition(consumerName, fingerprint string, schema core.Schema, condition SchemaCondition) bool {
+	key := cacheKey{consumerName: consumerName, fingerprint: fingerprint}
 
-	if cached, exists := r.conditionCache[key]; exists {
+	r.cacheMu.RLock()
+	cached, exists := r.conditionCache[key]
+	r.cacheMu.RUnlock()
+	if exists {
 		return cached
 	}
 
 	matches := condition.Matches(schema)
+	r.cacheMu.Lock()
+	if len(r.conditionCache) >= maxCacheSize {
+		r.conditionCache = make(map[cacheKey]bool)
+	}
 	r.conditionCache[key] = matches
+	r.cacheMu.Unlock()
 	return matches
 }
+
+// fingerprint returns the structural fingerprint of a schema, computed once
+// per comparable schema instance.
+func (r *RegistryImpl) fingerprint(schema core.Schema) string {
+	if schema == nil || !reflect.TypeOf(schema).Comparable() {
+		return structure.Fingerprint(schema)
+	}
+
+	r.cacheMu.RLock()
+	fingerprint, exists := r.fingerprints[schema]
+	r.cacheMu.RUnlock()
+	if exists {
+		return fingerprint
+	}
+
+	fingerprint = structure.Fingerprint(schema)
+	r.cacheMu.Lock()
+	if len(r.fingerprints) >= maxCacheSize {
+		r.fingerprints = make(map[core.Schema]string)
+	}
+	r.fingerprints[schema] = fingerprint
+	r.cacheMu.Unlock()
+	return fingerprint
+}
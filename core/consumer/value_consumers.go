@@ -14,6 +14,7 @@ const (
 	PurposeDocumentation ConsumerPurpose = "documentation"
 	PurposeTransform     ConsumerPurpose = "transform"
 	PurposeAnalysis      ConsumerPurpose = "analysis"
+	PurposeLint          ConsumerPurpose = "lint"
 )
 
 // ExampleStringFormattingConsumer demonstrates how to implement a value consumer.
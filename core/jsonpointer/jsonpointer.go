@@ -0,0 +1,67 @@
+// Package jsonpointer formats, parses and resolves RFC 6901 JSON Pointers,
+// which locate values in JSON documents and schemas within schemas.
+package jsonpointer
+
+import (
+	"strconv"
+	"strings"
+)
+
+var (
+	escaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	unescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// Escape encodes a reference token for use in a JSON Pointer.
+func Escape(token string) string {
+	return escaper.Replace(token)
+}
+
+// Unescape decodes a reference token of a JSON Pointer.
+func Unescape(token string) string {
+	return unescaper.Replace(token)
+}
+
+// Format joins reference tokens into a JSON Pointer. No tokens point to the
+// whole document, "".
+func Format(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(Escape(token))
+	}
+	return b.String()
+}
+
+// Parse splits a JSON Pointer into its unescaped reference tokens.
+func Parse(pointer string) []string {
+	if pointer == "" {
+		return []string{}
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = Unescape(token)
+	}
+	return tokens
+}
+
+// Lookup returns the value at a JSON Pointer within a document decoded from
+// JSON, or nil if there is none.
+func Lookup(doc any, pointer string) any {
+	current := doc
+	for _, token := range Parse(pointer) {
+		switch node := current.(type) {
+		case map[string]any:
+			current = node[token]
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil
+			}
+			current = node[index]
+		default:
+			return nil
+		}
+	}
+	return current
+}
@@ -51,6 +51,12 @@ type ArraySchema interface {
 	MaxItems() *int
 	UniqueItemsRequired() bool
 	ContainsSchema() Schema
+
+	// Tuple introspection. PrefixItemSchemas returns the schemas for leading
+	// positions; RestItemSchema describes any items after them. A tuple without
+	// a rest schema is closed.
+	PrefixItemSchemas() []Schema
+	RestItemSchema() Schema
 }
 
 // ObjectSchema interface for object schemas with introspection methods.
@@ -64,6 +70,97 @@ type ObjectSchema interface {
 	AdditionalProperties() bool
 }
 
+// MapSchema interface for map schemas with typed keys and values.
+type MapSchema interface {
+	Schema
+	Accepter
+
+	// Introspection methods
+	KeySchema() Schema
+	ValueSchema() Schema
+	MinItems() *int
+	MaxItems() *int
+}
+
+// OptionalSchema interface for schemas that wrap another schema and additionally allow null.
+type OptionalSchema interface {
+	Schema
+	Accepter
+
+	// Introspection methods
+	ItemSchema() Schema
+}
+
+// NullSchema interface for schemas that only accept null.
+// Like BooleanSchema it has no distinguishing methods; dispatch on Type().
+type NullSchema interface {
+	Schema
+	Accepter
+}
+
+// AnySchema interface for schemas that accept every value.
+// Like BooleanSchema it has no distinguishing methods; dispatch on Type().
+type AnySchema interface {
+	Schema
+	Accepter
+}
+
+// ResultSchema interface for success/failure payloads.
+// Values are objects holding exactly one of "ok" (success) or "err" (failure).
+type ResultSchema interface {
+	Schema
+	Accepter
+
+	// Introspection methods
+	SuccessSchema() Schema
+	ErrorSchema() Schema
+}
+
+// RefSchema interface for schemas that point at another named schema.
+// The target is resolved lazily, which allows recursive and mutually
+// recursive types to be described without inlining.
+type RefSchema interface {
+	Schema
+	Accepter
+
+	// Introspection methods
+	ReferenceName() string
+	Resolve() (Schema, error)
+}
+
+// TypeParameterSchema interface for placeholders inside generic schemas.
+// A parameter stands in for the type argument supplied at instantiation.
+type TypeParameterSchema interface {
+	Schema
+	Accepter
+
+	// Introspection methods
+	ParameterName() string
+	Constraint() Schema
+}
+
+// GenericSchema interface for schema templates parameterized by type parameters,
+// such as Page[T]. The template refers to its parameters by TypeParameterSchema.
+type GenericSchema interface {
+	Schema
+	Accepter
+
+	// Introspection methods
+	TypeParameters() []TypeParameterSchema
+	Template() Schema
+}
+
+// InstanceSchema interface for a generic schema applied to concrete type
+// arguments, such as Page[User]. It behaves like a reference whose target is
+// the template with every parameter substituted.
+type InstanceSchema interface {
+	RefSchema
+
+	// Introspection methods
+	GenericName() string
+	TypeArguments() []Schema
+}
+
 // ArgSchema represents a named argument with its schema and description.
 // This is used for both function inputs and outputs to provide rich metadata.
 type ArgSchema interface {
@@ -119,6 +216,34 @@ type ServiceSchema interface {
 	Methods() []ServiceMethodSchema
 }
 
+// TopicSchema interface for topics of published messages. A message carries
+// a payload and optionally a key, used for filtering and ordering, and headers.
+type TopicSchema interface {
+	Schema
+	Accepter
+
+	// Introspection methods
+	Name() string
+	Payload() Schema
+	Key() Schema
+	Headers() Schema
+}
+
+// ComponentSchema interface for components, deployable units that provide
+// and require services, publish and subscribe to topics and take configuration.
+type ComponentSchema interface {
+	Schema
+	Accepter
+
+	// Introspection methods
+	Name() string
+	Provides() []ServiceSchema
+	Requires() []ServiceSchema
+	Publishes() []TopicSchema
+	Subscribes() []TopicSchema
+	Config() Schema
+}
+
 // UnionSchema interface for union schemas with introspection methods.
 type UnionSchema interface {
 	Schema
@@ -126,4 +251,16 @@ type UnionSchema interface {
 
 	// Introspection methods
 	Schemas() []Schema
+	Discriminator() string
+	Mode() UnionMode
 }
+
+// UnionMode determines how many union branches a value may match.
+type UnionMode string
+
+const (
+	// UnionOneOf requires a value to match exactly one branch.
+	UnionOneOf UnionMode = "oneOf"
+	// UnionAnyOf requires a value to match at least one branch.
+	UnionAnyOf UnionMode = "anyOf"
+)
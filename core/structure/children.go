@@ -0,0 +1,125 @@
+package structure
+
+import (
+	"sort"
+	"strconv"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/jsonpointer"
+)
+
+// Child is a schema nested directly in another.
+type Child struct {
+	// Pointer is the JSON Pointer of the child relative to its parent, such
+	// as /properties/name, /items or /methods/get
+	Pointer string
+
+	Schema core.Schema
+}
+
+// Children returns the schemas nested directly in schema, in a stable order.
+// They are found through the core interfaces, so any implementation of them
+// has children: the properties and pattern properties of objects, the items
+// of arrays, the keys and values of maps, the members of unions, function
+// inputs, outputs and errors, service methods, topic parts, component
+// services, topics and configuration, and generic templates. References are
+// not resolved; they have no children.
+func Children(schema core.Schema) []Child {
+	var children []Child
+	add := func(pointer string, child core.Schema) {
+		if child != nil {
+			children = append(children, Child{Pointer: pointer, Schema: child})
+		}
+	}
+
+	switch s := schema.(type) {
+	case core.ObjectSchema:
+		properties := s.Properties()
+		for _, name := range sortedNames(properties) {
+			add("/properties/"+jsonpointer.Escape(name), properties[name])
+		}
+		if p, ok := s.(interface {
+			PatternProperties() map[string]core.Schema
+		}); ok {
+			patterns := p.PatternProperties()
+			for _, pattern := range sortedNames(patterns) {
+				add("/patternProperties/"+jsonpointer.Escape(pattern), patterns[pattern])
+			}
+		}
+	case core.ArraySchema:
+		add("/items", s.ItemSchema())
+		add("/contains", s.ContainsSchema())
+		add("/rest", s.RestItemSchema())
+		for i, item := range s.PrefixItemSchemas() {
+			add("/prefixItems/"+strconv.Itoa(i), item)
+		}
+	case core.MapSchema:
+		add("/key", s.KeySchema())
+		add("/value", s.ValueSchema())
+	case core.OptionalSchema:
+		add("/item", s.ItemSchema())
+	case core.UnionSchema:
+		for i, member := range s.Schemas() {
+			add("/members/"+strconv.Itoa(i), member)
+		}
+	case core.ResultSchema:
+		add("/ok", s.SuccessSchema())
+		add("/err", s.ErrorSchema())
+	case core.TypeParameterSchema:
+		add("/constraint", s.Constraint())
+	case core.GenericSchema:
+		for i, param := range s.TypeParameters() {
+			if param != nil {
+				add("/parameters/"+strconv.Itoa(i), param)
+			}
+		}
+		add("/template", s.Template())
+	case core.FunctionSchema:
+		addArgs(add, "/inputs/", s.Inputs())
+		addArgs(add, "/outputs/", s.Outputs())
+		add("/errors", s.Errors())
+	case core.ServiceSchema:
+		for _, method := range s.Methods() {
+			if function := method.Function(); function != nil {
+				add("/methods/"+jsonpointer.Escape(method.Name()), function)
+			}
+		}
+	case core.TopicSchema:
+		add("/key", s.Key())
+		add("/payload", s.Payload())
+		add("/headers", s.Headers())
+	case core.ComponentSchema:
+		for _, service := range s.Provides() {
+			add("/provides/"+jsonpointer.Escape(service.Name()), service)
+		}
+		for _, service := range s.Requires() {
+			add("/requires/"+jsonpointer.Escape(service.Name()), service)
+		}
+		for _, topic := range s.Publishes() {
+			add("/publishes/"+jsonpointer.Escape(topic.Name()), topic)
+		}
+		for _, topic := range s.Subscribes() {
+			add("/subscribes/"+jsonpointer.Escape(topic.Name()), topic)
+		}
+		add("/config", s.Config())
+	}
+	return children
+}
+
+func addArgs(add func(string, core.Schema), prefix string, args core.ArgSchemas) {
+	if args == nil {
+		return
+	}
+	for _, arg := range args.Args() {
+		add(prefix+jsonpointer.Escape(arg.Name()), arg.Schema())
+	}
+}
+
+func sortedNames(schemas map[string]core.Schema) []string {
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,774 @@
+package structure
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/jsonpointer"
+)
+
+// Compatibility classifies a change between two versions of a schema.
+//
+// For data schemas, a backward compatible change lets the new schema read
+// values written with the old one, and a forward compatible change lets the
+// old schema read values written with the new one. For functions and
+// services the view is the caller's: a backward compatible change keeps
+// existing callers working against the new version, and a forward compatible
+// change lets new callers work against the old version.
+type Compatibility string
+
+const (
+	// FullyCompatible changes are both backward and forward compatible.
+	FullyCompatible Compatibility = "full"
+	// BackwardCompatible changes loosen what is accepted.
+	BackwardCompatible Compatibility = "backward"
+	// ForwardCompatible changes tighten what is accepted.
+	ForwardCompatible Compatibility = "forward"
+	// Breaking changes are neither backward nor forward compatible.
+	Breaking Compatibility = "breaking"
+)
+
+// Combine returns the compatibility of two changes applied together.
+func (c Compatibility) Combine(other Compatibility) Compatibility {
+	switch {
+	case c == FullyCompatible || c == "":
+		return other
+	case other == FullyCompatible || other == "" || other == c:
+		return c
+	default:
+		return Breaking
+	}
+}
+
+// Satisfies reports whether changes of this compatibility are allowed under
+// the required one. An empty requirement allows everything.
+func (c Compatibility) Satisfies(required Compatibility) bool {
+	switch required {
+	case "", Breaking:
+		return true
+	case FullyCompatible:
+		return c == FullyCompatible
+	default:
+		return c == FullyCompatible || c == required
+	}
+}
+
+// ChangeKind is what happened to the schema element at a change's path.
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change is a single difference between two schemas.
+type Change struct {
+	// Path is a JSON Pointer into the schema, such as /properties/email or
+	// /methods/get/inputs/id.
+	Path          string
+	Kind          ChangeKind
+	Message       string
+	Compatibility Compatibility
+}
+
+func (c Change) String() string {
+	path := c.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s: %s (%s)", path, c.Message, c.Compatibility)
+}
+
+// Changes is the result of Diff.
+type Changes []Change
+
+// Compatibility returns the combined compatibility of all changes.
+// No changes at all are fully compatible.
+func (c Changes) Compatibility() Compatibility {
+	result := FullyCompatible
+	for _, change := range c {
+		result = result.Combine(change.Compatibility)
+	}
+	return result
+}
+
+// Violations returns the changes that are not allowed under the required
+// compatibility.
+func (c Changes) Violations(required Compatibility) Changes {
+	var violations Changes
+	for _, change := range c {
+		if !change.Compatibility.Satisfies(required) {
+			violations = append(violations, change)
+		}
+	}
+	return violations
+}
+
+// Diff compares an old and a new version of a schema and returns the changes
+// that affect which values are accepted, in path order. Metadata such as
+// descriptions and examples is not compared, and references are compared by
+// name without being resolved.
+func Diff(old, new core.Schema) Changes {
+	d := &differ{}
+	d.schema("", old, new)
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		return d.changes[i].Path < d.changes[j].Path
+	})
+	return d.changes
+}
+
+// differ collects changes while walking two schema trees side by side.
+type differ struct {
+	changes Changes
+
+	// inverted is set below function outputs and errors, where a tighter
+	// schema is safe for existing callers.
+	inverted bool
+}
+
+func (d *differ) add(path string, kind ChangeKind, compatibility Compatibility, format string, args ...any) {
+	if d.inverted {
+		switch compatibility {
+		case BackwardCompatible:
+			compatibility = ForwardCompatible
+		case ForwardCompatible:
+			compatibility = BackwardCompatible
+		}
+	}
+	d.changes = append(d.changes, Change{
+		Path:          path,
+		Kind:          kind,
+		Message:       fmt.Sprintf(format, args...),
+		Compatibility: compatibility,
+	})
+}
+
+// loosened and tightened are shorthands for the common constraint changes.
+func (d *differ) loosened(path, format string, args ...any) {
+	d.add(path, ChangeChanged, BackwardCompatible, format, args...)
+}
+
+func (d *differ) tightened(path, format string, args ...any) {
+	d.add(path, ChangeChanged, ForwardCompatible, format, args...)
+}
+
+// schema compares two schemas at the same path. A nil schema accepts anything.
+func (d *differ) schema(path string, old, new core.Schema) {
+	if old == nil && new == nil {
+		return
+	}
+	if old == nil {
+		d.add(path, ChangeAdded, ForwardCompatible, "schema added")
+		return
+	}
+	if new == nil {
+		d.add(path, ChangeRemoved, BackwardCompatible, "schema removed")
+		return
+	}
+	if Equal(old, new, IgnoreMetadata()) {
+		return
+	}
+	if old.Type() != new.Type() {
+		d.typeChange(path, old, new)
+		return
+	}
+
+	switch old.Type() {
+	case core.TypeString:
+		d.stringSchema(path, old, new)
+	case core.TypeNumber:
+		o, ok1 := old.(core.NumberSchema)
+		n, ok2 := new.(core.NumberSchema)
+		if ok1 && ok2 {
+			lowerBound(d, path, "minimum", o.Minimum(), n.Minimum())
+			upperBound(d, path, "maximum", o.Maximum(), n.Maximum())
+		}
+	case core.TypeInteger:
+		o, ok1 := old.(core.IntegerSchema)
+		n, ok2 := new.(core.IntegerSchema)
+		if ok1 && ok2 {
+			lowerBound(d, path, "minimum", o.Minimum(), n.Minimum())
+			upperBound(d, path, "maximum", o.Maximum(), n.Maximum())
+		}
+	case core.TypeArray:
+		d.arraySchema(path, old, new)
+	case core.TypeStructure:
+		d.objectSchema(path, old, new)
+	case core.TypeMap:
+		o, ok1 := old.(core.MapSchema)
+		n, ok2 := new.(core.MapSchema)
+		if ok1 && ok2 {
+			d.schema(path+"/key", o.KeySchema(), n.KeySchema())
+			d.schema(path+"/value", o.ValueSchema(), n.ValueSchema())
+			lowerBound(d, path, "minItems", o.MinItems(), n.MinItems())
+			upperBound(d, path, "maxItems", o.MaxItems(), n.MaxItems())
+		}
+	case core.TypeOptional:
+		o, ok1 := old.(core.OptionalSchema)
+		n, ok2 := new.(core.OptionalSchema)
+		if ok1 && ok2 {
+			d.schema(path, o.ItemSchema(), n.ItemSchema())
+		}
+	case core.TypeUnion:
+		d.unionSchema(path, old, new)
+	case core.TypeResult:
+		o, ok1 := old.(core.ResultSchema)
+		n, ok2 := new.(core.ResultSchema)
+		if ok1 && ok2 {
+			d.schema(path+"/ok", o.SuccessSchema(), n.SuccessSchema())
+			d.schema(path+"/err", o.ErrorSchema(), n.ErrorSchema())
+		}
+	case core.TypeParameter:
+		o, ok1 := old.(core.TypeParameterSchema)
+		n, ok2 := new.(core.TypeParameterSchema)
+		if ok1 && ok2 {
+			if o.ParameterName() != n.ParameterName() {
+				d.add(path, ChangeChanged, Breaking, "type parameter renamed from %s to %s", o.ParameterName(), n.ParameterName())
+			}
+			d.schema(path+"/constraint", o.Constraint(), n.Constraint())
+		}
+	case core.TypeGeneric:
+		o, ok1 := old.(core.GenericSchema)
+		n, ok2 := new.(core.GenericSchema)
+		if ok1 && ok2 {
+			if len(o.TypeParameters()) != len(n.TypeParameters()) {
+				d.add(path, ChangeChanged, Breaking, "type parameters changed from %d to %d", len(o.TypeParameters()), len(n.TypeParameters()))
+				return
+			}
+			for i, param := range o.TypeParameters() {
+				d.schema(path+"/parameters/"+strconv.Itoa(i), param, n.TypeParameters()[i])
+			}
+			d.schema(path+"/template", o.Template(), n.Template())
+		}
+	case core.TypeRef:
+		d.add(path, ChangeChanged, Breaking, "reference changed from %s to %s", refName(old), refName(new))
+	case core.TypeFunction:
+		d.functionSchema(path, old, new)
+	case core.TypeService:
+		d.serviceSchema(path, old, new)
+	case core.TypeTopic:
+		o, ok1 := old.(core.TopicSchema)
+		n, ok2 := new.(core.TopicSchema)
+		if ok1 && ok2 {
+			d.schema(path+"/payload", o.Payload(), n.Payload())
+			d.schema(path+"/key", o.Key(), n.Key())
+			d.schema(path+"/headers", o.Headers(), n.Headers())
+		}
+	case core.TypeComponent:
+		d.componentSchema(path, old, new)
+	}
+}
+
+// typeChange classifies a change of schema type. Widening to any, to an
+// optional of the same schema or from integer to number loosens the schema;
+// the reverse tightens it. Anything else breaks.
+func (d *differ) typeChange(path string, old, new core.Schema) {
+	switch {
+	case new.Type() == core.TypeAny:
+		d.loosened(path, "type changed from %s to any", old.Type())
+	case old.Type() == core.TypeAny:
+		d.tightened(path, "type changed from any to %s", new.Type())
+	case old.Type() == core.TypeInteger && new.Type() == core.TypeNumber:
+		d.loosened(path, "type changed from integer to number")
+	case old.Type() == core.TypeNumber && new.Type() == core.TypeInteger:
+		d.tightened(path, "type changed from number to integer")
+	case new.Type() == core.TypeOptional && optionalOf(new, old.Type()) != nil:
+		d.loosened(path, "schema made optional")
+		d.schema(path, old, optionalOf(new, old.Type()))
+	case old.Type() == core.TypeOptional && optionalOf(old, new.Type()) != nil:
+		d.tightened(path, "schema no longer optional")
+		d.schema(path, optionalOf(old, new.Type()), new)
+	default:
+		d.add(path, ChangeChanged, Breaking, "type changed from %s to %s", old.Type(), new.Type())
+	}
+}
+
+// optionalOf returns the item schema of an optional schema if it has the given type.
+func optionalOf(schema core.Schema, itemType core.SchemaType) core.Schema {
+	if s, ok := schema.(core.OptionalSchema); ok && s.ItemSchema() != nil && s.ItemSchema().Type() == itemType {
+		return s.ItemSchema()
+	}
+	return nil
+}
+
+func (d *differ) stringSchema(path string, old, new core.Schema) {
+	o, ok1 := old.(core.StringSchema)
+	n, ok2 := new.(core.StringSchema)
+	if !ok1 || !ok2 {
+		return
+	}
+
+	lowerBound(d, path, "minLength", o.MinLength(), n.MinLength())
+	upperBound(d, path, "maxLength", o.MaxLength(), n.MaxLength())
+	d.keyword(path, "pattern", o.Pattern(), n.Pattern())
+	d.keyword(path, "format", o.Format(), n.Format())
+
+	oldEnum, newEnum := o.EnumValues(), n.EnumValues()
+	switch {
+	case len(oldEnum) == 0 && len(newEnum) == 0:
+	case len(oldEnum) == 0:
+		d.add(path+"/enum", ChangeAdded, ForwardCompatible, "enum added")
+	case len(newEnum) == 0:
+		d.add(path+"/enum", ChangeRemoved, BackwardCompatible, "enum removed")
+	default:
+		added, removed := setDifference(oldEnum, newEnum)
+		if len(added) > 0 {
+			d.add(path+"/enum", ChangeAdded, BackwardCompatible, "enum values %s added", strings.Join(added, ", "))
+		}
+		if len(removed) > 0 {
+			d.add(path+"/enum", ChangeRemoved, ForwardCompatible, "enum values %s removed", strings.Join(removed, ", "))
+		}
+	}
+}
+
+// keyword compares a string-valued constraint where empty means unconstrained.
+func (d *differ) keyword(path, name, old, new string) {
+	switch {
+	case old == new:
+	case old == "":
+		d.add(path+"/"+name, ChangeAdded, ForwardCompatible, "%s %q added", name, new)
+	case new == "":
+		d.add(path+"/"+name, ChangeRemoved, BackwardCompatible, "%s %q removed", name, old)
+	default:
+		d.add(path+"/"+name, ChangeChanged, Breaking, "%s changed from %q to %q", name, old, new)
+	}
+}
+
+// lowerBound compares minimum-like constraints: raising one tightens the schema.
+func lowerBound[T int | int64 | float64](d *differ, path, name string, old, new *T) {
+	switch {
+	case old == nil && new == nil:
+	case old == nil:
+		d.add(path+"/"+name, ChangeAdded, ForwardCompatible, "%s %v added", name, *new)
+	case new == nil:
+		d.add(path+"/"+name, ChangeRemoved, BackwardCompatible, "%s %v removed", name, *old)
+	case *new > *old:
+		d.tightened(path+"/"+name, "%s raised from %v to %v", name, *old, *new)
+	case *new < *old:
+		d.loosened(path+"/"+name, "%s lowered from %v to %v", name, *old, *new)
+	}
+}
+
+// upperBound compares maximum-like constraints: lowering one tightens the schema.
+func upperBound[T int | int64 | float64](d *differ, path, name string, old, new *T) {
+	switch {
+	case old == nil && new == nil:
+	case old == nil:
+		d.add(path+"/"+name, ChangeAdded, ForwardCompatible, "%s %v added", name, *new)
+	case new == nil:
+		d.add(path+"/"+name, ChangeRemoved, BackwardCompatible, "%s %v removed", name, *old)
+	case *new < *old:
+		d.tightened(path+"/"+name, "%s lowered from %v to %v", name, *old, *new)
+	case *new > *old:
+		d.loosened(path+"/"+name, "%s raised from %v to %v", name, *old, *new)
+	}
+}
+
+func (d *differ) arraySchema(path string, old, new core.Schema) {
+	o, ok1 := old.(core.ArraySchema)
+	n, ok2 := new.(core.ArraySchema)
+	if !ok1 || !ok2 {
+		return
+	}
+
+	d.schema(path+"/items", o.ItemSchema(), n.ItemSchema())
+	lowerBound(d, path, "minItems", o.MinItems(), n.MinItems())
+	upperBound(d, path, "maxItems", o.MaxItems(), n.MaxItems())
+	if o.UniqueItemsRequired() != n.UniqueItemsRequired() {
+		if n.UniqueItemsRequired() {
+			d.tightened(path+"/uniqueItems", "unique items required")
+		} else {
+			d.loosened(path+"/uniqueItems", "unique items no longer required")
+		}
+	}
+
+	// A contains schema is a requirement, so adding one tightens the array
+	switch oc, nc := o.ContainsSchema(), n.ContainsSchema(); {
+	case oc == nil && nc != nil:
+		d.add(path+"/contains", ChangeAdded, ForwardCompatible, "contains added")
+	case oc != nil && nc == nil:
+		d.add(path+"/contains", ChangeRemoved, BackwardCompatible, "contains removed")
+	default:
+		d.schema(path+"/contains", oc, nc)
+	}
+
+	oldPrefix, newPrefix := o.PrefixItemSchemas(), n.PrefixItemSchemas()
+	if len(oldPrefix) != len(newPrefix) {
+		d.add(path+"/prefixItems", ChangeChanged, Breaking, "tuple length changed from %d to %d", len(oldPrefix), len(newPrefix))
+	} else {
+		for i := range oldPrefix {
+			d.schema(path+"/prefixItems/"+strconv.Itoa(i), oldPrefix[i], newPrefix[i])
+		}
+	}
+	d.schema(path+"/rest", o.RestItemSchema(), n.RestItemSchema())
+}
+
+// fields describes named members that can be required, such as object
+// properties or function arguments.
+type fields struct {
+	schemas  map[string]core.Schema
+	required map[string]bool
+	open     bool
+}
+
+func (d *differ) objectSchema(path string, old, new core.Schema) {
+	o, ok1 := old.(core.ObjectSchema)
+	n, ok2 := new.(core.ObjectSchema)
+	if !ok1 || !ok2 {
+		return
+	}
+
+	d.fields(path, "/properties/", "property",
+		fields{o.Properties(), stringSet(o.Required()), o.AdditionalProperties()},
+		fields{n.Properties(), stringSet(n.Required()), n.AdditionalProperties()})
+
+	type extended interface {
+		MinProperties() *int
+		MaxProperties() *int
+		PatternProperties() map[string]core.Schema
+		PropertyDependencies() map[string][]string
+	}
+	oe, ok1 := old.(extended)
+	ne, ok2 := new.(extended)
+	if !ok1 || !ok2 {
+		return
+	}
+
+	lowerBound(d, path, "minProperties", oe.MinProperties(), ne.MinProperties())
+	upperBound(d, path, "maxProperties", oe.MaxProperties(), ne.MaxProperties())
+
+	oldPatterns, newPatterns := oe.PatternProperties(), ne.PatternProperties()
+	for _, pattern := range unionKeys(oldPatterns, newPatterns) {
+		patternPath := path + "/patternProperties/" + jsonpointer.Escape(pattern)
+		oldSchema, inOld := oldPatterns[pattern]
+		newSchema, inNew := newPatterns[pattern]
+		switch {
+		case !inOld:
+			d.add(patternPath, ChangeAdded, ForwardCompatible, "pattern property %s added", pattern)
+		case !inNew:
+			d.add(patternPath, ChangeRemoved, BackwardCompatible, "pattern property %s removed", pattern)
+		default:
+			d.schema(patternPath, oldSchema, newSchema)
+		}
+	}
+
+	oldDeps, newDeps := oe.PropertyDependencies(), ne.PropertyDependencies()
+	for _, name := range unionKeys(oldDeps, newDeps) {
+		added, removed := setDifference(oldDeps[name], newDeps[name])
+		depPath := path + "/dependencies/" + jsonpointer.Escape(name)
+		if len(added) > 0 {
+			d.add(depPath, ChangeAdded, ForwardCompatible, "%s now requires %s", name, strings.Join(added, ", "))
+		}
+		if len(removed) > 0 {
+			d.add(depPath, ChangeRemoved, BackwardCompatible, "%s no longer requires %s", name, strings.Join(removed, ", "))
+		}
+	}
+}
+
+// fields compares named members. Declared members are taken to be the
+// contract: adding an optional member is compatible both ways when the old
+// version allowed undeclared members, even though those could have held
+// values of any shape.
+func (d *differ) fields(path, prefix, noun string, old, new fields) {
+	for _, name := range unionKeys(old.schemas, new.schemas) {
+		fieldPath := path + prefix + jsonpointer.Escape(name)
+		oldSchema, inOld := old.schemas[name]
+		newSchema, inNew := new.schemas[name]
+
+		switch {
+		case !inOld:
+			// Old values lack the member; new values only fit the old schema if it was open
+			compatibility := Breaking
+			switch {
+			case !new.required[name] && old.open:
+				compatibility = FullyCompatible
+			case !new.required[name]:
+				compatibility = BackwardCompatible
+			case old.open:
+				compatibility = ForwardCompatible
+			}
+			d.add(fieldPath, ChangeAdded, compatibility, "%s %s %s added", requiredLabel(new.required[name]), noun, name)
+		case !inNew:
+			// New values lack the member; old values only fit the new schema if it is open
+			compatibility := Breaking
+			switch {
+			case !old.required[name] && new.open:
+				compatibility = FullyCompatible
+			case !old.required[name]:
+				compatibility = ForwardCompatible
+			case new.open:
+				compatibility = BackwardCompatible
+			}
+			d.add(fieldPath, ChangeRemoved, compatibility, "%s %s %s removed", requiredLabel(old.required[name]), noun, name)
+		default:
+			if old.required[name] != new.required[name] {
+				if new.required[name] {
+					d.tightened(fieldPath, "%s %s is now required", noun, name)
+				} else {
+					d.loosened(fieldPath, "%s %s is no longer required", noun, name)
+				}
+			}
+			d.schema(fieldPath, oldSchema, newSchema)
+		}
+	}
+
+	if old.open != new.open {
+		if new.open {
+			d.loosened(path, "additional %s allowed", plural(noun))
+		} else {
+			d.tightened(path, "additional %s no longer allowed", plural(noun))
+		}
+	}
+}
+
+func requiredLabel(required bool) string {
+	if required {
+		return "required"
+	}
+	return "optional"
+}
+
+func plural(noun string) string {
+	if strings.HasSuffix(noun, "y") {
+		return strings.TrimSuffix(noun, "y") + "ies"
+	}
+	return noun + "s"
+}
+
+// unionSchema matches members by reference name or structure. Adding a
+// member loosens the union and removing one tightens it.
+func (d *differ) unionSchema(path string, old, new core.Schema) {
+	o, ok1 := old.(core.UnionSchema)
+	n, ok2 := new.(core.UnionSchema)
+	if !ok1 || !ok2 {
+		return
+	}
+
+	if o.Discriminator() != n.Discriminator() {
+		d.add(path+"/discriminator", ChangeChanged, Breaking, "discriminator changed from %q to %q", o.Discriminator(), n.Discriminator())
+	}
+	if o.Mode() != n.Mode() {
+		if n.Mode() == core.UnionAnyOf {
+			d.loosened(path+"/mode", "union mode changed from %s to %s", o.Mode(), n.Mode())
+		} else {
+			d.tightened(path+"/mode", "union mode changed from %s to %s", o.Mode(), n.Mode())
+		}
+	}
+
+	matched := make(map[int]bool)
+	for i, member := range o.Schemas() {
+		memberPath := path + "/members/" + strconv.Itoa(i)
+		j := matchMember(member, n.Schemas(), matched)
+		if j < 0 {
+			d.add(memberPath, ChangeRemoved, ForwardCompatible, "union member %s removed", memberLabel(member))
+			continue
+		}
+		matched[j] = true
+		d.schema(memberPath, member, n.Schemas()[j])
+	}
+	for j, member := range n.Schemas() {
+		if !matched[j] {
+			d.add(path+"/members/"+strconv.Itoa(j), ChangeAdded, BackwardCompatible, "union member %s added", memberLabel(member))
+		}
+	}
+}
+
+// matchMember finds the union member in candidates that corresponds to
+// member: an equal schema, or failing that one with the same name.
+func matchMember(member core.Schema, candidates []core.Schema, matched map[int]bool) int {
+	for j, candidate := range candidates {
+		if !matched[j] && Equal(member, candidate, IgnoreMetadata()) {
+			return j
+		}
+	}
+	name := memberName(member)
+	if name == "" {
+		return -1
+	}
+	for j, candidate := range candidates {
+		if !matched[j] && memberName(candidate) == name {
+			return j
+		}
+	}
+	return -1
+}
+
+func memberName(schema core.Schema) string {
+	if ref, ok := schema.(core.RefSchema); ok {
+		return ref.ReferenceName()
+	}
+	return schema.Metadata().Name
+}
+
+func memberLabel(schema core.Schema) string {
+	if name := memberName(schema); name != "" {
+		return name
+	}
+	return string(schema.Type())
+}
+
+func refName(schema core.Schema) string {
+	if ref, ok := schema.(core.RefSchema); ok {
+		return ref.ReferenceName()
+	}
+	return ""
+}
+
+// functionSchema compares inputs like object properties. Outputs and errors
+// are compared with inverted compatibility, since callers accept whatever
+// the old outputs allowed.
+func (d *differ) functionSchema(path string, old, new core.Schema) {
+	if method, ok := old.(core.ServiceMethodSchema); ok {
+		old = method.Function()
+	}
+	if method, ok := new.(core.ServiceMethodSchema); ok {
+		new = method.Function()
+	}
+	o, ok1 := old.(core.FunctionSchema)
+	n, ok2 := new.(core.FunctionSchema)
+	if !ok1 || !ok2 {
+		return
+	}
+
+	d.fields(path, "/inputs/", "argument", argFields(o.Inputs()), argFields(n.Inputs()))
+
+	d.inverted = !d.inverted
+	d.fields(path, "/outputs/", "output", argFields(o.Outputs()), argFields(n.Outputs()))
+	d.schema(path+"/errors", o.Errors(), n.Errors())
+	d.inverted = !d.inverted
+}
+
+func argFields(args core.ArgSchemas) fields {
+	f := fields{schemas: map[string]core.Schema{}, required: map[string]bool{}}
+	if args == nil {
+		return f
+	}
+	for _, arg := range args.Args() {
+		f.schemas[arg.Name()] = arg.Schema()
+		f.required[arg.Name()] = !arg.Optional()
+	}
+	f.open = args.AllowAdditional()
+	return f
+}
+
+// serviceSchema compares methods by name. Adding a method keeps existing
+// callers working; removing one only keeps the old version serving new callers.
+func (d *differ) serviceSchema(path string, old, new core.Schema) {
+	o, ok1 := old.(core.ServiceSchema)
+	n, ok2 := new.(core.ServiceSchema)
+	if !ok1 || !ok2 {
+		return
+	}
+
+	oldMethods := make(map[string]core.Schema, len(o.Methods()))
+	for _, method := range o.Methods() {
+		oldMethods[method.Name()] = method.Function()
+	}
+	newMethods := make(map[string]core.Schema, len(n.Methods()))
+	for _, method := range n.Methods() {
+		newMethods[method.Name()] = method.Function()
+	}
+
+	for _, name := range unionKeys(oldMethods, newMethods) {
+		methodPath := path + "/methods/" + jsonpointer.Escape(name)
+		oldMethod, inOld := oldMethods[name]
+		newMethod, inNew := newMethods[name]
+		switch {
+		case !inOld:
+			d.add(methodPath, ChangeAdded, BackwardCompatible, "method %s added", name)
+		case !inNew:
+			d.add(methodPath, ChangeRemoved, ForwardCompatible, "method %s removed", name)
+		default:
+			d.schema(methodPath, oldMethod, newMethod)
+		}
+	}
+}
+
+// componentSchema compares the services and topics of two components by name.
+// Providing and publishing more is backward compatible; requiring and
+// subscribing to more is forward compatible. Required services and published
+// topics are compared with inverted compatibility, like function outputs.
+func (d *differ) componentSchema(path string, old, new core.Schema) {
+	o, ok1 := old.(core.ComponentSchema)
+	n, ok2 := new.(core.ComponentSchema)
+	if !ok1 || !ok2 {
+		return
+	}
+
+	d.members(path+"/provides", "provided service", byName(o.Provides()), byName(n.Provides()), false, false)
+	d.members(path+"/requires", "required service", byName(o.Requires()), byName(n.Requires()), true, true)
+	d.members(path+"/publishes", "published topic", byName(o.Publishes()), byName(n.Publishes()), false, true)
+	d.members(path+"/subscribes", "subscribed topic", byName(o.Subscribes()), byName(n.Subscribes()), true, false)
+	d.schema(path+"/config", o.Config(), n.Config())
+}
+
+// members compares named component members. Adding a needed member is
+// forward compatible, adding an offered one backward compatible.
+func (d *differ) members(path, noun string, old, new map[string]core.Schema, needed, inverted bool) {
+	added, removed := BackwardCompatible, ForwardCompatible
+	if needed {
+		added, removed = removed, added
+	}
+
+	for _, name := range unionKeys(old, new) {
+		memberPath := path + "/" + jsonpointer.Escape(name)
+		oldMember, inOld := old[name]
+		newMember, inNew := new[name]
+		switch {
+		case !inOld:
+			d.add(memberPath, ChangeAdded, added, "%s %s added", noun, name)
+		case !inNew:
+			d.add(memberPath, ChangeRemoved, removed, "%s %s removed", noun, name)
+		default:
+			if inverted {
+				d.inverted = !d.inverted
+			}
+			d.schema(memberPath, oldMember, newMember)
+			if inverted {
+				d.inverted = !d.inverted
+			}
+		}
+	}
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// setDifference returns the values only in new and the values only in old.
+func setDifference(old, new []string) (added, removed []string) {
+	oldSet, newSet := stringSet(old), stringSet(new)
+	for _, v := range new {
+		if !oldSet[v] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range old {
+		if !newSet[v] {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,322 @@
+// Package structure compares schemas by structure. Equal reports whether two
+// schemas describe the same thing, and Fingerprint gives a stable hash that
+// can key caches of anything derived from a schema. Diff lists the changes
+// between two versions of a schema and classifies their compatibility.
+package structure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"defs.dev/schema/core"
+)
+
+// CompareOption adjusts what Equal and Fingerprint take into account.
+type CompareOption func(*compareConfig)
+
+type compareConfig struct {
+	ignoreMetadata bool
+}
+
+// IgnoreMetadata compares schemas by the values they describe only. Names,
+// descriptions, examples, tags and argument descriptions are left out;
+// property, argument and type parameter names are kept.
+func IgnoreMetadata() CompareOption {
+	return func(c *compareConfig) {
+		c.ignoreMetadata = true
+	}
+}
+
+// Equal reports whether two schemas are structurally equal: they have the
+// same type, constraints, annotations and children. References are equal when
+// they name the same schema; they are not resolved.
+func Equal(a, b core.Schema, options ...CompareOption) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return string(Canonical(a, options...)) == string(Canonical(b, options...))
+}
+
+// Fingerprint returns a stable hash of a schema's structure. Schemas are Equal
+// exactly when their fingerprints match, given the same options.
+func Fingerprint(schema core.Schema, options ...CompareOption) string {
+	sum := sha256.Sum256(Canonical(schema, options...))
+	return hex.EncodeToString(sum[:])
+}
+
+// Canonical returns the canonical encoding Equal and Fingerprint are based on:
+// compact JSON with sorted keys, in which set-like lists such as required
+// properties are sorted and ordered lists keep their order.
+func Canonical(schema core.Schema, options ...CompareOption) []byte {
+	config := &compareConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	data, err := json.Marshal(config.canonical(schema))
+	if err != nil {
+		// Every leaf value is encoded in advance, so this cannot happen
+		panic(fmt.Sprintf("structure: canonical encoding failed: %v", err))
+	}
+	return data
+}
+
+// canonical converts a schema to a tree of maps, lists and encoded values.
+func (c *compareConfig) canonical(schema core.Schema) any {
+	if schema == nil {
+		return nil
+	}
+
+	node := map[string]any{"type": schema.Type()}
+	if !c.ignoreMetadata {
+		metadata := schema.Metadata()
+		metadata.Composition = nil
+		node["metadata"] = value(metadata)
+	}
+	if annotations := schema.Annotations(); len(annotations) > 0 {
+		list := make([]any, len(annotations))
+		for i, annotation := range annotations {
+			list[i] = map[string]any{"name": annotation.Name(), "value": value(annotation.Value())}
+		}
+		node["annotations"] = list
+	}
+
+	if d, ok := schema.(interface{ DefaultValue() *string }); ok && d.DefaultValue() != nil {
+		node["default"] = value(*d.DefaultValue())
+	}
+	if d, ok := schema.(interface{ DefaultValue() *float64 }); ok && d.DefaultValue() != nil {
+		node["default"] = value(*d.DefaultValue())
+	}
+	if d, ok := schema.(interface{ DefaultValue() *int64 }); ok && d.DefaultValue() != nil {
+		node["default"] = value(*d.DefaultValue())
+	}
+	if d, ok := schema.(interface{ DefaultValue() *bool }); ok && d.DefaultValue() != nil {
+		node["default"] = value(*d.DefaultValue())
+	}
+	if d, ok := schema.(interface{ DefaultValue() []any }); ok && d.DefaultValue() != nil {
+		node["default"] = value(d.DefaultValue())
+	}
+	if d, ok := schema.(interface{ DefaultValue() map[string]any }); ok && d.DefaultValue() != nil {
+		node["default"] = value(d.DefaultValue())
+	}
+
+	switch schema.Type() {
+	case core.TypeRef:
+		// References are compared by name, which also keeps recursive schemas finite
+		if s, ok := schema.(core.RefSchema); ok {
+			node["ref"] = s.ReferenceName()
+		}
+		if s, ok := schema.(core.InstanceSchema); ok {
+			node["generic"] = s.GenericName()
+			node["arguments"] = c.list(s.TypeArguments())
+		}
+	case core.TypeString:
+		if s, ok := schema.(core.StringSchema); ok {
+			node["minLength"] = s.MinLength()
+			node["maxLength"] = s.MaxLength()
+			node["pattern"] = s.Pattern()
+			node["format"] = s.Format()
+			node["enum"] = s.EnumValues()
+		}
+	case core.TypeNumber:
+		if s, ok := schema.(core.NumberSchema); ok {
+			node["minimum"] = s.Minimum()
+			node["maximum"] = s.Maximum()
+		}
+	case core.TypeInteger:
+		if s, ok := schema.(core.IntegerSchema); ok {
+			node["minimum"] = s.Minimum()
+			node["maximum"] = s.Maximum()
+		}
+	case core.TypeBoolean:
+		if b, ok := schema.(interface{ CaseInsensitive() bool }); ok {
+			node["caseInsensitive"] = b.CaseInsensitive()
+		}
+	case core.TypeArray:
+		if s, ok := schema.(core.ArraySchema); ok {
+			node["items"] = c.canonical(s.ItemSchema())
+			node["minItems"] = s.MinItems()
+			node["maxItems"] = s.MaxItems()
+			node["uniqueItems"] = s.UniqueItemsRequired()
+			node["contains"] = c.canonical(s.ContainsSchema())
+			node["prefixItems"] = c.list(s.PrefixItemSchemas())
+			node["rest"] = c.canonical(s.RestItemSchema())
+		}
+	case core.TypeStructure:
+		if s, ok := schema.(core.ObjectSchema); ok {
+			node["properties"] = c.schemaMap(s.Properties())
+			node["required"] = sortedStrings(s.Required())
+			node["additionalProperties"] = s.AdditionalProperties()
+			if o, ok := schema.(interface {
+				MinProperties() *int
+				MaxProperties() *int
+				PatternProperties() map[string]core.Schema
+				PropertyDependencies() map[string][]string
+			}); ok {
+				node["minProperties"] = o.MinProperties()
+				node["maxProperties"] = o.MaxProperties()
+				node["patternProperties"] = c.schemaMap(o.PatternProperties())
+				dependencies := make(map[string]any, len(o.PropertyDependencies()))
+				for name, required := range o.PropertyDependencies() {
+					dependencies[name] = sortedStrings(required)
+				}
+				node["dependencies"] = dependencies
+			}
+		}
+	case core.TypeMap:
+		if s, ok := schema.(core.MapSchema); ok {
+			node["key"] = c.canonical(s.KeySchema())
+			node["value"] = c.canonical(s.ValueSchema())
+			node["minItems"] = s.MinItems()
+			node["maxItems"] = s.MaxItems()
+		}
+	case core.TypeOptional:
+		if s, ok := schema.(core.OptionalSchema); ok {
+			node["items"] = c.canonical(s.ItemSchema())
+		}
+	case core.TypeUnion:
+		if s, ok := schema.(core.UnionSchema); ok {
+			node["members"] = c.list(s.Schemas())
+			node["discriminator"] = s.Discriminator()
+			node["mode"] = s.Mode()
+		}
+	case core.TypeResult:
+		if s, ok := schema.(core.ResultSchema); ok {
+			node["ok"] = c.canonical(s.SuccessSchema())
+			node["err"] = c.canonical(s.ErrorSchema())
+		}
+	case core.TypeParameter:
+		if s, ok := schema.(core.TypeParameterSchema); ok {
+			node["parameter"] = s.ParameterName()
+			node["constraint"] = c.canonical(s.Constraint())
+		}
+	case core.TypeGeneric:
+		if s, ok := schema.(core.GenericSchema); ok {
+			params := make([]any, len(s.TypeParameters()))
+			for i, param := range s.TypeParameters() {
+				params[i] = c.canonical(param)
+			}
+			node["parameters"] = params
+			node["template"] = c.canonical(s.Template())
+		}
+	case core.TypeService:
+		if s, ok := schema.(core.ServiceSchema); ok {
+			node["service"] = s.Name()
+			methods := make([]any, len(s.Methods()))
+			for i, method := range s.Methods() {
+				methods[i] = map[string]any{"name": method.Name(), "function": c.canonical(method.Function())}
+			}
+			node["methods"] = methods
+		}
+	case core.TypeTopic:
+		if s, ok := schema.(core.TopicSchema); ok {
+			node["payload"] = c.canonical(s.Payload())
+			node["key"] = c.canonical(s.Key())
+			node["headers"] = c.canonical(s.Headers())
+		}
+	case core.TypeComponent:
+		if s, ok := schema.(core.ComponentSchema); ok {
+			node["provides"] = c.schemaMap(byName(s.Provides()))
+			node["requires"] = c.schemaMap(byName(s.Requires()))
+			node["publishes"] = c.schemaMap(byName(s.Publishes()))
+			node["subscribes"] = c.schemaMap(byName(s.Subscribes()))
+			node["config"] = c.canonical(s.Config())
+		}
+	case core.TypeFunction:
+		// Service methods report the function type and wrap the actual function
+		if method, ok := schema.(core.ServiceMethodSchema); ok {
+			schema = method.Function()
+		}
+		if s, ok := schema.(core.FunctionSchema); ok {
+			node["inputs"] = c.args(s.Inputs())
+			node["outputs"] = c.args(s.Outputs())
+			node["errors"] = c.canonical(s.Errors())
+			if f, ok := schema.(interface {
+				AdditionalInputs() bool
+				AdditionalOutputs() bool
+			}); ok {
+				node["additionalInputs"] = f.AdditionalInputs()
+				node["additionalOutputs"] = f.AdditionalOutputs()
+			}
+		}
+	}
+	return node
+}
+
+func (c *compareConfig) list(schemas []core.Schema) []any {
+	list := make([]any, len(schemas))
+	for i, schema := range schemas {
+		list[i] = c.canonical(schema)
+	}
+	return list
+}
+
+// byName keys the services or topics of a component by name, since their
+// order does not matter.
+func byName[T interface {
+	core.Schema
+	Name() string
+}](schemas []T) map[string]core.Schema {
+	result := make(map[string]core.Schema, len(schemas))
+	for _, schema := range schemas {
+		result[schema.Name()] = schema
+	}
+	return result
+}
+
+func (c *compareConfig) schemaMap(schemas map[string]core.Schema) map[string]any {
+	result := make(map[string]any, len(schemas))
+	for name, schema := range schemas {
+		result[name] = c.canonical(schema)
+	}
+	return result
+}
+
+func (c *compareConfig) args(args core.ArgSchemas) any {
+	if args == nil {
+		return nil
+	}
+
+	list := make([]any, len(args.Args()))
+	for i, arg := range args.Args() {
+		node := map[string]any{
+			"name":        arg.Name(),
+			"schema":      c.canonical(arg.Schema()),
+			"optional":    arg.Optional(),
+			"constraints": arg.Constraints(),
+		}
+		if !c.ignoreMetadata {
+			node["description"] = arg.Description()
+		}
+		list[i] = node
+	}
+
+	node := map[string]any{
+		"args":            list,
+		"allowAdditional": args.AllowAdditional(),
+		"additional":      c.canonical(args.AdditionalSchema()),
+	}
+	if !c.ignoreMetadata {
+		node["name"] = args.CollectionName()
+		node["description"] = args.CollectionDescription()
+	}
+	return node
+}
+
+// value encodes an arbitrary value, such as an annotation value or a
+// default. Values JSON cannot encode are compared by their printed form.
+func value(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprintf("%#v", v))
+	}
+	return data
+}
+
+func sortedStrings(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -33,10 +33,14 @@ const (
 	TypeOptional  SchemaType = "optional"
 	TypeMap       SchemaType = "map"
 	TypeUnion     SchemaType = "union"
+	TypeResult    SchemaType = "result"
 	TypeRef       SchemaType = "ref"
 	TypeParameter SchemaType = "parameter"
+	TypeGeneric   SchemaType = "generic"
 	TypeFunction  SchemaType = "function"
 	TypeService   SchemaType = "service"
+	TypeTopic     SchemaType = "topic"
+	TypeComponent SchemaType = "component"
 
 	// Validation schema types for file system validation
 	TypeFileValidation      SchemaType = "file-validation"
@@ -52,6 +56,34 @@ type SchemaMetadata struct {
 	Examples    []any             `json:"examples,omitempty"`
 	Tags        []string          `json:"tags,omitempty"`
 	Properties  map[string]string `json:"properties,omitempty"`
+
+	// Composition records how the schema was derived from other schemas, if it was.
+	Composition *Composition `json:"-"`
+}
+
+// CompositionKind identifies the operator a schema was derived with.
+type CompositionKind string
+
+const (
+	CompositionExtend CompositionKind = "extend"
+	CompositionMerge  CompositionKind = "merge"
+	CompositionPick   CompositionKind = "pick"
+	CompositionOmit   CompositionKind = "omit"
+	CompositionAllOf  CompositionKind = "allOf"
+)
+
+// Composition is the provenance of a derived schema. The derived schema is
+// always complete on its own; exporters may use the provenance to reproduce
+// the derivation (allOf, extends, Pick<>) instead of the flattened result.
+type Composition struct {
+	Kind CompositionKind
+
+	// Sources are the schemas the result was derived from. For extend these
+	// are the base followed by the extension.
+	Sources []Schema
+
+	// Keys are the picked or omitted property names.
+	Keys []string
 }
 
 func (m SchemaMetadata) ToMap() map[string]any {
@@ -0,0 +1,488 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var _ Value[any] = &treeValue{}
+var _ ValueAccepter = &treeValue{}
+var _ PathAware = &treeValue{}
+
+// maxValueDepth ends value trees nested deeper than any real value, such as
+// maps that contain themselves
+const maxValueDepth = 256
+
+// treeValue is a node of a value tree built by NewValue or ValueOf. It holds
+// the Go value it was built from, the typed value built for it and its
+// position in the tree.
+//
+// NewValue and ValueOf deep-copy the Go value first, so changing it later
+// does not change the tree. Value returns the tree's copy, which must not be
+// modified; Copy returns a deep copy of it.
+type treeValue struct {
+	raw   any
+	typed any // StringValue, NumberValue, IntegerValue, BooleanValue, ArrayValue, MapValue, StructureValue or nil
+	path  []string
+}
+
+func (v *treeValue) Value() any {
+	return v.raw
+}
+
+func (v *treeValue) Copy() any {
+	return deepCopy(v.raw)
+}
+
+func (v *treeValue) String() string {
+	if s, ok := v.typed.(fmt.Stringer); ok {
+		return s.String()
+	}
+	if v.raw == nil {
+		return "null"
+	}
+	return fmt.Sprintf("%v", v.raw)
+}
+
+func (v *treeValue) IsNull() bool {
+	return v.raw == nil
+}
+
+func (v *treeValue) IsComposite() bool {
+	switch v.typed.(type) {
+	case ArrayValue[any], MapValue[any, any], StructureValue[any]:
+		return true
+	}
+	return false
+}
+
+func (v *treeValue) Path() []string {
+	return append([]string(nil), v.path...)
+}
+
+// AcceptValue dispatches to the visitor method of the typed value. Null
+// values and Go values of unknown types visit nothing.
+func (v *treeValue) AcceptValue(visitor ValueVisitor) error {
+	if accepter, ok := v.typed.(ValueAccepter); ok {
+		return accepter.AcceptValue(visitor)
+	}
+	return nil
+}
+
+// NewValue builds an immutable value tree from a Go value, such as one
+// decoded from JSON, guided by a schema. Every node of the tree implements
+// ValueAccepter, which dispatches to the visitor method of its type, and
+// PathAware.
+//
+// Strings, numbers, integers and booleans become StringValue, NumberValue,
+// IntegerValue and BooleanValue, arrays become ArrayValue, objects become
+// StructureValue and maps become MapValue. Unions take the first branch the
+// value fits; references, generics and type parameters are looked through.
+// An error is returned for values that do not fit the kind of their schema.
+func NewValue(schema Schema, raw any) (Value[any], error) {
+	return newValue(schema, deepCopy(raw), []string{}, 0)
+}
+
+// ValueOf builds an immutable value tree from a Go value by its Go types.
+// Maps with string keys become StructureValue.
+func ValueOf(raw any) Value[any] {
+	return valueOf(deepCopy(raw), []string{}, 0)
+}
+
+func newValue(schema Schema, raw any, path []string, depth int) (Value[any], error) {
+	if schema == nil || depth > maxValueDepth {
+		return valueOf(raw, path, depth), nil
+	}
+
+	switch schema.Type() {
+	case TypeAny, TypeService:
+		return valueOf(raw, path, depth), nil
+	case TypeNull:
+		if raw != nil {
+			return nil, mismatch(schema, raw, path)
+		}
+		return node(nil, nil, path), nil
+	case TypeOptional:
+		if raw == nil {
+			return node(nil, nil, path), nil
+		}
+		if s, ok := schema.(OptionalSchema); ok {
+			return newValue(s.ItemSchema(), raw, path, depth+1)
+		}
+	case TypeUnion:
+		if s, ok := schema.(UnionSchema); ok {
+			for _, branch := range s.Schemas() {
+				if value, err := newValue(branch, raw, path, depth+1); err == nil {
+					return value, nil
+				}
+			}
+			return nil, mismatch(schema, raw, path)
+		}
+	case TypeRef:
+		if s, ok := schema.(RefSchema); ok {
+			target, err := s.Resolve()
+			if err != nil {
+				return nil, err
+			}
+			return newValue(target, raw, path, depth+1)
+		}
+	case TypeGeneric:
+		if s, ok := schema.(GenericSchema); ok {
+			return newValue(s.Template(), raw, path, depth+1)
+		}
+	case TypeParameter:
+		if s, ok := schema.(TypeParameterSchema); ok {
+			return newValue(s.Constraint(), raw, path, depth+1)
+		}
+	}
+
+	if raw == nil {
+		return nil, mismatch(schema, raw, path)
+	}
+
+	switch schema.Type() {
+	case TypeString:
+		switch v := raw.(type) {
+		case string:
+			return node(raw, NewStringValue(v), path), nil
+		case time.Time:
+			return node(raw, NewStringValue(v.Format(time.RFC3339Nano)), path), nil
+		}
+		return nil, mismatch(schema, raw, path)
+	case TypeNumber:
+		if f, ok := toNumber(raw); ok {
+			return node(raw, NewNumberValue(f), path), nil
+		}
+		return nil, mismatch(schema, raw, path)
+	case TypeInteger:
+		if n, ok := toInteger(raw); ok {
+			return node(raw, NewIntegerValue(n), path), nil
+		}
+		return nil, mismatch(schema, raw, path)
+	case TypeBoolean:
+		if b, ok := raw.(bool); ok {
+			return node(raw, NewBooleanValue(b), path), nil
+		}
+		return nil, mismatch(schema, raw, path)
+	case TypeArray:
+		items, ok := toSlice(raw)
+		if !ok {
+			return nil, mismatch(schema, raw, path)
+		}
+		s, _ := schema.(ArraySchema)
+		return newArray(raw, items, path, depth, func(i int) Schema {
+			return itemSchema(s, i)
+		})
+	case TypeStructure:
+		fields, ok := toFields(raw)
+		if !ok {
+			return nil, mismatch(schema, raw, path)
+		}
+		var properties map[string]Schema
+		if s, ok := schema.(ObjectSchema); ok {
+			properties = s.Properties()
+		}
+		return newStructure(raw, fields, properties, path, depth)
+	case TypeMap:
+		entries, ok := toFields(raw)
+		if !ok {
+			return nil, mismatch(schema, raw, path)
+		}
+		var valueSchema Schema
+		if s, ok := schema.(MapSchema); ok {
+			valueSchema = s.ValueSchema()
+		}
+		values := make(map[string]Value[any], len(entries))
+		for key, entry := range entries {
+			value, err := newValue(valueSchema, entry, appendPath(path, key), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			values[key] = value
+		}
+		return node(raw, &mapValue{keys: sortedKeys(values), entries: values}, path), nil
+	case TypeResult:
+		fields, ok := toFields(raw)
+		if !ok {
+			return nil, mismatch(schema, raw, path)
+		}
+		var parts map[string]Schema
+		if s, ok := schema.(ResultSchema); ok {
+			parts = map[string]Schema{"ok": s.SuccessSchema(), "err": s.ErrorSchema()}
+		}
+		return newStructure(raw, fields, parts, path, depth)
+	case TypeFunction:
+		fields, ok := toFields(raw)
+		if !ok {
+			return nil, mismatch(schema, raw, path)
+		}
+		args := make(map[string]Schema)
+		if s, ok := schema.(FunctionSchema); ok && s.Inputs() != nil {
+			for _, arg := range s.Inputs().Args() {
+				args[arg.Name()] = arg.Schema()
+			}
+		}
+		return newStructure(raw, fields, args, path, depth)
+	case TypeTopic:
+		fields, ok := toFields(raw)
+		if !ok {
+			return nil, mismatch(schema, raw, path)
+		}
+		var parts map[string]Schema
+		if s, ok := schema.(TopicSchema); ok {
+			parts = map[string]Schema{"key": s.Key(), "payload": s.Payload(), "headers": s.Headers()}
+		}
+		return newStructure(raw, fields, parts, path, depth)
+	case TypeComponent:
+		if s, ok := schema.(ComponentSchema); ok && s.Config() != nil {
+			return newValue(s.Config(), raw, path, depth+1)
+		}
+	}
+	return valueOf(raw, path, depth), nil
+}
+
+func valueOf(raw any, path []string, depth int) Value[any] {
+	if depth > maxValueDepth {
+		return node(raw, nil, path)
+	}
+
+	switch v := raw.(type) {
+	case nil:
+		return node(nil, nil, path)
+	case string:
+		return node(raw, NewStringValue(v), path)
+	case bool:
+		return node(raw, NewBooleanValue(v), path)
+	case float32, float64:
+		f, _ := toNumber(raw)
+		return node(raw, NewNumberValue(f), path)
+	case json.Number:
+		if n, ok := toInteger(v); ok {
+			return node(raw, NewIntegerValue(n), path)
+		}
+		if f, ok := toNumber(v); ok {
+			return node(raw, NewNumberValue(f), path)
+		}
+		return node(raw, NewStringValue(string(v)), path)
+	case time.Time:
+		return node(raw, NewStringValue(v.Format(time.RFC3339Nano)), path)
+	}
+
+	if n, ok := toInteger(raw); ok {
+		return node(raw, NewIntegerValue(n), path)
+	}
+	if f, ok := toNumber(raw); ok {
+		return node(raw, NewNumberValue(f), path)
+	}
+	if items, ok := toSlice(raw); ok {
+		value, _ := newArray(raw, items, path, depth, func(int) Schema { return nil })
+		return value
+	}
+	if fields, ok := toFields(raw); ok {
+		value, _ := newStructure(raw, fields, nil, path, depth)
+		return value
+	}
+	return node(raw, nil, path)
+}
+
+func newArray(raw any, items []any, path []string, depth int, schemaAt func(int) Schema) (Value[any], error) {
+	values := make([]Value[any], len(items))
+	for i, item := range items {
+		value, err := newValue(schemaAt(i), item, appendPath(path, strconv.Itoa(i)), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return node(raw, NewArrayValue(values...), path), nil
+}
+
+// newStructure builds the fields of a structure, guided by the schemas of
+// known fields. Other fields are built by their Go types.
+func newStructure(raw any, fields map[string]any, schemas map[string]Schema, path []string, depth int) (Value[any], error) {
+	values := make(map[string]Value[any], len(fields))
+	for key, field := range fields {
+		value, err := newValue(schemas[key], field, appendPath(path, key), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return node(raw, &structureValue{keys: sortedKeys(values), fields: values}, path), nil
+}
+
+// itemSchema returns the schema of the array item at an index.
+func itemSchema(s ArraySchema, i int) Schema {
+	if s == nil {
+		return nil
+	}
+	if prefix := s.PrefixItemSchemas(); len(prefix) > 0 {
+		if i < len(prefix) {
+			return prefix[i]
+		}
+		return s.RestItemSchema()
+	}
+	return s.ItemSchema()
+}
+
+func node(raw any, typed any, path []string) *treeValue {
+	return &treeValue{raw: raw, typed: typed, path: path}
+}
+
+func mismatch(schema Schema, raw any, path []string) error {
+	return fmt.Errorf("value at /%s: expected %s, got %T", strings.Join(path, "/"), schema.Type(), raw)
+}
+
+func appendPath(path []string, segment string) []string {
+	return append(append(make([]string, 0, len(path)+1), path...), segment)
+}
+
+// toNumber converts Go numbers and json.Number to float64.
+func toNumber(raw any) (float64, bool) {
+	if n, ok := raw.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	v := reflect.ValueOf(raw)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// toInteger converts Go integers, whole floats and integral json.Number
+// values that fit in an int.
+func toInteger(raw any) (int, bool) {
+	if n, ok := raw.(json.Number); ok {
+		i, err := n.Int64()
+		if err != nil || i < math.MinInt || i > math.MaxInt {
+			return 0, false
+		}
+		return int(i), true
+	}
+	v := reflect.ValueOf(raw)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < math.MinInt || v.Int() > math.MaxInt {
+			return 0, false
+		}
+		return int(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt {
+			return 0, false
+		}
+		return int(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) || f < math.MinInt || f >= math.MaxInt {
+			return 0, false
+		}
+		return int(f), true
+	}
+	return 0, false
+}
+
+// toSlice returns the items of a slice or array.
+func toSlice(raw any) ([]any, bool) {
+	if items, ok := raw.([]any); ok {
+		return items, true
+	}
+	v := reflect.ValueOf(raw)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+	items := make([]any, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	return items, true
+}
+
+// toFields returns the entries of a map with string keys.
+func toFields(raw any) (map[string]any, bool) {
+	if fields, ok := raw.(map[string]any); ok {
+		return fields, true
+	}
+	v := reflect.ValueOf(raw)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	fields := make(map[string]any, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		fields[iter.Key().String()] = iter.Value().Interface()
+	}
+	return fields, true
+}
+
+// deepCopy copies the slices and maps of a Go value. Values nested deeper
+// than maxValueDepth are shared rather than copied.
+func deepCopy(raw any) any {
+	return copyValue(raw, 0)
+}
+
+func copyValue(raw any, depth int) any {
+	if depth > maxValueDepth {
+		return raw
+	}
+	switch v := raw.(type) {
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = copyValue(item, depth+1)
+		}
+		return items
+	case map[string]any:
+		fields := make(map[string]any, len(v))
+		for key, field := range v {
+			fields[key] = copyValue(field, depth+1)
+		}
+		return fields
+	}
+
+	v := reflect.ValueOf(raw)
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return raw
+		}
+		items := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items.Index(i).Set(copyElement(v.Index(i), depth+1))
+		}
+		return items.Interface()
+	case reflect.Map:
+		if v.IsNil() {
+			return raw
+		}
+		entries := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entries.SetMapIndex(iter.Key(), copyElement(iter.Value(), depth+1))
+		}
+		return entries.Interface()
+	}
+	return raw
+}
+
+// copyElement copies an element of a typed slice or map, keeping its type.
+func copyElement(element reflect.Value, depth int) reflect.Value {
+	if element.Kind() == reflect.Interface && element.IsNil() {
+		return element
+	}
+	copied := copyValue(element.Interface(), depth)
+	if copied == nil {
+		return reflect.Zero(element.Type())
+	}
+	return reflect.ValueOf(copied).Convert(element.Type())
+}
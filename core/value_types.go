@@ -0,0 +1,271 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+var _ ArrayValue[any] = &arrayValue{}
+var _ MapValue[any, any] = &mapValue{}
+var _ StructureValue[any] = &structureValue{}
+
+// arrayValue is an immutable array of values.
+type arrayValue struct {
+	items []Value[any]
+}
+
+// NewArrayValue creates an immutable array value.
+func NewArrayValue(items ...Value[any]) ArrayValue[any] {
+	return &arrayValue{items: append([]Value[any](nil), items...)}
+}
+
+// Value returns the Go values of the items.
+func (v *arrayValue) Value() []any {
+	values := make([]any, len(v.items))
+	for i, item := range v.items {
+		values[i] = item.Value()
+	}
+	return values
+}
+
+// Copy returns deep copies of the Go values of the items.
+func (v *arrayValue) Copy() []any {
+	values := make([]any, len(v.items))
+	for i, item := range v.items {
+		values[i] = item.Copy()
+	}
+	return values
+}
+
+func (v *arrayValue) String() string {
+	return fmt.Sprintf("%v", v.Value())
+}
+
+func (v *arrayValue) IsNull() bool {
+	return false
+}
+
+func (v *arrayValue) IsComposite() bool {
+	return true
+}
+
+func (v *arrayValue) IsEmpty() bool {
+	return len(v.items) == 0
+}
+
+func (v *arrayValue) Size() int {
+	return len(v.items)
+}
+
+func (v *arrayValue) Length() int {
+	return len(v.items)
+}
+
+// Get returns the Go value of the item at an index.
+func (v *arrayValue) Get(index int) (any, error) {
+	if index < 0 || index >= len(v.items) {
+		return nil, fmt.Errorf("index %d out of range for array of length %d", index, len(v.items))
+	}
+	return v.items[index].Value(), nil
+}
+
+func (v *arrayValue) Values() []CompositeEntry[int, any] {
+	entries := make([]CompositeEntry[int, any], len(v.items))
+	for i, item := range v.items {
+		entries[i] = CompositeEntry[int, any]{PathFragment: strconv.Itoa(i), Key: i, Value: item}
+	}
+	return entries
+}
+
+func (v *arrayValue) AcceptValue(visitor ValueVisitor) error {
+	return visitor.VisitArray(v)
+}
+
+// mapValue is an immutable map of values with string keys, ordered by key.
+type mapValue struct {
+	keys    []string
+	entries map[string]Value[any]
+}
+
+// NewMapValue creates an immutable map value.
+func NewMapValue(entries map[string]Value[any]) MapValue[any, any] {
+	return &mapValue{keys: sortedKeys(entries), entries: copyEntries(entries)}
+}
+
+// Value returns the Go values of the entries.
+func (v *mapValue) Value() map[any]any {
+	values := make(map[any]any, len(v.keys))
+	for _, key := range v.keys {
+		values[key] = v.entries[key].Value()
+	}
+	return values
+}
+
+// Copy returns deep copies of the Go values of the entries.
+func (v *mapValue) Copy() map[any]any {
+	values := make(map[any]any, len(v.keys))
+	for _, key := range v.keys {
+		values[key] = v.entries[key].Copy()
+	}
+	return values
+}
+
+func (v *mapValue) String() string {
+	return fmt.Sprintf("%v", v.Value())
+}
+
+func (v *mapValue) IsNull() bool {
+	return false
+}
+
+func (v *mapValue) IsComposite() bool {
+	return true
+}
+
+func (v *mapValue) IsEmpty() bool {
+	return len(v.keys) == 0
+}
+
+func (v *mapValue) Size() int {
+	return len(v.keys)
+}
+
+// Get returns the Go value of the entry with a key.
+func (v *mapValue) Get(key any) (any, error) {
+	entry, ok := v.entry(key)
+	if !ok {
+		return nil, fmt.Errorf("key %v not found", key)
+	}
+	return entry.Value(), nil
+}
+
+func (v *mapValue) Has(key any) bool {
+	_, ok := v.entry(key)
+	return ok
+}
+
+func (v *mapValue) Values() []CompositeEntry[any, any] {
+	entries := make([]CompositeEntry[any, any], len(v.keys))
+	for i, key := range v.keys {
+		entries[i] = CompositeEntry[any, any]{PathFragment: key, Key: key, Value: v.entries[key]}
+	}
+	return entries
+}
+
+func (v *mapValue) AcceptValue(visitor ValueVisitor) error {
+	return visitor.VisitMap(v)
+}
+
+func (v *mapValue) entry(key any) (Value[any], bool) {
+	name, ok := key.(string)
+	if !ok {
+		return nil, false
+	}
+	entry, ok := v.entries[name]
+	return entry, ok
+}
+
+// structureValue is an immutable structure of named values, ordered by name.
+type structureValue struct {
+	keys   []string
+	fields map[string]Value[any]
+}
+
+// NewStructureValue creates an immutable structure value.
+func NewStructureValue(fields map[string]Value[any]) StructureValue[any] {
+	return &structureValue{keys: sortedKeys(fields), fields: copyEntries(fields)}
+}
+
+// Value returns the Go values of the fields as a map[string]any.
+func (v *structureValue) Value() any {
+	values := make(map[string]any, len(v.keys))
+	for _, key := range v.keys {
+		values[key] = v.fields[key].Value()
+	}
+	return values
+}
+
+// Copy returns deep copies of the Go values of the fields as a map[string]any.
+func (v *structureValue) Copy() any {
+	values := make(map[string]any, len(v.keys))
+	for _, key := range v.keys {
+		values[key] = v.fields[key].Copy()
+	}
+	return values
+}
+
+func (v *structureValue) String() string {
+	return fmt.Sprintf("%v", v.Value())
+}
+
+func (v *structureValue) IsNull() bool {
+	return false
+}
+
+func (v *structureValue) IsComposite() bool {
+	return true
+}
+
+func (v *structureValue) IsEmpty() bool {
+	return len(v.keys) == 0
+}
+
+func (v *structureValue) Size() int {
+	return len(v.keys)
+}
+
+func (v *structureValue) Get(key string) (Value[any], error) {
+	field, ok := v.fields[key]
+	if !ok {
+		return nil, fmt.Errorf("field %s not found", key)
+	}
+	return field, nil
+}
+
+func (v *structureValue) Has(key string) bool {
+	_, ok := v.fields[key]
+	return ok
+}
+
+func (v *structureValue) Keys() []string {
+	return append([]string(nil), v.keys...)
+}
+
+func (v *structureValue) Values() []CompositeEntry[string, any] {
+	entries := make([]CompositeEntry[string, any], len(v.keys))
+	for i, key := range v.keys {
+		entries[i] = CompositeEntry[string, any]{PathFragment: key, Key: key, Value: v.fields[key]}
+	}
+	return entries
+}
+
+// Entries returns the fields in key order, one single-entry map per field.
+func (v *structureValue) Entries() []map[string]Value[any] {
+	entries := make([]map[string]Value[any], len(v.keys))
+	for i, key := range v.keys {
+		entries[i] = map[string]Value[any]{key: v.fields[key]}
+	}
+	return entries
+}
+
+func (v *structureValue) AcceptValue(visitor ValueVisitor) error {
+	return visitor.VisitObject(v)
+}
+
+func sortedKeys(entries map[string]Value[any]) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func copyEntries(entries map[string]Value[any]) map[string]Value[any] {
+	copied := make(map[string]Value[any], len(entries))
+	for key, entry := range entries {
+		copied[key] = entry
+	}
+	return copied
+}
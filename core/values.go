@@ -95,13 +95,42 @@ type NumberLike interface {
 	int | float64
 }
 
+// PathAware is implemented by values that know their position in a value tree.
+type PathAware interface {
+	// Path returns the path fragments from the root of the tree to the value.
+	Path() []string
+}
+
 var _ Value[any] = &baseValue[any]{}
+var _ StringValue = &baseValue[string]{}
+var _ ValueAccepter = &baseValue[string]{}
 
+// baseValue is an immutable scalar value.
 type baseValue[T any] struct {
 	value     T
 	composite bool
 }
 
+// NewStringValue creates an immutable string value.
+func NewStringValue(value string) StringValue {
+	return &baseValue[string]{value: value}
+}
+
+// NewNumberValue creates an immutable number value.
+func NewNumberValue(value float64) NumberValue {
+	return &baseValue[float64]{value: value}
+}
+
+// NewIntegerValue creates an immutable integer value.
+func NewIntegerValue(value int) IntegerValue {
+	return &baseValue[int]{value: value}
+}
+
+// NewBooleanValue creates an immutable boolean value.
+func NewBooleanValue(value bool) BooleanValue {
+	return &baseValue[bool]{value: value}
+}
+
 // Copy implements Value.
 func (v *baseValue[T]) Copy() T {
 	return v.value
@@ -115,7 +144,14 @@ func (v *baseValue[T]) IsComposite() bool {
 // IsNull implements Value.
 func (v *baseValue[T]) IsNull() bool {
 	val := reflect.ValueOf(v.value)
-	return val.IsNil()
+	if !val.IsValid() {
+		return true
+	}
+	switch val.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return val.IsNil()
+	}
+	return false
 }
 
 // String implements Value.
@@ -127,3 +163,18 @@ func (v *baseValue[T]) String() string {
 func (v *baseValue[T]) Value() T {
 	return v.value
 }
+
+// AcceptValue implements ValueAccepter for the scalar value types.
+func (v *baseValue[T]) AcceptValue(visitor ValueVisitor) error {
+	switch value := any(v).(type) {
+	case *baseValue[string]:
+		return visitor.VisitString(value)
+	case *baseValue[float64]:
+		return visitor.VisitNumber(value)
+	case *baseValue[int]:
+		return visitor.VisitInteger(value)
+	case *baseValue[bool]:
+		return visitor.VisitBoolean(value)
+	}
+	return nil
+}
@@ -7,11 +7,21 @@ type SchemaVisitor interface {
 	VisitNumber(NumberSchema) error
 	VisitInteger(IntegerSchema) error
 	VisitBoolean(BooleanSchema) error
+	VisitNull(NullSchema) error
+	VisitAny(AnySchema) error
 	VisitArray(ArraySchema) error
 	VisitObject(ObjectSchema) error
+	VisitMap(MapSchema) error
+	VisitOptional(OptionalSchema) error
 	VisitFunction(FunctionSchema) error
 	VisitService(ServiceSchema) error
+	VisitTopic(TopicSchema) error
+	VisitComponent(ComponentSchema) error
 	VisitUnion(UnionSchema) error
+	VisitRef(RefSchema) error
+	VisitResult(ResultSchema) error
+	VisitParameter(TypeParameterSchema) error
+	VisitGeneric(GenericSchema) error
 }
 
 // Accepter defines the interface for schemas that can accept visitors.
@@ -32,7 +32,7 @@ func (a *SimpleAnnotationSchema) ValidateAsAnnotation() error {
 	case core.TypeStructure:
 		// Objects are allowed - we trust the builder to create valid property schemas
 		return nil
-	case core.TypeFunction, core.TypeService:
+	case core.TypeFunction, core.TypeService, core.TypeTopic, core.TypeComponent:
 		return fmt.Errorf("%s schemas not allowed in annotations", schemaType)
 	default:
 		return nil // Allow other types for now
@@ -3,7 +3,12 @@
 package engine
 
 import (
+	"fmt"
+	"strings"
+
+	"defs.dev/schema/consume/lint"
 	"defs.dev/schema/core"
+	"defs.dev/schema/core/structure"
 )
 
 // SchemaEngine is the central coordination layer that manages all cross-cutting
@@ -17,6 +22,14 @@ type SchemaEngine interface {
 	ListSchemas() []string
 	HasSchema(name string) bool
 
+	// Generics - Parameterized schema templates registered with RegisterSchema
+	Instantiate(name string, args ...core.Schema) (core.InstanceSchema, error)
+
+	// Migrations - Upgrading values between versions of a named schema
+	RegisterMigration(from, to string, steps ...MigrationStep) error
+	Migrate(from, to string, value any) (any, error)
+	Upgrade(from string, value any) (any, string, error)
+
 	// Extension Management - Pluggable schema types
 	RegisterSchemaType(typeName string, factory SchemaTypeFactory) error
 	CreateSchema(typeName string, config any) (core.Schema, error)
@@ -103,11 +116,15 @@ type EngineConfig struct {
 	CircularDepthLimit int `json:"circularDepthLimit"`
 
 	// Validation configuration
-	StrictMode         bool `json:"strictMode"`         // Reject unknown annotations
-	ValidateOnRegister bool `json:"validateOnRegister"` // Validate schemas when registered
+	StrictMode         bool        `json:"strictMode"`         // Reject unknown annotations
+	ValidateOnRegister bool        `json:"validateOnRegister"` // Lint registered schemas in Validate(); registration does not lint
+	Lint               lint.Config `json:"lint,omitempty"`     // Rule severities used when linting registered schemas
 
 	// Performance configuration
 	EnableConcurrency bool `json:"enableConcurrency"` // Allow concurrent operations
+
+	// Versioning configuration
+	Compatibility structure.Compatibility `json:"compatibility,omitempty"` // Required compatibility with the previous version of a versioned schema
 }
 
 // DefaultEngineConfig returns a sensible default configuration for the engine.
@@ -155,6 +172,12 @@ const (
 	ErrorTypeInvalidConfig      = "invalid_config"
 	ErrorTypeValidationFailed   = "validation_failed"
 	ErrorTypeInvalidAnnotation  = "invalid_annotation"
+	ErrorTypeInvalidTypeArgs    = "invalid_type_arguments"
+	ErrorTypeIncompatible       = "incompatible_schema"
+	ErrorTypeMigrationExists    = "migration_exists"
+	ErrorTypeMigrationNotFound  = "migration_not_found"
+	ErrorTypeMigrationFailed    = "migration_failed"
+	ErrorTypeLintFailed         = "lint_failed"
 )
 
 // Helper functions for creating common errors
@@ -182,6 +205,14 @@ func NewTypeNotFoundError(typeName string) error {
 	}
 }
 
+func NewInvalidTypeArgumentsError(genericName, message string) error {
+	return EngineError{
+		Type:    ErrorTypeInvalidTypeArgs,
+		Message: message,
+		Details: map[string]any{"generic_name": genericName},
+	}
+}
+
 func NewCircularDependencyError(path []string) error {
 	return EngineError{
 		Type:    ErrorTypeCircularDependency,
@@ -189,3 +220,27 @@ func NewCircularDependencyError(path []string) error {
 		Details: map[string]any{"resolution_path": path},
 	}
 }
+
+func NewIncompatibleSchemaError(name, previous string, changes structure.Changes) error {
+	messages := make([]string, len(changes))
+	for i, change := range changes {
+		messages[i] = change.String()
+	}
+	return EngineError{
+		Type:    ErrorTypeIncompatible,
+		Message: fmt.Sprintf("schema %s is incompatible with %s: %s", name, previous, strings.Join(messages, "; ")),
+		Details: map[string]any{"schema_name": name, "previous_version": previous, "changes": changes},
+	}
+}
+
+func NewLintError(name string, issues []lint.Issue) error {
+	messages := make([]string, len(issues))
+	for i, issue := range issues {
+		messages[i] = issue.String()
+	}
+	return EngineError{
+		Type:    ErrorTypeLintFailed,
+		Message: fmt.Sprintf("schema %s failed linting: %s", name, strings.Join(messages, "; ")),
+		Details: map[string]any{"schema_name": name, "issues": issues},
+	}
+}
@@ -0,0 +1,314 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+)
+
+// InstanceSchemaConfig holds the configuration for building an InstanceSchema.
+type InstanceSchemaConfig struct {
+	Metadata    core.SchemaMetadata
+	Annotations []core.Annotation
+	GenericName string
+	Arguments   []core.Schema
+	Resolved    core.Schema
+
+	// Name is the name of the concrete type. When empty, it is derived from
+	// the generic and argument names, such as PageUser for Page[User].
+	Name string
+
+	// Engine resolves the instance by its concrete type name when Resolved is
+	// not set, such as for instances loaded from a schema document.
+	Engine SchemaEngine
+}
+
+// InstanceSchema is a generic schema applied to concrete type arguments, such as
+// Page[User]. It is created by SchemaEngine.Instantiate and resolves to the
+// template with every type parameter substituted.
+type InstanceSchema struct {
+	config InstanceSchemaConfig
+}
+
+// Ensure InstanceSchema implements the API interfaces at compile time
+var _ core.Schema = (*InstanceSchema)(nil)
+var _ core.InstanceSchema = (*InstanceSchema)(nil)
+var _ core.Accepter = (*InstanceSchema)(nil)
+
+// NewInstanceSchema creates a new InstanceSchema with the given configuration.
+func NewInstanceSchema(config InstanceSchemaConfig) *InstanceSchema {
+	return &InstanceSchema{config: config}
+}
+
+// Type returns the schema type constant. Instances behave like references.
+func (i *InstanceSchema) Type() core.SchemaType {
+	return core.TypeRef
+}
+
+// Metadata returns the schema metadata.
+func (i *InstanceSchema) Metadata() core.SchemaMetadata {
+	return i.config.Metadata
+}
+
+// Annotations returns the annotations of the schema.
+func (i *InstanceSchema) Annotations() []core.Annotation {
+	if i.config.Annotations == nil {
+		return nil
+	}
+	result := make([]core.Annotation, len(i.config.Annotations))
+	copy(result, i.config.Annotations)
+	return result
+}
+
+// Clone returns a deep copy of the InstanceSchema.
+func (i *InstanceSchema) Clone() core.Schema {
+	newConfig := i.config
+
+	// Deep copy metadata examples and tags
+	if i.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(i.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, i.config.Metadata.Examples)
+	}
+
+	if i.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(i.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, i.config.Metadata.Tags)
+	}
+
+	if i.config.Arguments != nil {
+		newConfig.Arguments = make([]core.Schema, len(i.config.Arguments))
+		copy(newConfig.Arguments, i.config.Arguments)
+	}
+
+	return NewInstanceSchema(newConfig)
+}
+
+// GenericName returns the name of the instantiated generic schema.
+func (i *InstanceSchema) GenericName() string {
+	return i.config.GenericName
+}
+
+// TypeArguments returns the type arguments, in parameter order.
+func (i *InstanceSchema) TypeArguments() []core.Schema {
+	if i.config.Arguments == nil {
+		return nil
+	}
+	result := make([]core.Schema, len(i.config.Arguments))
+	copy(result, i.config.Arguments)
+	return result
+}
+
+// ReferenceName returns the name of the concrete type, such as PageUser for
+// Page[User]. Targets without generics use it for the substituted schema.
+func (i *InstanceSchema) ReferenceName() string {
+	if i.config.Name != "" {
+		return i.config.Name
+	}
+	return instanceName(i.config.GenericName, i.config.Arguments)
+}
+
+// Resolve returns the template with the type arguments substituted.
+func (i *InstanceSchema) Resolve() (core.Schema, error) {
+	if i.config.Resolved != nil {
+		return i.config.Resolved, nil
+	}
+	if i.config.Engine != nil {
+		return i.config.Engine.ResolveSchema(i.ReferenceName())
+	}
+	return nil, fmt.Errorf("generic instance %s has not been resolved", i.ReferenceName())
+}
+
+// Note: Validation moved to consumer-driven architecture.
+// Use schema/consumer.Registry.ProcessValueWithPurpose("validation", schema, value) instead.
+
+// Accept implements the visitor pattern for schema traversal.
+func (i *InstanceSchema) Accept(visitor core.SchemaVisitor) error {
+	return visitor.VisitRef(i)
+}
+
+// instanceName derives the concrete type name of a generic instance by
+// appending the names of its arguments, e.g. Page + [User] gives PageUser.
+func instanceName(genericName string, args []core.Schema) string {
+	var b strings.Builder
+	b.WriteString(genericName)
+	for _, arg := range args {
+		b.WriteString(argumentName(arg))
+	}
+	return b.String()
+}
+
+// argumentName returns the name a type argument contributes to an instance name.
+func argumentName(arg core.Schema) string {
+	if arg == nil {
+		return "Any"
+	}
+	if name := arg.Metadata().Name; name != "" {
+		return name
+	}
+	if ref, ok := arg.(core.RefSchema); ok && ref.ReferenceName() != "" {
+		return ref.ReferenceName()
+	}
+	typeName := string(arg.Type())
+	if typeName == "" {
+		return "Any"
+	}
+	return strings.ToUpper(typeName[:1]) + typeName[1:]
+}
+
+// instanceKey identifies an instantiation in the cache. Arguments are keyed by
+// fingerprint, so structurally equal arguments share an instance while two
+// anonymous schemas of the same type that differ do not.
+func (e *schemaEngineImpl) instanceKey(genericName string, args []core.Schema) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			parts[i] = "nil"
+			continue
+		}
+		parts[i] = e.fingerprint(arg)
+	}
+	return genericName + "[" + strings.Join(parts, ",") + "]"
+}
+
+// Generic Methods
+
+func (e *schemaEngineImpl) Instantiate(name string, args ...core.Schema) (core.InstanceSchema, error) {
+	return e.instantiate(name, args, map[string]*InstanceSchema{})
+}
+
+// instantiate builds an instance of a generic schema. pending holds the
+// instances this instantiation is building: a template may refer to an
+// instance of itself, such as Tree[T] holding children of Tree[T], and the
+// instance being built is returned for those. Concurrent instantiations do
+// not see each other's pending instances.
+func (e *schemaEngineImpl) instantiate(name string, args []core.Schema, pending map[string]*InstanceSchema) (core.InstanceSchema, error) {
+	key := e.instanceKey(name, args)
+	if instance, ok := pending[key]; ok {
+		return instance, nil
+	}
+
+	e.instancesMu.RLock()
+	cached, isCached := e.instances[key]
+	e.instancesMu.RUnlock()
+	if isCached && e.config.EnableCache {
+		return cached, nil
+	}
+
+	schema, err := e.ResolveSchema(name)
+	if err != nil {
+		return nil, err
+	}
+	generic, ok := schema.(core.GenericSchema)
+	if !ok {
+		return nil, NewInvalidTypeArgumentsError(name, fmt.Sprintf("schema %s is not generic", name))
+	}
+
+	params := generic.TypeParameters()
+	if len(args) != len(params) {
+		return nil, NewInvalidTypeArgumentsError(name,
+			fmt.Sprintf("generic %s expects %d type arguments, got %d", name, len(params), len(args)))
+	}
+
+	bindings := make(map[string]core.Schema, len(params))
+	for i, param := range params {
+		arg := args[i]
+		if arg == nil {
+			return nil, NewInvalidTypeArgumentsError(name,
+				fmt.Sprintf("type argument for %s cannot be nil", param.ParameterName()))
+		}
+		if constraint := param.Constraint(); constraint != nil && constraint.Type() != arg.Type() {
+			return nil, NewInvalidTypeArgumentsError(name,
+				fmt.Sprintf("type argument for %s must be of type %s, got %s", param.ParameterName(), constraint.Type(), arg.Type()))
+		}
+		bindings[param.ParameterName()] = arg
+	}
+
+	instance := NewInstanceSchema(InstanceSchemaConfig{
+		GenericName: name,
+		Arguments:   append([]core.Schema(nil), args...),
+		Name:        e.reserveInstanceName(key, instanceName(name, args)),
+	})
+	referenceName := instance.ReferenceName()
+
+	pending[key] = instance
+	resolved, err := e.substitute(generic.Template(), bindings, pending)
+	delete(pending, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate %s: %w", name, err)
+	}
+	if resolved != nil {
+		metadata := resolved.Metadata()
+		metadata.Name = referenceName
+		resolved = schemas.WithMetadata(resolved, metadata)
+	}
+	instance.config.Resolved = resolved
+
+	if e.config.EnableCache {
+		e.instancesMu.Lock()
+		defer e.instancesMu.Unlock()
+
+		// A concurrent instantiation may have finished first; its instance is kept
+		if cached, ok := e.instances[key]; ok {
+			return cached, nil
+		}
+		e.instances[key] = instance
+	}
+
+	return instance, nil
+}
+
+// reserveInstanceName returns the name of the instance with the given key.
+// Instances are named after their arguments, so arguments that differ but
+// have the same names, such as two schemas named User, would give two
+// instances of the same name; the first instance keeps the name and later
+// ones get the fingerprint of their key appended.
+func (e *schemaEngineImpl) reserveInstanceName(key, name string) string {
+	e.instancesMu.Lock()
+	defer e.instancesMu.Unlock()
+
+	if other, taken := e.instanceNames[name]; taken && other != key {
+		sum := sha256.Sum256([]byte(key))
+		name += "_" + hex.EncodeToString(sum[:4])
+	}
+	e.instanceNames[name] = key
+	return name
+}
+
+// substitute replaces the type parameters in a template with their bindings.
+// Nested instances whose arguments mention a parameter are re-instantiated.
+func (e *schemaEngineImpl) substitute(template core.Schema, bindings map[string]core.Schema, pending map[string]*InstanceSchema) (core.Schema, error) {
+	var substituteErr error
+	result := schemas.Transform(template, func(s core.Schema) (core.Schema, bool) {
+		switch node := s.(type) {
+		case core.TypeParameterSchema:
+			if arg, ok := bindings[node.ParameterName()]; ok {
+				return arg, true
+			}
+		case core.InstanceSchema:
+			args := node.TypeArguments()
+			for i, arg := range args {
+				substituted, err := e.substitute(arg, bindings, pending)
+				if err != nil {
+					substituteErr = err
+					return s, true
+				}
+				args[i] = substituted
+			}
+			instance, err := e.instantiate(node.GenericName(), args, pending)
+			if err != nil {
+				substituteErr = err
+				return s, true
+			}
+			return instance, true
+		}
+		return nil, false
+	})
+	if substituteErr != nil {
+		return nil, substituteErr
+	}
+	return result, nil
+}
@@ -3,9 +3,13 @@ package engine
 import (
 	"defs.dev/schema/consume/validation"
 	"fmt"
+	"reflect"
+	"sort"
 	"sync"
 
+	"defs.dev/schema/consume/lint"
 	"defs.dev/schema/core"
+	"defs.dev/schema/core/structure"
 )
 
 // schemaEngineImpl is the concrete implementation of SchemaEngine
@@ -25,10 +29,22 @@ type schemaEngineImpl struct {
 	annotations map[string]AnnotationSchema
 	annotMu     sync.RWMutex
 
-	// Dependency resolution cache
+	// Resolved schemas by reference name and by fingerprint, and the
+	// fingerprints of schema instances
+	references      map[string]core.Schema
 	resolutionCache map[string]core.Schema
+	fingerprints    map[core.Schema]string
 	cacheMu         sync.RWMutex
 
+	// Generic instantiations, and the instance keys by reference name
+	instances     map[string]core.InstanceSchema
+	instanceNames map[string]string
+	instancesMu   sync.RWMutex
+
+	// Migrations between schema versions, keyed by the version they start from
+	migrations   map[string]*migration
+	migrationsMu sync.RWMutex
+
 	// Global mutex for operations that need to coordinate across systems
 	globalMu sync.RWMutex
 }
@@ -40,7 +56,12 @@ func newSchemaEngineImpl(config EngineConfig) SchemaEngine {
 		schemas:         make(map[string]core.Schema),
 		typeFactories:   make(map[string]SchemaTypeFactory),
 		annotations:     make(map[string]AnnotationSchema),
+		references:      make(map[string]core.Schema),
 		resolutionCache: make(map[string]core.Schema),
+		fingerprints:    make(map[core.Schema]string),
+		instances:       make(map[string]core.InstanceSchema),
+		instanceNames:   make(map[string]string),
+		migrations:      make(map[string]*migration),
 	}
 
 	// Register built-in annotations
@@ -68,10 +89,9 @@ func (e *schemaEngineImpl) RegisterSchema(name string, schema core.Schema) error
 		return NewSchemaExistsError(name)
 	}
 
-	// Validate schema if configured to do so
-	if e.config.ValidateOnRegister {
-		// TODO: Use consumer-driven validation once we have actual validators registered
-		// For now, skip validation to avoid breaking existing functionality
+	// Check the new version against the previous one
+	if err := e.checkCompatibility(name, schema); err != nil {
+		return err
 	}
 
 	// Register the schema
@@ -83,6 +103,52 @@ func (e *schemaEngineImpl) RegisterSchema(name string, schema core.Schema) error
 	return nil
 }
 
+// checkCompatibility compares a versioned schema with the closest lower version
+// of the same schema and rejects the changes the configured compatibility does
+// not allow. The caller must hold schemaMu.
+func (e *schemaEngineImpl) checkCompatibility(name string, schema core.Schema) error {
+	if e.config.Compatibility == "" {
+		return nil
+	}
+	ref, err := ParseReference(name)
+	if err != nil || !ref.IsVersioned() {
+		return nil
+	}
+
+	var previous SchemaReference
+	for _, version := range e.versionsOf(ref) {
+		if compareVersions(version.Version(), ref.Version()) < 0 {
+			previous = version
+		}
+	}
+	if previous == nil {
+		return nil
+	}
+
+	changes := structure.Diff(e.schemas[previous.FullName()], schema)
+	if violations := changes.Violations(e.config.Compatibility); len(violations) > 0 {
+		return NewIncompatibleSchemaError(name, previous.FullName(), violations)
+	}
+	return nil
+}
+
+// versionsOf returns the registered versions of the schema a reference names,
+// oldest first. The caller must hold schemaMu.
+func (e *schemaEngineImpl) versionsOf(ref SchemaReference) []SchemaReference {
+	var versions []SchemaReference
+	for registered := range e.schemas {
+		other, err := ParseReference(registered)
+		if err != nil || !other.IsVersioned() || other.Name() != ref.Name() || other.Namespace() != ref.Namespace() {
+			continue
+		}
+		versions = append(versions, other)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i].Version(), versions[j].Version()) < 0
+	})
+	return versions
+}
+
 func (e *schemaEngineImpl) ResolveSchema(name string) (core.Schema, error) {
 	if name == "" {
 		return nil, fmt.Errorf("schema name cannot be empty")
@@ -131,8 +197,9 @@ func (e *schemaEngineImpl) ResolveReference(ref SchemaReference) (core.Schema, e
 		return nil, err
 	}
 
-	// Cache the result
+	// Share one instance between structurally equal schemas and cache it
 	if e.config.EnableCache {
+		schema = e.cached(schema)
 		e.setCached(fullName, schema)
 	}
 
@@ -375,8 +442,26 @@ func (e *schemaEngineImpl) HasAnnotation(name string) bool {
 // Engine Management Methods
 
 func (e *schemaEngineImpl) Validate() error {
-	// TODO: Use consumer-driven validation for all registered schemas
-	// For now, skip validation to avoid breaking existing functionality
+	// Lint all registered schemas; only error-severity issues fail validation
+	if e.config.ValidateOnRegister {
+		e.schemaMu.RLock()
+		names := make([]string, 0, len(e.schemas))
+		for name := range e.schemas {
+			names = append(names, name)
+		}
+		schemas := make(map[string]core.Schema, len(e.schemas))
+		for name, schema := range e.schemas {
+			schemas[name] = schema
+		}
+		e.schemaMu.RUnlock()
+
+		sort.Strings(names)
+		for _, name := range names {
+			if result := lint.Lint(schemas[name], e.config.Lint); result.HasErrors() {
+				return NewLintError(name, result.Errors())
+			}
+		}
+	}
 
 	// Validate all annotation schemas (this uses ValidateAsAnnotation, not core validation)
 	e.annotMu.RLock()
@@ -414,9 +499,20 @@ func (e *schemaEngineImpl) Reset() error {
 
 	// Clear cache
 	e.cacheMu.Lock()
+	e.references = make(map[string]core.Schema)
 	e.resolutionCache = make(map[string]core.Schema)
+	e.fingerprints = make(map[core.Schema]string)
 	e.cacheMu.Unlock()
 
+	e.instancesMu.Lock()
+	e.instances = make(map[string]core.InstanceSchema)
+	e.instanceNames = make(map[string]string)
+	e.instancesMu.Unlock()
+
+	e.migrationsMu.Lock()
+	e.migrations = make(map[string]*migration)
+	e.migrationsMu.Unlock()
+
 	// Re-register built-in annotations
 	e.registerBuiltinAnnotations()
 
@@ -432,7 +528,12 @@ func (e *schemaEngineImpl) Clone() SchemaEngine {
 		schemas:         make(map[string]core.Schema),
 		typeFactories:   make(map[string]SchemaTypeFactory),
 		annotations:     make(map[string]AnnotationSchema),
+		references:      make(map[string]core.Schema),
 		resolutionCache: make(map[string]core.Schema),
+		fingerprints:    make(map[core.Schema]string),
+		instances:       make(map[string]core.InstanceSchema),
+		instanceNames:   make(map[string]string),
+		migrations:      make(map[string]*migration),
 	}
 
 	// Copy schemas
@@ -456,6 +557,13 @@ func (e *schemaEngineImpl) Clone() SchemaEngine {
 	}
 	e.annotMu.RUnlock()
 
+	// Copy migrations
+	e.migrationsMu.RLock()
+	for from, m := range e.migrations {
+		clone.migrations[from] = m
+	}
+	e.migrationsMu.RUnlock()
+
 	return clone
 }
 
@@ -506,32 +614,38 @@ func (e *schemaEngineImpl) resolveWithContext(ref SchemaReference, ctx *resoluti
 		ctx.depth--
 	}()
 
-	// For now, resolve using simple name lookup
-	// In future phases, this will handle namespaces and versions
+	// Prefer a schema registered under the fully qualified name, then fall back
+	// to simple name lookup
+	if fullName != ref.Name() && e.HasSchema(fullName) {
+		return e.ResolveSchema(fullName)
+	}
 	return e.ResolveSchema(ref.Name())
 }
 
 // Cache management
+
+// getCached returns the schema a reference resolved to before.
 func (e *schemaEngineImpl) getCached(key string) core.Schema {
 	e.cacheMu.RLock()
 	defer e.cacheMu.RUnlock()
-
-	return e.resolutionCache[key]
+	return e.references[key]
 }
 
+// setCached records the schema a reference resolved to.
 func (e *schemaEngineImpl) setCached(key string, schema core.Schema) {
 	e.cacheMu.Lock()
 	defer e.cacheMu.Unlock()
 
 	// Check cache size limit
-	if len(e.resolutionCache) >= e.config.MaxCacheSize {
+	if len(e.references) >= e.config.MaxCacheSize {
 		// Simple eviction: clear the cache
-		e.resolutionCache = make(map[string]core.Schema)
+		e.references = make(map[string]core.Schema)
 	}
-
-	e.resolutionCache[key] = schema
+	e.references[key] = schema
 }
 
+// clearRelatedCache forgets resolved references when a schema is registered.
+// Schemas cached by fingerprint stay valid, as they are keyed by content.
 func (e *schemaEngineImpl) clearRelatedCache(schemaName string) {
 	if !e.config.EnableCache {
 		return
@@ -540,9 +654,63 @@ func (e *schemaEngineImpl) clearRelatedCache(schemaName string) {
 	e.cacheMu.Lock()
 	defer e.cacheMu.Unlock()
 
-	// For now, clear entire cache when any schema changes
-	// In the future, we could be more intelligent about this
-	e.resolutionCache = make(map[string]core.Schema)
+	// Any reference may resolve through the new schema, so clear them all
+	e.references = make(map[string]core.Schema)
+}
+
+// cached returns the schema resolved before with the same fingerprint, so that
+// references to structurally equal schemas, under whichever name, resolve to
+// one instance and share what is cached by schema identity. A schema not seen
+// before is cached.
+func (e *schemaEngineImpl) cached(schema core.Schema) core.Schema {
+	fingerprint := e.fingerprint(schema)
+
+	e.cacheMu.RLock()
+	cached, exists := e.resolutionCache[fingerprint]
+	e.cacheMu.RUnlock()
+	if exists {
+		return cached
+	}
+
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+
+	if cached, exists := e.resolutionCache[fingerprint]; exists {
+		return cached
+	}
+
+	// Check cache size limit
+	if len(e.resolutionCache) >= e.config.MaxCacheSize {
+		// Simple eviction: clear the cache
+		e.resolutionCache = make(map[string]core.Schema)
+	}
+
+	e.resolutionCache[fingerprint] = schema
+	return schema
+}
+
+// fingerprint returns the structural fingerprint of a schema, computed once
+// per schema instance.
+func (e *schemaEngineImpl) fingerprint(schema core.Schema) string {
+	if schema == nil || !reflect.TypeOf(schema).Comparable() {
+		return structure.Fingerprint(schema)
+	}
+
+	e.cacheMu.RLock()
+	fingerprint, exists := e.fingerprints[schema]
+	e.cacheMu.RUnlock()
+	if exists {
+		return fingerprint
+	}
+
+	fingerprint = structure.Fingerprint(schema)
+	e.cacheMu.Lock()
+	if len(e.fingerprints) >= e.config.MaxCacheSize {
+		e.fingerprints = make(map[core.Schema]string)
+	}
+	e.fingerprints[schema] = fingerprint
+	e.cacheMu.Unlock()
+	return fingerprint
 }
 
 // Factory validation
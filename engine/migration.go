@@ -0,0 +1,249 @@
+package engine
+
+import (
+	"fmt"
+
+	"defs.dev/schema/consume/validation"
+)
+
+// MigrationStep transforms a value written under one version of a schema into
+// the shape of a later one. Steps receive a copy of the value and may modify
+// it. Any func with this signature can be used as a custom step.
+type MigrationStep func(value any) (any, error)
+
+// migration upgrades values from one version of a schema to another.
+type migration struct {
+	to    SchemaReference
+	steps []MigrationStep
+}
+
+// RenameField moves an object field to a new name. Values without the field
+// are left unchanged.
+func RenameField(from, to string) MigrationStep {
+	return objectStep(func(object map[string]any) error {
+		if value, ok := object[from]; ok {
+			delete(object, from)
+			object[to] = value
+		}
+		return nil
+	})
+}
+
+// RemoveField deletes an object field.
+func RemoveField(name string) MigrationStep {
+	return objectStep(func(object map[string]any) error {
+		delete(object, name)
+		return nil
+	})
+}
+
+// SetDefault sets an object field to a value when it is missing.
+func SetDefault(name string, value any) MigrationStep {
+	return objectStep(func(object map[string]any) error {
+		if _, ok := object[name]; !ok {
+			object[name] = value
+		}
+		return nil
+	})
+}
+
+// SplitField replaces an object field with the fields split returns, such as
+// a full name split into first and last names. Values without the field are
+// left unchanged.
+func SplitField(name string, split func(value any) (map[string]any, error)) MigrationStep {
+	return objectStep(func(object map[string]any) error {
+		value, ok := object[name]
+		if !ok {
+			return nil
+		}
+		fields, err := split(value)
+		if err != nil {
+			return fmt.Errorf("splitting %s: %w", name, err)
+		}
+		delete(object, name)
+		for field, fieldValue := range fields {
+			object[field] = fieldValue
+		}
+		return nil
+	})
+}
+
+// MergeFields replaces object fields with a single field holding the result
+// of merge, which receives the fields that are present.
+func MergeFields(names []string, into string, merge func(fields map[string]any) (any, error)) MigrationStep {
+	return objectStep(func(object map[string]any) error {
+		fields := make(map[string]any, len(names))
+		for _, name := range names {
+			if value, ok := object[name]; ok {
+				fields[name] = value
+				delete(object, name)
+			}
+		}
+		merged, err := merge(fields)
+		if err != nil {
+			return fmt.Errorf("merging into %s: %w", into, err)
+		}
+		object[into] = merged
+		return nil
+	})
+}
+
+// objectStep adapts a function that modifies an object into a step.
+func objectStep(apply func(object map[string]any) error) MigrationStep {
+	return func(value any) (any, error) {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected an object, got %T", value)
+		}
+		if err := apply(object); err != nil {
+			return nil, err
+		}
+		return object, nil
+	}
+}
+
+// RegisterMigration registers the steps that upgrade values from one version
+// of a schema to a later one, both given as versioned references such as
+// app:User@1.0.0. Each version can be migrated from only once.
+func (e *schemaEngineImpl) RegisterMigration(from, to string, steps ...MigrationStep) error {
+	fromRef, err := parseVersionedReference(from)
+	if err != nil {
+		return err
+	}
+	toRef, err := parseVersionedReference(to)
+	if err != nil {
+		return err
+	}
+	if fromRef.Name() != toRef.Name() || fromRef.Namespace() != toRef.Namespace() {
+		return fmt.Errorf("migration from %s to %s must stay within one schema", from, to)
+	}
+	if compareVersions(fromRef.Version(), toRef.Version()) >= 0 {
+		return fmt.Errorf("migration from %s to %s must go to a later version", from, to)
+	}
+
+	e.migrationsMu.Lock()
+	defer e.migrationsMu.Unlock()
+
+	if existing, ok := e.migrations[fromRef.FullName()]; ok {
+		return EngineError{
+			Type:    ErrorTypeMigrationExists,
+			Message: fmt.Sprintf("migration from %s already registered to %s", from, existing.to.FullName()),
+			Details: map[string]any{"from": fromRef.FullName(), "to": existing.to.FullName()},
+		}
+	}
+	e.migrations[fromRef.FullName()] = &migration{to: toRef, steps: steps}
+	return nil
+}
+
+// Upgrade migrates a value written under a versioned schema to the latest
+// registered version of that schema.
+func (e *schemaEngineImpl) Upgrade(from string, value any) (any, string, error) {
+	fromRef, err := parseVersionedReference(from)
+	if err != nil {
+		return nil, "", err
+	}
+
+	e.schemaMu.RLock()
+	versions := e.versionsOf(fromRef)
+	e.schemaMu.RUnlock()
+	if len(versions) == 0 {
+		return nil, "", NewSchemaNotFoundError(fromRef.FullName())
+	}
+
+	latest := versions[len(versions)-1].FullName()
+	result, err := e.Migrate(from, latest, value)
+	return result, latest, err
+}
+
+// Migrate chains the registered migrations from one version of a schema to
+// another and validates the result against the target version.
+func (e *schemaEngineImpl) Migrate(from, to string, value any) (any, error) {
+	fromRef, err := parseVersionedReference(from)
+	if err != nil {
+		return nil, err
+	}
+	toRef, err := parseVersionedReference(to)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := e.ResolveSchema(toRef.FullName())
+	if err != nil {
+		return nil, err
+	}
+
+	value = copyValue(value)
+	current := fromRef
+	for current.FullName() != toRef.FullName() {
+		e.migrationsMu.RLock()
+		m, ok := e.migrations[current.FullName()]
+		e.migrationsMu.RUnlock()
+		if !ok || compareVersions(m.to.Version(), toRef.Version()) > 0 {
+			return nil, EngineError{
+				Type:    ErrorTypeMigrationNotFound,
+				Message: fmt.Sprintf("no migration path from %s to %s", from, to),
+				Details: map[string]any{"from": fromRef.FullName(), "to": toRef.FullName(), "stuck_at": current.FullName()},
+			}
+		}
+
+		for i, step := range m.steps {
+			if value, err = step(value); err != nil {
+				return nil, EngineError{
+					Type:    ErrorTypeMigrationFailed,
+					Message: fmt.Sprintf("migration from %s to %s failed at step %d: %v", current.FullName(), m.to.FullName(), i+1, err),
+					Details: map[string]any{"from": current.FullName(), "to": m.to.FullName(), "step": i + 1},
+				}
+			}
+		}
+		current = m.to
+	}
+
+	result := validation.ValidateValue(target, value)
+	if !result.Valid && len(result.Errors) > 0 {
+		issue := result.Errors[0]
+		return nil, EngineError{
+			Type:    ErrorTypeValidationFailed,
+			Message: fmt.Sprintf("migrated value does not match %s: %s", toRef.FullName(), issue.Message),
+			Details: map[string]any{
+				"schema_name": toRef.FullName(),
+				"value":       value,
+				"path":        issue.Path,
+				"code":        issue.Code,
+				"all_errors":  result.Errors,
+			},
+		}
+	}
+	return value, nil
+}
+
+func parseVersionedReference(name string) (SchemaReference, error) {
+	ref, err := ParseReference(name)
+	if err != nil {
+		return nil, err
+	}
+	if !ref.IsVersioned() {
+		return nil, fmt.Errorf("reference %s has no version", name)
+	}
+	return ref, nil
+}
+
+// copyValue deep-copies the maps and slices of a decoded value so migration
+// steps can modify it without touching the caller's value.
+func copyValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[key] = copyValue(item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = copyValue(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"fmt"
+
+	"defs.dev/schema/core"
+)
+
+// RefSchemaConfig holds the configuration for building a RefSchema.
+type RefSchemaConfig struct {
+	Metadata    core.SchemaMetadata
+	Annotations []core.Annotation
+	Reference   SchemaReference
+	Engine      SchemaEngine
+}
+
+// RefSchema points at a named schema registered with a SchemaEngine.
+// The target is only looked up when Resolve is called, so a schema may refer
+// to itself (directly or through other schemas) before it is registered.
+type RefSchema struct {
+	config RefSchemaConfig
+}
+
+// Ensure RefSchema implements the API interfaces at compile time
+var _ core.Schema = (*RefSchema)(nil)
+var _ core.RefSchema = (*RefSchema)(nil)
+var _ core.Accepter = (*RefSchema)(nil)
+
+// NewRefSchema creates a new RefSchema with the given configuration.
+func NewRefSchema(config RefSchemaConfig) *RefSchema {
+	return &RefSchema{config: config}
+}
+
+// NewRef creates a RefSchema for the named schema, resolved through the given engine.
+func NewRef(engine SchemaEngine, name string) *RefSchema {
+	return NewRefSchema(RefSchemaConfig{
+		Reference: NewReference(name),
+		Engine:    engine,
+	})
+}
+
+// Type returns the schema type constant.
+func (r *RefSchema) Type() core.SchemaType {
+	return core.TypeRef
+}
+
+// Metadata returns the schema metadata.
+func (r *RefSchema) Metadata() core.SchemaMetadata {
+	return r.config.Metadata
+}
+
+// Annotations returns the annotations of the schema.
+func (r *RefSchema) Annotations() []core.Annotation {
+	if r.config.Annotations == nil {
+		return nil
+	}
+	result := make([]core.Annotation, len(r.config.Annotations))
+	copy(result, r.config.Annotations)
+	return result
+}
+
+// Clone returns a deep copy of the RefSchema.
+func (r *RefSchema) Clone() core.Schema {
+	newConfig := r.config
+
+	// Deep copy metadata examples and tags
+	if r.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(r.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, r.config.Metadata.Examples)
+	}
+
+	if r.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(r.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, r.config.Metadata.Tags)
+	}
+
+	// Note: Reference and Engine are shared, the target is resolved on demand
+
+	return NewRefSchema(newConfig)
+}
+
+// Reference returns the reference to the target schema.
+func (r *RefSchema) Reference() SchemaReference {
+	return r.config.Reference
+}
+
+// ReferenceName returns the name of the target schema.
+func (r *RefSchema) ReferenceName() string {
+	if r.config.Reference == nil {
+		return ""
+	}
+	return r.config.Reference.Name()
+}
+
+// Resolve looks up the target schema through the engine.
+func (r *RefSchema) Resolve() (core.Schema, error) {
+	if r.config.Reference == nil {
+		return nil, fmt.Errorf("reference cannot be nil")
+	}
+	if r.config.Engine == nil {
+		return nil, fmt.Errorf("no schema engine to resolve reference %s", r.config.Reference.FullName())
+	}
+	return r.config.Engine.ResolveReference(r.config.Reference)
+}
+
+// Note: Validation moved to consumer-driven architecture.
+// Use schema/consumer.Registry.ProcessValueWithPurpose("validation", schema, value) instead.
+
+// Accept implements the visitor pattern for schema traversal.
+func (r *RefSchema) Accept(visitor core.SchemaVisitor) error {
+	return visitor.VisitRef(r)
+}
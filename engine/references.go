@@ -1,8 +1,11 @@
 package engine
 
 import (
+	"cmp"
 	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 )
 
 // SimpleReference is the default implementation of SchemaReference
@@ -192,6 +195,29 @@ func isValidVersion(s string) bool {
 	return matched
 }
 
+// compareVersions orders versions such as 1.2.0 and 1.10.0. Dot-separated
+// parts are compared as numbers when both are numeric and as strings
+// otherwise; a version that is a prefix of another is lower.
+func compareVersions(a, b string) int {
+	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		numA, errA := strconv.Atoi(partsA[i])
+		numB, errB := strconv.Atoi(partsB[i])
+		switch {
+		case errA == nil && errB == nil:
+			if c := cmp.Compare(numA, numB); c != 0 {
+				return c
+			}
+		default:
+			if c := strings.Compare(partsA[i], partsB[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmp.Compare(len(partsA), len(partsB))
+}
+
 // ReferenceSet provides utilities for working with collections of references
 type ReferenceSet struct {
 	refs map[string]SchemaReference
@@ -0,0 +1,275 @@
+// Package broker provides an in-process publish/subscribe broker for topics
+// described by core.TopicSchema.
+//
+// Publishing validates a message against its topic schema and delivers it to
+// every matching subscription. Each subscription has its own buffer, so slow
+// subscribers either hold up publishers or lose messages, depending on their
+// overflow policy:
+//
+//	b := broker.NewBroker()
+//	orders, _ := b.CreateTopic(orderTopicSchema)
+//	sub, _ := orders.Subscribe(ctx, api.SubscribeOptions{Keys: []any{"eu"}})
+//	orders.Publish(ctx, api.Message{Key: "eu", Payload: order})
+//	msg := <-sub.Messages()
+package broker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"defs.dev/schema/api"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+)
+
+// DefaultBufferSize is the subscription buffer size used when none is given.
+const DefaultBufferSize = 64
+
+// ValidationError is returned when a published message does not match its topic schema.
+type ValidationError struct {
+	Topic  string
+	Result validation.ValidationResult
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Result.Errors))
+	for i, issue := range e.Result.Errors {
+		messages[i] = issue.Message
+		if len(issue.Path) > 0 {
+			messages[i] = strings.Join(issue.Path, ".") + ": " + issue.Message
+		}
+	}
+	return fmt.Sprintf("invalid message for topic %s: %s", e.Topic, strings.Join(messages, "; "))
+}
+
+// Broker implements api.TopicBroker for in-process topics.
+type Broker struct {
+	mu     sync.RWMutex
+	topics map[string]*Topic
+}
+
+// Ensure Broker implements the API interface at compile time
+var _ api.TopicBroker = (*Broker)(nil)
+
+// NewBroker creates a new in-process broker.
+func NewBroker() *Broker {
+	return &Broker{
+		topics: make(map[string]*Topic),
+	}
+}
+
+// CreateTopic creates a topic for the schema, named by the schema name.
+func (b *Broker) CreateTopic(schema core.TopicSchema) (api.Topic, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("topic schema cannot be nil")
+	}
+	name := schema.Name()
+	if name == "" {
+		return nil, fmt.Errorf("topic name cannot be empty")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.topics[name]; exists {
+		return nil, fmt.Errorf("topic %s already exists", name)
+	}
+	topic := &Topic{
+		schema:        schema,
+		validator:     validation.Compile(schema),
+		subscriptions: make(map[*subscription]struct{}),
+	}
+	b.topics[name] = topic
+	return topic, nil
+}
+
+// GetTopic returns the topic with the given name.
+func (b *Broker) GetTopic(name string) (api.Topic, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	topic, exists := b.topics[name]
+	if !exists {
+		return nil, false
+	}
+	return topic, true
+}
+
+// DeleteTopic removes a topic and closes its subscriptions.
+func (b *Broker) DeleteTopic(name string) error {
+	b.mu.Lock()
+	topic, exists := b.topics[name]
+	delete(b.topics, name)
+	b.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("topic %s not found", name)
+	}
+	topic.closeAll()
+	return nil
+}
+
+// List returns the names of all topics.
+func (b *Broker) List() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	names := make([]string, 0, len(b.topics))
+	for name := range b.topics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Count returns the number of topics.
+func (b *Broker) Count() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.topics)
+}
+
+// Exists checks whether a topic exists.
+func (b *Broker) Exists(name string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, exists := b.topics[name]
+	return exists
+}
+
+// Clear removes all topics and closes their subscriptions.
+func (b *Broker) Clear() error {
+	b.mu.Lock()
+	topics := b.topics
+	b.topics = make(map[string]*Topic)
+	b.mu.Unlock()
+
+	for _, topic := range topics {
+		topic.closeAll()
+	}
+	return nil
+}
+
+// Topic is an in-process topic. Messages are delivered to subscriptions in
+// the order they are published.
+type Topic struct {
+	schema    core.TopicSchema
+	validator *validation.Validator
+
+	mu            sync.RWMutex
+	subscriptions map[*subscription]struct{}
+}
+
+// Ensure Topic implements the API interface at compile time
+var _ api.Topic = (*Topic)(nil)
+
+// Name returns the topic name.
+func (t *Topic) Name() string {
+	return t.schema.Name()
+}
+
+// Schema returns the topic schema.
+func (t *Topic) Schema() core.TopicSchema {
+	return t.schema
+}
+
+// Publish validates a message and delivers it to every subscription whose key
+// filter matches. Blocking subscriptions with a full buffer hold up Publish
+// until they catch up or ctx is done.
+func (t *Topic) Publish(ctx context.Context, message api.Message) error {
+	if result := t.validator.Validate(message.ToMap()); !result.Valid {
+		return &ValidationError{Topic: t.Name(), Result: result}
+	}
+
+	t.mu.RLock()
+	subscriptions := make([]*subscription, 0, len(t.subscriptions))
+	for sub := range t.subscriptions {
+		if sub.matches(message) {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+	t.mu.RUnlock()
+
+	for _, sub := range subscriptions {
+		if err := sub.deliver(ctx, message); err != nil {
+			return fmt.Errorf("publishing to %s: %w", t.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Subscribe starts a subscription, which ends when it is closed, when ctx is
+// done or when the topic is deleted.
+func (t *Topic) Subscribe(ctx context.Context, options api.SubscribeOptions) (api.Subscription, error) {
+	switch options.Overflow {
+	case "":
+		options.Overflow = api.OverflowBlock
+	case api.OverflowBlock, api.OverflowDropNewest, api.OverflowDropOldest:
+	default:
+		return nil, fmt.Errorf("unknown overflow policy %q", options.Overflow)
+	}
+	if options.BufferSize <= 0 {
+		options.BufferSize = DefaultBufferSize
+	}
+
+	sub := &subscription{
+		topic:    t,
+		messages: make(chan api.Message, options.BufferSize),
+		done:     make(chan struct{}),
+		overflow: options.Overflow,
+	}
+	if len(options.Keys) > 0 {
+		sub.keys = make(map[string]bool, len(options.Keys))
+		for _, key := range options.Keys {
+			sub.keys[keyString(key)] = true
+		}
+	}
+
+	t.mu.Lock()
+	t.subscriptions[sub] = struct{}{}
+	t.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.Close()
+		case <-sub.done:
+		}
+	}()
+	return sub, nil
+}
+
+// Subscribers returns the number of active subscriptions.
+func (t *Topic) Subscribers() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.subscriptions)
+}
+
+func (t *Topic) remove(sub *subscription) {
+	t.mu.Lock()
+	delete(t.subscriptions, sub)
+	t.mu.Unlock()
+}
+
+func (t *Topic) closeAll() {
+	t.mu.RLock()
+	subscriptions := make([]*subscription, 0, len(t.subscriptions))
+	for sub := range t.subscriptions {
+		subscriptions = append(subscriptions, sub)
+	}
+	t.mu.RUnlock()
+
+	for _, sub := range subscriptions {
+		sub.Close()
+	}
+}
+
+// keyString is the form keys are compared in, so that keys decoded from JSON
+// numbers match Go integers.
+func keyString(key any) string {
+	return fmt.Sprint(key)
+}
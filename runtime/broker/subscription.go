@@ -0,0 +1,98 @@
+package broker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"defs.dev/schema/api"
+)
+
+// subscription implements api.Subscription with a buffered channel.
+type subscription struct {
+	topic    *Topic
+	keys     map[string]bool
+	overflow api.OverflowPolicy
+	dropped  atomic.Int64
+
+	// mu is held for reading while delivering and for writing while closing,
+	// so messages is never sent on after it is closed.
+	mu       sync.RWMutex
+	closed   bool
+	messages chan api.Message
+
+	// done is closed first on Close, releasing blocked publishers.
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Ensure subscription implements the API interface at compile time
+var _ api.Subscription = (*subscription)(nil)
+
+func (s *subscription) Messages() <-chan api.Message {
+	return s.messages
+}
+
+func (s *subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+func (s *subscription) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+
+		s.mu.Lock()
+		s.closed = true
+		close(s.messages)
+		s.mu.Unlock()
+
+		s.topic.remove(s)
+	})
+	return nil
+}
+
+// matches reports whether the key filter accepts a message.
+func (s *subscription) matches(message api.Message) bool {
+	return s.keys == nil || s.keys[keyString(message.Key)]
+}
+
+// deliver hands a message to the subscriber according to the overflow policy.
+// Messages for closed subscriptions are discarded.
+func (s *subscription) deliver(ctx context.Context, message api.Message) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil
+	}
+
+	switch s.overflow {
+	case api.OverflowDropNewest:
+		select {
+		case s.messages <- message:
+		default:
+			s.dropped.Add(1)
+		}
+	case api.OverflowDropOldest:
+		for {
+			select {
+			case s.messages <- message:
+				return nil
+			default:
+			}
+			select {
+			case <-s.messages:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case s.messages <- message:
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
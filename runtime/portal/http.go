@@ -2,6 +2,8 @@ package portal
 
 import (
 	"context"
+	"defs.dev/schema/consume/coercion"
+	"defs.dev/schema/consume/defaults"
 	"defs.dev/schema/consume/validation"
 	registry2 "defs.dev/schema/runtime/registry"
 	"encoding/json"
@@ -35,6 +37,9 @@ type HTTPPortal struct {
 	functions map[string]api.Function
 	schemas   map[string]core.FunctionSchema
 
+	// validators holds the compiled argument validators of each schema
+	validators map[string]*argValidators
+
 	// Client components
 	client *http.Client
 
@@ -70,6 +75,10 @@ type HTTPConfig struct {
 	// Limits
 	MaxRequestSize int64
 	RateLimit      *RateLimitConfig
+
+	// Inputs
+	Coercion      *coercion.Options // convert inputs to their schema types before validation
+	ApplyDefaults bool              // fill schema defaults into absent inputs before validation
 }
 
 // TLSConfig holds TLS configuration.
@@ -106,6 +115,7 @@ func NewHTTPPortal(config *HTTPConfig) *HTTPPortal {
 		mux:             mux,
 		functions:       make(map[string]api.Function),
 		schemas:         make(map[string]core.FunctionSchema),
+		validators:      make(map[string]*argValidators),
 		client: &http.Client{
 			Timeout: config.ClientTimeout,
 		},
@@ -172,6 +182,7 @@ func (h *HTTPPortal) Apply(ctx context.Context, function api.Function) (api.Addr
 	// Also register function directly for HTTP-specific access patterns
 	h.functions[name] = function
 	h.schemas[name] = function.Schema()
+	h.validators[name] = compileArgs(function.Schema())
 
 	// Register HTTP endpoint
 	path := "/functions/" + name
@@ -210,6 +221,7 @@ func (h *HTTPPortal) ApplyService(ctx context.Context, service api.Service) (api
 		// For now, just store the schema
 		// h.functions[functionName] = method
 		h.schemas[functionName] = method.Function()
+		h.validators[functionName] = compileArgs(method.Function())
 
 		// Register HTTP endpoint
 		path := "/services/" + name + "/" + methodName
@@ -383,6 +395,12 @@ func (h *HTTPPortal) Health(ctx context.Context) error {
 	return nil
 }
 
+// Do sends an outgoing HTTP request with the portal's client, so calls to
+// remote endpoints share its configured timeout.
+func (h *HTTPPortal) Do(req *http.Request) (*http.Response, error) {
+	return h.client.Do(req)
+}
+
 // GetFunctionRegistry returns the underlying function registry
 func (h *HTTPPortal) GetFunctionRegistry() api.FunctionRegistry {
 	return h.funcRegistry
@@ -446,6 +464,7 @@ func (h *HTTPPortal) handleFunctionCall(w http.ResponseWriter, r *http.Request)
 	h.mu.RLock()
 	function, exists := h.functions[functionName]
 	schema, hasSchema := h.schemas[functionName]
+	validators := h.validators[functionName]
 	h.mu.RUnlock()
 
 	if !exists {
@@ -460,12 +479,37 @@ func (h *HTTPPortal) handleFunctionCall(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Convert loosely typed inputs
+	if hasSchema && h.config.Coercion != nil {
+		result, err := coercion.Coerce(schema, requestData, *h.config.Coercion)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Coercion error: %v", err), http.StatusBadRequest)
+			return
+		}
+		if coerced, ok := result.Value.(map[string]any); ok {
+			requestData = coerced
+		}
+	}
+
+	// Fill in defaults of absent inputs
+	if hasSchema && h.config.ApplyDefaults {
+		if requestData == nil {
+			requestData = make(map[string]any)
+		}
+		value, err := defaults.Apply(schema, requestData)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Defaults error: %v", err), http.StatusBadRequest)
+			return
+		}
+		requestData = value.(map[string]any)
+	}
+
 	// Create function input
 	input := api.NewFunctionData(requestData)
 
 	// Validate input if schema is available
 	if hasSchema {
-		if err := h.validateInput(input, schema); err != nil {
+		if err := h.validateInput(input, schema, validators); err != nil {
 			http.Error(w, fmt.Sprintf("Validation error: %v", err), http.StatusBadRequest)
 			return
 		}
@@ -481,7 +525,7 @@ func (h *HTTPPortal) handleFunctionCall(w http.ResponseWriter, r *http.Request)
 
 	// Validate output if schema is available
 	if hasSchema {
-		if err := h.validateOutput(output, schema); err != nil {
+		if err := h.validateOutput(output, schema, validators); err != nil {
 			http.Error(w, fmt.Sprintf("Output validation error: %v", err), http.StatusInternalServerError)
 			return
 		}
@@ -528,7 +572,40 @@ func (h *HTTPPortal) extractServiceName(path string) string {
 	return path
 }
 
-func (h *HTTPPortal) validateInput(input api.FunctionData, schema core.FunctionSchema) error {
+// argValidators holds the compiled validators of the inputs and outputs of a
+// function schema, by argument name.
+type argValidators struct {
+	inputs  map[string]*validation.Validator
+	outputs map[string]*validation.Validator
+}
+
+// compileArgs compiles the argument schemas of a function schema.
+func compileArgs(schema core.FunctionSchema) *argValidators {
+	validators := &argValidators{
+		inputs:  make(map[string]*validation.Validator),
+		outputs: make(map[string]*validation.Validator),
+	}
+	if schema == nil {
+		return validators
+	}
+	for _, arg := range schema.Inputs().Args() {
+		validators.inputs[arg.Name()] = validation.Compile(arg.Schema())
+	}
+	for _, arg := range schema.Outputs().Args() {
+		validators.outputs[arg.Name()] = validation.Compile(arg.Schema())
+	}
+	return validators
+}
+
+// validateArg validates the value of an argument with its compiled validator.
+func validateArg(validators map[string]*validation.Validator, name string, schema core.Schema, value any) validation.ValidationResult {
+	if validator, ok := validators[name]; ok {
+		return validator.Validate(value)
+	}
+	return validation.ValidateValue(schema, value)
+}
+
+func (h *HTTPPortal) validateInput(input api.FunctionData, schema core.FunctionSchema, validators *argValidators) error {
 	// Validate each input parameter against its schema
 	inputMap := input.ToMap()
 	inputs := schema.Inputs()
@@ -542,7 +619,7 @@ func (h *HTTPPortal) validateInput(input api.FunctionData, schema core.FunctionS
 
 		if value, exists := inputMap[inputName]; exists {
 			// Validate the input value against its schema
-			result := validation.ValidateValue(inputSchema, value)
+			result := validateArg(validators.inputs, inputName, inputSchema, value)
 			if !result.Valid {
 				for _, issue := range result.Errors {
 					pathStr := inputName
@@ -564,7 +641,7 @@ func (h *HTTPPortal) validateInput(input api.FunctionData, schema core.FunctionS
 	return nil
 }
 
-func (h *HTTPPortal) validateOutput(output api.FunctionData, schema core.FunctionSchema) error {
+func (h *HTTPPortal) validateOutput(output api.FunctionData, schema core.FunctionSchema, validators *argValidators) error {
 	// Validate each output parameter against its schema
 	outputMap := output.ToMap()
 	outputs := schema.Outputs()
@@ -578,7 +655,7 @@ func (h *HTTPPortal) validateOutput(output api.FunctionData, schema core.Functio
 
 		if value, exists := outputMap[outputName]; exists {
 			// Validate the output value against its schema
-			result := validation.ValidateValue(outputSchema, value)
+			result := validateArg(validators.outputs, outputName, outputSchema, value)
 			if !result.Valid {
 				for _, issue := range result.Errors {
 					pathStr := outputName
@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
 	"defs.dev/schema/api"
+	"defs.dev/schema/runtime/broker"
 	"github.com/gorilla/websocket"
 )
 
@@ -20,6 +22,9 @@ type WebSocketPortal struct {
 	funcRegistry    api.FunctionRegistry
 	serviceRegistry api.ServiceRegistry
 
+	// Topics exposed for publishing and subscribing
+	topics map[string]api.Topic
+
 	// WebSocket-specific fields (transport concerns only)
 	config      *WebSocketConfig
 	connections map[string]*websocket.Conn
@@ -81,6 +86,7 @@ func NewWebSocketPortal(config *WebSocketConfig, funcRegistry api.FunctionRegist
 	portal := &WebSocketPortal{
 		funcRegistry:    funcRegistry,
 		serviceRegistry: serviceRegistry,
+		topics:          make(map[string]api.Topic),
 		config:          config,
 		connections:     make(map[string]*websocket.Conn),
 		upgrader: websocket.Upgrader{
@@ -140,6 +146,28 @@ func (p *WebSocketPortal) ApplyService(ctx context.Context, service api.Service)
 	return address, nil
 }
 
+// ApplyTopic makes a topic available for publishing and subscribing via WebSocket
+func (p *WebSocketPortal) ApplyTopic(ctx context.Context, topic api.Topic) (api.Address, error) {
+	if topic == nil {
+		return nil, fmt.Errorf("topic cannot be nil")
+	}
+
+	name := topic.Name()
+	if name == "" {
+		return nil, fmt.Errorf("topic name cannot be empty")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.topics[name]; exists {
+		return nil, fmt.Errorf("topic already applied: %s", name)
+	}
+	p.topics[name] = topic
+
+	return p.generateTopicAddress(name), nil
+}
+
 // ResolveFunction resolves an address to a callable function
 func (p *WebSocketPortal) ResolveFunction(ctx context.Context, address api.Address) (api.Function, error) {
 	if address.Scheme() != "ws" && address.Scheme() != "wss" {
@@ -283,14 +311,17 @@ func (p *WebSocketPortal) GetServiceRegistry() api.ServiceRegistry {
 
 // WebSocket message types
 type WSMessage struct {
-	Type      string         `json:"type"`
-	ID        string         `json:"id,omitempty"`
-	Function  string         `json:"function,omitempty"`
-	Service   string         `json:"service,omitempty"`
-	Method    string         `json:"method,omitempty"`
-	Data      map[string]any `json:"data,omitempty"`
-	Error     string         `json:"error,omitempty"`
-	Timestamp int64          `json:"timestamp,omitempty"`
+	Type      string                `json:"type"`
+	ID        string                `json:"id,omitempty"`
+	Function  string                `json:"function,omitempty"`
+	Service   string                `json:"service,omitempty"`
+	Method    string                `json:"method,omitempty"`
+	Topic     string                `json:"topic,omitempty"`
+	Message   *api.Message          `json:"message,omitempty"`
+	Options   *api.SubscribeOptions `json:"options,omitempty"`
+	Data      map[string]any        `json:"data,omitempty"`
+	Error     string                `json:"error,omitempty"`
+	Timestamp int64                 `json:"timestamp,omitempty"`
 }
 
 // WebSocket message types
@@ -300,6 +331,13 @@ const (
 	WSMsgTypeError    = "error"
 	WSMsgTypePing     = "ping"
 	WSMsgTypePong     = "pong"
+
+	// Topics: subscriptions are identified by the ID of their subscribe message,
+	// and every delivered message is an event carrying that ID
+	WSMsgTypePublish     = "publish"
+	WSMsgTypeSubscribe   = "subscribe"
+	WSMsgTypeUnsubscribe = "unsubscribe"
+	WSMsgTypeEvent       = "event"
 )
 
 // handleWebSocket handles WebSocket connections
@@ -338,11 +376,17 @@ func (p *WebSocketPortal) handleWebSocket(w http.ResponseWriter, r *http.Request
 	defer ticker.Stop()
 
 	// Handle messages
-	go p.handleConnection(conn, connID)
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		p.handleConnection(conn, connID)
+	}()
 
 	// Send pings
 	for {
 		select {
+		case <-closed:
+			return
 		case <-ticker.C:
 			conn.SetWriteDeadline(time.Now().Add(p.config.WriteTimeout))
 			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
@@ -354,6 +398,15 @@ func (p *WebSocketPortal) handleWebSocket(w http.ResponseWriter, r *http.Request
 
 // handleConnection handles messages from a WebSocket connection
 func (p *WebSocketPortal) handleConnection(conn *websocket.Conn, connID string) {
+	// Topic subscriptions live as long as the connection
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	session := &wsSession{
+		conn:          conn,
+		writeTimeout:  p.config.WriteTimeout,
+		subscriptions: make(map[string]api.Subscription),
+	}
+
 	for {
 		var msg WSMessage
 		err := conn.ReadJSON(&msg)
@@ -364,21 +417,104 @@ func (p *WebSocketPortal) handleConnection(conn *websocket.Conn, connID string)
 			break
 		}
 
-		response := p.processMessage(msg)
+		var response *WSMessage
+		switch msg.Type {
+		case WSMsgTypeSubscribe:
+			response = p.handleSubscribe(ctx, session, msg)
+		case WSMsgTypeUnsubscribe:
+			response = session.unsubscribe(msg)
+		default:
+			response = p.processMessage(msg)
+		}
 		if response != nil {
-			conn.SetWriteDeadline(time.Now().Add(p.config.WriteTimeout))
-			if err := conn.WriteJSON(response); err != nil {
+			if err := session.send(response); err != nil {
 				break
 			}
 		}
 	}
 }
 
+// wsSession holds the topic subscriptions of one connection. Responses and
+// events are written through it so that only one goroutine writes at a time.
+type wsSession struct {
+	conn          *websocket.Conn
+	writeTimeout  time.Duration
+	writeMu       sync.Mutex
+	subscriptions map[string]api.Subscription
+	subMu         sync.Mutex
+}
+
+func (s *wsSession) send(msg *WSMessage) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.conn.SetWriteDeadline(time.Now().Add(s.writeTimeout))
+	return s.conn.WriteJSON(msg)
+}
+
+// unsubscribe closes the subscription started by the subscribe message with the same ID.
+func (s *wsSession) unsubscribe(msg WSMessage) *WSMessage {
+	s.subMu.Lock()
+	sub, exists := s.subscriptions[msg.ID]
+	delete(s.subscriptions, msg.ID)
+	s.subMu.Unlock()
+
+	if !exists {
+		return &WSMessage{Type: WSMsgTypeError, ID: msg.ID, Error: fmt.Sprintf("subscription not found: %s", msg.ID), Timestamp: time.Now().Unix()}
+	}
+	sub.Close()
+	return &WSMessage{Type: WSMsgTypeResponse, ID: msg.ID, Timestamp: time.Now().Unix()}
+}
+
+// handleSubscribe subscribes the connection to a topic and forwards its
+// messages as events until the subscription or the connection ends.
+func (p *WebSocketPortal) handleSubscribe(ctx context.Context, session *wsSession, msg WSMessage) *WSMessage {
+	p.mu.RLock()
+	topic, exists := p.topics[msg.Topic]
+	p.mu.RUnlock()
+	if !exists {
+		return &WSMessage{Type: WSMsgTypeError, ID: msg.ID, Error: fmt.Sprintf("topic not found: %s", msg.Topic), Timestamp: time.Now().Unix()}
+	}
+
+	session.subMu.Lock()
+	defer session.subMu.Unlock()
+	if msg.ID == "" || session.subscriptions[msg.ID] != nil {
+		return &WSMessage{Type: WSMsgTypeError, ID: msg.ID, Error: "subscribe messages need a unique id", Timestamp: time.Now().Unix()}
+	}
+
+	var options api.SubscribeOptions
+	if msg.Options != nil {
+		options = *msg.Options
+	}
+	sub, err := topic.Subscribe(ctx, options)
+	if err != nil {
+		return &WSMessage{Type: WSMsgTypeError, ID: msg.ID, Error: err.Error(), Timestamp: time.Now().Unix()}
+	}
+	session.subscriptions[msg.ID] = sub
+
+	// Confirm before the first event is sent
+	if err := session.send(&WSMessage{Type: WSMsgTypeResponse, ID: msg.ID, Topic: msg.Topic, Timestamp: time.Now().Unix()}); err != nil {
+		sub.Close()
+		return nil
+	}
+	go func() {
+		for message := range sub.Messages() {
+			event := &WSMessage{Type: WSMsgTypeEvent, ID: msg.ID, Topic: msg.Topic, Message: &message, Timestamp: time.Now().Unix()}
+			if err := session.send(event); err != nil {
+				sub.Close()
+			}
+		}
+	}()
+	return nil
+}
+
 // processMessage processes a WebSocket message and returns a response
 func (p *WebSocketPortal) processMessage(msg WSMessage) *WSMessage {
 	switch msg.Type {
 	case WSMsgTypeCall:
 		return p.handleFunctionCall(msg)
+	case WSMsgTypePublish:
+		return p.handlePublish(msg)
 	case WSMsgTypePing:
 		return &WSMessage{
 			Type:      WSMsgTypePong,
@@ -465,6 +601,35 @@ func (p *WebSocketPortal) handleFunctionCall(msg WSMessage) *WSMessage {
 	return response
 }
 
+// handlePublish publishes a message to a topic
+func (p *WebSocketPortal) handlePublish(msg WSMessage) *WSMessage {
+	p.mu.RLock()
+	topic, exists := p.topics[msg.Topic]
+	p.mu.RUnlock()
+
+	response := &WSMessage{
+		Type:      WSMsgTypeResponse,
+		ID:        msg.ID,
+		Topic:     msg.Topic,
+		Timestamp: time.Now().Unix(),
+	}
+
+	switch {
+	case !exists:
+		response.Type = WSMsgTypeError
+		response.Error = fmt.Sprintf("topic not found: %s", msg.Topic)
+	case msg.Message == nil:
+		response.Type = WSMsgTypeError
+		response.Error = "publish message has no message"
+	default:
+		if err := topic.Publish(context.Background(), *msg.Message); err != nil {
+			response.Type = WSMsgTypeError
+			response.Error = err.Error()
+		}
+	}
+	return response
+}
+
 // handleHealth handles health check requests
 func (p *WebSocketPortal) handleHealth(w http.ResponseWriter, r *http.Request) {
 	p.mu.RLock()
@@ -476,6 +641,7 @@ func (p *WebSocketPortal) handleHealth(w http.ResponseWriter, r *http.Request) {
 		"timestamp":   time.Now().Unix(),
 		"functions":   p.funcRegistry.Count(),
 		"services":    p.serviceRegistry.Count(),
+		"topics":      len(p.topics),
 		"connections": len(p.connections),
 	})
 }
@@ -510,6 +676,20 @@ func (p *WebSocketPortal) generateServiceAddress(name string) api.Address {
 		Build()
 }
 
+func (p *WebSocketPortal) generateTopicAddress(name string) api.Address {
+	scheme := "ws"
+	if p.config.Port == 443 {
+		scheme = "wss"
+	}
+
+	return NewAddressBuilder().
+		Scheme(scheme).
+		Host(p.config.Host).
+		Port(p.config.Port).
+		Path(fmt.Sprintf("/topic/%s", name)).
+		Build()
+}
+
 // WebSocket client functionality
 
 // CallFunction calls a remote function via WebSocket
@@ -570,6 +750,146 @@ func (p *WebSocketPortal) CallFunction(ctx context.Context, address api.Address,
 	return api.NewFunctionDataValue(response.Data), nil
 }
 
+// PublishTopic publishes a message to a topic exposed by a remote WebSocket portal
+func (p *WebSocketPortal) PublishTopic(ctx context.Context, address api.Address, message api.Message) error {
+	conn, topicName, err := p.dialTopic(ctx, address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	msg := WSMessage{
+		Type:      WSMsgTypePublish,
+		ID:        fmt.Sprintf("publish_%d", time.Now().UnixNano()),
+		Topic:     topicName,
+		Message:   &message,
+		Timestamp: time.Now().Unix(),
+	}
+	if err := conn.WriteJSON(msg); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	var response WSMessage
+	if err := conn.ReadJSON(&response); err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if response.Type == WSMsgTypeError {
+		return fmt.Errorf("remote error: %s", response.Error)
+	}
+	return nil
+}
+
+// SubscribeTopic subscribes to a topic exposed by a remote WebSocket portal.
+// The options are applied by the remote portal; messages it drops are not
+// counted locally.
+func (p *WebSocketPortal) SubscribeTopic(ctx context.Context, address api.Address, options api.SubscribeOptions) (api.Subscription, error) {
+	conn, topicName, err := p.dialTopic(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := WSMessage{
+		Type:      WSMsgTypeSubscribe,
+		ID:        fmt.Sprintf("subscribe_%d", time.Now().UnixNano()),
+		Topic:     topicName,
+		Options:   &options,
+		Timestamp: time.Now().Unix(),
+	}
+	if err := conn.WriteJSON(msg); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	var response WSMessage
+	if err := conn.ReadJSON(&response); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if response.Type == WSMsgTypeError {
+		conn.Close()
+		return nil, fmt.Errorf("remote error: %s", response.Error)
+	}
+
+	bufferSize := options.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = broker.DefaultBufferSize
+	}
+	sub := &wsSubscription{
+		conn:     conn,
+		messages: make(chan api.Message, bufferSize),
+		done:     make(chan struct{}),
+	}
+	go sub.receive(msg.ID)
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.Close()
+		case <-sub.done:
+		}
+	}()
+	return sub, nil
+}
+
+// dialTopic connects to the portal serving a topic address and returns the topic name.
+func (p *WebSocketPortal) dialTopic(ctx context.Context, address api.Address) (*websocket.Conn, string, error) {
+	topicName, found := strings.CutPrefix(address.Path(), "/topic/")
+	if !found || topicName == "" {
+		return nil, "", fmt.Errorf("invalid topic address: %s", address.String())
+	}
+
+	endpoint := fmt.Sprintf("%s://%s%s", address.Scheme(), address.Authority(), p.config.Path)
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to connect: %w", err)
+	}
+	return conn, topicName, nil
+}
+
+// wsSubscription implements api.Subscription for a topic on a remote portal.
+type wsSubscription struct {
+	conn      *websocket.Conn
+	messages  chan api.Message
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (s *wsSubscription) Messages() <-chan api.Message {
+	return s.messages
+}
+
+func (s *wsSubscription) Dropped() int64 {
+	return 0
+}
+
+func (s *wsSubscription) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.conn.Close()
+	})
+	return nil
+}
+
+// receive forwards events for the subscription until the connection ends.
+func (s *wsSubscription) receive(id string) {
+	defer close(s.messages)
+	defer s.Close()
+
+	for {
+		var msg WSMessage
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		if msg.Type != WSMsgTypeEvent || msg.ID != id || msg.Message == nil {
+			continue
+		}
+		select {
+		case s.messages <- *msg.Message:
+		case <-s.done:
+			return
+		}
+	}
+}
+
 // Stats returns statistics about the WebSocket portal
 func (p *WebSocketPortal) Stats() WebSocketPortalStats {
 	p.mu.RLock()
@@ -0,0 +1,400 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"defs.dev/schema/api"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/structure"
+)
+
+// ComponentRegistry wires components together and runs them.
+// A required service is satisfied by a service another component provides
+// whose schema is compatible with the requirement: it offers at least the
+// required methods and accepts at least the inputs the requirement allows.
+type ComponentRegistry struct {
+	mu         sync.RWMutex
+	components map[string]api.Component
+
+	// started lists the services started by Start, in start order
+	started []api.Service
+	// starting is set while Start runs component callbacks without mu
+	starting bool
+}
+
+// Ensure ComponentRegistry implements the API interface at compile time
+var _ api.ComponentRegistry = (*ComponentRegistry)(nil)
+
+// NewComponentRegistry creates a new component registry.
+func NewComponentRegistry() *ComponentRegistry {
+	return &ComponentRegistry{
+		components: make(map[string]api.Component),
+	}
+}
+
+// Register adds a component after checking that it provides a service for
+// every provided service schema and that its configuration is valid.
+func (r *ComponentRegistry) Register(component api.Component) error {
+	if component == nil {
+		return fmt.Errorf("component cannot be nil")
+	}
+	schema := component.Schema()
+	if schema == nil {
+		return fmt.Errorf("component schema cannot be nil")
+	}
+	name := component.Name()
+	if name == "" {
+		return fmt.Errorf("component name cannot be empty")
+	}
+
+	for _, provided := range schema.Provides() {
+		if providedService(component, provided.Name()) == nil {
+			return fmt.Errorf("component %s does not implement provided service %s", name, provided.Name())
+		}
+	}
+	if schema.Config() != nil {
+		if result := validation.ValidateValue(schema.Config(), component.Config()); !result.Valid {
+			messages := make([]string, len(result.Errors))
+			for i, issue := range result.Errors {
+				messages[i] = issue.Message
+				if len(issue.Path) > 0 {
+					messages[i] = strings.Join(issue.Path, ".") + ": " + issue.Message
+				}
+			}
+			return fmt.Errorf("invalid config for component %s: %s", name, strings.Join(messages, "; "))
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.components[name]; exists {
+		return fmt.Errorf("component %s already registered", name)
+	}
+	r.components[name] = component
+	return nil
+}
+
+// Get retrieves a component by name.
+func (r *ComponentRegistry) Get(name string) (api.Component, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	component, exists := r.components[name]
+	return component, exists
+}
+
+// Unregister removes a component. Components cannot be removed while running.
+func (r *ComponentRegistry) Unregister(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started != nil || r.starting {
+		return fmt.Errorf("cannot unregister component %s while components are running", name)
+	}
+	if _, exists := r.components[name]; !exists {
+		return fmt.Errorf("component %s not found", name)
+	}
+	delete(r.components, name)
+	return nil
+}
+
+// Wire resolves every required service to a provided one. When several
+// provided services are compatible, the one with the required name wins.
+func (r *ComponentRegistry) Wire() ([]api.ComponentBinding, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.wire()
+}
+
+// StartOrder returns component names in dependency order, providers first.
+// Independent components are ordered by name.
+func (r *ComponentRegistry) StartOrder() ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	bindings, err := r.wire()
+	if err != nil {
+		return nil, err
+	}
+	return r.order(bindings)
+}
+
+// Start binds the required services of every component and starts the
+// provided services in dependency order. If a service fails to start, the
+// services already started are stopped again. Components are bound and
+// started without holding the registry lock, so callbacks may use it.
+func (r *ComponentRegistry) Start(ctx context.Context) error {
+	r.mu.Lock()
+	snapshot, err := r.startSnapshot()
+	if err != nil {
+		r.mu.Unlock()
+		return err
+	}
+	r.starting = true
+	r.mu.Unlock()
+
+	started, err := snapshot.start(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.starting = false
+	if err != nil {
+		return err
+	}
+	r.started = started
+	return nil
+}
+
+// startSnapshot captures what Start needs from the registry; the caller holds mu.
+func (r *ComponentRegistry) startSnapshot() (*startSnapshot, error) {
+	if r.started != nil || r.starting {
+		return nil, fmt.Errorf("components are already running")
+	}
+	bindings, err := r.wire()
+	if err != nil {
+		return nil, err
+	}
+	order, err := r.order(bindings)
+	if err != nil {
+		return nil, err
+	}
+	components := make(map[string]api.Component, len(r.components))
+	for name, component := range r.components {
+		components[name] = component
+	}
+	return &startSnapshot{components: components, bindings: bindings, order: order}, nil
+}
+
+// startSnapshot holds the components, bindings and start order of one Start.
+type startSnapshot struct {
+	components map[string]api.Component
+	bindings   []api.ComponentBinding
+	order      []string
+}
+
+// start binds and starts the components in order.
+func (s *startSnapshot) start(ctx context.Context) ([]api.Service, error) {
+	started := []api.Service{}
+	for _, name := range s.order {
+		component := s.components[name]
+		for _, binding := range s.bindings {
+			if binding.Component != name {
+				continue
+			}
+			provider := providedService(s.components[binding.Provider], binding.Service)
+			if err := component.Bind(binding.Required, provider); err != nil {
+				stopAll(ctx, started)
+				return nil, fmt.Errorf("binding %s of component %s: %w", binding.Required, name, err)
+			}
+		}
+		for _, service := range component.Services() {
+			if err := service.Start(ctx); err != nil {
+				stopAll(ctx, started)
+				return nil, fmt.Errorf("starting service %s of component %s: %w", service.Name(), name, err)
+			}
+			started = append(started, service)
+		}
+	}
+	return started, nil
+}
+
+// Stop stops the services started by Start in reverse order. Services are
+// stopped without holding the registry lock.
+func (r *ComponentRegistry) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	started := r.started
+	r.started = nil
+	r.mu.Unlock()
+
+	if started == nil {
+		return fmt.Errorf("components are not running")
+	}
+	return stopAll(ctx, started)
+}
+
+// Running reports whether the components have been started.
+func (r *ComponentRegistry) Running() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.started != nil
+}
+
+// List returns the names of all registered components.
+func (r *ComponentRegistry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.components))
+	for name := range r.components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Count returns the number of registered components.
+func (r *ComponentRegistry) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.components)
+}
+
+// Exists checks whether a component is registered.
+func (r *ComponentRegistry) Exists(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, exists := r.components[name]
+	return exists
+}
+
+// Clear removes all components. Components cannot be removed while running.
+func (r *ComponentRegistry) Clear() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started != nil || r.starting {
+		return fmt.Errorf("cannot clear components while they are running")
+	}
+	r.components = make(map[string]api.Component)
+	return nil
+}
+
+// wire resolves requirements; the caller holds mu.
+func (r *ComponentRegistry) wire() ([]api.ComponentBinding, error) {
+	var bindings []api.ComponentBinding
+	for _, name := range r.sortedNames() {
+		for _, required := range r.components[name].Schema().Requires() {
+			binding, err := r.resolve(name, required)
+			if err != nil {
+				return nil, err
+			}
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings, nil
+}
+
+// resolve finds the provider of a required service among the other components.
+func (r *ComponentRegistry) resolve(name string, required core.ServiceSchema) (api.ComponentBinding, error) {
+	var candidates, named []api.ComponentBinding
+	for _, providerName := range r.sortedNames() {
+		if providerName == name {
+			continue
+		}
+		for _, provided := range r.components[providerName].Schema().Provides() {
+			if !structure.Diff(required, provided).Compatibility().Satisfies(structure.BackwardCompatible) {
+				continue
+			}
+			binding := api.ComponentBinding{
+				Component: name,
+				Required:  required.Name(),
+				Provider:  providerName,
+				Service:   provided.Name(),
+			}
+			candidates = append(candidates, binding)
+			if provided.Name() == required.Name() {
+				named = append(named, binding)
+			}
+		}
+	}
+
+	switch {
+	case len(candidates) == 1:
+		return candidates[0], nil
+	case len(named) == 1:
+		return named[0], nil
+	case len(candidates) == 0:
+		return api.ComponentBinding{}, fmt.Errorf("no provider for service %s required by component %s", required.Name(), name)
+	default:
+		providers := make([]string, len(candidates))
+		for i, candidate := range candidates {
+			providers[i] = candidate.Provider + "." + candidate.Service
+		}
+		return api.ComponentBinding{}, fmt.Errorf("service %s required by component %s has several providers: %s",
+			required.Name(), name, strings.Join(providers, ", "))
+	}
+}
+
+// order sorts components topologically so that providers come before the
+// components requiring them; the caller holds mu.
+func (r *ComponentRegistry) order(bindings []api.ComponentBinding) ([]string, error) {
+	dependents := make(map[string][]string)
+	pending := make(map[string]int, len(r.components))
+	for name := range r.components {
+		pending[name] = 0
+	}
+	for _, binding := range bindings {
+		dependents[binding.Provider] = append(dependents[binding.Provider], binding.Component)
+		pending[binding.Component]++
+	}
+
+	var ready []string
+	for name, count := range pending {
+		if count == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	order := make([]string, 0, len(r.components))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, name)
+		for _, dependent := range dependents[name] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) < len(r.components) {
+		var cycle []string
+		for name, count := range pending {
+			if count > 0 {
+				cycle = append(cycle, name)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("dependency cycle between components: %s", strings.Join(cycle, ", "))
+	}
+	return order, nil
+}
+
+func (r *ComponentRegistry) sortedNames() []string {
+	names := make([]string, 0, len(r.components))
+	for name := range r.components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// providedService returns the service of a component with the given name.
+func providedService(component api.Component, name string) api.Service {
+	for _, service := range component.Services() {
+		if service.Name() == name {
+			return service
+		}
+	}
+	return nil
+}
+
+// stopAll stops services in reverse order and reports every failure.
+func stopAll(ctx context.Context, services []api.Service) error {
+	var errs []error
+	for i := len(services) - 1; i >= 0; i-- {
+		if err := services[i].Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stopping service %s: %w", services[i].Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
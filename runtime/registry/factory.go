@@ -27,6 +27,11 @@ func (f *DefaultFactory) CreateServiceRegistry() api.ServiceRegistry {
 	return NewServiceRegistry()
 }
 
+// CreateComponentRegistry creates a new component registry instance.
+func (f *DefaultFactory) CreateComponentRegistry() api.ComponentRegistry {
+	return NewComponentRegistry()
+}
+
 // CreateConsumer creates a new consumer instance.
 func (f *DefaultFactory) CreateConsumer() api.Consumer {
 	return NewConsumer()
@@ -44,6 +49,10 @@ func CreateServiceRegistry() api.ServiceRegistry {
 	return DefaultRegistryFactory.CreateServiceRegistry()
 }
 
+func CreateComponentRegistry() api.ComponentRegistry {
+	return DefaultRegistryFactory.CreateComponentRegistry()
+}
+
 func CreateConsumer() api.Consumer {
 	return DefaultRegistryFactory.CreateConsumer()
 }
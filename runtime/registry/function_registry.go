@@ -2,6 +2,7 @@ package registry
 
 import (
 	"context"
+	"defs.dev/schema/consume/defaults"
 	"defs.dev/schema/consume/validation"
 	"fmt"
 	"sync"
@@ -16,6 +17,12 @@ type FunctionRegistry struct {
 	mu        sync.RWMutex
 	functions map[string]api.Function
 	metadata  map[string]FunctionMetadata
+
+	// validators holds the compiled validators of function schemas
+	validators map[string]*validation.Validator
+
+	// applyDefaults fills schema defaults into call parameters
+	applyDefaults bool
 }
 
 // FunctionMetadata holds additional metadata about registered functions.
@@ -32,8 +39,9 @@ var _ api.FunctionRegistry = (*FunctionRegistry)(nil)
 // NewFunctionRegistry creates a new thread-safe function registry.
 func NewFunctionRegistry() *FunctionRegistry {
 	return &FunctionRegistry{
-		functions: make(map[string]api.Function),
-		metadata:  make(map[string]FunctionMetadata),
+		functions:  make(map[string]api.Function),
+		metadata:   make(map[string]FunctionMetadata),
+		validators: make(map[string]*validation.Validator),
 	}
 }
 
@@ -52,6 +60,10 @@ func (r *FunctionRegistry) Register(name string, fn api.Function) error {
 	defer r.mu.Unlock()
 
 	r.functions[name] = fn
+	delete(r.validators, name)
+	if schema := fn.Schema(); schema != nil {
+		r.validators[name] = validation.Compile(schema)
+	}
 	r.metadata[name] = FunctionMetadata{
 		RegisteredAt: getCurrentTimestamp(),
 		Tags:         []string{},
@@ -112,6 +124,7 @@ func (r *FunctionRegistry) Unregister(name string) error {
 
 	delete(r.functions, name)
 	delete(r.metadata, name)
+	delete(r.validators, name)
 
 	return nil
 }
@@ -123,26 +136,33 @@ func (r *FunctionRegistry) Clear() error {
 
 	r.functions = make(map[string]api.Function)
 	r.metadata = make(map[string]FunctionMetadata)
+	r.validators = make(map[string]*validation.Validator)
 
 	return nil
 }
 
 // Validation methods
 
-// Validate validates input parameters for a function.
+// Validate validates input parameters for a function against its schema,
+// which is compiled once when the function is registered.
 func (r *FunctionRegistry) Validate(name string, input any) validation.ValidationResult {
 	r.mu.RLock()
 	_, exists := r.functions[name]
+	validator := r.validators[name]
 	r.mu.RUnlock()
 
 	if !exists {
 		return validation.NewValidationError([]string{}, "function_not_found",
 			fmt.Sprintf("function %s not found", name))
 	}
+	if validator == nil {
+		return validation.NewValidationResult()
+	}
 
-	// Note: Function schema validation moved to consumer-driven architecture.
-	// For now, return a valid result as validation is handled by consumers.
-	return validation.NewValidationResult()
+	if data, ok := input.(api.FunctionData); ok {
+		input = data.ToMap()
+	}
+	return validator.Validate(input)
 }
 
 // Execution methods
@@ -154,6 +174,13 @@ func (r *FunctionRegistry) Call(ctx context.Context, name string, params api.Fun
 		return nil, fmt.Errorf("function %s not found", name)
 	}
 
+	if r.defaultsEnabled() {
+		var err error
+		if params, err = applyDefaults(fn.Schema(), params); err != nil {
+			return nil, fmt.Errorf("applying defaults for function %s: %w", name, err)
+		}
+	}
+
 	// Note: Input validation moved to consumer-driven architecture.
 	// Execute function directly.
 	output, err := fn.Call(ctx, params)
@@ -165,6 +192,41 @@ func (r *FunctionRegistry) Call(ctx context.Context, name string, params api.Fun
 	return output, nil
 }
 
+// SetApplyDefaults enables or disables filling schema defaults into the
+// parameters of Call for inputs that are absent, before the function runs.
+func (r *FunctionRegistry) SetApplyDefaults(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.applyDefaults = enabled
+}
+
+func (r *FunctionRegistry) defaultsEnabled() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.applyDefaults
+}
+
+// applyDefaults returns parameters with the input defaults of a function schema filled in.
+func applyDefaults(schema core.FunctionSchema, params api.FunctionData) (api.FunctionData, error) {
+	if schema == nil {
+		return params, nil
+	}
+	var input map[string]any
+	if params != nil {
+		input = params.ToMap()
+	}
+	if input == nil {
+		input = make(map[string]any)
+	}
+	value, err := defaults.Apply(schema, input)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewFunctionDataValue(value), nil
+}
+
 // CallTyped executes a typed function with type-safe input and output (deprecated).
 func (r *FunctionRegistry) CallTyped(ctx context.Context, name string, input any, output any) error {
 	fn, exists := r.Get(name)
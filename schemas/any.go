@@ -0,0 +1,73 @@
+package schemas
+
+import (
+	"defs.dev/schema/core"
+)
+
+// AnySchemaConfig holds the configuration for building an AnySchema.
+type AnySchemaConfig struct {
+	Metadata    core.SchemaMetadata
+	Annotations []core.Annotation
+}
+
+// AnySchema accepts every value, including null. It describes dynamically
+// typed values such as Go interface{} fields.
+type AnySchema struct {
+	config AnySchemaConfig
+}
+
+// Ensure AnySchema implements the API interfaces at compile time
+var _ core.Schema = (*AnySchema)(nil)
+var _ core.AnySchema = (*AnySchema)(nil)
+var _ core.Accepter = (*AnySchema)(nil)
+
+// NewAnySchema creates a new AnySchema with the given configuration.
+func NewAnySchema(config AnySchemaConfig) *AnySchema {
+	return &AnySchema{config: config}
+}
+
+// Type returns the schema type constant.
+func (a *AnySchema) Type() core.SchemaType {
+	return core.TypeAny
+}
+
+// Metadata returns the schema metadata.
+func (a *AnySchema) Metadata() core.SchemaMetadata {
+	return a.config.Metadata
+}
+
+// Annotations returns the annotations of the schema.
+func (a *AnySchema) Annotations() []core.Annotation {
+	if a.config.Annotations == nil {
+		return nil
+	}
+	result := make([]core.Annotation, len(a.config.Annotations))
+	copy(result, a.config.Annotations)
+	return result
+}
+
+// Clone returns a deep copy of the AnySchema.
+func (a *AnySchema) Clone() core.Schema {
+	newConfig := a.config
+
+	// Deep copy metadata examples and tags
+	if a.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(a.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, a.config.Metadata.Examples)
+	}
+
+	if a.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(a.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, a.config.Metadata.Tags)
+	}
+
+	return NewAnySchema(newConfig)
+}
+
+// Note: Validation moved to consumer-driven architecture.
+// Use schema/consumer.Registry.ProcessValueWithPurpose("validation", schema, value) instead.
+
+// Accept implements the visitor pattern for schema traversal.
+func (a *AnySchema) Accept(visitor core.SchemaVisitor) error {
+	return visitor.VisitAny(a)
+}
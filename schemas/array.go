@@ -16,6 +16,8 @@ type ArraySchemaConfig struct {
 	MaxItems       *int
 	UniqueItems    bool
 	ContainsSchema core.Schema
+	PrefixItems    []core.Schema
+	RestItems      core.Schema
 	DefaultVal     []any
 }
 
@@ -76,6 +78,11 @@ func (a *ArraySchema) Clone() core.Schema {
 		copy(newConfig.DefaultVal, a.config.DefaultVal)
 	}
 
+	if a.config.PrefixItems != nil {
+		newConfig.PrefixItems = make([]core.Schema, len(a.config.PrefixItems))
+		copy(newConfig.PrefixItems, a.config.PrefixItems)
+	}
+
 	// Note: ItemSchema and ContainsSchema are not deeply cloned as they should be immutable
 	// If deep cloning is needed, it should be done at the configuration level
 
@@ -107,6 +114,21 @@ func (a *ArraySchema) ContainsSchema() core.Schema {
 	return a.config.ContainsSchema
 }
 
+// PrefixItemSchemas returns the per-position schemas of a tuple array.
+func (a *ArraySchema) PrefixItemSchemas() []core.Schema {
+	if a.config.PrefixItems == nil {
+		return nil
+	}
+	result := make([]core.Schema, len(a.config.PrefixItems))
+	copy(result, a.config.PrefixItems)
+	return result
+}
+
+// RestItemSchema returns the schema for items following the tuple positions.
+func (a *ArraySchema) RestItemSchema() core.Schema {
+	return a.config.RestItems
+}
+
 // DefaultValue returns the default value.
 func (a *ArraySchema) DefaultValue() []any {
 	if a.config.DefaultVal == nil {
@@ -0,0 +1,122 @@
+package schemas
+
+import (
+	"defs.dev/schema/core"
+)
+
+// ComponentSchemaConfig holds the configuration for building a ComponentSchema.
+type ComponentSchemaConfig struct {
+	Metadata    core.SchemaMetadata
+	Annotations []core.Annotation
+	Provides    []core.ServiceSchema
+	Requires    []core.ServiceSchema
+	Publishes   []core.TopicSchema
+	Subscribes  []core.TopicSchema
+	Config      core.Schema
+}
+
+// ComponentSchema describes a deployable unit: the services it provides and
+// requires, the topics it publishes and subscribes to, and its configuration.
+// The component is named by its metadata.
+type ComponentSchema struct {
+	config ComponentSchemaConfig
+}
+
+// Ensure ComponentSchema implements the API interfaces at compile time
+var _ core.Schema = (*ComponentSchema)(nil)
+var _ core.ComponentSchema = (*ComponentSchema)(nil)
+var _ core.Accepter = (*ComponentSchema)(nil)
+
+// NewComponentSchema creates a new ComponentSchema with the given configuration.
+func NewComponentSchema(config ComponentSchemaConfig) *ComponentSchema {
+	return &ComponentSchema{config: config}
+}
+
+// Type returns the schema type constant.
+func (c *ComponentSchema) Type() core.SchemaType {
+	return core.TypeComponent
+}
+
+// Metadata returns the schema metadata.
+func (c *ComponentSchema) Metadata() core.SchemaMetadata {
+	return c.config.Metadata
+}
+
+// Annotations returns the annotations of the schema.
+func (c *ComponentSchema) Annotations() []core.Annotation {
+	if c.config.Annotations == nil {
+		return nil
+	}
+	result := make([]core.Annotation, len(c.config.Annotations))
+	copy(result, c.config.Annotations)
+	return result
+}
+
+// Clone returns a deep copy of the ComponentSchema.
+func (c *ComponentSchema) Clone() core.Schema {
+	newConfig := c.config
+
+	// Deep copy metadata examples and tags
+	if c.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(c.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, c.config.Metadata.Examples)
+	}
+
+	if c.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(c.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, c.config.Metadata.Tags)
+	}
+
+	// Copy the lists; the service, topic and config schemas themselves are
+	// not deeply cloned as they should be immutable
+	newConfig.Provides = copySchemas(c.config.Provides)
+	newConfig.Requires = copySchemas(c.config.Requires)
+	newConfig.Publishes = copySchemas(c.config.Publishes)
+	newConfig.Subscribes = copySchemas(c.config.Subscribes)
+
+	return NewComponentSchema(newConfig)
+}
+
+// Name returns the component name.
+func (c *ComponentSchema) Name() string {
+	return c.config.Metadata.Name
+}
+
+// Provides returns the services the component provides.
+func (c *ComponentSchema) Provides() []core.ServiceSchema {
+	return copySchemas(c.config.Provides)
+}
+
+// Requires returns the services the component needs from other components.
+func (c *ComponentSchema) Requires() []core.ServiceSchema {
+	return copySchemas(c.config.Requires)
+}
+
+// Publishes returns the topics the component publishes to.
+func (c *ComponentSchema) Publishes() []core.TopicSchema {
+	return copySchemas(c.config.Publishes)
+}
+
+// Subscribes returns the topics the component subscribes to.
+func (c *ComponentSchema) Subscribes() []core.TopicSchema {
+	return copySchemas(c.config.Subscribes)
+}
+
+// Config returns the schema of the component configuration, or nil if it takes none.
+func (c *ComponentSchema) Config() core.Schema {
+	return c.config.Config
+}
+
+// Accept implements the visitor pattern for schema traversal.
+func (c *ComponentSchema) Accept(visitor core.SchemaVisitor) error {
+	return visitor.VisitComponent(c)
+}
+
+func copySchemas[T core.Schema](schemas []T) []T {
+	if schemas == nil {
+		return nil
+	}
+	result := make([]T, len(schemas))
+	copy(result, schemas)
+	return result
+}
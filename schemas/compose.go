@@ -0,0 +1,315 @@
+package schemas
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/structure"
+)
+
+// CompositionConflictError reports properties that are defined differently by
+// the schemas being combined.
+type CompositionConflictError struct {
+	Kind       core.CompositionKind
+	Properties []string
+}
+
+func (e *CompositionConflictError) Error() string {
+	return fmt.Sprintf("%s: conflicting definitions for properties: %s", e.Kind, strings.Join(e.Properties, ", "))
+}
+
+// Extend derives an object schema from base by adding the properties of
+// extension. Properties of the extension replace those of the base with the
+// same name. The result takes its metadata from the extension.
+func Extend(base, extension core.ObjectSchema) *ObjectSchema {
+	baseConfig := objectConfig(base)
+	config := objectConfig(extension)
+
+	properties := baseConfig.Properties
+	for name, prop := range config.Properties {
+		properties[name] = prop
+	}
+	config.Properties = properties
+	config.Required = unionStrings(baseConfig.Required, config.Required)
+	config.PatternProperties = mergeSchemaMaps(baseConfig.PatternProperties, config.PatternProperties)
+	config.PropertyDependencies = mergeDependencies(baseConfig.PropertyDependencies, config.PropertyDependencies)
+	if config.MinProperties == nil {
+		config.MinProperties = baseConfig.MinProperties
+	}
+	if config.MaxProperties == nil {
+		config.MaxProperties = baseConfig.MaxProperties
+	}
+
+	// The extension is recorded without its name, which belongs to the result
+	extensionMetadata := extension.Metadata()
+	extensionMetadata.Name = ""
+	extensionMetadata.Composition = nil
+
+	config.Metadata.Composition = &core.Composition{
+		Kind:    core.CompositionExtend,
+		Sources: []core.Schema{base, WithMetadata(extension, extensionMetadata)},
+	}
+	return NewObjectSchema(config)
+}
+
+// Merge combines the properties of two object schemas. A property defined by
+// both must have the same schema in each, otherwise a CompositionConflictError
+// is returned. The result only allows additional properties if both do.
+func Merge(a, b core.ObjectSchema) (*ObjectSchema, error) {
+	configA := objectConfig(a)
+	configB := objectConfig(b)
+
+	var conflicts []string
+	properties := configA.Properties
+	for name, prop := range configB.Properties {
+		if existing, ok := properties[name]; ok && !sameSchema(existing, prop) {
+			conflicts = append(conflicts, name)
+			continue
+		}
+		properties[name] = prop
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, &CompositionConflictError{Kind: core.CompositionMerge, Properties: conflicts}
+	}
+
+	return NewObjectSchema(ObjectSchemaConfig{
+		Metadata: core.SchemaMetadata{
+			Composition: &core.Composition{
+				Kind:    core.CompositionMerge,
+				Sources: []core.Schema{a, b},
+			},
+		},
+		Properties:           properties,
+		Required:             unionStrings(configA.Required, configB.Required),
+		AdditionalProperties: configA.AdditionalProperties && configB.AdditionalProperties,
+		PatternProperties:    mergeSchemaMaps(configA.PatternProperties, configB.PatternProperties),
+		PropertyDependencies: mergeDependencies(configA.PropertyDependencies, configB.PropertyDependencies),
+	}), nil
+}
+
+// AllOf returns the intersection of object schemas: a value is valid if it is
+// valid against every member. A property defined differently by several
+// members must be an object in each, and is intersected in turn; any other
+// difference is reported as a CompositionConflictError.
+func AllOf(members ...core.ObjectSchema) (*ObjectSchema, error) {
+	properties := make(map[string]core.Schema)
+	var required []string
+	var patternProperties map[string]core.Schema
+	var dependencies map[string][]string
+	additionalProperties := true
+
+	var conflicts []string
+	sources := make([]core.Schema, len(members))
+	for i, member := range members {
+		sources[i] = member
+		config := objectConfig(member)
+
+		for name, prop := range config.Properties {
+			existing, ok := properties[name]
+			if !ok || sameSchema(existing, prop) {
+				properties[name] = prop
+				continue
+			}
+
+			existingObject, existingIsObject := existing.(core.ObjectSchema)
+			propObject, propIsObject := prop.(core.ObjectSchema)
+			if !existingIsObject || !propIsObject {
+				conflicts = append(conflicts, name)
+				continue
+			}
+			intersection, err := AllOf(existingObject, propObject)
+			if err != nil {
+				conflicts = append(conflicts, name)
+				continue
+			}
+			properties[name] = intersection
+		}
+
+		required = unionStrings(required, config.Required)
+		patternProperties = mergeSchemaMaps(patternProperties, config.PatternProperties)
+		dependencies = mergeDependencies(dependencies, config.PropertyDependencies)
+		additionalProperties = additionalProperties && config.AdditionalProperties
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, &CompositionConflictError{Kind: core.CompositionAllOf, Properties: conflicts}
+	}
+
+	return NewObjectSchema(ObjectSchemaConfig{
+		Metadata: core.SchemaMetadata{
+			Composition: &core.Composition{
+				Kind:    core.CompositionAllOf,
+				Sources: sources,
+			},
+		},
+		Properties:           properties,
+		Required:             required,
+		AdditionalProperties: additionalProperties,
+		PatternProperties:    patternProperties,
+		PropertyDependencies: dependencies,
+	}), nil
+}
+
+// Pick derives an object schema holding only the named properties of source.
+// Naming a property the source does not define is an error.
+func Pick(source core.ObjectSchema, names ...string) (*ObjectSchema, error) {
+	config := objectConfig(source)
+
+	var missing []string
+	for _, name := range names {
+		if _, ok := config.Properties[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("pick: unknown properties: %s", strings.Join(missing, ", "))
+	}
+
+	keep := make(map[string]bool, len(names))
+	for _, name := range names {
+		keep[name] = true
+	}
+	return project(source, config, keep, core.CompositionPick, names), nil
+}
+
+// Omit derives an object schema holding all properties of source except the
+// named ones. Names the source does not define are ignored.
+func Omit(source core.ObjectSchema, names ...string) *ObjectSchema {
+	config := objectConfig(source)
+
+	drop := make(map[string]bool, len(names))
+	for _, name := range names {
+		drop[name] = true
+	}
+	keep := make(map[string]bool, len(config.Properties))
+	for name := range config.Properties {
+		keep[name] = !drop[name]
+	}
+	return project(source, config, keep, core.CompositionOmit, names)
+}
+
+// project keeps the properties of config selected by keep.
+func project(source core.ObjectSchema, config ObjectSchemaConfig, keep map[string]bool, kind core.CompositionKind, keys []string) *ObjectSchema {
+	properties := make(map[string]core.Schema)
+	for name, prop := range config.Properties {
+		if keep[name] {
+			properties[name] = prop
+		}
+	}
+
+	var required []string
+	for _, name := range config.Required {
+		if keep[name] {
+			required = append(required, name)
+		}
+	}
+
+	var dependencies map[string][]string
+	for name, deps := range config.PropertyDependencies {
+		if !keep[name] {
+			continue
+		}
+		if dependencies == nil {
+			dependencies = make(map[string][]string)
+		}
+		for _, dep := range deps {
+			if keep[dep] {
+				dependencies[name] = append(dependencies[name], dep)
+			}
+		}
+	}
+
+	return NewObjectSchema(ObjectSchemaConfig{
+		Metadata: core.SchemaMetadata{
+			Composition: &core.Composition{
+				Kind:    kind,
+				Sources: []core.Schema{source},
+				Keys:    append([]string(nil), keys...),
+			},
+		},
+		Properties:           properties,
+		Required:             required,
+		AdditionalProperties: config.AdditionalProperties,
+		PatternProperties:    config.PatternProperties,
+		PropertyDependencies: dependencies,
+	})
+}
+
+// objectConfig returns a copy of the configuration of an object schema that
+// can be modified freely. Object schemas from other packages are described
+// through the core.ObjectSchema interface.
+func objectConfig(s core.ObjectSchema) ObjectSchemaConfig {
+	var config ObjectSchemaConfig
+	if obj, ok := s.(*ObjectSchema); ok {
+		config = obj.config
+		config.PatternProperties = mergeSchemaMaps(nil, obj.config.PatternProperties)
+		config.PropertyDependencies = mergeDependencies(nil, obj.config.PropertyDependencies)
+	} else {
+		config = ObjectSchemaConfig{
+			Metadata:             s.Metadata(),
+			Annotations:          s.Annotations(),
+			AdditionalProperties: s.AdditionalProperties(),
+		}
+	}
+
+	config.Properties = make(map[string]core.Schema)
+	for name, prop := range s.Properties() {
+		config.Properties[name] = prop
+	}
+	config.Required = append([]string(nil), s.Required()...)
+	return config
+}
+
+// sameSchema reports whether two property schemas describe the same values.
+func sameSchema(a, b core.Schema) bool {
+	return a == b || structure.Equal(a, b, structure.IgnoreMetadata())
+}
+
+// unionStrings appends the entries of b missing from a, keeping order.
+func unionStrings(a, b []string) []string {
+	result := append([]string(nil), a...)
+	seen := make(map[string]bool, len(a))
+	for _, s := range a {
+		seen[s] = true
+	}
+	for _, s := range b {
+		if !seen[s] {
+			seen[s] = true
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// mergeSchemaMaps returns a new map holding the entries of a overlaid with b.
+func mergeSchemaMaps(a, b map[string]core.Schema) map[string]core.Schema {
+	if a == nil && b == nil {
+		return nil
+	}
+	result := make(map[string]core.Schema, len(a)+len(b))
+	for k, v := range a {
+		result[k] = v
+	}
+	for k, v := range b {
+		result[k] = v
+	}
+	return result
+}
+
+// mergeDependencies returns a new map holding the union of both dependency maps.
+func mergeDependencies(a, b map[string][]string) map[string][]string {
+	if a == nil && b == nil {
+		return nil
+	}
+	result := make(map[string][]string, len(a)+len(b))
+	for k, v := range a {
+		result[k] = append([]string(nil), v...)
+	}
+	for k, v := range b {
+		result[k] = unionStrings(result[k], v)
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package schemas
+
+import (
+	"defs.dev/schema/core"
+)
+
+// GenericSchemaConfig holds the configuration for building a GenericSchema.
+type GenericSchemaConfig struct {
+	Metadata       core.SchemaMetadata
+	Annotations    []core.Annotation
+	TypeParameters []core.TypeParameterSchema
+	Template       core.Schema
+}
+
+// GenericSchema is a schema template parameterized by type parameters, such as
+// Page[T]. Instantiating it replaces each parameter in the template with a
+// concrete schema.
+type GenericSchema struct {
+	config GenericSchemaConfig
+}
+
+// Ensure GenericSchema implements the API interfaces at compile time
+var _ core.Schema = (*GenericSchema)(nil)
+var _ core.GenericSchema = (*GenericSchema)(nil)
+var _ core.Accepter = (*GenericSchema)(nil)
+
+// NewGenericSchema creates a new GenericSchema with the given configuration.
+func NewGenericSchema(config GenericSchemaConfig) *GenericSchema {
+	return &GenericSchema{config: config}
+}
+
+// Type returns the schema type constant.
+func (g *GenericSchema) Type() core.SchemaType {
+	return core.TypeGeneric
+}
+
+// Metadata returns the schema metadata.
+func (g *GenericSchema) Metadata() core.SchemaMetadata {
+	return g.config.Metadata
+}
+
+// Annotations returns the annotations of the schema.
+func (g *GenericSchema) Annotations() []core.Annotation {
+	if g.config.Annotations == nil {
+		return nil
+	}
+	result := make([]core.Annotation, len(g.config.Annotations))
+	copy(result, g.config.Annotations)
+	return result
+}
+
+// Clone returns a deep copy of the GenericSchema.
+func (g *GenericSchema) Clone() core.Schema {
+	newConfig := g.config
+
+	// Deep copy metadata examples and tags
+	if g.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(g.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, g.config.Metadata.Examples)
+	}
+
+	if g.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(g.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, g.config.Metadata.Tags)
+	}
+
+	if g.config.TypeParameters != nil {
+		newConfig.TypeParameters = make([]core.TypeParameterSchema, len(g.config.TypeParameters))
+		copy(newConfig.TypeParameters, g.config.TypeParameters)
+	}
+
+	return NewGenericSchema(newConfig)
+}
+
+// TypeParameters returns the parameters of the template, in declaration order.
+func (g *GenericSchema) TypeParameters() []core.TypeParameterSchema {
+	if g.config.TypeParameters == nil {
+		return nil
+	}
+	result := make([]core.TypeParameterSchema, len(g.config.TypeParameters))
+	copy(result, g.config.TypeParameters)
+	return result
+}
+
+// Template returns the schema body that refers to the type parameters.
+func (g *GenericSchema) Template() core.Schema {
+	return g.config.Template
+}
+
+// Note: Validation moved to consumer-driven architecture.
+// Use schema/consumer.Registry.ProcessValueWithPurpose("validation", schema, value) instead.
+
+// Accept implements the visitor pattern for schema traversal.
+func (g *GenericSchema) Accept(visitor core.SchemaVisitor) error {
+	return visitor.VisitGeneric(g)
+}
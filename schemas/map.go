@@ -0,0 +1,102 @@
+package schemas
+
+import (
+	"defs.dev/schema/core"
+)
+
+// MapSchemaConfig holds the configuration for building a MapSchema.
+type MapSchemaConfig struct {
+	Metadata    core.SchemaMetadata
+	Annotations []core.Annotation
+	KeySchema   core.Schema
+	ValueSchema core.Schema
+	MinItems    *int
+	MaxItems    *int
+}
+
+// MapSchema is a clean, API-first implementation of map schemas.
+// Unlike an ObjectSchema used as a dictionary, it keeps the key type, so
+// key constraints (pattern, enum, integer keys) survive conversion and export.
+type MapSchema struct {
+	config MapSchemaConfig
+}
+
+// Ensure MapSchema implements the API interfaces at compile time
+var _ core.Schema = (*MapSchema)(nil)
+var _ core.MapSchema = (*MapSchema)(nil)
+var _ core.Accepter = (*MapSchema)(nil)
+
+// NewMapSchema creates a new MapSchema with the given configuration.
+func NewMapSchema(config MapSchemaConfig) *MapSchema {
+	return &MapSchema{config: config}
+}
+
+// Type returns the schema type constant.
+func (m *MapSchema) Type() core.SchemaType {
+	return core.TypeMap
+}
+
+// Metadata returns the schema metadata.
+func (m *MapSchema) Metadata() core.SchemaMetadata {
+	return m.config.Metadata
+}
+
+// Annotations returns the annotations of the schema.
+func (m *MapSchema) Annotations() []core.Annotation {
+	if m.config.Annotations == nil {
+		return nil
+	}
+	result := make([]core.Annotation, len(m.config.Annotations))
+	copy(result, m.config.Annotations)
+	return result
+}
+
+// Clone returns a deep copy of the MapSchema.
+func (m *MapSchema) Clone() core.Schema {
+	newConfig := m.config
+
+	// Deep copy metadata examples and tags
+	if m.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(m.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, m.config.Metadata.Examples)
+	}
+
+	if m.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(m.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, m.config.Metadata.Tags)
+	}
+
+	// Note: KeySchema and ValueSchema are not deeply cloned as they should be immutable
+
+	return NewMapSchema(newConfig)
+}
+
+// KeySchema returns the schema that every key must satisfy.
+// A nil key schema accepts any string key.
+func (m *MapSchema) KeySchema() core.Schema {
+	return m.config.KeySchema
+}
+
+// ValueSchema returns the schema that every value must satisfy.
+// A nil value schema accepts any value.
+func (m *MapSchema) ValueSchema() core.Schema {
+	return m.config.ValueSchema
+}
+
+// MinItems returns the minimum number of entries.
+func (m *MapSchema) MinItems() *int {
+	return m.config.MinItems
+}
+
+// MaxItems returns the maximum number of entries.
+func (m *MapSchema) MaxItems() *int {
+	return m.config.MaxItems
+}
+
+// Note: Validation moved to consumer-driven architecture.
+// Use schema/consumer.Registry.ProcessValueWithPurpose("validation", schema, value) instead.
+
+// Accept implements the visitor pattern for schema traversal.
+func (m *MapSchema) Accept(visitor core.SchemaVisitor) error {
+	return visitor.VisitMap(m)
+}
@@ -0,0 +1,73 @@
+package schemas
+
+import (
+	"defs.dev/schema/core"
+)
+
+// NullSchemaConfig holds the configuration for building a NullSchema.
+type NullSchemaConfig struct {
+	Metadata    core.SchemaMetadata
+	Annotations []core.Annotation
+}
+
+// NullSchema accepts only null. It is mostly useful as a union member or to
+// describe values that must be explicitly absent.
+type NullSchema struct {
+	config NullSchemaConfig
+}
+
+// Ensure NullSchema implements the API interfaces at compile time
+var _ core.Schema = (*NullSchema)(nil)
+var _ core.NullSchema = (*NullSchema)(nil)
+var _ core.Accepter = (*NullSchema)(nil)
+
+// NewNullSchema creates a new NullSchema with the given configuration.
+func NewNullSchema(config NullSchemaConfig) *NullSchema {
+	return &NullSchema{config: config}
+}
+
+// Type returns the schema type constant.
+func (n *NullSchema) Type() core.SchemaType {
+	return core.TypeNull
+}
+
+// Metadata returns the schema metadata.
+func (n *NullSchema) Metadata() core.SchemaMetadata {
+	return n.config.Metadata
+}
+
+// Annotations returns the annotations of the schema.
+func (n *NullSchema) Annotations() []core.Annotation {
+	if n.config.Annotations == nil {
+		return nil
+	}
+	result := make([]core.Annotation, len(n.config.Annotations))
+	copy(result, n.config.Annotations)
+	return result
+}
+
+// Clone returns a deep copy of the NullSchema.
+func (n *NullSchema) Clone() core.Schema {
+	newConfig := n.config
+
+	// Deep copy metadata examples and tags
+	if n.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(n.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, n.config.Metadata.Examples)
+	}
+
+	if n.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(n.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, n.config.Metadata.Tags)
+	}
+
+	return NewNullSchema(newConfig)
+}
+
+// Note: Validation moved to consumer-driven architecture.
+// Use schema/consumer.Registry.ProcessValueWithPurpose("validation", schema, value) instead.
+
+// Accept implements the visitor pattern for schema traversal.
+func (n *NullSchema) Accept(visitor core.SchemaVisitor) error {
+	return visitor.VisitNull(n)
+}
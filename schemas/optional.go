@@ -0,0 +1,82 @@
+package schemas
+
+import (
+	"defs.dev/schema/core"
+)
+
+// OptionalSchemaConfig holds the configuration for building an OptionalSchema.
+type OptionalSchemaConfig struct {
+	Metadata    core.SchemaMetadata
+	Annotations []core.Annotation
+	ItemSchema  core.Schema
+}
+
+// OptionalSchema wraps another schema and additionally accepts null.
+// This is distinct from a property not being required: a required property
+// with an optional schema must be present, but may be null.
+type OptionalSchema struct {
+	config OptionalSchemaConfig
+}
+
+// Ensure OptionalSchema implements the API interfaces at compile time
+var _ core.Schema = (*OptionalSchema)(nil)
+var _ core.OptionalSchema = (*OptionalSchema)(nil)
+var _ core.Accepter = (*OptionalSchema)(nil)
+
+// NewOptionalSchema creates a new OptionalSchema with the given configuration.
+func NewOptionalSchema(config OptionalSchemaConfig) *OptionalSchema {
+	return &OptionalSchema{config: config}
+}
+
+// Type returns the schema type constant.
+func (o *OptionalSchema) Type() core.SchemaType {
+	return core.TypeOptional
+}
+
+// Metadata returns the schema metadata.
+func (o *OptionalSchema) Metadata() core.SchemaMetadata {
+	return o.config.Metadata
+}
+
+// Annotations returns the annotations of the schema.
+func (o *OptionalSchema) Annotations() []core.Annotation {
+	if o.config.Annotations == nil {
+		return nil
+	}
+	result := make([]core.Annotation, len(o.config.Annotations))
+	copy(result, o.config.Annotations)
+	return result
+}
+
+// Clone returns a deep copy of the OptionalSchema.
+func (o *OptionalSchema) Clone() core.Schema {
+	newConfig := o.config
+
+	// Deep copy metadata examples and tags
+	if o.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(o.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, o.config.Metadata.Examples)
+	}
+
+	if o.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(o.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, o.config.Metadata.Tags)
+	}
+
+	// Note: ItemSchema is not deeply cloned as it should be immutable
+
+	return NewOptionalSchema(newConfig)
+}
+
+// ItemSchema returns the wrapped schema that non-null values must satisfy.
+func (o *OptionalSchema) ItemSchema() core.Schema {
+	return o.config.ItemSchema
+}
+
+// Note: Validation moved to consumer-driven architecture.
+// Use schema/consumer.Registry.ProcessValueWithPurpose("validation", schema, value) instead.
+
+// Accept implements the visitor pattern for schema traversal.
+func (o *OptionalSchema) Accept(visitor core.SchemaVisitor) error {
+	return visitor.VisitOptional(o)
+}
@@ -0,0 +1,84 @@
+package schemas
+
+import (
+	"defs.dev/schema/core"
+)
+
+// TypeParameterSchemaConfig holds the configuration for building a TypeParameterSchema.
+type TypeParameterSchemaConfig struct {
+	Metadata    core.SchemaMetadata
+	Annotations []core.Annotation
+	Constraint  core.Schema
+}
+
+// TypeParameterSchema is a placeholder inside a generic schema template. It is
+// identified by its name and replaced by a concrete schema on instantiation.
+type TypeParameterSchema struct {
+	config TypeParameterSchemaConfig
+}
+
+// Ensure TypeParameterSchema implements the API interfaces at compile time
+var _ core.Schema = (*TypeParameterSchema)(nil)
+var _ core.TypeParameterSchema = (*TypeParameterSchema)(nil)
+var _ core.Accepter = (*TypeParameterSchema)(nil)
+
+// NewTypeParameterSchema creates a new TypeParameterSchema with the given configuration.
+func NewTypeParameterSchema(config TypeParameterSchemaConfig) *TypeParameterSchema {
+	return &TypeParameterSchema{config: config}
+}
+
+// Type returns the schema type constant.
+func (p *TypeParameterSchema) Type() core.SchemaType {
+	return core.TypeParameter
+}
+
+// Metadata returns the schema metadata.
+func (p *TypeParameterSchema) Metadata() core.SchemaMetadata {
+	return p.config.Metadata
+}
+
+// Annotations returns the annotations of the schema.
+func (p *TypeParameterSchema) Annotations() []core.Annotation {
+	if p.config.Annotations == nil {
+		return nil
+	}
+	result := make([]core.Annotation, len(p.config.Annotations))
+	copy(result, p.config.Annotations)
+	return result
+}
+
+// Clone returns a deep copy of the TypeParameterSchema.
+func (p *TypeParameterSchema) Clone() core.Schema {
+	newConfig := p.config
+
+	// Deep copy metadata examples and tags
+	if p.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(p.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, p.config.Metadata.Examples)
+	}
+
+	if p.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(p.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, p.config.Metadata.Tags)
+	}
+
+	return NewTypeParameterSchema(newConfig)
+}
+
+// ParameterName returns the name the template uses for this parameter.
+func (p *TypeParameterSchema) ParameterName() string {
+	return p.config.Metadata.Name
+}
+
+// Constraint returns the schema type arguments must conform to, or nil.
+func (p *TypeParameterSchema) Constraint() core.Schema {
+	return p.config.Constraint
+}
+
+// Note: Validation moved to consumer-driven architecture.
+// Use schema/consumer.Registry.ProcessValueWithPurpose("validation", schema, value) instead.
+
+// Accept implements the visitor pattern for schema traversal.
+func (p *TypeParameterSchema) Accept(visitor core.SchemaVisitor) error {
+	return visitor.VisitParameter(p)
+}
@@ -0,0 +1,88 @@
+package schemas
+
+import (
+	"defs.dev/schema/core"
+)
+
+// ResultSchemaConfig holds the configuration for building a ResultSchema.
+type ResultSchemaConfig struct {
+	Metadata    core.SchemaMetadata
+	Annotations []core.Annotation
+	Success     core.Schema
+	Error       core.Schema
+}
+
+// ResultSchema describes a success/failure payload. A value holds exactly one of
+// "ok", validated against the success schema, or "err", validated against the
+// error schema.
+type ResultSchema struct {
+	config ResultSchemaConfig
+}
+
+// Ensure ResultSchema implements the API interfaces at compile time
+var _ core.Schema = (*ResultSchema)(nil)
+var _ core.ResultSchema = (*ResultSchema)(nil)
+var _ core.Accepter = (*ResultSchema)(nil)
+
+// NewResultSchema creates a new ResultSchema with the given configuration.
+func NewResultSchema(config ResultSchemaConfig) *ResultSchema {
+	return &ResultSchema{config: config}
+}
+
+// Type returns the schema type constant.
+func (r *ResultSchema) Type() core.SchemaType {
+	return core.TypeResult
+}
+
+// Metadata returns the schema metadata.
+func (r *ResultSchema) Metadata() core.SchemaMetadata {
+	return r.config.Metadata
+}
+
+// Annotations returns the annotations of the schema.
+func (r *ResultSchema) Annotations() []core.Annotation {
+	if r.config.Annotations == nil {
+		return nil
+	}
+	result := make([]core.Annotation, len(r.config.Annotations))
+	copy(result, r.config.Annotations)
+	return result
+}
+
+// Clone returns a deep copy of the ResultSchema.
+func (r *ResultSchema) Clone() core.Schema {
+	newConfig := r.config
+
+	// Deep copy metadata examples and tags
+	if r.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(r.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, r.config.Metadata.Examples)
+	}
+
+	if r.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(r.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, r.config.Metadata.Tags)
+	}
+
+	// Note: Success and Error schemas are not deeply cloned as they should be immutable
+
+	return NewResultSchema(newConfig)
+}
+
+// SuccessSchema returns the schema of the "ok" payload.
+func (r *ResultSchema) SuccessSchema() core.Schema {
+	return r.config.Success
+}
+
+// ErrorSchema returns the schema of the "err" payload.
+func (r *ResultSchema) ErrorSchema() core.Schema {
+	return r.config.Error
+}
+
+// Note: Validation moved to consumer-driven architecture.
+// Use schema/consumer.Registry.ProcessValueWithPurpose("validation", schema, value) instead.
+
+// Accept implements the visitor pattern for schema traversal.
+func (r *ResultSchema) Accept(visitor core.SchemaVisitor) error {
+	return visitor.VisitResult(r)
+}
@@ -0,0 +1,95 @@
+package schemas
+
+import (
+	"defs.dev/schema/core"
+)
+
+// TopicSchemaConfig holds the configuration for building a TopicSchema.
+type TopicSchemaConfig struct {
+	Metadata    core.SchemaMetadata
+	Annotations []core.Annotation
+	Payload     core.Schema
+	Key         core.Schema
+	Headers     core.Schema
+}
+
+// TopicSchema describes the messages published to a topic: a payload, and
+// optionally a key and headers. The topic is named by its metadata.
+type TopicSchema struct {
+	config TopicSchemaConfig
+}
+
+// Ensure TopicSchema implements the API interfaces at compile time
+var _ core.Schema = (*TopicSchema)(nil)
+var _ core.TopicSchema = (*TopicSchema)(nil)
+var _ core.Accepter = (*TopicSchema)(nil)
+
+// NewTopicSchema creates a new TopicSchema with the given configuration.
+func NewTopicSchema(config TopicSchemaConfig) *TopicSchema {
+	return &TopicSchema{config: config}
+}
+
+// Type returns the schema type constant.
+func (t *TopicSchema) Type() core.SchemaType {
+	return core.TypeTopic
+}
+
+// Metadata returns the schema metadata.
+func (t *TopicSchema) Metadata() core.SchemaMetadata {
+	return t.config.Metadata
+}
+
+// Annotations returns the annotations of the schema.
+func (t *TopicSchema) Annotations() []core.Annotation {
+	if t.config.Annotations == nil {
+		return nil
+	}
+	result := make([]core.Annotation, len(t.config.Annotations))
+	copy(result, t.config.Annotations)
+	return result
+}
+
+// Clone returns a deep copy of the TopicSchema.
+func (t *TopicSchema) Clone() core.Schema {
+	newConfig := t.config
+
+	// Deep copy metadata examples and tags
+	if t.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(t.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, t.config.Metadata.Examples)
+	}
+
+	if t.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(t.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, t.config.Metadata.Tags)
+	}
+
+	// Note: Payload, key and header schemas are not deeply cloned as they should be immutable
+
+	return NewTopicSchema(newConfig)
+}
+
+// Name returns the topic name.
+func (t *TopicSchema) Name() string {
+	return t.config.Metadata.Name
+}
+
+// Payload returns the schema of message payloads.
+func (t *TopicSchema) Payload() core.Schema {
+	return t.config.Payload
+}
+
+// Key returns the schema of message keys, or nil if messages are not keyed.
+func (t *TopicSchema) Key() core.Schema {
+	return t.config.Key
+}
+
+// Headers returns the schema of message headers, or nil if headers are not constrained.
+func (t *TopicSchema) Headers() core.Schema {
+	return t.config.Headers
+}
+
+// Accept implements the visitor pattern for schema traversal.
+func (t *TopicSchema) Accept(visitor core.SchemaVisitor) error {
+	return visitor.VisitTopic(t)
+}
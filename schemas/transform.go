@@ -0,0 +1,313 @@
+package schemas
+
+import (
+	"sort"
+	"strconv"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/jsonpointer"
+)
+
+// Transform returns a copy of schema in which every nested schema has been
+// passed through fn. fn is called parent-first; when it reports a replacement,
+// the replacement is used as is and its children are not visited.
+//
+// Only the containers defined in this package are rebuilt. Function, service,
+// topic, component and generic schemas, as well as schemas defined elsewhere
+// such as references, are treated as leaves.
+func Transform(schema core.Schema, fn func(core.Schema) (core.Schema, bool)) core.Schema {
+	if schema == nil {
+		return nil
+	}
+	if replacement, ok := fn(schema); ok {
+		return replacement
+	}
+	return rebuild(schema, nil, func(_ string, child core.Schema) core.Schema {
+		return Transform(child, fn)
+	})
+}
+
+// WithMetadata returns a copy of schema carrying the given metadata.
+// Schemas not defined in this package are returned unchanged.
+func WithMetadata(schema core.Schema, metadata core.SchemaMetadata) core.Schema {
+	return rebuild(schema, &metadata, func(_ string, child core.Schema) core.Schema {
+		return child
+	})
+}
+
+// MapChildren returns a copy of schema whose direct children have been passed
+// through fn, which receives each child with its JSON Pointer relative to
+// schema as given by structure.Children, such as /properties/name or /items.
+// Unlike Transform, it also maps the children of function, service, topic,
+// component and generic schemas. When fn returns every child unchanged,
+// schema itself is returned; schemas not defined in this package are not
+// rebuilt.
+func MapChildren(schema core.Schema, fn func(pointer string, child core.Schema) core.Schema) core.Schema {
+	changed := false
+	mapChild := func(pointer string, child core.Schema) core.Schema {
+		if child == nil {
+			return nil
+		}
+		result := fn(pointer, child)
+		if result != child {
+			changed = true
+		}
+		return result
+	}
+
+	var result core.Schema
+	switch s := schema.(type) {
+	case *TypeParameterSchema:
+		config := s.config
+		config.Constraint = mapChild("/constraint", s.config.Constraint)
+		result = NewTypeParameterSchema(config)
+	case *GenericSchema:
+		config := s.config
+		if s.config.TypeParameters != nil {
+			config.TypeParameters = make([]core.TypeParameterSchema, len(s.config.TypeParameters))
+			for i, param := range s.config.TypeParameters {
+				config.TypeParameters[i] = mapTyped(mapChild, "/parameters/"+strconv.Itoa(i), param)
+			}
+		}
+		config.Template = mapChild("/template", s.config.Template)
+		result = NewGenericSchema(config)
+	case *FunctionSchema:
+		clone := *s
+		clone.inputs = mapArgs(mapChild, "/inputs/", s.inputs)
+		clone.outputs = mapArgs(mapChild, "/outputs/", s.outputs)
+		clone.errors = mapChild("/errors", s.errors)
+		result = &clone
+	case *ServiceSchema:
+		clone := *s
+		clone.methods = make([]*ServiceMethodSchema, len(s.methods))
+		for i, method := range s.methods {
+			methodClone := *method
+			methodClone.function = mapTyped(mapChild, "/methods/"+jsonpointer.Escape(method.name), method.function)
+			clone.methods[i] = &methodClone
+		}
+		result = &clone
+	case *TopicSchema:
+		config := s.config
+		config.Key = mapChild("/key", s.config.Key)
+		config.Payload = mapChild("/payload", s.config.Payload)
+		config.Headers = mapChild("/headers", s.config.Headers)
+		result = NewTopicSchema(config)
+	case *ComponentSchema:
+		config := s.config
+		config.Provides = mapNamed(mapChild, "/provides/", s.config.Provides)
+		config.Requires = mapNamed(mapChild, "/requires/", s.config.Requires)
+		config.Publishes = mapNamed(mapChild, "/publishes/", s.config.Publishes)
+		config.Subscribes = mapNamed(mapChild, "/subscribes/", s.config.Subscribes)
+		config.Config = mapChild("/config", s.config.Config)
+		result = NewComponentSchema(config)
+	default:
+		result = rebuild(schema, nil, mapChild)
+	}
+
+	if !changed {
+		return schema
+	}
+	return result
+}
+
+// mapTyped maps a child that must keep its interface type; replacements of
+// another type are ignored.
+func mapTyped[T core.Schema](mapChild func(string, core.Schema) core.Schema, pointer string, child T) T {
+	if typed, ok := mapChild(pointer, child).(T); ok {
+		return typed
+	}
+	return child
+}
+
+func mapNamed[T interface {
+	core.Schema
+	Name() string
+}](mapChild func(string, core.Schema) core.Schema, prefix string, children []T) []T {
+	if children == nil {
+		return nil
+	}
+	result := make([]T, len(children))
+	for i, child := range children {
+		result[i] = mapTyped(mapChild, prefix+jsonpointer.Escape(child.Name()), child)
+	}
+	return result
+}
+
+func mapArgs(mapChild func(string, core.Schema) core.Schema, prefix string, args ArgSchemas) ArgSchemas {
+	result := args
+	result.args = make([]ArgSchema, len(args.args))
+	for i, arg := range args.args {
+		arg.schema = mapChild(prefix+jsonpointer.Escape(arg.name), arg.schema)
+		result.args[i] = arg
+	}
+	return result
+}
+
+// sortedKeys returns the keys of a map of schemas in order.
+func sortedKeys(schemas map[string]core.Schema) []string {
+	keys := make([]string, 0, len(schemas))
+	for key := range schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// rebuild copies a schema defined in this package, optionally replacing its
+// metadata, and maps each of its direct children through child.
+func rebuild(schema core.Schema, metadata *core.SchemaMetadata, child func(string, core.Schema) core.Schema) core.Schema {
+	mapChild := func(pointer string, s core.Schema) core.Schema {
+		if s == nil {
+			return nil
+		}
+		return child(pointer, s)
+	}
+
+	switch s := schema.(type) {
+	case *StringSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		return NewStringSchema(config)
+	case *NumberSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		return NewNumberSchema(config)
+	case *IntegerSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		return NewIntegerSchema(config)
+	case *BooleanSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		return NewBooleanSchema(config)
+	case *NullSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		return NewNullSchema(config)
+	case *AnySchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		return NewAnySchema(config)
+	case *TypeParameterSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		return NewTypeParameterSchema(config)
+	case *GenericSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		return NewGenericSchema(config)
+	case *ArraySchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		config.ItemSchema = mapChild("/items", s.config.ItemSchema)
+		config.ContainsSchema = mapChild("/contains", s.config.ContainsSchema)
+		config.RestItems = mapChild("/rest", s.config.RestItems)
+		if s.config.PrefixItems != nil {
+			config.PrefixItems = make([]core.Schema, len(s.config.PrefixItems))
+			for i, item := range s.config.PrefixItems {
+				config.PrefixItems[i] = mapChild("/prefixItems/"+strconv.Itoa(i), item)
+			}
+		}
+		return NewArraySchema(config)
+	case *ObjectSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		// Properties are mapped in name order, so walks are deterministic
+		if s.config.Properties != nil {
+			config.Properties = make(map[string]core.Schema, len(s.config.Properties))
+			for _, name := range sortedKeys(s.config.Properties) {
+				config.Properties[name] = mapChild("/properties/"+jsonpointer.Escape(name), s.config.Properties[name])
+			}
+		}
+		if s.config.PatternProperties != nil {
+			config.PatternProperties = make(map[string]core.Schema, len(s.config.PatternProperties))
+			for _, pattern := range sortedKeys(s.config.PatternProperties) {
+				config.PatternProperties[pattern] = mapChild("/patternProperties/"+jsonpointer.Escape(pattern), s.config.PatternProperties[pattern])
+			}
+		}
+		return NewObjectSchema(config)
+	case *MapSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		config.KeySchema = mapChild("/key", s.config.KeySchema)
+		config.ValueSchema = mapChild("/value", s.config.ValueSchema)
+		return NewMapSchema(config)
+	case *OptionalSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		config.ItemSchema = mapChild("/item", s.config.ItemSchema)
+		return NewOptionalSchema(config)
+	case *UnionSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		if s.config.Schemas != nil {
+			config.Schemas = make([]core.Schema, len(s.config.Schemas))
+			for i, member := range s.config.Schemas {
+				config.Schemas[i] = mapChild("/members/"+strconv.Itoa(i), member)
+			}
+		}
+		return NewUnionSchema(config)
+	case *ResultSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		config.Success = mapChild("/ok", s.config.Success)
+		config.Error = mapChild("/err", s.config.Error)
+		return NewResultSchema(config)
+	case *FunctionSchema:
+		if metadata == nil {
+			return s
+		}
+		clone := *s
+		clone.metadata = *metadata
+		return &clone
+	case *ServiceSchema:
+		if metadata == nil {
+			return s
+		}
+		clone := *s
+		clone.metadata = *metadata
+		return &clone
+	case *TopicSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		return NewTopicSchema(config)
+	case *ComponentSchema:
+		config := s.config
+		if metadata != nil {
+			config.Metadata = *metadata
+		}
+		return NewComponentSchema(config)
+	default:
+		return schema
+	}
+}
@@ -0,0 +1,108 @@
+package schemas
+
+import (
+	"defs.dev/schema/core"
+)
+
+// UnionSchemaConfig holds the configuration for building a UnionSchema.
+type UnionSchemaConfig struct {
+	Metadata      core.SchemaMetadata
+	Annotations   []core.Annotation
+	Schemas       []core.Schema
+	Discriminator string
+	Mode          core.UnionMode
+}
+
+// UnionSchema is a clean, API-first implementation of union schemas.
+// A value is accepted when it matches exactly one (oneOf) or at least one (anyOf)
+// of the member schemas. An optional discriminator property selects the branch
+// for object values directly.
+type UnionSchema struct {
+	config UnionSchemaConfig
+}
+
+// Ensure UnionSchema implements the API interfaces at compile time
+var _ core.Schema = (*UnionSchema)(nil)
+var _ core.UnionSchema = (*UnionSchema)(nil)
+var _ core.Accepter = (*UnionSchema)(nil)
+
+// NewUnionSchema creates a new UnionSchema with the given configuration.
+// An empty mode defaults to oneOf.
+func NewUnionSchema(config UnionSchemaConfig) *UnionSchema {
+	if config.Mode == "" {
+		config.Mode = core.UnionOneOf
+	}
+	return &UnionSchema{config: config}
+}
+
+// Type returns the schema type constant.
+func (u *UnionSchema) Type() core.SchemaType {
+	return core.TypeUnion
+}
+
+// Metadata returns the schema metadata.
+func (u *UnionSchema) Metadata() core.SchemaMetadata {
+	return u.config.Metadata
+}
+
+// Annotations returns the annotations of the schema.
+func (u *UnionSchema) Annotations() []core.Annotation {
+	if u.config.Annotations == nil {
+		return nil
+	}
+	result := make([]core.Annotation, len(u.config.Annotations))
+	copy(result, u.config.Annotations)
+	return result
+}
+
+// Clone returns a deep copy of the UnionSchema.
+func (u *UnionSchema) Clone() core.Schema {
+	newConfig := u.config
+
+	// Deep copy metadata examples and tags
+	if u.config.Metadata.Examples != nil {
+		newConfig.Metadata.Examples = make([]any, len(u.config.Metadata.Examples))
+		copy(newConfig.Metadata.Examples, u.config.Metadata.Examples)
+	}
+
+	if u.config.Metadata.Tags != nil {
+		newConfig.Metadata.Tags = make([]string, len(u.config.Metadata.Tags))
+		copy(newConfig.Metadata.Tags, u.config.Metadata.Tags)
+	}
+
+	// Copy the member list; member schemas themselves are immutable
+	if u.config.Schemas != nil {
+		newConfig.Schemas = make([]core.Schema, len(u.config.Schemas))
+		copy(newConfig.Schemas, u.config.Schemas)
+	}
+
+	return NewUnionSchema(newConfig)
+}
+
+// Schemas returns the member schemas of the union.
+func (u *UnionSchema) Schemas() []core.Schema {
+	if u.config.Schemas == nil {
+		return nil
+	}
+	result := make([]core.Schema, len(u.config.Schemas))
+	copy(result, u.config.Schemas)
+	return result
+}
+
+// Discriminator returns the name of the discriminator property, or "" if none.
+func (u *UnionSchema) Discriminator() string {
+	return u.config.Discriminator
+}
+
+// Mode returns whether the union is exclusive (oneOf) or inclusive (anyOf).
+func (u *UnionSchema) Mode() core.UnionMode {
+	return u.config.Mode
+}
+
+// Note: Validation moved to consumer-driven architecture.
+// Use schema/consumer.Registry.ProcessValueWithPurpose("validation", schema, value) instead.
+
+// Accept implements the visitor pattern for schema traversal.
+func (u *UnionSchema) Accept(visitor core.SchemaVisitor) error {
+	return visitor.VisitUnion(u)
+}
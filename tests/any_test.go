@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/visit/export/golang"
+	jsonexport "defs.dev/schema/visit/export/json"
+	"defs.dev/schema/visit/export/python"
+	"defs.dev/schema/visit/export/typescript"
+)
+
+func TestAnySchemaValidation(t *testing.T) {
+	schema := builders.NewAnySchema().Build()
+
+	if schema.Type() != core.TypeAny {
+		t.Errorf("Expected type %s, got %s", core.TypeAny, schema.Type())
+	}
+
+	for _, v := range []any{nil, "text", 42, 3.14, true, []any{1, "a"}, map[string]any{"a": 1}} {
+		if result := validation.ValidateValue(schema, v); !result.Valid {
+			t.Errorf("Expected %v to be valid, got errors: %v", v, result.Errors)
+		}
+	}
+
+	// Any properties accept scalars, unlike flexible object schemas
+	object := builders.NewObjectSchema().
+		Property("payload", schema).
+		Required("payload").
+		Build()
+	for _, v := range []any{"text", 42, map[string]any{"nested": true}} {
+		if result := validation.ValidateValue(object, map[string]any{"payload": v}); !result.Valid {
+			t.Errorf("Expected payload %v to be valid, got errors: %v", v, result.Errors)
+		}
+	}
+}
+
+func TestAnySchemaExport(t *testing.T) {
+	schema := builders.NewAnySchema().Name("Payload").Build()
+
+	t.Run("JSON Schema", func(t *testing.T) {
+		output, err := jsonexport.NewGenerator().Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate JSON Schema: %v", err)
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal(output, &doc); err != nil {
+			t.Fatalf("Failed to parse JSON Schema: %v", err)
+		}
+		if _, ok := doc["type"]; ok {
+			t.Errorf("Expected no type constraint, got %v", doc["type"])
+		}
+	})
+
+	t.Run("TypeScript", func(t *testing.T) {
+		output, err := typescript.NewGenerator().Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		if !strings.Contains(string(output), "export type Payload = unknown;") {
+			t.Errorf("Unexpected TypeScript output:\n%s", output)
+		}
+	})
+
+	t.Run("Go", func(t *testing.T) {
+		output, err := golang.NewGenerator(golang.DefaultGoOptions()).Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate Go: %v", err)
+		}
+		if !strings.Contains(string(output), "Payload") || !strings.Contains(string(output), "any") {
+			t.Errorf("Unexpected Go output:\n%s", output)
+		}
+	})
+
+	t.Run("Python", func(t *testing.T) {
+		output, err := python.NewGenerator(python.DefaultPythonOptions()).Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate Python: %v", err)
+		}
+		if !strings.Contains(string(output), "Payload = Any") || !strings.Contains(string(output), "from typing import Any") {
+			t.Errorf("Unexpected Python output:\n%s", output)
+		}
+	})
+}
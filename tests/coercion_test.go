@@ -0,0 +1,199 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"defs.dev/schema/api"
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/coercion"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/runtime/portal"
+)
+
+// newQuerySchema builds an object whose values typically arrive as strings.
+func newQuerySchema() core.ObjectSchema {
+	return builders.NewObjectSchema().
+		Name("Query").
+		Property("page", builders.NewIntegerSchema().Min(1).Build()).
+		Property("ratio", builders.NewNumberSchema().Build()).
+		Property("active", builders.NewBooleanSchema().Build()).
+		Property("ids", builders.NewArraySchema().Items(builders.NewIntegerSchema().Build()).Build()).
+		Property("since", builders.NewStringSchema().Format("date-time").Build()).
+		Property("name", builders.NewStringSchema().Build()).
+		Property("limit", builders.NewOptionalSchema().Of(builders.NewIntegerSchema().Build()).Build()).
+		Property("id", builders.NewUnionSchema().Schemas(
+			builders.NewBooleanSchema().Build(),
+			builders.NewIntegerSchema().Build(),
+		).Build()).
+		Build()
+}
+
+func coercionPaths(coercions []coercion.Coercion) []string {
+	paths := make([]string, len(coercions))
+	for i, c := range coercions {
+		paths[i] = c.Path
+	}
+	return paths
+}
+
+func TestCoerce(t *testing.T) {
+	query := newQuerySchema()
+
+	t.Run("strict", func(t *testing.T) {
+		input := map[string]any{
+			"page":   "2",
+			"ratio":  "0.5",
+			"active": "true",
+			"ids":    "1,2,3",
+			"since":  "2024-03-01T10:00:00Z",
+			"name":   "x",
+			"id":     "17",
+		}
+		result, err := coercion.Coerce(query, input, coercion.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := map[string]any{
+			"page":   int64(2),
+			"ratio":  0.5,
+			"active": true,
+			"ids":    []any{int64(1), int64(2), int64(3)},
+			"since":  time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+			"name":   "x",
+			"id":     int64(17),
+		}
+		if !reflect.DeepEqual(result.Value, expected) {
+			t.Fatalf("unexpected value:\n%v\nexpected:\n%v", result.Value, expected)
+		}
+		if v := validation.ValidateValue(query, result.Value); !v.Valid {
+			t.Fatalf("expected a valid value: %v", v.Errors)
+		}
+		if input["page"] != "2" {
+			t.Fatal("expected the input not to be modified")
+		}
+
+		paths := []string{"/active", "/id", "/ids", "/ids/0", "/ids/1", "/ids/2", "/page", "/ratio", "/since"}
+		if got := coercionPaths(result.Coercions); !reflect.DeepEqual(got, paths) {
+			t.Fatalf("unexpected coercions %v", got)
+		}
+		if c := result.Coercions[6]; c.From != "2" || c.To != int64(2) || c.Type != core.TypeInteger {
+			t.Fatalf("unexpected coercion %+v", c)
+		}
+	})
+
+	t.Run("strict failures", func(t *testing.T) {
+		input := map[string]any{"page": " 2 ", "active": "yes", "ratio": "1.5"}
+		result, err := coercion.Coerce(query, input, coercion.Options{Mode: coercion.ModeStrict})
+		var coercionErr *coercion.Error
+		if !errors.As(err, &coercionErr) {
+			t.Fatalf("expected a coercion error, got %v", err)
+		}
+		if len(coercionErr.Failures) != 2 || coercionErr.Failures[0].Path != "/active" || coercionErr.Failures[1].Path != "/page" {
+			t.Fatalf("unexpected failures %+v", coercionErr.Failures)
+		}
+		if result.Value.(map[string]any)["ratio"] != 1.5 {
+			t.Fatalf("expected other values to be coerced, got %v", result.Value)
+		}
+	})
+
+	t.Run("lenient", func(t *testing.T) {
+		input := map[string]any{
+			"page":   " 3.0 ",
+			"active": "Yes",
+			"ids":    "[4, 5]",
+			"name":   42.0,
+			"limit":  "",
+		}
+		result, err := coercion.Coerce(query, input, coercion.Options{Mode: coercion.ModeLenient})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]any{
+			"page":   int64(3),
+			"active": true,
+			"ids":    []any{int64(4), int64(5)},
+			"name":   "42",
+			"limit":  nil,
+		}
+		if !reflect.DeepEqual(result.Value, expected) {
+			t.Fatalf("unexpected value:\n%v\nexpected:\n%v", result.Value, expected)
+		}
+
+		// Values that cannot be converted are left for validation
+		result, err = coercion.Coerce(query, map[string]any{"page": "many"}, coercion.Options{Mode: coercion.ModeLenient})
+		if err != nil || result.Value.(map[string]any)["page"] != "many" {
+			t.Fatalf("expected the value to be kept, got %v: %v", result.Value, err)
+		}
+		if validation.ValidateValue(query, result.Value).Valid {
+			t.Fatal("expected validation to reject the value")
+		}
+	})
+
+	t.Run("json numbers", func(t *testing.T) {
+		options := coercion.Options{UseNumber: true, Separator: ";"}
+		result, err := coercion.Coerce(query, map[string]any{"ratio": "0.1000000000000000055511151231257827", "ids": "7;8"}, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		object := result.Value.(map[string]any)
+		if object["ratio"] != json.Number("0.1000000000000000055511151231257827") {
+			t.Fatalf("expected the exact number, got %#v", object["ratio"])
+		}
+		if !reflect.DeepEqual(object["ids"], []any{json.Number("7"), json.Number("8")}) {
+			t.Fatalf("unexpected ids %#v", object["ids"])
+		}
+		if v := validation.ValidateValue(query, object); !v.Valid {
+			t.Fatalf("expected json.Number values to validate: %v", v.Errors)
+		}
+
+		result, err = coercion.Coerce(query, map[string]any{"page": json.Number("4")}, coercion.Options{})
+		if err != nil || result.Value.(map[string]any)["page"] != int64(4) {
+			t.Fatalf("expected json.Number to be converted, got %v: %v", result.Value, err)
+		}
+	})
+}
+
+func TestHTTPPortalCoercion(t *testing.T) {
+	config := portal.DefaultHTTPConfig()
+	config.Coercion = &coercion.Options{Mode: coercion.ModeStrict}
+	httpPortal := portal.NewHTTPPortal(config)
+
+	double := &E2ETestFunction{
+		name: "double",
+		schema: builders.NewFunctionSchema().
+			RequiredInput("n", builders.NewIntegerSchema().Build()).
+			Name("double").
+			Build(),
+		handler: func(ctx context.Context, params api.FunctionData) (api.FunctionData, error) {
+			n, _ := params.Get("n")
+			return api.NewFunctionData(map[string]any{"result": n.(int64) * 2}), nil
+		},
+	}
+	if _, err := httpPortal.Apply(context.Background(), double); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(httpPortal.HandleHTTP().(http.Handler))
+	defer server.Close()
+
+	for input, status := range map[string]int{"21": http.StatusOK, "twenty": http.StatusBadRequest} {
+		body, _ := json.Marshal(map[string]any{"n": input})
+		resp, err := http.Post(server.URL+"/functions/double", "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Fatalf("%q: expected status %d, got %d", input, status, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/structure"
+	"defs.dev/schema/engine"
+)
+
+// findChange returns the change at a path, failing the test if there is none.
+func findChange(t *testing.T, changes structure.Changes, path string) structure.Change {
+	t.Helper()
+	for _, change := range changes {
+		if change.Path == path {
+			return change
+		}
+	}
+	t.Fatalf("Expected a change at %s, got %v", path, changes)
+	return structure.Change{}
+}
+
+func TestSchemaDiff(t *testing.T) {
+	t.Run("Properties", func(t *testing.T) {
+		old := builders.NewObjectSchema().
+			Property("id", builders.NewIntegerSchema().Build()).
+			Property("name", builders.NewStringSchema().MaxLength(50).Build()).
+			Property("nickname", builders.NewStringSchema().Build()).
+			Required("id").
+			Build()
+		new := builders.NewObjectSchema().
+			Description("Renamed descriptions are not changes").
+			Property("id", builders.NewIntegerSchema().Build()).
+			Property("name", builders.NewStringSchema().MaxLength(100).Build()).
+			Property("email", builders.NewStringSchema().Build()).
+			Required("id", "name").
+			Build()
+
+		changes := structure.Diff(old, new)
+		if len(changes) != 4 {
+			t.Fatalf("Expected 4 changes, got %v", changes)
+		}
+		expected := map[string]structure.Compatibility{
+			"/properties/email":          structure.FullyCompatible,
+			"/properties/name":           structure.ForwardCompatible,
+			"/properties/name/maxLength": structure.BackwardCompatible,
+			"/properties/nickname":       structure.FullyCompatible,
+		}
+		for path, compatibility := range expected {
+			if change := findChange(t, changes, path); change.Compatibility != compatibility {
+				t.Errorf("Expected %s to be %s, got %s", path, compatibility, change)
+			}
+		}
+		if changes.Compatibility() != structure.Breaking {
+			t.Errorf("Expected loosening and tightening together to break, got %s", changes.Compatibility())
+		}
+		if len(structure.Diff(old, old.Clone())) != 0 {
+			t.Error("Expected no changes between equal schemas")
+		}
+	})
+
+	t.Run("Closed objects", func(t *testing.T) {
+		old := builders.NewObjectSchema().
+			Property("id", builders.NewIntegerSchema().Build()).
+			AdditionalProperties(false).
+			Build()
+		withRequired := builders.NewObjectSchema().
+			Property("id", builders.NewIntegerSchema().Build()).
+			Property("kind", builders.NewStringSchema().Build()).
+			Required("kind").
+			AdditionalProperties(false).
+			Build()
+		if change := findChange(t, structure.Diff(old, withRequired), "/properties/kind"); change.Compatibility != structure.Breaking {
+			t.Errorf("Expected required property on closed object to break, got %s", change)
+		}
+	})
+
+	t.Run("Types and constraints", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			old, new core.Schema
+			expected structure.Compatibility
+		}{
+			{"integer to number", builders.NewIntegerSchema().Build(), builders.NewNumberSchema().Build(), structure.BackwardCompatible},
+			{"string to integer", builders.NewStringSchema().Build(), builders.NewIntegerSchema().Build(), structure.Breaking},
+			{"made optional", builders.NewStringSchema().Build(), builders.NewOptionalSchema().Of(builders.NewStringSchema().Build()).Build(), structure.BackwardCompatible},
+			{"minimum raised", builders.NewIntegerSchema().Min(1).Build(), builders.NewIntegerSchema().Min(5).Build(), structure.ForwardCompatible},
+			{"pattern added", builders.NewStringSchema().Build(), builders.NewStringSchema().Pattern("^a").Build(), structure.ForwardCompatible},
+			{"pattern changed", builders.NewStringSchema().Pattern("^a").Build(), builders.NewStringSchema().Pattern("^b").Build(), structure.Breaking},
+			{"enum value added", builders.NewStringSchema().Enum("a").Build(), builders.NewStringSchema().Enum("a", "b").Build(), structure.BackwardCompatible},
+			{"items tightened", builders.NewArraySchema().Items(builders.NewStringSchema().Build()).Build(), builders.NewArraySchema().Items(builders.NewStringSchema().MinLength(1).Build()).Build(), structure.ForwardCompatible},
+			{"reference renamed", engine.NewRef(engine.NewSchemaEngine(), "A"), engine.NewRef(engine.NewSchemaEngine(), "B"), structure.Breaking},
+		}
+		for _, c := range cases {
+			if got := structure.Diff(c.old, c.new).Compatibility(); got != c.expected {
+				t.Errorf("%s: expected %s, got %s (%v)", c.name, c.expected, got, structure.Diff(c.old, c.new))
+			}
+		}
+	})
+
+	t.Run("Functions and services", func(t *testing.T) {
+		old := builders.NewFunctionSchema().
+			RequiredInput("id", builders.NewStringSchema().Build()).
+			RequiredOutput("name", builders.NewStringSchema().Build()).
+			Build()
+		widened := builders.NewFunctionSchema().
+			RequiredInput("id", builders.NewStringSchema().Build()).
+			OptionalInput("verbose", builders.NewBooleanSchema().Build()).
+			RequiredOutput("name", builders.NewStringSchema().MinLength(1).Build()).
+			Build()
+		narrowed := builders.NewFunctionSchema().
+			RequiredInput("id", builders.NewStringSchema().MinLength(3).Build()).
+			RequiredInput("tenant", builders.NewStringSchema().Build()).
+			RequiredOutput("name", builders.NewStringSchema().Build()).
+			Build()
+
+		changes := structure.Diff(old, widened)
+		if changes.Compatibility() != structure.BackwardCompatible {
+			t.Errorf("Expected new optional input and tighter output to keep callers working, got %v", changes)
+		}
+		findChange(t, changes, "/inputs/verbose")
+		findChange(t, changes, "/outputs/name/minLength")
+
+		if change := findChange(t, structure.Diff(old, narrowed), "/inputs/tenant"); change.Compatibility != structure.Breaking || change.Kind != structure.ChangeAdded {
+			t.Errorf("Expected required input to break callers, got %s", change)
+		}
+
+		oldService := builders.NewServiceSchema().Name("Users").Method("get", old).Method("delete", old).Build()
+		newService := builders.NewServiceSchema().Name("Users").Method("get", narrowed).Method("list", old).Build()
+		changes = structure.Diff(oldService, newService)
+		if change := findChange(t, changes, "/methods/list"); change.Compatibility != structure.BackwardCompatible {
+			t.Errorf("Expected added method to be backward compatible, got %s", change)
+		}
+		if change := findChange(t, changes, "/methods/delete"); change.Kind != structure.ChangeRemoved {
+			t.Errorf("Expected removed method, got %s", change)
+		}
+		findChange(t, changes, "/methods/get/inputs/id/minLength")
+	})
+}
+
+func TestEngineCompatibilityMode(t *testing.T) {
+	v1 := builders.NewObjectSchema().
+		Property("id", builders.NewIntegerSchema().Build()).
+		Required("id").
+		Build()
+	v2 := builders.NewObjectSchema().
+		Property("id", builders.NewIntegerSchema().Build()).
+		Property("email", builders.NewStringSchema().Build()).
+		Required("id").
+		Build()
+	v3 := builders.NewObjectSchema().
+		Property("id", builders.NewIntegerSchema().Build()).
+		Property("email", builders.NewStringSchema().Build()).
+		Required("id", "email").
+		Build()
+
+	config := engine.DefaultEngineConfig()
+	config.Compatibility = structure.BackwardCompatible
+	e := engine.NewSchemaEngineWithConfig(config)
+
+	if err := e.RegisterSchema("app:User@1.9.0", v1); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.RegisterSchema("app:User@1.10.0", v2); err != nil {
+		t.Errorf("Expected compatible version to register, got %v", err)
+	}
+
+	err := e.RegisterSchema("app:User@2.0.0", v3)
+	var engineErr engine.EngineError
+	if !errors.As(err, &engineErr) || engineErr.Type != engine.ErrorTypeIncompatible {
+		t.Fatalf("Expected incompatible schema error, got %v", err)
+	}
+	details := engineErr.Details.(map[string]any)
+	if details["previous_version"] != "app:User@1.10.0" {
+		t.Errorf("Expected comparison with the closest lower version, got %v", details["previous_version"])
+	}
+	if e.HasSchema("app:User@2.0.0") {
+		t.Error("Expected rejected version not to be registered")
+	}
+
+	if err := e.RegisterSchema("other:User@2.0.0", v3); err != nil {
+		t.Errorf("Expected other namespaces to be unaffected, got %v", err)
+	}
+	if err := engine.NewSchemaEngine().RegisterSchema("app:User@2.0.0", v3); err != nil {
+		t.Errorf("Expected no check without a compatibility mode, got %v", err)
+	}
+}
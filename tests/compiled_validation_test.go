@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"defs.dev/schema/api"
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/engine"
+	"defs.dev/schema/runtime/registry"
+)
+
+// newInventorySchema builds an array of items whose SKUs must match a pattern.
+func newInventorySchema() core.ArraySchema {
+	item := builders.NewObjectSchema().
+		Property("id", builders.NewIntegerSchema().Min(1).Build()).
+		Property("sku", builders.NewStringSchema().Pattern(`^[A-Z]{3}-[0-9]{4}$`).Build()).
+		Property("tags", builders.NewArraySchema().Items(builders.NewStringSchema().Build()).Build()).
+		Required("id", "sku").
+		Build()
+	return builders.NewArraySchema().Items(item).Build()
+}
+
+func newInventory(n int) []any {
+	items := make([]any, n)
+	for i := range items {
+		items[i] = map[string]any{"id": float64(i + 1), "sku": "ABC-1234", "tags": []any{"new"}}
+	}
+	return items
+}
+
+// issueCodes returns the sorted codes of the errors of a result, which
+// objects report in no particular order.
+func issueCodes(result validation.ValidationResult) []string {
+	codes := make([]string, len(result.Errors))
+	for i, issue := range result.Errors {
+		codes[i] = issue.Code
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func TestCompile(t *testing.T) {
+	schema := newInventorySchema()
+	validator := validation.Compile(schema)
+	if validator.Schema() != schema {
+		t.Fatal("expected the validator to keep its schema")
+	}
+
+	invalid := newInventory(3)
+	invalid[1] = map[string]any{"id": float64(0), "sku": "abc"}
+	for _, value := range []any{newInventory(3), invalid, "not an array"} {
+		compiled, expected := validator.Validate(value), validation.ValidateValue(schema, value)
+		if compiled.Valid != expected.Valid || !reflect.DeepEqual(issueCodes(compiled), issueCodes(expected)) {
+			t.Fatalf("compiled result differs:\n%+v\nexpected:\n%+v", compiled, expected)
+		}
+	}
+	if result := validator.Validate(invalid); result.Valid || len(result.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %+v", result)
+	}
+
+	t.Run("recursive references", func(t *testing.T) {
+		node := newTreeSchema(t, engine.NewSchemaEngine())
+		validator := validation.Compile(node)
+		tree := map[string]any{"value": "root", "children": []any{
+			map[string]any{"value": "a", "children": []any{map[string]any{}}},
+		}}
+		result := validator.Validate(tree)
+		if result.Valid || result.Errors[0].Code != "missing_required_property" {
+			t.Fatalf("expected the nested node to be invalid, got %+v", result)
+		}
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(valid bool) {
+				defer wg.Done()
+				value := newInventory(50)
+				if !valid {
+					value = invalid
+				}
+				if result := validator.Validate(value); result.Valid != valid {
+					t.Errorf("expected valid=%v, got %+v", valid, result)
+				}
+			}(i%2 == 0)
+		}
+		wg.Wait()
+	})
+}
+
+func TestFunctionRegistryCompiledValidation(t *testing.T) {
+	functions := registry.NewFunctionRegistry()
+	greet := &E2ETestFunction{
+		name: "greet",
+		schema: builders.NewFunctionSchema().
+			RequiredInput("name", builders.NewStringSchema().MinLength(1).Build()).
+			Name("greet").
+			Build(),
+		handler: func(ctx context.Context, params api.FunctionData) (api.FunctionData, error) {
+			return params, nil
+		},
+	}
+	if err := functions.Register("greet", greet); err != nil {
+		t.Fatal(err)
+	}
+
+	if result := functions.Validate("greet", map[string]any{"name": "Ada"}); !result.Valid {
+		t.Fatalf("expected valid input, got %+v", result.Errors)
+	}
+	if result := functions.Validate("greet", api.NewFunctionData(map[string]any{"name": ""})); result.Valid {
+		t.Fatal("expected an empty name to be invalid")
+	}
+	if result := functions.Validate("greet", map[string]any{}); result.Valid || result.Errors[0].Code != "missing_required_input" {
+		t.Fatalf("expected a missing input, got %+v", result)
+	}
+}
+
+// BenchmarkValidation compares validating against a schema compiled once
+// with ValidateValue, which resolves the consumers of every nested value as
+// it validates it.
+func BenchmarkValidation(b *testing.B) {
+	schema := newInventorySchema()
+	validator := validation.Compile(schema)
+	for _, size := range []int{1, 10000} {
+		value := newInventory(size)
+		b.Run(fmt.Sprintf("ValidateValue/%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !validation.ValidateValue(schema, value).Valid {
+					b.Fatal("expected a valid value")
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Compiled/%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !validator.Validate(value).Valid {
+					b.Fatal("expected a valid value")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/api"
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/construct/document"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/structure"
+	"defs.dev/schema/runtime/registry"
+	jsonexport "defs.dev/schema/visit/export/json"
+)
+
+// newStoreServiceSchema builds a key-value store service; withDelete adds a
+// second method.
+func newStoreServiceSchema(name string, withDelete bool) core.ServiceSchema {
+	get := builders.NewFunctionSchema().
+		RequiredInput("key", builders.NewStringSchema().Build()).
+		RequiredOutput("value", builders.NewStringSchema().Build()).
+		Build()
+	service := builders.NewServiceSchema().Name(name).Method("get", get)
+	if withDelete {
+		service = service.Method("delete", builders.NewFunctionSchema().
+			RequiredInput("key", builders.NewStringSchema().Build()).
+			Build())
+	}
+	return service.Build()
+}
+
+// recordedService records when it is started and stopped.
+type recordedService struct {
+	api.Service
+	log     *[]string
+	failing bool
+	onStart func()
+}
+
+func (s *recordedService) Start(ctx context.Context) error {
+	if s.failing {
+		return fmt.Errorf("refusing to start")
+	}
+	if s.onStart != nil {
+		s.onStart()
+	}
+	*s.log = append(*s.log, "start "+s.Name())
+	return s.Service.Start(ctx)
+}
+
+func (s *recordedService) Stop(ctx context.Context) error {
+	*s.log = append(*s.log, "stop "+s.Name())
+	return s.Service.Stop(ctx)
+}
+
+func newRecordedComponent(log *[]string, schema core.ComponentSchema, config map[string]any) *api.ComponentImpl {
+	var services []api.Service
+	for _, provided := range schema.Provides() {
+		services = append(services, &recordedService{Service: api.NewService(provided.Name(), provided), log: log})
+	}
+	return api.NewComponent(schema, config, services...)
+}
+
+func newShopComponentSchemas() (storage, catalog, web core.ComponentSchema) {
+	storage = builders.NewComponentSchema().
+		Name("storage").
+		Provides(newStoreServiceSchema("kv", true)).
+		Config(builders.NewObjectSchema().
+			Property("path", builders.NewStringSchema().Build()).
+			Required("path").
+			Build()).
+		Build()
+	catalog = builders.NewComponentSchema().
+		Name("catalog").
+		Requires(newStoreServiceSchema("store", false)).
+		Provides(newStoreServiceSchema("products", false)).
+		Publishes(newOrderTopicSchema()).
+		Build()
+	web = builders.NewComponentSchema().
+		Name("web").
+		Requires(newStoreServiceSchema("products", false)).
+		Subscribes(newOrderTopicSchema()).
+		Build()
+	return storage, catalog, web
+}
+
+func TestComponentSchema(t *testing.T) {
+	_, catalog, _ := newShopComponentSchemas()
+
+	if catalog.Type() != core.TypeComponent || catalog.Name() != "catalog" {
+		t.Fatalf("unexpected component %s %q", catalog.Type(), catalog.Name())
+	}
+	if len(catalog.Provides()) != 1 || len(catalog.Requires()) != 1 || len(catalog.Publishes()) != 1 || len(catalog.Subscribes()) != 0 {
+		t.Fatal("unexpected component members")
+	}
+
+	t.Run("json export", func(t *testing.T) {
+		output, err := jsonexport.NewGenerator().Generate(catalog)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var exported map[string]any
+		if err := json.Unmarshal(output, &exported); err != nil {
+			t.Fatal(err)
+		}
+		component, _ := exported["x-component"].(map[string]any)
+		if exported["title"] != "catalog" || fmt.Sprint(component["requires"]) != "[store]" {
+			t.Fatalf("unexpected export %s", output)
+		}
+	})
+
+	t.Run("document round trip", func(t *testing.T) {
+		data, err := document.Marshal(catalog)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := document.Unmarshal(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !structure.Equal(catalog, decoded) {
+			t.Fatalf("round trip changed the component:\n%s", data)
+		}
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		providesMore := builders.NewComponentSchema().
+			Name("catalog").
+			Requires(newStoreServiceSchema("store", false)).
+			Provides(newStoreServiceSchema("products", true)).
+			Publishes(newOrderTopicSchema()).
+			Build()
+		if got := structure.Diff(catalog, providesMore).Compatibility(); got != structure.BackwardCompatible {
+			t.Fatalf("providing more should be backward compatible, got %s", got)
+		}
+
+		requiresMore := builders.NewComponentSchema().
+			Name("catalog").
+			Requires(newStoreServiceSchema("store", true)).
+			Provides(newStoreServiceSchema("products", false)).
+			Publishes(newOrderTopicSchema()).
+			Build()
+		if got := structure.Diff(catalog, requiresMore).Compatibility(); got != structure.ForwardCompatible {
+			t.Fatalf("requiring more should be forward compatible, got %s", got)
+		}
+	})
+}
+
+func TestComponentRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("starts in dependency order", func(t *testing.T) {
+		var log []string
+		storage, catalog, web := newShopComponentSchemas()
+		r := registry.NewComponentRegistry()
+		for _, component := range []api.Component{
+			newRecordedComponent(&log, web, nil),
+			newRecordedComponent(&log, catalog, nil),
+			newRecordedComponent(&log, storage, map[string]any{"path": "/tmp/kv"}),
+		} {
+			if err := r.Register(component); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// The store requirement has only get; kv provides get and delete
+		bindings, err := r.Wire()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(bindings) != 2 || bindings[0] != (api.ComponentBinding{Component: "catalog", Required: "store", Provider: "storage", Service: "kv"}) {
+			t.Fatalf("unexpected bindings %+v", bindings)
+		}
+
+		order, err := r.StartOrder()
+		if err != nil || strings.Join(order, ",") != "storage,catalog,web" {
+			t.Fatalf("unexpected order %v: %v", order, err)
+		}
+
+		if err := r.Start(ctx); err != nil {
+			t.Fatal(err)
+		}
+		webComponent, _ := r.Get("web")
+		if products, ok := webComponent.(*api.ComponentImpl).Dependency("products"); !ok || !products.IsRunning() {
+			t.Fatal("expected web to be bound to the running products service")
+		}
+		if err := r.Stop(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(log, ","); got != "start kv,start products,stop products,stop kv" {
+			t.Fatalf("unexpected lifecycle %s", got)
+		}
+	})
+
+	t.Run("rejects invalid components", func(t *testing.T) {
+		var log []string
+		storage, _, _ := newShopComponentSchemas()
+		r := registry.NewComponentRegistry()
+
+		if err := r.Register(newRecordedComponent(&log, storage, map[string]any{})); err == nil {
+			t.Fatal("expected missing config to fail")
+		}
+		if err := r.Register(api.NewComponent(storage, map[string]any{"path": "/tmp/kv"})); err == nil {
+			t.Fatal("expected a component without its provided service to fail")
+		}
+	})
+
+	t.Run("unresolved requirements", func(t *testing.T) {
+		var log []string
+		_, catalog, web := newShopComponentSchemas()
+		r := registry.NewComponentRegistry()
+		r.Register(newRecordedComponent(&log, catalog, nil))
+		r.Register(newRecordedComponent(&log, web, nil))
+
+		if _, err := r.Wire(); err == nil || !strings.Contains(err.Error(), "no provider for service store") {
+			t.Fatalf("expected missing provider, got %v", err)
+		}
+		if err := r.Start(ctx); err == nil || r.Running() {
+			t.Fatal("expected start to fail")
+		}
+
+		// A provider without the required method does not match
+		r.Register(newRecordedComponent(&log, builders.NewComponentSchema().
+			Name("empty").
+			Provides(builders.NewServiceSchema().Name("store").Build()).
+			Build(), nil))
+		if _, err := r.Wire(); err == nil {
+			t.Fatal("expected an incompatible provider to be ignored")
+		}
+	})
+
+	t.Run("dependency cycle", func(t *testing.T) {
+		var log []string
+		r := registry.NewComponentRegistry()
+		r.Register(newRecordedComponent(&log, builders.NewComponentSchema().
+			Name("a").
+			Provides(newStoreServiceSchema("first", false)).
+			Requires(newStoreServiceSchema("second", false)).
+			Build(), nil))
+		r.Register(newRecordedComponent(&log, builders.NewComponentSchema().
+			Name("b").
+			Provides(newStoreServiceSchema("second", false)).
+			Requires(newStoreServiceSchema("first", false)).
+			Build(), nil))
+
+		if _, err := r.StartOrder(); err == nil || !strings.Contains(err.Error(), "cycle") {
+			t.Fatalf("expected cycle, got %v", err)
+		}
+	})
+
+	t.Run("failed start stops started services", func(t *testing.T) {
+		var log []string
+		storage, catalog, _ := newShopComponentSchemas()
+		r := registry.NewComponentRegistry()
+		r.Register(newRecordedComponent(&log, storage, map[string]any{"path": "/tmp/kv"}))
+
+		failing := newRecordedComponent(&log, catalog, nil)
+		failing.Services()[0].(*recordedService).failing = true
+		r.Register(failing)
+
+		if err := r.Start(ctx); err == nil {
+			t.Fatal("expected start to fail")
+		}
+		if got := strings.Join(log, ","); got != "start kv,stop kv" {
+			t.Fatalf("unexpected lifecycle %s", got)
+		}
+	})
+
+	t.Run("callbacks can use the registry", func(t *testing.T) {
+		var log []string
+		storage, _, _ := newShopComponentSchemas()
+		r := registry.NewComponentRegistry()
+		component := newRecordedComponent(&log, storage, map[string]any{"path": "/tmp/kv"})
+		r.Register(component)
+
+		var restart error
+		component.Services()[0].(*recordedService).onStart = func() {
+			if _, ok := r.Get("storage"); !ok || r.Running() {
+				t.Error("expected the registry to be readable and not yet running during start")
+			}
+			restart = r.Start(ctx)
+		}
+		if err := r.Start(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if restart == nil || !r.Running() {
+			t.Fatalf("expected a nested start to be refused, got %v", restart)
+		}
+		if err := r.Stop(ctx); err != nil || r.Running() {
+			t.Fatalf("expected stop to succeed, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+	jsonexport "defs.dev/schema/visit/export/json"
+	"defs.dev/schema/visit/export/typescript"
+)
+
+func newEntitySchema() core.ObjectSchema {
+	return builders.NewObjectSchema().
+		Name("Entity").
+		Property("id", builders.NewIntegerSchema().Min(1).Build()).
+		Property("createdAt", builders.NewStringSchema().Build()).
+		Required("id").
+		Build()
+}
+
+func newAccountSchema() core.ObjectSchema {
+	return builders.NewObjectSchema().
+		Extend(newEntitySchema()).
+		Name("Account").
+		Property("email", builders.NewStringSchema().Email().Build()).
+		Required("email").
+		Build()
+}
+
+func TestObjectComposition(t *testing.T) {
+	t.Run("Extend", func(t *testing.T) {
+		account := newAccountSchema()
+
+		if account.Metadata().Name != "Account" {
+			t.Errorf("Expected extension to keep its name, got %q", account.Metadata().Name)
+		}
+		if len(account.Properties()) != 3 || len(account.Required()) != 2 {
+			t.Errorf("Expected base and own properties, got %v required %v", account.Properties(), account.Required())
+		}
+		composition := account.Metadata().Composition
+		if composition == nil || composition.Kind != core.CompositionExtend || composition.Sources[0].Metadata().Name != "Entity" {
+			t.Fatalf("Expected extend provenance from Entity, got %+v", composition)
+		}
+
+		if result := validation.ValidateValue(account, map[string]any{"id": 1, "email": "a@example.com"}); !result.Valid {
+			t.Errorf("Expected account to be valid, got errors: %v", result.Errors)
+		}
+		if result := validation.ValidateValue(account, map[string]any{"email": "a@example.com"}); result.Valid {
+			t.Error("Expected account without base property id to be invalid")
+		}
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		audited := builders.NewObjectSchema().
+			Name("Audited").
+			Property("createdAt", builders.NewStringSchema().Build()).
+			Property("updatedAt", builders.NewStringSchema().Build()).
+			Build()
+
+		merged, err := schemas.Merge(newEntitySchema(), audited)
+		if err != nil {
+			t.Fatalf("Expected compatible schemas to merge, got %v", err)
+		}
+		if len(merged.Properties()) != 3 {
+			t.Errorf("Expected 3 properties, got %v", merged.Properties())
+		}
+
+		conflicting := builders.NewObjectSchema().
+			Property("id", builders.NewStringSchema().Build()).
+			Build()
+		_, err = schemas.Merge(newEntitySchema(), conflicting)
+		var conflict *schemas.CompositionConflictError
+		if !errors.As(err, &conflict) || len(conflict.Properties) != 1 || conflict.Properties[0] != "id" {
+			t.Errorf("Expected conflict on id, got %v", err)
+		}
+	})
+
+	t.Run("Pick and Omit", func(t *testing.T) {
+		account := newAccountSchema()
+
+		picked, err := schemas.Pick(account, "id", "email")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(picked.Properties()) != 2 || len(picked.Required()) != 2 {
+			t.Errorf("Expected id and email, got %v required %v", picked.Properties(), picked.Required())
+		}
+		if _, err := schemas.Pick(account, "missing"); err == nil {
+			t.Error("Expected error picking an unknown property")
+		}
+
+		omitted := schemas.Omit(account, "id")
+		if _, ok := omitted.Properties()["id"]; ok {
+			t.Error("Expected id to be omitted")
+		}
+		if len(omitted.Required()) != 1 || omitted.Required()[0] != "email" {
+			t.Errorf("Expected only email to stay required, got %v", omitted.Required())
+		}
+	})
+
+	t.Run("AllOf", func(t *testing.T) {
+		withAddress := builders.NewObjectSchema().
+			Property("address", builders.NewObjectSchema().
+				Property("city", builders.NewStringSchema().Build()).
+				Required("city").
+				Build()).
+			Build()
+		withZip := builders.NewObjectSchema().
+			Property("address", builders.NewObjectSchema().
+				Property("zip", builders.NewStringSchema().Build()).
+				Required("zip").
+				Build()).
+			Build()
+
+		intersection, err := schemas.AllOf(withAddress, withZip)
+		if err != nil {
+			t.Fatalf("Expected nested objects to intersect, got %v", err)
+		}
+		address := intersection.Properties()["address"].(core.ObjectSchema)
+		if len(address.Properties()) != 2 || len(address.Required()) != 2 {
+			t.Errorf("Expected intersected address, got %v required %v", address.Properties(), address.Required())
+		}
+
+		conflicting := builders.NewObjectSchema().
+			Property("address", builders.NewStringSchema().Build()).
+			Build()
+		if _, err := schemas.AllOf(withAddress, conflicting); err == nil {
+			t.Error("Expected conflict intersecting an object with a string")
+		}
+	})
+}
+
+func TestObjectCompositionExport(t *testing.T) {
+	account := newAccountSchema()
+
+	t.Run("JSON Schema", func(t *testing.T) {
+		output, err := jsonexport.NewGenerator().Generate(account)
+		if err != nil {
+			t.Fatalf("Failed to generate JSON Schema: %v", err)
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal(output, &doc); err != nil {
+			t.Fatalf("Failed to parse JSON Schema: %v", err)
+		}
+		branches, ok := doc["allOf"].([]any)
+		if !ok || len(branches) != 2 {
+			t.Fatalf("Expected allOf with 2 branches, got %v", doc)
+		}
+		if branches[0].(map[string]any)["$ref"] != "#/definitions/Entity" {
+			t.Errorf("Expected $ref to Entity, got %v", branches[0])
+		}
+		if definitions, ok := doc["definitions"].(map[string]any); !ok || definitions["Entity"] == nil {
+			t.Errorf("Expected Entity in definitions, got %v", doc["definitions"])
+		}
+
+		closed := builders.NewObjectSchema().Extend(newEntitySchema()).Name("Closed").AdditionalProperties(false).Build()
+		output, err = jsonexport.NewGenerator().Generate(closed)
+		if err != nil {
+			t.Fatalf("Failed to generate JSON Schema: %v", err)
+		}
+		if strings.Contains(string(output), "allOf") {
+			t.Errorf("Expected closed extension to be flattened:\n%s", output)
+		}
+	})
+
+	t.Run("TypeScript", func(t *testing.T) {
+		output, err := typescript.NewGenerator().Generate(account)
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		if !strings.Contains(string(output), "export interface Account extends Entity {") {
+			t.Errorf("Unexpected TypeScript output:\n%s", output)
+		}
+
+		picked, err := schemas.Pick(account, "id", "email")
+		if err != nil {
+			t.Fatal(err)
+		}
+		metadata := picked.Metadata()
+		metadata.Name = "AccountKey"
+		output, err = typescript.NewGenerator().Generate(schemas.WithMetadata(picked, metadata))
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		if !strings.Contains(string(output), `export type AccountKey = Pick<Account, "id" | "email">;`) {
+			t.Errorf("Unexpected TypeScript output:\n%s", output)
+		}
+	})
+}
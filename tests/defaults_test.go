@@ -0,0 +1,232 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"defs.dev/schema/api"
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/defaults"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/runtime/portal"
+	"defs.dev/schema/runtime/registry"
+)
+
+// newSettingsSchema builds an object with defaults at several levels.
+func newSettingsSchema() core.ObjectSchema {
+	retry := builders.NewObjectSchema().
+		Name("Retry").
+		Property("attempts", builders.NewIntegerSchema().Min(1).Default(3).Build()).
+		Property("backoff", builders.NewNumberSchema().Default(1.5).Build()).
+		Build()
+	return builders.NewObjectSchema().
+		Name("Settings").
+		Property("name", builders.NewStringSchema().Build()).
+		Property("mode", builders.NewStringSchema().Enum("fast", "safe").Default("safe").Build()).
+		Property("verbose", builders.NewBooleanSchema().Default(false).Build()).
+		Property("tags", builders.NewArraySchema().Items(builders.NewStringSchema().Build()).Default([]any{"default"}).Build()).
+		Property("retry", builders.NewObjectSchema().
+			Default(map[string]any{}).
+			Property("attempts", builders.NewIntegerSchema().Min(1).Default(3).Build()).
+			Build()).
+		Property("endpoints", builders.NewArraySchema().Items(retry).Build()).
+		Property("nickname", builders.NewOptionalSchema().Of(builders.NewStringSchema().Default("anon").Build()).Build()).
+		Required("name").
+		Build()
+}
+
+// newGreetFunction builds a function whose optional inputs have defaults.
+func newGreetFunction() *E2ETestFunction {
+	return &E2ETestFunction{
+		name: "greet",
+		schema: builders.NewFunctionSchema().
+			RequiredInput("name", builders.NewStringSchema().Build()).
+			OptionalInput("greeting", builders.NewStringSchema().Default("Hello").Build()).
+			OptionalInput("excited", builders.NewBooleanSchema().Default(false).Build()).
+			Name("greet").
+			Build(),
+		handler: func(ctx context.Context, params api.FunctionData) (api.FunctionData, error) {
+			greeting, _ := params.Get("greeting")
+			name, _ := params.Get("name")
+			excited, _ := params.Get("excited")
+			message := ""
+			if greeting != nil {
+				message = greeting.(string) + ", " + name.(string)
+			}
+			if excited == true {
+				message += "!"
+			}
+			return api.NewFunctionData(map[string]any{"message": message}), nil
+		},
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	settings := newSettingsSchema()
+
+	t.Run("nested objects and arrays", func(t *testing.T) {
+		input := map[string]any{
+			"name":      "api",
+			"verbose":   true,
+			"endpoints": []any{map[string]any{"attempts": int64(5)}, map[string]any{}},
+		}
+		value, err := defaults.Apply(settings, input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := map[string]any{
+			"name":    "api",
+			"mode":    "safe",
+			"verbose": true,
+			"tags":    []any{"default"},
+			"retry":   map[string]any{"attempts": int64(3)},
+			"endpoints": []any{
+				map[string]any{"attempts": int64(5), "backoff": 1.5},
+				map[string]any{"attempts": int64(3), "backoff": 1.5},
+			},
+			"nickname": "anon",
+		}
+		if !reflect.DeepEqual(value, expected) {
+			t.Fatalf("unexpected value:\n%v\nexpected:\n%v", value, expected)
+		}
+		if result := validation.ValidateValue(settings, value); !result.Valid {
+			t.Fatalf("expected a valid value: %v", result.Errors)
+		}
+	})
+
+	t.Run("input and defaults are not modified", func(t *testing.T) {
+		input := map[string]any{"name": "api", "endpoints": []any{map[string]any{}}}
+		value, err := defaults.Apply(settings, input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(input) != 2 || len(input["endpoints"].([]any)[0].(map[string]any)) != 0 {
+			t.Fatalf("input was modified: %v", input)
+		}
+
+		value.(map[string]any)["tags"].([]any)[0] = "changed"
+		again, _ := defaults.Apply(settings, map[string]any{})
+		if tags := again.(map[string]any)["tags"].([]any); tags[0] != "default" {
+			t.Fatalf("schema default was modified: %v", tags)
+		}
+	})
+
+	t.Run("present values are kept", func(t *testing.T) {
+		value, err := defaults.Apply(settings, map[string]any{"name": "api", "mode": "fast", "nickname": nil})
+		if err != nil {
+			t.Fatal(err)
+		}
+		object := value.(map[string]any)
+		if object["mode"] != "fast" {
+			t.Fatalf("expected mode to be kept, got %v", object["mode"])
+		}
+		if nickname, ok := object["nickname"]; !ok || nickname != nil {
+			t.Fatalf("expected an explicit null to be kept, got %v", nickname)
+		}
+	})
+
+	t.Run("root values", func(t *testing.T) {
+		value, err := defaults.Apply(builders.NewStringSchema().Default("x").Build(), nil)
+		if err != nil || value != "x" {
+			t.Fatalf("expected the root default, got %v: %v", value, err)
+		}
+		value, err = defaults.Apply(builders.NewIntegerSchema().Default(1).Build(), int64(7))
+		if err != nil || value != int64(7) {
+			t.Fatalf("expected the value to be kept, got %v: %v", value, err)
+		}
+		// Values of the wrong type are left for validation to report
+		value, err = defaults.Apply(settings, "not an object")
+		if err != nil || value != "not an object" {
+			t.Fatalf("expected the value to be kept, got %v: %v", value, err)
+		}
+	})
+
+	t.Run("function inputs and topics", func(t *testing.T) {
+		value, err := defaults.Apply(newGreetFunction().Schema(), map[string]any{"name": "Ada"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]any{"name": "Ada", "greeting": "Hello", "excited": false}
+		if !reflect.DeepEqual(value, expected) {
+			t.Fatalf("unexpected inputs %v", value)
+		}
+
+		topic := builders.NewTopicSchema().
+			Name("settings").
+			Payload(settings).
+			Build()
+		message, err := defaults.Apply(topic, map[string]any{"payload": map[string]any{"name": "api"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if payload := message.(map[string]any)["payload"].(map[string]any); payload["mode"] != "safe" {
+			t.Fatalf("expected payload defaults, got %v", payload)
+		}
+	})
+}
+
+func TestApplyDefaultsOptIn(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("function registry", func(t *testing.T) {
+		functions := registry.NewFunctionRegistry()
+		if err := functions.Register("greet", newGreetFunction()); err != nil {
+			t.Fatal(err)
+		}
+		input := api.NewFunctionData(map[string]any{"name": "Ada"})
+
+		output, err := functions.Call(ctx, "greet", input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if message, _ := output.Get("message"); message != "" {
+			t.Fatalf("expected no defaults without opting in, got %q", message)
+		}
+
+		functions.SetApplyDefaults(true)
+		output, err = functions.Call(ctx, "greet", input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if message, _ := output.Get("message"); message != "Hello, Ada" {
+			t.Fatalf("expected defaults to be applied, got %q", message)
+		}
+		if input.Has("greeting") {
+			t.Fatal("expected the call parameters not to be modified")
+		}
+	})
+
+	t.Run("http portal", func(t *testing.T) {
+		config := portal.DefaultHTTPConfig()
+		config.ApplyDefaults = true
+		httpPortal := portal.NewHTTPPortal(config)
+		if _, err := httpPortal.Apply(ctx, newGreetFunction()); err != nil {
+			t.Fatal(err)
+		}
+		server := httptest.NewServer(httpPortal.HandleHTTP().(http.Handler))
+		defer server.Close()
+
+		body, _ := json.Marshal(map[string]any{"name": "Ada"})
+		resp, err := http.Post(server.URL+"/functions/greet", "application/json", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		var response map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+			t.Fatalf("status %d: %v", resp.StatusCode, err)
+		}
+		result, _ := response["result"].(map[string]any)
+		if result["message"] != "Hello, Ada" {
+			t.Fatalf("expected defaults to be applied, got %v", response)
+		}
+	})
+}
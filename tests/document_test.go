@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/construct/document"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/annotation"
+	"defs.dev/schema/core/structure"
+	"defs.dev/schema/engine"
+	"defs.dev/schema/schemas"
+)
+
+// newCatalogSchema builds an object touching every schema type the document
+// format covers.
+func newCatalogSchema(t *testing.T) core.Schema {
+	t.Helper()
+
+	e, _, user := newPageEngine(t)
+	pageOfUsers, err := e.Instantiate("Page", user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := newTreeSchema(t, e)
+
+	sku, err := annotation.NewRegistry().Create("x-sku", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := schemas.NewStringSchema(schemas.StringSchemaConfig{
+		Metadata:    core.SchemaMetadata{Name: "Code", Tags: []string{"id"}},
+		Annotations: []core.Annotation{sku},
+		Pattern:     regexp.MustCompile("^[a-z]+$"),
+		Format:      "uuid",
+	})
+
+	getItem := builders.NewFunctionSchema().
+		Input("id", code).
+		Returns(newUserResult()).
+		Description("Looks up an item").
+		Build()
+
+	return builders.NewObjectSchema().
+		Extend(newEntitySchema()).
+		Name("Catalog").
+		Description("Everything at once").
+		Property("code", code).
+		Property("status", builders.NewStringSchema().Enum("draft", "live").Default("draft").Build()).
+		Property("price", builders.NewNumberSchema().Range(0, 1e6).Build()).
+		Property("stock", builders.NewIntegerSchema().Min(0).Example(12).Build()).
+		Property("sizes", builders.NewArraySchema().Items(builders.NewIntegerSchema().Build()).Default([]any{int64(1), int64(2)}).Build()).
+		Property("active", builders.NewBooleanSchema().Build()).
+		Property("record", newRecordTuple().Build()).
+		Property("labels", builders.NewMapSchema().KeyPattern("^[a-z]+$").Values(builders.NewStringSchema().Build()).Build()).
+		Property("note", builders.NewOptionalSchema().Of(builders.NewStringSchema().Build()).Build()).
+		Property("owner", builders.NewUnionSchema().
+			Schemas(builders.NewStringSchema().Build(), builders.NewNullSchema().Build()).
+			AnyOf().
+			Build()).
+		Property("users", pageOfUsers).
+		Property("tree", node).
+		Property("extra", builders.NewAnySchema().Build()).
+		Property("getItem", getItem).
+		Property("api", builders.NewServiceSchema().Name("ItemService").Method("get", getItem).Build()).
+		Required("code", "status").
+		Build()
+}
+
+func TestSchemaDocumentRoundTrip(t *testing.T) {
+	catalog := newCatalogSchema(t)
+
+	data, err := document.Marshal(catalog)
+	if err != nil {
+		t.Fatalf("Failed to marshal schema: %v", err)
+	}
+
+	loaded, err := document.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal schema: %v", err)
+	}
+
+	t.Run("Canonical", func(t *testing.T) {
+		again, err := document.Marshal(loaded)
+		if err != nil {
+			t.Fatalf("Failed to marshal loaded schema: %v", err)
+		}
+		if !bytes.Equal(data, again) {
+			t.Errorf("Expected identical documents, got:\n%s\n---\n%s", data, again)
+		}
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		if !structure.Equal(catalog, loaded) {
+			t.Errorf("Expected loaded schema to equal the original:\n%s\n---\n%s", structure.Canonical(catalog), structure.Canonical(loaded))
+		}
+
+		properties := loaded.(core.ObjectSchema).Properties()
+		if examples := properties["stock"].Metadata().Examples; !reflect.DeepEqual(examples, []any{int64(12)}) {
+			t.Errorf("Expected integer examples to stay int64, got %#v", examples)
+		}
+		if sizes := properties["sizes"].(interface{ DefaultValue() []any }).DefaultValue(); !reflect.DeepEqual(sizes, []any{int64(1), int64(2)}) {
+			t.Errorf("Expected integer defaults to stay int64, got %#v", sizes)
+		}
+	})
+
+	t.Run("Definitions", func(t *testing.T) {
+		for _, want := range []string{`"PageUser"`, `"Node"`, `"generic": "Page"`, `"kind": "extend"`, `"x-sku"`} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("Expected %s in document", want)
+			}
+		}
+	})
+
+	t.Run("Loaded schema", func(t *testing.T) {
+		object, ok := loaded.(core.ObjectSchema)
+		if !ok {
+			t.Fatalf("Expected object schema, got %T", loaded)
+		}
+		if object.Metadata().Name != "Catalog" || object.Metadata().Composition == nil {
+			t.Errorf("Expected name and provenance to survive, got %+v", object.Metadata())
+		}
+
+		code := object.Properties()["code"]
+		if len(code.Annotations()) != 1 || code.Annotations()[0].Name() != "x-sku" || code.Annotations()[0].Value() != true {
+			t.Errorf("Expected annotation to survive, got %v", code.Annotations())
+		}
+
+		service, ok := object.Properties()["api"].(core.ServiceSchema)
+		if !ok || service.Name() != "ItemService" || len(service.Methods()) != 1 {
+			t.Errorf("Expected service with one method, got %v", object.Properties()["api"])
+		}
+	})
+
+	t.Run("Loaded schema validates like the original", func(t *testing.T) {
+		values := []map[string]any{
+			{"id": 1, "code": "abc", "status": "live"},
+			{"id": 1, "code": "abc", "status": "archived"},
+			{"id": 1, "code": "abc", "status": "live", "tree": map[string]any{"value": "root", "children": []any{map[string]any{}}}},
+			{"id": 1, "code": "abc", "status": "live", "users": map[string]any{"items": []any{map[string]any{"id": 0}}, "total": 1}},
+			{"id": 1, "code": "abc", "status": "live", "record": []any{"a", 1, true}},
+		}
+		for _, value := range values {
+			want := validation.ValidateValue(catalog, value).Valid
+			if got := validation.ValidateValue(loaded, value).Valid; got != want {
+				t.Errorf("Expected valid=%v for %v, got %v", want, value, got)
+			}
+		}
+	})
+
+	t.Run("Existing engine", func(t *testing.T) {
+		e := engine.NewSchemaEngine()
+		if _, err := document.Unmarshal(data, document.WithEngine(e)); err != nil {
+			t.Fatal(err)
+		}
+		if !e.HasSchema("Node") {
+			t.Error("Expected definitions to be registered with the engine")
+		}
+	})
+
+	t.Run("Invalid documents", func(t *testing.T) {
+		for _, data := range []string{`{`, `{"version":"0","schema":{"type":"string"}}`, `{"version":"1","schema":{"type":"bogus"}}`} {
+			if _, err := document.Unmarshal([]byte(data)); err == nil {
+				t.Errorf("Expected error loading %s", data)
+			}
+		}
+	})
+}
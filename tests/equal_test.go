@@ -0,0 +1,219 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/consumer"
+	"defs.dev/schema/core/structure"
+	"defs.dev/schema/engine"
+	"defs.dev/schema/schemas"
+	jsonexport "defs.dev/schema/visit/export/json"
+)
+
+func TestSchemaEquality(t *testing.T) {
+	t.Run("Equal", func(t *testing.T) {
+		a := newAccountSchema()
+		b := newAccountSchema()
+		if a == b || !structure.Equal(a, b) || !structure.Equal(a, a.Clone()) {
+			t.Error("Expected separately built schemas to be equal")
+		}
+		if structure.Fingerprint(a) != structure.Fingerprint(b) {
+			t.Error("Expected equal schemas to share a fingerprint")
+		}
+
+		reordered := builders.NewObjectSchema().
+			Property("a", builders.NewStringSchema().Build()).
+			Property("b", builders.NewStringSchema().Build()).
+			Required("b", "a").
+			Build()
+		ordered := builders.NewObjectSchema().
+			Property("b", builders.NewStringSchema().Build()).
+			Property("a", builders.NewStringSchema().Build()).
+			Required("a", "b").
+			Build()
+		if !structure.Equal(reordered, ordered) {
+			t.Error("Expected property and required order not to matter")
+		}
+	})
+
+	t.Run("Differences", func(t *testing.T) {
+		base := builders.NewStringSchema().MinLength(1).Build()
+		different := []core.Schema{
+			builders.NewStringSchema().MinLength(2).Build(),
+			builders.NewStringSchema().MinLength(1).Pattern("^a").Build(),
+			builders.NewIntegerSchema().Build(),
+			builders.NewOptionalSchema().Of(base).Build(),
+			engine.NewRef(engine.NewSchemaEngine(), "Other"),
+		}
+		for _, other := range different {
+			if structure.Equal(base, other) || structure.Fingerprint(base) == structure.Fingerprint(other) {
+				t.Errorf("Expected %s to differ from %s", structure.Canonical(other), structure.Canonical(base))
+			}
+		}
+	})
+
+	t.Run("Ignore metadata", func(t *testing.T) {
+		named := builders.NewStringSchema().Name("Code").Description("A code").MinLength(1).Build()
+		plain := builders.NewStringSchema().MinLength(1).Build()
+		if structure.Equal(named, plain) {
+			t.Error("Expected metadata to count by default")
+		}
+		if !structure.Equal(named, plain, structure.IgnoreMetadata()) {
+			t.Error("Expected schemas to be equal ignoring metadata")
+		}
+		if structure.Fingerprint(named, structure.IgnoreMetadata()) != structure.Fingerprint(plain, structure.IgnoreMetadata()) {
+			t.Error("Expected fingerprints to match ignoring metadata")
+		}
+	})
+}
+
+func TestFingerprintKeyedCaches(t *testing.T) {
+	t.Run("Generic instances", func(t *testing.T) {
+		e, _, _ := newPageEngine(t)
+
+		first, err := e.Instantiate("Page", builders.NewStringSchema().MinLength(1).Build())
+		if err != nil {
+			t.Fatal(err)
+		}
+		same, err := e.Instantiate("Page", builders.NewStringSchema().MinLength(1).Build())
+		if err != nil {
+			t.Fatal(err)
+		}
+		other, err := e.Instantiate("Page", builders.NewStringSchema().MinLength(2).Build())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first != same {
+			t.Error("Expected structurally equal arguments to share an instance")
+		}
+		if first == other {
+			t.Error("Expected different arguments to get different instances")
+		}
+	})
+
+	t.Run("Resolved references", func(t *testing.T) {
+		e := engine.NewSchemaEngine()
+		for name, schema := range map[string]core.Schema{
+			"Email":   builders.NewStringSchema().MinLength(3).Build(),
+			"Contact": builders.NewStringSchema().MinLength(3).Build(),
+			"Code":    builders.NewStringSchema().MinLength(4).Build(),
+		} {
+			if err := e.RegisterSchema(name, schema); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		resolve := func(name string) core.Schema {
+			schema, err := engine.NewRef(e, name).Resolve()
+			if err != nil {
+				t.Fatal(err)
+			}
+			return schema
+		}
+		if resolve("Email") != resolve("Contact") {
+			t.Error("Expected structurally equal schemas to resolve to one instance")
+		}
+		if resolve("Email") == resolve("Code") {
+			t.Error("Expected different schemas to resolve to different instances")
+		}
+
+		// Registering a schema invalidates the references cached before
+		ref, err := engine.ParseReference("contacts:Email")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if schema, err := e.ResolveReference(ref); err != nil || schema != resolve("Email") {
+			t.Fatalf("Expected the simple name to be used, got %v: %v", schema, err)
+		}
+		if err := e.RegisterSchema("contacts:Email", builders.NewStringSchema().MinLength(5).Build()); err != nil {
+			t.Fatal(err)
+		}
+		if schema, err := e.ResolveReference(ref); err != nil || schema.(core.StringSchema).MinLength() == nil || *schema.(core.StringSchema).MinLength() != 5 {
+			t.Fatalf("Expected the newly registered schema, got %v: %v", schema, err)
+		}
+	})
+
+	t.Run("Consumer conditions", func(t *testing.T) {
+		condition := &countingCondition{}
+		r := consumer.NewRegistry()
+		if err := r.RegisterValueConsumer(&conditionConsumer{condition: condition}); err != nil {
+			t.Fatal(err)
+		}
+		for _, schema := range []core.Schema{
+			builders.NewStringSchema().MinLength(3).Build(),
+			builders.NewStringSchema().MinLength(3).Build(),
+		} {
+			if len(r.GetApplicableValueConsumers(schema)) != 1 {
+				t.Fatal("Expected the consumer to apply")
+			}
+		}
+		if condition.calls != 1 {
+			t.Errorf("Expected equal schemas to share one cached condition result, got %d checks", condition.calls)
+		}
+	})
+
+	t.Run("Export definitions", func(t *testing.T) {
+		entity := newEntitySchema()
+		otherEntity := builders.NewObjectSchema().
+			Name("Entity").
+			Property("uuid", builders.NewStringSchema().Build()).
+			Build()
+
+		merged, err := schemas.Merge(entity, otherEntity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		holder := builders.NewObjectSchema().
+			Name("Holder").
+			Property("merged", merged).
+			Property("account", newAccountSchema()).
+			Property("other", builders.NewObjectSchema().Extend(newEntitySchema()).Name("Other").Build()).
+			Build()
+
+		output, err := jsonexport.NewGenerator().Generate(holder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var doc struct {
+			Definitions map[string]any `json:"definitions"`
+		}
+		if err := json.Unmarshal(output, &doc); err != nil {
+			t.Fatal(err)
+		}
+		if len(doc.Definitions) != 2 || doc.Definitions["Entity"] == nil || doc.Definitions["Entity2"] == nil {
+			t.Errorf("Expected one Entity definition plus a renamed distinct one, got %s", output)
+		}
+	})
+}
+
+// countingCondition matches every schema and counts its checks.
+type countingCondition struct {
+	calls int
+}
+
+func (c *countingCondition) Matches(core.Schema) bool {
+	c.calls++
+	return true
+}
+
+func (c *countingCondition) String() string {
+	return "counting"
+}
+
+// conditionConsumer is a value consumer that only has a condition.
+type conditionConsumer struct {
+	condition consumer.SchemaCondition
+}
+
+func (c *conditionConsumer) Name() string                                { return "condition" }
+func (c *conditionConsumer) Purpose() consumer.ConsumerPurpose           { return "test" }
+func (c *conditionConsumer) ApplicableSchemas() consumer.SchemaCondition { return c.condition }
+func (c *conditionConsumer) Metadata() consumer.ConsumerMetadata {
+	return consumer.ConsumerMetadata{Name: c.Name(), Purpose: c.Purpose()}
+}
+func (c *conditionConsumer) ProcessValue(consumer.ProcessingContext, core.Value[any]) (consumer.ConsumerResult, error) {
+	return nil, nil
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/generation"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+)
+
+// newCustomerSchema builds an object using most schema types and constraints.
+func newCustomerSchema() core.ObjectSchema {
+	address := builders.NewObjectSchema().
+		Name("Address").
+		Property("street", builders.NewStringSchema().MinLength(3).MaxLength(40).Build()).
+		Property("zip", builders.NewStringSchema().Pattern(`^[0-9]{5}(-[0-9]{4})?$`).Build()).
+		Required("street", "zip").
+		Build()
+	return builders.NewObjectSchema().
+		Name("Customer").
+		Property("id", builders.NewStringSchema().UUID().Build()).
+		Property("email", builders.NewStringSchema().Email().Build()).
+		Property("homepage", builders.NewStringSchema().URL().Build()).
+		Property("created", builders.NewStringSchema().Format("date-time").Build()).
+		Property("code", builders.NewStringSchema().Pattern(`^[A-Z]{3}-\d{4}$`).Build()).
+		Property("plan", builders.NewStringSchema().Enum("free", "pro", "team").Build()).
+		Property("seats", builders.NewIntegerSchema().Range(1, 50).Build()).
+		Property("balance", builders.NewNumberSchema().Range(-100, 100).Build()).
+		Property("tags", builders.NewArraySchema().Items(builders.NewStringSchema().Enum("a", "b", "c", "d").Build()).Range(1, 3).UniqueItems().Build()).
+		Property("limits", builders.NewMapSchema().KeyPattern(`^[a-z]+$`).Values(builders.NewIntegerSchema().Min(0).Build()).MaxItems(2).Build()).
+		Property("address", address).
+		Property("nickname", builders.NewOptionalSchema().Of(builders.NewStringSchema().MaxLength(8).Build()).Build()).
+		Property("contact", builders.NewUnionSchema().Schemas(
+			builders.NewStringSchema().Email().Build(),
+			builders.NewIntegerSchema().Min(1000).Build(),
+		).Build()).
+		Property("status", builders.NewResultSchema().
+			Ok(builders.NewBooleanSchema().Build()).
+			Err(builders.NewStringSchema().MinLength(1).Build()).
+			Build()).
+		Required("id", "email", "created", "plan", "seats", "tags", "address").
+		Build()
+}
+
+func TestGenerate(t *testing.T) {
+	customer := newCustomerSchema()
+
+	t.Run("valid values", func(t *testing.T) {
+		for seed := int64(0); seed < 50; seed++ {
+			value, err := generation.Generate(customer, generation.Options{Seed: seed})
+			if err != nil {
+				t.Fatalf("seed %d: %v", seed, err)
+			}
+			if result := validation.ValidateValue(customer, value); !result.Valid {
+				t.Fatalf("seed %d: generated invalid value %v: %v", seed, value, result.Errors)
+			}
+
+			object := value.(map[string]any)
+			if _, err := time.Parse(time.RFC3339, object["created"].(string)); err != nil {
+				t.Fatalf("seed %d: %v", seed, err)
+			}
+			if code, ok := object["code"]; ok && !regexp.MustCompile(`^[A-Z]{3}-\d{4}$`).MatchString(code.(string)) {
+				t.Fatalf("seed %d: code %q does not match its pattern", seed, code)
+			}
+		}
+	})
+
+	t.Run("deterministic seeds", func(t *testing.T) {
+		first, _ := generation.Generate(customer, generation.Options{Seed: 7})
+		second, _ := generation.Generate(customer, generation.Options{Seed: 7})
+		other, _ := generation.Generate(customer, generation.Options{Seed: 8})
+		if !reflect.DeepEqual(first, second) {
+			t.Fatalf("expected the same value for the same seed:\n%v\n%v", first, second)
+		}
+		if reflect.DeepEqual(first, other) {
+			t.Fatal("expected different values for different seeds")
+		}
+	})
+
+	t.Run("boundary values", func(t *testing.T) {
+		name := builders.NewStringSchema().MinLength(3).MaxLength(8).Build()
+		seats := builders.NewIntegerSchema().Range(1, 50).Build()
+		tags := builders.NewArraySchema().Items(builders.NewIntegerSchema().Build()).Range(2, 4).Build()
+
+		for seed := int64(0); seed < 20; seed++ {
+			options := generation.Options{Seed: seed, Mode: generation.ModeBoundary}
+			if value, _ := generation.Generate(name, options); len(value.(string)) != 3 && len(value.(string)) != 8 {
+				t.Fatalf("unexpected boundary string %q", value)
+			}
+			if value, _ := generation.Generate(seats, options); value != int64(1) && value != int64(50) {
+				t.Fatalf("unexpected boundary integer %v", value)
+			}
+			if value, _ := generation.Generate(tags, options); len(value.([]any)) != 2 && len(value.([]any)) != 4 {
+				t.Fatalf("unexpected boundary array %v", value)
+			}
+			value, err := generation.Generate(customer, options)
+			if err != nil || !validation.ValidateValue(customer, value).Valid {
+				t.Fatalf("seed %d: expected a valid boundary value, got %v: %v", seed, value, err)
+			}
+		}
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		for seed := int64(0); seed < 50; seed++ {
+			value, err := generation.Generate(customer, generation.Options{Seed: seed, Mode: generation.ModeInvalid})
+			if err != nil {
+				t.Fatalf("seed %d: %v", seed, err)
+			}
+			if validation.ValidateValue(customer, value).Valid {
+				t.Fatalf("seed %d: expected an invalid value, got %v", seed, value)
+			}
+		}
+
+		_, err := generation.Generate(builders.NewAnySchema().Build(), generation.Options{Mode: generation.ModeInvalid})
+		if !errors.Is(err, generation.ErrNoInvalidValue) {
+			t.Fatalf("expected no invalid value for any, got %v", err)
+		}
+	})
+
+	t.Run("functions and topics", func(t *testing.T) {
+		for _, schema := range []core.Schema{
+			builders.NewFunctionSchema().
+				RequiredInput("customer", customer).
+				OptionalInput("dryRun", builders.NewBooleanSchema().Build()).
+				Build(),
+			newOrderTopicSchema(),
+		} {
+			for _, mode := range []generation.Mode{generation.ModeValid, generation.ModeInvalid} {
+				value, err := generation.Generate(schema, generation.Options{Seed: 3, Mode: mode})
+				if err != nil {
+					t.Fatalf("%s %s: %v", schema.Type(), mode, err)
+				}
+				if valid := validation.ValidateValue(schema, value).Valid; valid != (mode == generation.ModeValid) {
+					t.Fatalf("%s %s: unexpected value %v", schema.Type(), mode, value)
+				}
+			}
+		}
+	})
+}
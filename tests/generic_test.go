@@ -0,0 +1,338 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/engine"
+	"defs.dev/schema/visit/export/golang"
+	jsonexport "defs.dev/schema/visit/export/json"
+	"defs.dev/schema/visit/export/python"
+	"defs.dev/schema/visit/export/typescript"
+)
+
+// newPageEngine registers a generic Page[T] envelope and a User schema.
+func newPageEngine(t *testing.T) (engine.SchemaEngine, core.GenericSchema, core.ObjectSchema) {
+	t.Helper()
+
+	param := builders.NewTypeParameterSchema("T").Build()
+	page := builders.NewGenericSchema().
+		Name("Page").
+		TypeParameters(param).
+		Template(builders.NewObjectSchema().
+			Property("items", builders.NewArraySchema().Items(param).Build()).
+			Property("total", builders.NewIntegerSchema().Min(0).Build()).
+			Required("items", "total").
+			Build()).
+		Build()
+	user := builders.NewObjectSchema().
+		Name("User").
+		Property("id", builders.NewIntegerSchema().Min(1).Build()).
+		Required("id").
+		Build()
+
+	e := engine.NewSchemaEngine()
+	if err := e.RegisterSchema("Page", page); err != nil {
+		t.Fatalf("Failed to register Page: %v", err)
+	}
+	if err := e.RegisterSchema("User", user); err != nil {
+		t.Fatalf("Failed to register User: %v", err)
+	}
+	return e, page, user
+}
+
+// hookedInstance calls hook whenever its type arguments are read, which
+// instantiation does while substituting the template holding it.
+type hookedInstance struct {
+	core.InstanceSchema
+	hook func()
+}
+
+func (h hookedInstance) TypeArguments() []core.Schema {
+	h.hook()
+	return h.InstanceSchema.TypeArguments()
+}
+
+func TestGenericSchemaInstantiation(t *testing.T) {
+	e, _, user := newPageEngine(t)
+
+	pageOfUsers, err := e.Instantiate("Page", user)
+	if err != nil {
+		t.Fatalf("Failed to instantiate Page[User]: %v", err)
+	}
+	if pageOfUsers.ReferenceName() != "PageUser" || pageOfUsers.GenericName() != "Page" {
+		t.Errorf("Unexpected instance names: %s, %s", pageOfUsers.ReferenceName(), pageOfUsers.GenericName())
+	}
+
+	t.Run("Instantiations are cached", func(t *testing.T) {
+		again, err := e.Instantiate("Page", user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again != pageOfUsers {
+			t.Error("Expected the cached instance to be returned")
+		}
+	})
+
+	t.Run("Substituted template", func(t *testing.T) {
+		resolved, err := pageOfUsers.Resolve()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resolved.Metadata().Name != "PageUser" {
+			t.Errorf("Expected resolved schema to be named PageUser, got %q", resolved.Metadata().Name)
+		}
+		items := resolved.(core.ObjectSchema).Properties()["items"].(core.ArraySchema)
+		if items.ItemSchema() != user {
+			t.Errorf("Expected items to hold User, got %v", items.ItemSchema())
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		valid := map[string]any{"items": []any{map[string]any{"id": 1}}, "total": 1}
+		if result := validation.ValidateValue(pageOfUsers, valid); !result.Valid {
+			t.Errorf("Expected page to be valid, got errors: %v", result.Errors)
+		}
+
+		invalid := map[string]any{"items": []any{map[string]any{"id": 0}}, "total": 1}
+		if result := validation.ValidateValue(pageOfUsers, invalid); result.Valid {
+			t.Error("Expected page with invalid user to be rejected")
+		}
+	})
+
+	t.Run("Invalid type arguments", func(t *testing.T) {
+		if _, err := e.Instantiate("Page"); err == nil {
+			t.Error("Expected error for missing type argument")
+		}
+		if _, err := e.Instantiate("User", user); err == nil {
+			t.Error("Expected error for instantiating a non-generic schema")
+		}
+
+		param := builders.NewTypeParameterSchema("K").Constraint(builders.NewStringSchema().Build()).Build()
+		keyed := builders.NewGenericSchema().TypeParameters(param).Template(param).Build()
+		if err := e.RegisterSchema("Keyed", keyed); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := e.Instantiate("Keyed", builders.NewIntegerSchema().Build()); err == nil {
+			t.Error("Expected error for argument violating the constraint")
+		}
+	})
+
+	t.Run("Recursive template", func(t *testing.T) {
+		param := builders.NewTypeParameterSchema("T").Build()
+		self := engine.NewInstanceSchema(engine.InstanceSchemaConfig{
+			GenericName: "Tree",
+			Arguments:   []core.Schema{param},
+		})
+		tree := builders.NewGenericSchema().
+			Name("Tree").
+			TypeParameters(param).
+			Template(builders.NewObjectSchema().
+				Property("value", param).
+				Property("children", builders.NewArraySchema().Items(self).Build()).
+				Required("value").
+				Build()).
+			Build()
+		if err := e.RegisterSchema("Tree", tree); err != nil {
+			t.Fatal(err)
+		}
+
+		treeOfStrings, err := e.Instantiate("Tree", builders.NewStringSchema().Name("Label").Build())
+		if err != nil {
+			t.Fatalf("Failed to instantiate recursive template: %v", err)
+		}
+
+		value := map[string]any{"value": "root", "children": []any{
+			map[string]any{"value": "leaf"},
+		}}
+		if result := validation.ValidateValue(treeOfStrings, value); !result.Valid {
+			t.Errorf("Expected tree to be valid, got errors: %v", result.Errors)
+		}
+		bad := map[string]any{"value": "root", "children": []any{map[string]any{"value": 1}}}
+		if result := validation.ValidateValue(treeOfStrings, bad); result.Valid {
+			t.Error("Expected tree with non-string leaf to be rejected")
+		}
+	})
+
+	t.Run("Concurrent recursive instantiation", func(t *testing.T) {
+		// While a recursive template is being substituted, another caller
+		// instantiates it too; it must get an instance that resolves, not
+		// the one still being built
+		var armed atomic.Bool
+		var concurrentErr error
+		label := builders.NewStringSchema().Name("Word").Build()
+		param := builders.NewTypeParameterSchema("T").Build()
+		self := hookedInstance{
+			InstanceSchema: engine.NewInstanceSchema(engine.InstanceSchemaConfig{
+				GenericName: "Forest",
+				Arguments:   []core.Schema{param},
+			}),
+			hook: func() {
+				if !armed.CompareAndSwap(true, false) {
+					return
+				}
+				done := make(chan struct{})
+				go func() {
+					defer close(done)
+					instance, err := e.Instantiate("Forest", label)
+					if err == nil {
+						_, err = instance.Resolve()
+					}
+					concurrentErr = err
+				}()
+				<-done
+			},
+		}
+		forest := builders.NewGenericSchema().
+			Name("Forest").
+			TypeParameters(param).
+			Template(builders.NewObjectSchema().
+				Property("value", param).
+				Property("trees", builders.NewArraySchema().Items(self).Build()).
+				Build()).
+			Build()
+		if err := e.RegisterSchema("Forest", forest); err != nil {
+			t.Fatal(err)
+		}
+
+		armed.Store(true)
+		if _, err := e.Instantiate("Forest", label); err != nil {
+			t.Fatal(err)
+		}
+		if armed.Load() {
+			t.Fatal("Expected the template to be substituted")
+		}
+		if concurrentErr != nil {
+			t.Errorf("Expected the concurrent caller to get a resolved instance: %v", concurrentErr)
+		}
+	})
+
+	t.Run("Arguments sharing a name", func(t *testing.T) {
+		other := builders.NewObjectSchema().
+			Name("User").
+			Property("email", builders.NewStringSchema().Build()).
+			Build()
+		first, err := e.Instantiate("Page", user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := e.Instantiate("Page", other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first.ReferenceName() != "PageUser" || second.ReferenceName() == first.ReferenceName() {
+			t.Errorf("Expected distinct instance names, got %s and %s", first.ReferenceName(), second.ReferenceName())
+		}
+		resolved, err := second.Resolve()
+		if err != nil || resolved.Metadata().Name != second.ReferenceName() {
+			t.Errorf("Expected the instance to resolve to a schema named %s: %v", second.ReferenceName(), err)
+		}
+	})
+}
+
+func TestGenericSchemaExport(t *testing.T) {
+	e, page, user := newPageEngine(t)
+	pageOfUsers, err := e.Instantiate("Page", user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listing := builders.NewObjectSchema().
+		Name("Listing").
+		Property("page", pageOfUsers).
+		Required("page").
+		Build()
+
+	t.Run("JSON Schema", func(t *testing.T) {
+		output, err := jsonexport.NewGenerator().Generate(listing)
+		if err != nil {
+			t.Fatalf("Failed to generate JSON Schema: %v", err)
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal(output, &doc); err != nil {
+			t.Fatalf("Failed to parse JSON Schema: %v", err)
+		}
+		pageProp := doc["properties"].(map[string]any)["page"].(map[string]any)
+		if pageProp["$ref"] != "#/definitions/PageUser" {
+			t.Errorf("Expected $ref to #/definitions/PageUser, got %v", pageProp["$ref"])
+		}
+		if definitions, ok := doc["definitions"].(map[string]any); !ok || definitions["PageUser"] == nil {
+			t.Errorf("Expected PageUser in definitions, got %v", doc["definitions"])
+		}
+	})
+
+	t.Run("TypeScript", func(t *testing.T) {
+		output, err := typescript.NewGenerator().Generate(page)
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		for _, want := range []string{"export interface Page<T>", "items: T[]"} {
+			if !strings.Contains(string(output), want) {
+				t.Errorf("Expected %q in TypeScript output:\n%s", want, output)
+			}
+		}
+
+		output, err = typescript.NewGenerator().Generate(listing)
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		if !strings.Contains(string(output), "page: Page<User>") {
+			t.Errorf("Unexpected TypeScript output:\n%s", output)
+		}
+	})
+
+	t.Run("Go", func(t *testing.T) {
+		options := golang.DefaultGoOptions()
+		options.UseGenerics = true
+		output, err := golang.NewGenerator(options).Generate(page)
+		if err != nil {
+			t.Fatalf("Failed to generate Go: %v", err)
+		}
+		for _, want := range []string{"type Page[T any] struct", "Items []T"} {
+			if !strings.Contains(string(output), want) {
+				t.Errorf("Expected %q in Go output:\n%s", want, output)
+			}
+		}
+
+		output, err = golang.NewGenerator(options).Generate(listing)
+		if err != nil {
+			t.Fatalf("Failed to generate Go: %v", err)
+		}
+		if !strings.Contains(string(output), "Page[User]") {
+			t.Errorf("Unexpected Go output:\n%s", output)
+		}
+
+		output, err = golang.NewGenerator(golang.DefaultGoOptions()).Generate(listing)
+		if err != nil {
+			t.Fatalf("Failed to generate Go: %v", err)
+		}
+		if !strings.Contains(string(output), "PageUser") {
+			t.Errorf("Expected concrete type name without generics:\n%s", output)
+		}
+	})
+
+	t.Run("Python", func(t *testing.T) {
+		output, err := python.NewGenerator(python.DefaultPythonOptions()).Generate(page)
+		if err != nil {
+			t.Fatalf("Failed to generate Python: %v", err)
+		}
+		for _, want := range []string{`T = TypeVar("T")`, "class Page(BaseModel, Generic[T])", "List[T]"} {
+			if !strings.Contains(string(output), want) {
+				t.Errorf("Expected %q in Python output:\n%s", want, output)
+			}
+		}
+
+		output, err = python.NewGenerator(python.DefaultPythonOptions()).Generate(listing)
+		if err != nil {
+			t.Fatalf("Failed to generate Python: %v", err)
+		}
+		if !strings.Contains(string(output), `"Page[User]"`) {
+			t.Errorf("Unexpected Python output:\n%s", output)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"defs.dev/schema/core/jsonpointer"
+)
+
+func TestJSONPointerPackage(t *testing.T) {
+	tokens := []string{"definitions", "a/b", "m~n", "0"}
+	pointer := jsonpointer.Format(tokens)
+	if pointer != "/definitions/a~1b/m~0n/0" {
+		t.Fatalf("unexpected pointer %q", pointer)
+	}
+	if parsed := jsonpointer.Parse(pointer); !reflect.DeepEqual(parsed, tokens) {
+		t.Fatalf("unexpected tokens %v", parsed)
+	}
+	if parsed := jsonpointer.Parse(""); parsed == nil || len(parsed) != 0 {
+		t.Fatalf("expected no tokens for the whole document, got %v", parsed)
+	}
+
+	doc := map[string]any{"definitions": map[string]any{"a/b": map[string]any{"m~n": []any{"first"}}}}
+	if value := jsonpointer.Lookup(doc, pointer); value != "first" {
+		t.Fatalf("unexpected value %v", value)
+	}
+	if value := jsonpointer.Lookup(doc, "/definitions/missing/0"); value != nil {
+		t.Fatalf("expected no value, got %v", value)
+	}
+	if value := jsonpointer.Lookup(doc, ""); !reflect.DeepEqual(value, doc) {
+		t.Fatalf("expected the document, got %v", value)
+	}
+}
@@ -0,0 +1,222 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/construct/jsonschema"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/engine"
+	"defs.dev/schema/schemas"
+	jsonexport "defs.dev/schema/visit/export/json"
+)
+
+const petstoreJSONSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Store",
+  "type": "object",
+  "properties": {
+    "pets": {"type": "array", "items": {"$ref": "#/$defs/Pet"}},
+    "owner": {"$ref": "#/$defs/Owner"},
+    "tags": {"type": "object", "additionalProperties": {"type": "string"}},
+    "headers": {"type": "object", "patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": false},
+    "nickname": {"type": ["string", "null"]},
+    "score": {"type": "integer", "exclusiveMinimum": 0, "maximum": 10},
+    "legacy": {"type": "string", "contentEncoding": "base64"}
+  },
+  "required": ["pets"],
+  "$defs": {
+    "Pet": {
+      "oneOf": [{"$ref": "#/$defs/Cat"}, {"$ref": "#/$defs/Dog"}],
+      "discriminator": {"propertyName": "kind"}
+    },
+    "Cat": {
+      "type": "object",
+      "properties": {"kind": {"const": "cat"}, "lives": {"type": "integer"}},
+      "required": ["kind"]
+    },
+    "Dog": {
+      "type": "object",
+      "properties": {"kind": {"enum": ["dog"]}, "friends": {"type": "array", "items": {"$ref": "#/$defs/Dog"}}},
+      "required": ["kind"]
+    },
+    "Named": {
+      "type": "object",
+      "properties": {"name": {"type": "string", "minLength": 1}},
+      "required": ["name"]
+    },
+    "Owner": {
+      "allOf": [{"$ref": "#/$defs/Named"}],
+      "properties": {"email": {"type": "string", "format": "email"}},
+      "required": ["email"]
+    }
+  }
+}`
+
+func TestJSONSchemaImport(t *testing.T) {
+	e := engine.NewSchemaEngine()
+	schema, report, err := jsonschema.Import([]byte(petstoreJSONSchema), e)
+	if err != nil {
+		t.Fatalf("Failed to import: %v", err)
+	}
+
+	t.Run("Definitions", func(t *testing.T) {
+		for _, name := range []string{"Pet", "Cat", "Dog", "Named", "Owner"} {
+			if !e.HasSchema(name) {
+				t.Errorf("Expected %s to be registered", name)
+			}
+		}
+
+		pet, err := e.ResolveSchema("Pet")
+		if err != nil {
+			t.Fatal(err)
+		}
+		union, ok := pet.(core.UnionSchema)
+		if !ok || union.Discriminator() != "kind" || len(union.Schemas()) != 2 {
+			t.Errorf("Expected discriminated union of two, got %T %v", pet, pet)
+		}
+
+		owner, err := e.ResolveSchema("Owner")
+		if err != nil {
+			t.Fatal(err)
+		}
+		object, ok := owner.(core.ObjectSchema)
+		if !ok || object.Metadata().Composition == nil || object.Metadata().Composition.Kind != core.CompositionAllOf {
+			t.Fatalf("Expected allOf composition, got %T", owner)
+		}
+		if len(object.Properties()) != 2 || len(object.Required()) != 2 {
+			t.Errorf("Expected name and email, got %v required %v", object.Properties(), object.Required())
+		}
+	})
+
+	t.Run("Shapes", func(t *testing.T) {
+		store, ok := schema.(core.ObjectSchema)
+		if !ok || store.Metadata().Name != "Store" {
+			t.Fatalf("Expected Store object, got %T", schema)
+		}
+		properties := store.Properties()
+		if properties["tags"].Type() != core.TypeMap {
+			t.Errorf("Expected map, got %s", properties["tags"].Type())
+		}
+		if properties["nickname"].Type() != core.TypeOptional {
+			t.Errorf("Expected optional, got %s", properties["nickname"].Type())
+		}
+		if headers, ok := properties["headers"].(*schemas.ObjectSchema); !ok || len(headers.PatternProperties()) != 1 {
+			t.Errorf("Expected pattern properties, got %v", properties["headers"])
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		cases := []struct {
+			value map[string]any
+			valid bool
+		}{
+			{map[string]any{"pets": []any{map[string]any{"kind": "cat", "lives": 9}}}, true},
+			{map[string]any{"pets": []any{map[string]any{"kind": "dog", "friends": []any{map[string]any{"kind": "dog"}}}}}, true},
+			{map[string]any{"pets": []any{map[string]any{"kind": "dog", "friends": []any{map[string]any{"kind": "cow"}}}}}, false},
+			{map[string]any{"pets": []any{}, "owner": map[string]any{"name": "Ann", "email": "ann@example.com"}}, true},
+			{map[string]any{"pets": []any{}, "owner": map[string]any{"email": "ann@example.com"}}, false},
+			{map[string]any{"pets": []any{}, "score": 0}, false},
+			{map[string]any{"pets": []any{}, "headers": map[string]any{"x-id": "1"}}, true},
+			{map[string]any{"pets": []any{}, "headers": map[string]any{"x-id": 1}}, false},
+			{map[string]any{"pets": []any{}, "headers": map[string]any{"id": "1"}}, false},
+		}
+		for _, c := range cases {
+			if result := validation.ValidateValue(schema, c.value); result.Valid != c.valid {
+				t.Errorf("Expected valid=%v for %v, got errors %v", c.valid, c.value, result.Errors)
+			}
+		}
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		keywords := report.Keywords()
+		if len(keywords) != 1 || keywords[0] != "contentEncoding" {
+			t.Fatalf("Expected contentEncoding to be reported, got %v", report.Unsupported)
+		}
+		if report.Unsupported[0].Path != "#/properties/legacy" {
+			t.Errorf("Expected pointer to the legacy property, got %s", report.Unsupported[0].Path)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		for _, data := range []string{`{`, `{"$ref": "#/$defs/Missing"}`, `{"type": "bogus"}`} {
+			if _, _, err := jsonschema.Import([]byte(data), engine.NewSchemaEngine()); err == nil {
+				t.Errorf("Expected error importing %s", data)
+			}
+		}
+	})
+}
+
+func TestJSONSchemaExportRoundTrip(t *testing.T) {
+	e := engine.NewSchemaEngine()
+	tree := newTreeSchema(t, e)
+
+	for _, draft := range []string{"draft-07", "draft-2020-12"} {
+		t.Run(draft, func(t *testing.T) {
+			data, err := jsonexport.NewGenerator(jsonexport.WithDraft(draft)).Generate(tree)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			imported, report, err := jsonschema.Import(data, engine.NewSchemaEngine())
+			if err != nil {
+				t.Fatalf("Failed to import exported schema: %v\n%s", err, data)
+			}
+			if !report.Empty() {
+				t.Errorf("Expected complete import, got %v", report.Unsupported)
+			}
+
+			again, err := jsonexport.NewGenerator(jsonexport.WithDraft(draft)).Generate(imported)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(again) != string(data) {
+				t.Errorf("Expected identical export, got:\n%s\n---\n%s", data, again)
+			}
+		})
+	}
+
+	t.Run("Pattern properties", func(t *testing.T) {
+		headers := builders.NewObjectSchema().
+			PatternProperty("^x-", builders.NewStringSchema().Build()).
+			AdditionalProperties(false).
+			Build()
+		data, err := jsonexport.NewGenerator().Generate(headers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), `"patternProperties"`) {
+			t.Fatalf("Expected patternProperties in %s", data)
+		}
+		imported, report, err := jsonschema.Import(data, engine.NewSchemaEngine())
+		if err != nil || !report.Empty() {
+			t.Fatalf("Failed to import %s: %v %v", data, err, report)
+		}
+		for _, schema := range []core.Schema{headers, imported} {
+			if !validation.ValidateValue(schema, map[string]any{"x-id": "1"}).Valid {
+				t.Errorf("Expected matching property to be valid against %s", data)
+			}
+			if validation.Compile(schema).Validate(map[string]any{"x-id": 1}).Valid {
+				t.Errorf("Expected pattern property schema to apply against %s", data)
+			}
+		}
+	})
+
+	t.Run("Result and tuple", func(t *testing.T) {
+		for _, schema := range []core.Schema{newUserResult(), newRecordTuple().Build()} {
+			data, err := jsonexport.NewGenerator().Generate(schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			imported, report, err := jsonschema.Import(data, engine.NewSchemaEngine())
+			if err != nil || !report.Empty() {
+				t.Fatalf("Failed to import %s: %v %v", data, err, report)
+			}
+			if imported.Type() != schema.Type() {
+				t.Errorf("Expected %s, got %s", schema.Type(), imported.Type())
+			}
+		}
+	})
+}
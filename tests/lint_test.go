@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/lint"
+	"defs.dev/schema/core"
+	"defs.dev/schema/engine"
+)
+
+// newSloppyServiceSchema builds a service that breaks every built-in lint rule once.
+func newSloppyServiceSchema() core.ServiceSchema {
+	address := builders.NewObjectSchema().
+		PatternProperty("^x-(", builders.NewStringSchema().Build()).
+		Property("zip", builders.NewStringSchema().MinLength(10).MaxLength(5).Build()).
+		Required("zip", "city").
+		Build()
+	user := builders.NewObjectSchema().
+		Name("User").
+		Property("address", address).
+		Property("role", builders.NewStringSchema().Enum("admin", "user", "admin").Default("root").Build()).
+		Property("age", builders.NewIntegerSchema().Min(0).Example(-1).Build()).
+		Build()
+	update := builders.NewFunctionSchema().
+		RequiredInput("user", user).
+		Build()
+	return builders.NewServiceSchema().Name("users").Method("update", update).Build()
+}
+
+func issuesByRule(result lint.Result) map[string]lint.Issue {
+	issues := make(map[string]lint.Issue)
+	for _, issue := range result.Issues {
+		issues[issue.Rule] = issue
+	}
+	return issues
+}
+
+func TestLint(t *testing.T) {
+	t.Run("built-in rules", func(t *testing.T) {
+		result := lint.Lint(newSloppyServiceSchema(), lint.Config{})
+		issues := issuesByRule(result)
+
+		address := "/methods/update/inputs/user/properties/address"
+		for rule, expected := range map[string]lint.Issue{
+			"min-max-length":        {Severity: lint.SeverityError, Path: address + "/properties/zip"},
+			"invalid-pattern":       {Severity: lint.SeverityError, Path: address},
+			"undeclared-required":   {Severity: lint.SeverityError, Path: address},
+			"invalid-default":       {Severity: lint.SeverityError, Path: "/methods/update/inputs/user/properties/role"},
+			"invalid-example":       {Severity: lint.SeverityWarning, Path: "/methods/update/inputs/user/properties/age"},
+			"unnamed-nested-object": {Severity: lint.SeverityInfo, Path: address},
+			"function-description":  {Severity: lint.SeverityWarning, Path: "/methods/update"},
+			"duplicate-enum":        {Severity: lint.SeverityWarning, Path: "/methods/update/inputs/user/properties/role"},
+		} {
+			issue, ok := issues[rule]
+			if !ok {
+				t.Errorf("expected an issue from %s", rule)
+				continue
+			}
+			if issue.Severity != expected.Severity || issue.Path != expected.Path {
+				t.Errorf("unexpected %s issue: %s", rule, issue)
+			}
+		}
+		if len(result.Issues) != 8 || len(result.Errors()) != 4 {
+			t.Fatalf("expected 8 issues, 4 errors: %v", result.Issues)
+		}
+		if !strings.Contains(issues["undeclared-required"].Message, `"city"`) {
+			t.Errorf("unexpected message %q", issues["undeclared-required"].Message)
+		}
+	})
+
+	t.Run("clean schema", func(t *testing.T) {
+		result := lint.Lint(newWalkedServiceSchema(), lint.Config{})
+		if result.HasErrors() || len(result.WithSeverity(lint.SeverityWarning)) != 0 {
+			t.Fatalf("unexpected issues %v", result.Issues)
+		}
+	})
+
+	t.Run("configured severities", func(t *testing.T) {
+		result := lint.Lint(newSloppyServiceSchema(), lint.Config{Severities: map[string]lint.Severity{
+			"min-max-length":        lint.SeverityWarning,
+			"invalid-pattern":       lint.SeverityOff,
+			"unnamed-nested-object": lint.SeverityOff,
+		}})
+		issues := issuesByRule(result)
+		if _, ok := issues["invalid-pattern"]; ok {
+			t.Error("expected the disabled rule not to run")
+		}
+		if issues["min-max-length"].Severity != lint.SeverityWarning {
+			t.Errorf("unexpected severity %s", issues["min-max-length"].Severity)
+		}
+		if len(result.Issues) != 6 || len(result.Errors()) != 2 {
+			t.Fatalf("expected 6 issues, 2 errors: %v", result.Issues)
+		}
+	})
+}
+
+func TestEngineLint(t *testing.T) {
+	config := engine.DefaultEngineConfig()
+	e := engine.NewSchemaEngineWithConfig(config)
+	if err := e.RegisterSchema("users", newWalkedServiceSchema()); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Validate(); err != nil {
+		t.Fatalf("expected a clean schema to validate: %v", err)
+	}
+
+	if err := e.RegisterSchema("sloppy", newSloppyServiceSchema()); err != nil {
+		t.Fatal(err)
+	}
+	err := e.Validate()
+	var engineErr engine.EngineError
+	if !errors.As(err, &engineErr) || engineErr.Type != engine.ErrorTypeLintFailed {
+		t.Fatalf("expected a lint error, got %v", err)
+	}
+
+	// Without validation on register, schemas are not linted
+	config.ValidateOnRegister = false
+	e = engine.NewSchemaEngineWithConfig(config)
+	e.RegisterSchema("sloppy", newSloppyServiceSchema())
+	if err := e.Validate(); err != nil {
+		t.Fatalf("expected linting to be skipped: %v", err)
+	}
+}
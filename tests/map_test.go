@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/visit/export/golang"
+	jsonexport "defs.dev/schema/visit/export/json"
+	"defs.dev/schema/visit/export/python"
+	"defs.dev/schema/visit/export/typescript"
+)
+
+func TestMapSchemaValidation(t *testing.T) {
+	t.Run("Value schema", func(t *testing.T) {
+		schema := builders.NewMapSchema().
+			Values(builders.NewIntegerSchema().Min(0).Build()).
+			Build()
+
+		if schema.Type() != core.TypeMap {
+			t.Errorf("Expected type %s, got %s", core.TypeMap, schema.Type())
+		}
+
+		valid := []any{
+			map[string]any{"a": 1, "b": 2},
+			map[string]int{"x": 3},
+			map[string]any{},
+		}
+		for _, v := range valid {
+			if result := validation.ValidateValue(schema, v); !result.Valid {
+				t.Errorf("Expected %v to be valid, got errors: %v", v, result.Errors)
+			}
+		}
+
+		result := validation.ValidateValue(schema, map[string]any{"a": 1, "b": -1})
+		if result.Valid {
+			t.Fatal("Expected negative value to be invalid")
+		}
+		if len(result.Errors[0].Path) == 0 || result.Errors[0].Path[0] != "b" {
+			t.Errorf("Expected error path to start with key 'b', got %v", result.Errors[0].Path)
+		}
+
+		for _, v := range []any{"not a map", 42, []any{1}} {
+			if result := validation.ValidateValue(schema, v); result.Valid {
+				t.Errorf("Expected %v to be invalid for map schema", v)
+			}
+		}
+	})
+
+	t.Run("Key constraints", func(t *testing.T) {
+		pattern := builders.NewMapSchema().KeyPattern("^[a-z]+$").Build()
+		if result := validation.ValidateValue(pattern, map[string]any{"abc": 1}); !result.Valid {
+			t.Errorf("Expected lowercase key to be valid, got errors: %v", result.Errors)
+		}
+		result := validation.ValidateValue(pattern, map[string]any{"ABC": 1})
+		if result.Valid || result.Errors[0].Code != "invalid_map_key" {
+			t.Errorf("Expected invalid_map_key, got %v", result.Errors)
+		}
+
+		enum := builders.NewMapSchema().KeyEnum("red", "green").Build()
+		if result := validation.ValidateValue(enum, map[string]any{"red": 1, "blue": 2}); result.Valid {
+			t.Error("Expected key outside enum to be invalid")
+		}
+
+		integer := builders.NewMapSchema().IntegerKeys().Build()
+		if result := validation.ValidateValue(integer, map[string]any{"1": "a", "-2": "b"}); !result.Valid {
+			t.Errorf("Expected integer string keys to be valid, got errors: %v", result.Errors)
+		}
+		if result := validation.ValidateValue(integer, map[int]string{1: "a"}); !result.Valid {
+			t.Errorf("Expected int keys to be valid, got errors: %v", result.Errors)
+		}
+		if result := validation.ValidateValue(integer, map[string]any{"one": "a"}); result.Valid {
+			t.Error("Expected non-integer key to be invalid")
+		}
+	})
+
+	t.Run("Entry count", func(t *testing.T) {
+		schema := builders.NewMapSchema().MinItems(1).MaxItems(2).Build()
+
+		if result := validation.ValidateValue(schema, map[string]any{}); result.Valid {
+			t.Error("Expected empty map to be invalid")
+		}
+		if result := validation.ValidateValue(schema, map[string]any{"a": 1, "b": 2, "c": 3}); result.Valid {
+			t.Error("Expected three entries to be invalid")
+		}
+	})
+}
+
+func TestMapSchemaExport(t *testing.T) {
+	schema := builders.NewMapSchema().
+		Name("Scores").
+		KeyEnum("alice", "bob").
+		Values(builders.NewIntegerSchema().Build()).
+		MinItems(1).
+		Build()
+
+	t.Run("JSON Schema", func(t *testing.T) {
+		output, err := jsonexport.NewGenerator().Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate JSON Schema: %v", err)
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal(output, &doc); err != nil {
+			t.Fatalf("Failed to parse JSON Schema: %v", err)
+		}
+		if doc["type"] != "object" {
+			t.Errorf("Expected type object, got %v", doc["type"])
+		}
+		if values, ok := doc["additionalProperties"].(map[string]any); !ok || values["type"] != "integer" {
+			t.Errorf("Expected integer additionalProperties, got %v", doc["additionalProperties"])
+		}
+		if keys, ok := doc["propertyNames"].(map[string]any); !ok || keys["enum"] == nil {
+			t.Errorf("Expected enum propertyNames, got %v", doc["propertyNames"])
+		}
+		if doc["minProperties"] != float64(1) {
+			t.Errorf("Expected minProperties 1, got %v", doc["minProperties"])
+		}
+	})
+
+	t.Run("TypeScript", func(t *testing.T) {
+		output, err := typescript.NewGenerator().Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		if !strings.Contains(string(output), `export type Scores = Record<"alice" | "bob", number>;`) {
+			t.Errorf("Unexpected TypeScript output:\n%s", output)
+		}
+	})
+
+	t.Run("Go", func(t *testing.T) {
+		output, err := golang.NewGenerator(golang.DefaultGoOptions()).Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate Go: %v", err)
+		}
+		if !strings.Contains(string(output), "type Scores map[string]int64") {
+			t.Errorf("Unexpected Go output:\n%s", output)
+		}
+	})
+
+	t.Run("Python", func(t *testing.T) {
+		output, err := python.NewGenerator(python.DefaultPythonOptions()).Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate Python: %v", err)
+		}
+		if !strings.Contains(string(output), "Scores = Dict[str, int]") || !strings.Contains(string(output), "from typing import Dict") {
+			t.Errorf("Unexpected Python output:\n%s", output)
+		}
+	})
+}
+
+func TestTypedMapBuilder(t *testing.T) {
+	schema := builders.NewTypedMapSchema[string, int64](nil, builders.NewIntegerSchema().Build()).
+		Name("Scores").
+		MaxItems(2).
+		Build()
+
+	mapSchema, ok := schema.(core.MapSchema)
+	if !ok {
+		t.Fatalf("Expected core.MapSchema, got %T", schema)
+	}
+	if mapSchema.ValueSchema().Type() != core.TypeInteger || *mapSchema.MaxItems() != 2 {
+		t.Errorf("Unexpected map schema %+v", mapSchema)
+	}
+	if result := validation.ValidateValue(schema, map[string]any{"a": 1, "b": 2, "c": 3}); result.Valid {
+		t.Error("Expected too many entries to be invalid")
+	}
+}
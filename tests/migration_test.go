@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/core"
+	"defs.dev/schema/engine"
+)
+
+// newUserVersionsEngine registers three versions of a user schema with the
+// migrations between them.
+func newUserVersionsEngine(t *testing.T) engine.SchemaEngine {
+	t.Helper()
+	e := engine.NewSchemaEngine()
+
+	versions := map[string][]string{
+		"app:User@1.0.0": {"fullname", "mail"},
+		"app:User@2.0.0": {"first", "last", "email"},
+		"app:User@3.0.0": {"name", "email", "role"},
+	}
+	for name, fields := range versions {
+		var b core.ObjectSchemaBuilder = builders.NewObjectSchema()
+		for _, field := range fields {
+			b = b.Property(field, builders.NewStringSchema().Build())
+		}
+		if err := e.RegisterSchema(name, b.Required(fields...).AdditionalProperties(false).Build()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := e.RegisterMigration("app:User@1.0.0", "app:User@2.0.0",
+		engine.RenameField("mail", "email"),
+		engine.SplitField("fullname", func(value any) (map[string]any, error) {
+			first, last, ok := strings.Cut(value.(string), " ")
+			if !ok {
+				return nil, fmt.Errorf("%q has no last name", value)
+			}
+			return map[string]any{"first": first, "last": last}, nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = e.RegisterMigration("app:User@2.0.0", "app:User@3.0.0",
+		engine.MergeFields([]string{"first", "last"}, "name", func(fields map[string]any) (any, error) {
+			return fmt.Sprintf("%s %s", fields["first"], fields["last"]), nil
+		}),
+		engine.SetDefault("role", "member"),
+		func(value any) (any, error) {
+			user := value.(map[string]any)
+			if email, ok := user["email"].(string); ok {
+				user["email"] = strings.ToLower(email)
+			}
+			return user, nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestVersionMigrations(t *testing.T) {
+	e := newUserVersionsEngine(t)
+
+	t.Run("Upgrade", func(t *testing.T) {
+		stored := map[string]any{"fullname": "Ada Lovelace", "mail": "ADA@example.com"}
+		upgraded, version, err := e.Upgrade("app:User@1.0.0", stored)
+		if err != nil {
+			t.Fatalf("Upgrade failed: %v", err)
+		}
+		if version != "app:User@3.0.0" {
+			t.Errorf("Expected latest version, got %s", version)
+		}
+		user := upgraded.(map[string]any)
+		if user["name"] != "Ada Lovelace" || user["email"] != "ada@example.com" || user["role"] != "member" || len(user) != 3 {
+			t.Errorf("Unexpected upgraded value %v", user)
+		}
+		if _, ok := stored["email"]; ok {
+			t.Error("Expected the stored value not to be modified")
+		}
+	})
+
+	t.Run("Partial migration", func(t *testing.T) {
+		upgraded, err := e.Migrate("app:User@1.0.0", "app:User@2.0.0", map[string]any{"fullname": "Alan Turing", "mail": "alan@example.com"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if upgraded.(map[string]any)["last"] != "Turing" {
+			t.Errorf("Unexpected value %v", upgraded)
+		}
+
+		current, version, err := e.Upgrade("app:User@3.0.0", map[string]any{"name": "x", "email": "x", "role": "admin"})
+		if err != nil || version != "app:User@3.0.0" || current.(map[string]any)["role"] != "admin" {
+			t.Errorf("Expected latest values to pass through, got %v %v", current, err)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		var engineErr engine.EngineError
+
+		_, _, err := e.Upgrade("app:User@1.0.0", map[string]any{"fullname": "Plato", "mail": "p@example.com"})
+		if !errors.As(err, &engineErr) || engineErr.Type != engine.ErrorTypeMigrationFailed {
+			t.Errorf("Expected failing step to be reported, got %v", err)
+		}
+
+		_, _, err = e.Upgrade("app:User@1.0.0", map[string]any{"fullname": "Ada Lovelace"})
+		if !errors.As(err, &engineErr) || engineErr.Type != engine.ErrorTypeValidationFailed {
+			t.Errorf("Expected result to be validated against the target, got %v", err)
+		}
+
+		_, _, err = e.Upgrade("app:User@0.9.0", map[string]any{})
+		if !errors.As(err, &engineErr) || engineErr.Type != engine.ErrorTypeMigrationNotFound {
+			t.Errorf("Expected missing migration path, got %v", err)
+		}
+
+		if err := e.RegisterMigration("app:User@1.0.0", "app:User@3.0.0"); err == nil {
+			t.Error("Expected a second migration from the same version to fail")
+		}
+		if err := e.RegisterMigration("app:User@3.0.0", "app:User@2.0.0"); err == nil {
+			t.Error("Expected a downgrade migration to fail")
+		}
+		if err := e.RegisterMigration("app:User@3.0.0", "app:Account@4.0.0"); err == nil {
+			t.Error("Expected a migration across schemas to fail")
+		}
+		if _, err := e.Clone().Migrate("app:User@2.0.0", "app:User@3.0.0", map[string]any{"first": "a", "last": "b", "email": "c"}); err != nil {
+			t.Errorf("Expected clones to keep migrations, got %v", err)
+		}
+	})
+}
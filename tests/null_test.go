@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	jsonexport "defs.dev/schema/visit/export/json"
+	"defs.dev/schema/visit/export/python"
+	"defs.dev/schema/visit/export/typescript"
+)
+
+func TestNullSchemaValidation(t *testing.T) {
+	schema := builders.NewNullSchema().Build()
+
+	if schema.Type() != core.TypeNull {
+		t.Errorf("Expected type %s, got %s", core.TypeNull, schema.Type())
+	}
+
+	var nilPointer *string
+	for _, v := range []any{nil, nilPointer} {
+		if result := validation.ValidateValue(schema, v); !result.Valid {
+			t.Errorf("Expected %v to be valid, got errors: %v", v, result.Errors)
+		}
+	}
+
+	for _, v := range []any{"", 0, false, map[string]any{}} {
+		result := validation.ValidateValue(schema, v)
+		if result.Valid || result.Errors[0].Code != "type_mismatch" {
+			t.Errorf("Expected type_mismatch for %v, got %v", v, result.Errors)
+		}
+	}
+}
+
+func TestNullSchemaExport(t *testing.T) {
+	schema := builders.NewNullSchema().Name("Nothing").Build()
+
+	t.Run("JSON Schema", func(t *testing.T) {
+		output, err := jsonexport.NewGenerator().Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate JSON Schema: %v", err)
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal(output, &doc); err != nil {
+			t.Fatalf("Failed to parse JSON Schema: %v", err)
+		}
+		if doc["type"] != "null" {
+			t.Errorf("Expected type null, got %v", doc["type"])
+		}
+	})
+
+	t.Run("TypeScript", func(t *testing.T) {
+		output, err := typescript.NewGenerator().Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		if !strings.Contains(string(output), "export type Nothing = null;") {
+			t.Errorf("Unexpected TypeScript output:\n%s", output)
+		}
+	})
+
+	t.Run("Python", func(t *testing.T) {
+		output, err := python.NewGenerator(python.DefaultPythonOptions()).Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate Python: %v", err)
+		}
+		if !strings.Contains(string(output), "Nothing = None") {
+			t.Errorf("Unexpected Python output:\n%s", output)
+		}
+	})
+}
@@ -603,6 +603,8 @@ func (v *testObjectVisitor) VisitString(core.StringSchema) error   { return nil
 func (v *testObjectVisitor) VisitNumber(core.NumberSchema) error   { return nil }
 func (v *testObjectVisitor) VisitInteger(core.IntegerSchema) error { return nil }
 func (v *testObjectVisitor) VisitBoolean(core.BooleanSchema) error { return nil }
+func (v *testObjectVisitor) VisitNull(core.NullSchema) error       { return nil }
+func (v *testObjectVisitor) VisitAny(core.AnySchema) error         { return nil }
 func (v *testObjectVisitor) VisitArray(core.ArraySchema) error     { return nil }
 func (v *testObjectVisitor) VisitObject(schema core.ObjectSchema) error {
 	if v.visitObject != nil {
@@ -610,9 +612,17 @@ func (v *testObjectVisitor) VisitObject(schema core.ObjectSchema) error {
 	}
 	return nil
 }
-func (v *testObjectVisitor) VisitFunction(core.FunctionSchema) error { return nil }
-func (v *testObjectVisitor) VisitService(core.ServiceSchema) error   { return nil }
-func (v *testObjectVisitor) VisitUnion(core.UnionSchema) error       { return nil }
+func (v *testObjectVisitor) VisitMap(core.MapSchema) error                 { return nil }
+func (v *testObjectVisitor) VisitOptional(core.OptionalSchema) error       { return nil }
+func (v *testObjectVisitor) VisitFunction(core.FunctionSchema) error       { return nil }
+func (v *testObjectVisitor) VisitService(core.ServiceSchema) error         { return nil }
+func (v *testObjectVisitor) VisitUnion(core.UnionSchema) error             { return nil }
+func (v *testObjectVisitor) VisitRef(core.RefSchema) error                 { return nil }
+func (v *testObjectVisitor) VisitResult(core.ResultSchema) error           { return nil }
+func (v *testObjectVisitor) VisitParameter(core.TypeParameterSchema) error { return nil }
+func (v *testObjectVisitor) VisitGeneric(core.GenericSchema) error         { return nil }
+func (v *testObjectVisitor) VisitTopic(core.TopicSchema) error             { return nil }
+func (v *testObjectVisitor) VisitComponent(core.ComponentSchema) error     { return nil }
 
 func TestObjectBuilderAdditionalMethods(t *testing.T) {
 	t.Run("Builder fluent API", func(t *testing.T) {
@@ -0,0 +1,186 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"defs.dev/schema/api"
+	"defs.dev/schema/construct/openapi"
+	"defs.dev/schema/core"
+	"defs.dev/schema/engine"
+	"defs.dev/schema/runtime/portal"
+	"defs.dev/schema/runtime/registry"
+)
+
+const petstoreOpenAPI = `{
+  "openapi": "3.1.0",
+  "info": {"title": "Petstore", "version": "1.0.0"},
+  "servers": [{"url": "https://petstore.example.com/v1"}],
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "tags": ["pets"],
+        "parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer", "maximum": 100}}],
+        "responses": {
+          "200": {"description": "A page of pets", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}},
+          "default": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "post": {
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+        "responses": {"201": {"description": "Created"}}
+      }
+    },
+    "/pets/{petId}": {
+      "parameters": [{"name": "petId", "in": "path", "schema": {"type": "string"}}],
+      "get": {
+        "operationId": "showPetById",
+        "parameters": [
+          {"name": "X-Trace", "in": "header", "schema": {"type": "string"}},
+          {"name": "session", "in": "cookie", "schema": {"type": "string"}}
+        ],
+        "responses": {"200": {"description": "A pet", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "properties": {"id": {"type": "integer"}, "name": {"type": "string"}, "tag": {"type": "string"}},
+        "required": ["id", "name"]
+      },
+      "Error": {
+        "type": "object",
+        "properties": {"code": {"type": "integer"}, "message": {"type": "string"}},
+        "required": ["code", "message"]
+      }
+    },
+    "responses": {
+      "Error": {"description": "Unexpected error", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}}
+    }
+  }
+}`
+
+func TestOpenAPIImport(t *testing.T) {
+	e := engine.NewSchemaEngine()
+	spec, err := openapi.Import([]byte(petstoreOpenAPI), e)
+	if err != nil {
+		t.Fatalf("Failed to import: %v", err)
+	}
+
+	t.Run("Service", func(t *testing.T) {
+		if !e.HasSchema("Pet") || !e.HasSchema("Error") {
+			t.Error("Expected component schemas to be registered")
+		}
+		if spec.Service.Name() != "Petstore" || spec.Service.Metadata().Version != "1.0.0" {
+			t.Errorf("Expected Petstore 1.0.0, got %s %s", spec.Service.Name(), spec.Service.Metadata().Version)
+		}
+		if len(spec.Service.Methods()) != 3 {
+			t.Fatalf("Expected 3 methods, got %d", len(spec.Service.Methods()))
+		}
+		if _, ok := spec.Operation("postPets"); !ok {
+			t.Error("Expected a derived name for the operation without operationId")
+		}
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		list, _ := spec.Operation("listPets")
+		fn := list.Function
+		if len(fn.Inputs().Args()) != 1 || !fn.Inputs().Args()[0].Optional() {
+			t.Errorf("Expected one optional input, got %v", fn.Inputs().Args())
+		}
+		if outputs := fn.Outputs().Args(); len(outputs) != 1 || outputs[0].Schema().Type() != core.TypeArray {
+			t.Errorf("Expected array result, got %v", outputs)
+		}
+		if ref, ok := fn.Errors().(core.RefSchema); !ok || ref.ReferenceName() != "Error" {
+			t.Errorf("Expected Error response as error schema, got %v", fn.Errors())
+		}
+
+		show, _ := spec.Operation("showPetById")
+		if len(show.Parameters) != 2 || show.Parameters[0].Name != "petId" || !show.Parameters[0].Required {
+			t.Errorf("Expected required path parameter and header, got %v", show.Parameters)
+		}
+		if keywords := spec.Report.Keywords(); len(keywords) != 1 || keywords[0] != "in" {
+			t.Errorf("Expected cookie parameter to be reported, got %v", spec.Report.Unsupported)
+		}
+	})
+
+	t.Run("Invalid documents", func(t *testing.T) {
+		for _, data := range []string{`{`, `{"openapi": "2.0"}`, `{"swagger": "2.0"}`} {
+			if _, err := openapi.Import([]byte(data), engine.NewSchemaEngine()); err == nil {
+				t.Errorf("Expected error importing %s", data)
+			}
+		}
+	})
+}
+
+func TestOpenAPIFunctions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/pets/7":
+			json.NewEncoder(w).Encode(map[string]any{"id": 7, "name": "Rex", "tag": r.Header.Get("X-Trace")})
+		case r.Method == http.MethodGet && r.URL.Path == "/pets":
+			json.NewEncoder(w).Encode([]any{map[string]any{"id": 1, "name": r.URL.Query().Get("limit")}})
+		case r.Method == http.MethodPost && r.URL.Path == "/pets":
+			var pet map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&pet); err != nil || pet["name"] != "Tom" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"code": 404, "message": "not found"})
+		}
+	}))
+	defer server.Close()
+
+	spec, err := openapi.Import([]byte(petstoreOpenAPI), engine.NewSchemaEngine())
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec.BaseURL = server.URL
+
+	functions := registry.NewFunctionRegistry()
+	if err := spec.Register(functions, portal.NewHTTPPortal(nil)); err != nil {
+		t.Fatalf("Failed to register functions: %v", err)
+	}
+	ctx := context.Background()
+
+	output, err := functions.Call(ctx, "showPetById", api.NewFunctionData(map[string]any{"petId": 7, "X-Trace": "abc"}))
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if pet, _ := output.Get("result"); pet.(map[string]any)["tag"] != "abc" {
+		t.Errorf("Expected path and header parameters to be sent, got %v", pet)
+	}
+
+	output, err = functions.Call(ctx, "listPets", api.NewFunctionData(map[string]any{"limit": 5}))
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if pets, _ := output.Get("result"); pets.([]any)[0].(map[string]any)["name"] != "5" {
+		t.Errorf("Expected query parameter to be sent, got %v", pets)
+	}
+
+	if _, err := functions.Call(ctx, "postPets", api.NewFunctionData(map[string]any{"body": map[string]any{"id": 2, "name": "Tom"}})); err != nil {
+		t.Errorf("Expected body to be sent, got %v", err)
+	}
+
+	_, err = functions.Call(ctx, "showPetById", api.NewFunctionData(map[string]any{"petId": 8}))
+	var responseErr *openapi.ResponseError
+	if !errors.As(err, &responseErr) || responseErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 response error, got %v", err)
+	}
+
+	if _, err := functions.Call(ctx, "showPetById", api.NewFunctionData(nil)); err == nil {
+		t.Error("Expected missing path parameter to fail")
+	}
+}
@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/construct/native"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/annotation"
+	"defs.dev/schema/runtime/registry"
+	"defs.dev/schema/visit/export/golang"
+	jsonexport "defs.dev/schema/visit/export/json"
+	"defs.dev/schema/visit/export/python"
+	"defs.dev/schema/visit/export/typescript"
+)
+
+func TestOptionalSchemaValidation(t *testing.T) {
+	stringSchema := builders.NewStringSchema().MinLength(2).Build()
+	optional := builders.NewOptionalSchema().Of(stringSchema).Build()
+
+	if optional.Type() != core.TypeOptional {
+		t.Errorf("Expected type %s, got %s", core.TypeOptional, optional.Type())
+	}
+
+	t.Run("Null only accepted when wrapped", func(t *testing.T) {
+		if result := validation.ValidateValue(optional, nil); !result.Valid {
+			t.Errorf("Expected nil to be valid for optional schema, got errors: %v", result.Errors)
+		}
+		if result := validation.ValidateValue(optional, (*string)(nil)); !result.Valid {
+			t.Errorf("Expected typed nil pointer to be valid, got errors: %v", result.Errors)
+		}
+		if result := validation.ValidateValue(stringSchema, nil); result.Valid {
+			t.Error("Expected nil to be invalid for unwrapped string schema")
+		}
+	})
+
+	t.Run("Non-null values use wrapped schema", func(t *testing.T) {
+		if result := validation.ValidateValue(optional, "ok"); !result.Valid {
+			t.Errorf("Expected 'ok' to be valid, got errors: %v", result.Errors)
+		}
+		value := "abc"
+		if result := validation.ValidateValue(optional, &value); !result.Valid {
+			t.Errorf("Expected pointer to valid string to be valid, got errors: %v", result.Errors)
+		}
+		if result := validation.ValidateValue(optional, "x"); result.Valid {
+			t.Error("Expected too-short string to be invalid")
+		}
+		if result := validation.ValidateValue(optional, 42); result.Valid {
+			t.Error("Expected integer to be invalid")
+		}
+	})
+
+	t.Run("Nullable is distinct from not required", func(t *testing.T) {
+		object := builders.NewObjectSchema().
+			Property("nickname", optional).
+			Property("email", builders.NewStringSchema().Build()).
+			Required("nickname").
+			Build()
+
+		if result := validation.ValidateValue(object, map[string]any{"nickname": nil}); !result.Valid {
+			t.Errorf("Expected required nullable property to accept nil, got errors: %v", result.Errors)
+		}
+		if result := validation.ValidateValue(object, map[string]any{}); result.Valid {
+			t.Error("Expected missing required nullable property to be invalid")
+		}
+		if result := validation.ValidateValue(object, map[string]any{"nickname": nil, "email": nil}); result.Valid {
+			t.Error("Expected nil for non-nullable optional property to be invalid")
+		}
+	})
+}
+
+func TestOptionalSchemaFromPointer(t *testing.T) {
+	annotationReg := annotation.NewRegistry()
+	converter := native.NewDefaultTypeConverter(annotationReg, registry.NewDefaultValidatorRegistry(annotationReg))
+
+	type Profile struct {
+		Name     string  `json:"name"`
+		Nickname *string `json:"nickname"`
+	}
+
+	schema, err := converter.FromType(reflect.TypeOf(Profile{}))
+	if err != nil {
+		t.Fatalf("FromType() error = %v", err)
+	}
+
+	properties := schema.(core.ObjectSchema).Properties()
+	if properties["name"].Type() != core.TypeString {
+		t.Errorf("Expected name to be a string, got %s", properties["name"].Type())
+	}
+	nickname, ok := properties["nickname"].(core.OptionalSchema)
+	if !ok || nickname.Type() != core.TypeOptional {
+		t.Fatalf("Expected nickname to be optional, got %s", properties["nickname"].Type())
+	}
+	if nickname.ItemSchema().Type() != core.TypeString {
+		t.Errorf("Expected optional string, got %s", nickname.ItemSchema().Type())
+	}
+}
+
+func TestOptionalSchemaExport(t *testing.T) {
+	schema := builders.NewObjectSchema().
+		Name("Profile").
+		Property("nickname", builders.NewOptionalSchema().Of(builders.NewStringSchema().Build()).Build()).
+		Required("nickname").
+		Build()
+
+	t.Run("JSON Schema", func(t *testing.T) {
+		output, err := jsonexport.NewGenerator().Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate JSON Schema: %v", err)
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal(output, &doc); err != nil {
+			t.Fatalf("Failed to parse JSON Schema: %v", err)
+		}
+		nickname := doc["properties"].(map[string]any)["nickname"].(map[string]any)
+		if !reflect.DeepEqual(nickname["type"], []any{"string", "null"}) {
+			t.Errorf("Expected type [string null], got %v", nickname["type"])
+		}
+	})
+
+	t.Run("TypeScript", func(t *testing.T) {
+		output, err := typescript.NewGenerator().Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		if !strings.Contains(string(output), "nickname: string | null;") {
+			t.Errorf("Unexpected TypeScript output:\n%s", output)
+		}
+	})
+
+	t.Run("Go", func(t *testing.T) {
+		output, err := golang.NewGenerator(golang.DefaultGoOptions()).Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate Go: %v", err)
+		}
+		if !strings.Contains(string(output), "*string") {
+			t.Errorf("Unexpected Go output:\n%s", output)
+		}
+	})
+
+	t.Run("Python", func(t *testing.T) {
+		output, err := python.NewGenerator(python.DefaultPythonOptions()).Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate Python: %v", err)
+		}
+		if !strings.Contains(string(output), "Optional[str]") {
+			t.Errorf("Unexpected Python output:\n%s", output)
+		}
+	})
+}
+
+func TestTypedOptionalBuilder(t *testing.T) {
+	name := "Ada"
+	schema := builders.NewTypedOptionalSchema[string](builders.NewStringSchema().MinLength(1).Build()).
+		Name("Nickname").
+		Example(&name).
+		Example(nil).
+		Build()
+
+	if schema.Type() != core.TypeOptional {
+		t.Fatalf("Expected an optional schema, got %s", schema.Type())
+	}
+	if examples := schema.Metadata().Examples; !reflect.DeepEqual(examples, []any{"Ada", nil}) {
+		t.Errorf("Unexpected examples %v", examples)
+	}
+	if !validation.ValidateValue(schema, nil).Valid || validation.ValidateValue(schema, "").Valid {
+		t.Error("Expected null to be valid and an empty string invalid")
+	}
+}
@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/engine"
+	"defs.dev/schema/visit/export/golang"
+	jsonexport "defs.dev/schema/visit/export/json"
+	"defs.dev/schema/visit/export/python"
+	"defs.dev/schema/visit/export/typescript"
+)
+
+// newTreeSchema registers a recursive Node schema with the engine and returns it.
+func newTreeSchema(t *testing.T, e engine.SchemaEngine) core.ObjectSchema {
+	t.Helper()
+
+	node := builders.NewObjectSchema().
+		Name("Node").
+		Property("value", builders.NewStringSchema().Build()).
+		Property("children", builders.NewArraySchema().Items(engine.NewRef(e, "Node")).Build()).
+		Required("value").
+		Build()
+
+	if err := e.RegisterSchema("Node", node); err != nil {
+		t.Fatalf("Failed to register Node: %v", err)
+	}
+	return node
+}
+
+func TestRefSchemaValidation(t *testing.T) {
+	t.Run("Recursive tree", func(t *testing.T) {
+		e := engine.NewSchemaEngine()
+		node := newTreeSchema(t, e)
+
+		tree := map[string]any{
+			"value": "root",
+			"children": []any{
+				map[string]any{"value": "a"},
+				map[string]any{"value": "b", "children": []any{
+					map[string]any{"value": "b1"},
+				}},
+			},
+		}
+		if result := validation.ValidateValue(node, tree); !result.Valid {
+			t.Errorf("Expected tree to be valid, got errors: %v", result.Errors)
+		}
+
+		invalid := map[string]any{
+			"value": "root",
+			"children": []any{
+				map[string]any{"children": []any{}},
+			},
+		}
+		result := validation.ValidateValue(node, invalid)
+		if result.Valid {
+			t.Fatal("Expected child without value to be invalid")
+		}
+		if result.Errors[0].Code != "missing_required_property" {
+			t.Errorf("Expected missing_required_property, got %v", result.Errors)
+		}
+	})
+
+	t.Run("Unresolved reference", func(t *testing.T) {
+		ref := engine.NewRef(engine.NewSchemaEngine(), "Missing")
+
+		result := validation.ValidateValue(ref, "anything")
+		if result.Valid || result.Errors[0].Code != "unresolved_reference" {
+			t.Errorf("Expected unresolved_reference, got %v", result.Errors)
+		}
+	})
+
+	t.Run("Circular reference chain", func(t *testing.T) {
+		e := engine.NewSchemaEngine()
+		if err := e.RegisterSchema("A", engine.NewRef(e, "B")); err != nil {
+			t.Fatal(err)
+		}
+		if err := e.RegisterSchema("B", engine.NewRef(e, "A")); err != nil {
+			t.Fatal(err)
+		}
+
+		result := validation.ValidateValue(engine.NewRef(e, "A"), "anything")
+		if result.Valid || result.Errors[0].Code != "circular_reference" {
+			t.Errorf("Expected circular_reference, got %v", result.Errors)
+		}
+	})
+
+	t.Run("Self-referential union", func(t *testing.T) {
+		e := engine.NewSchemaEngine()
+		union := builders.NewUnionSchema().Schemas(
+			engine.NewRef(e, "U"),
+			builders.NewIntegerSchema().Build(),
+		).Build()
+		if err := e.RegisterSchema("U", union); err != nil {
+			t.Fatal(err)
+		}
+
+		if result := validation.ValidateValue(union, int64(3)); !result.Valid {
+			t.Errorf("Expected integer to be valid, got %v", result.Errors)
+		}
+		for name, result := range map[string]validation.ValidationResult{
+			"uncompiled": validation.ValidateValue(engine.NewRef(e, "U"), "str"),
+			"compiled":   validation.Compile(union).Validate("str"),
+		} {
+			if result.Valid || !strings.Contains(fmt.Sprint(result.Errors), "circular_reference") {
+				t.Errorf("%s: expected circular_reference, got %v", name, result.Errors)
+			}
+		}
+	})
+}
+
+func TestRefSchemaExport(t *testing.T) {
+	e := engine.NewSchemaEngine()
+	node := newTreeSchema(t, e)
+
+	t.Run("JSON Schema", func(t *testing.T) {
+		output, err := jsonexport.NewGenerator().Generate(node)
+		if err != nil {
+			t.Fatalf("Failed to generate JSON Schema: %v", err)
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal(output, &doc); err != nil {
+			t.Fatalf("Failed to parse JSON Schema: %v", err)
+		}
+		children := doc["properties"].(map[string]any)["children"].(map[string]any)
+		items := children["items"].(map[string]any)
+		if items["$ref"] != "#/definitions/Node" {
+			t.Errorf("Expected $ref to #/definitions/Node, got %v", items["$ref"])
+		}
+		definitions, ok := doc["definitions"].(map[string]any)
+		if !ok || definitions["Node"] == nil {
+			t.Errorf("Expected Node in definitions, got %v", doc["definitions"])
+		}
+	})
+
+	t.Run("TypeScript", func(t *testing.T) {
+		output, err := typescript.NewGenerator().Generate(node)
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		if !strings.Contains(string(output), "children?: Node[]") {
+			t.Errorf("Unexpected TypeScript output:\n%s", output)
+		}
+	})
+
+	t.Run("Go", func(t *testing.T) {
+		output, err := golang.NewGenerator(golang.DefaultGoOptions()).Generate(node)
+		if err != nil {
+			t.Fatalf("Failed to generate Go: %v", err)
+		}
+		if !strings.Contains(string(output), "Children []Node") {
+			t.Errorf("Unexpected Go output:\n%s", output)
+		}
+	})
+
+	t.Run("Python", func(t *testing.T) {
+		output, err := python.NewGenerator(python.DefaultPythonOptions()).Generate(node)
+		if err != nil {
+			t.Fatalf("Failed to generate Python: %v", err)
+		}
+		if !strings.Contains(string(output), `List["Node"]`) {
+			t.Errorf("Unexpected Python output:\n%s", output)
+		}
+	})
+}
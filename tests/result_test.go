@@ -0,0 +1,214 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/visit/export/golang"
+	jsonexport "defs.dev/schema/visit/export/json"
+	"defs.dev/schema/visit/export/python"
+	"defs.dev/schema/visit/export/typescript"
+)
+
+func newUserResult() core.ResultSchema {
+	user := builders.NewObjectSchema().
+		Name("User").
+		Property("id", builders.NewIntegerSchema().Min(1).Build()).
+		Required("id").
+		Build()
+	notFound := builders.NewObjectSchema().
+		Name("NotFound").
+		Property("message", builders.NewStringSchema().Build()).
+		Required("message").
+		Build()
+
+	return builders.NewResultSchema().
+		Name("UserResult").
+		Ok(user).
+		Err(notFound).
+		Build()
+}
+
+func TestResultSchemaValidation(t *testing.T) {
+	schema := newUserResult()
+
+	if schema.Type() != core.TypeResult {
+		t.Errorf("Expected type %s, got %s", core.TypeResult, schema.Type())
+	}
+
+	valid := []any{
+		map[string]any{"ok": map[string]any{"id": 1}},
+		map[string]any{"err": map[string]any{"message": "no such user"}},
+	}
+	for _, v := range valid {
+		if result := validation.ValidateValue(schema, v); !result.Valid {
+			t.Errorf("Expected %v to be valid, got errors: %v", v, result.Errors)
+		}
+	}
+
+	invalidShapes := []any{
+		map[string]any{},
+		map[string]any{"ok": map[string]any{"id": 1}, "err": map[string]any{"message": "x"}},
+		map[string]any{"ok": map[string]any{"id": 1}, "extra": true},
+	}
+	for _, v := range invalidShapes {
+		result := validation.ValidateValue(schema, v)
+		if result.Valid || result.Errors[0].Code != "invalid_result" {
+			t.Errorf("Expected invalid_result for %v, got %v", v, result.Errors)
+		}
+	}
+
+	result := validation.ValidateValue(schema, map[string]any{"ok": map[string]any{"id": 0}})
+	if result.Valid {
+		t.Fatal("Expected invalid success payload to be rejected")
+	}
+	if len(result.Errors[0].Path) == 0 || result.Errors[0].Path[0] != "ok" {
+		t.Errorf("Expected error path to start with 'ok', got %v", result.Errors[0].Path)
+	}
+
+	if result := validation.ValidateValue(schema, "ok"); result.Valid {
+		t.Error("Expected non-object to be invalid")
+	}
+}
+
+func TestTypedResultBuilder(t *testing.T) {
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	schema := builders.NewTypedResultSchema[user, string]().
+		Name("Lookup").
+		Ok(builders.NewObjectSchema().Build()).
+		Err(builders.NewStringSchema().Build()).
+		OkExample(user{ID: 1}).
+		ErrExample("not found").
+		Build()
+
+	resultSchema, ok := schema.(core.ResultSchema)
+	if !ok {
+		t.Fatalf("Expected core.ResultSchema, got %T", schema)
+	}
+	if resultSchema.ErrorSchema().Type() != core.TypeString {
+		t.Errorf("Expected string error schema, got %s", resultSchema.ErrorSchema().Type())
+	}
+	if len(schema.Metadata().Examples) != 2 {
+		t.Errorf("Expected 2 examples, got %d", len(schema.Metadata().Examples))
+	}
+}
+
+func TestFunctionReturnsResult(t *testing.T) {
+	result := newUserResult()
+	fn := builders.NewFunctionSchema().
+		Input("id", builders.NewIntegerSchema().Build()).
+		Returns(result).
+		Build()
+
+	if fn.Errors() != result.ErrorSchema() {
+		t.Error("Expected function errors to be the result's error schema")
+	}
+	outputs := fn.Outputs().Args()
+	if len(outputs) != 1 || outputs[0].Name() != "result" || outputs[0].Schema().Type() != core.TypeResult {
+		t.Errorf("Expected a single result output, got %v", outputs)
+	}
+	if required := fn.RequiredOutputs(); len(required) != 1 || required[0] != "result" {
+		t.Errorf("Expected result output to be required, got %v", required)
+	}
+}
+
+func TestResultSchemaExport(t *testing.T) {
+	schema := newUserResult()
+
+	t.Run("JSON Schema", func(t *testing.T) {
+		output, err := jsonexport.NewGenerator().Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate JSON Schema: %v", err)
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal(output, &doc); err != nil {
+			t.Fatalf("Failed to parse JSON Schema: %v", err)
+		}
+		branches, ok := doc["oneOf"].([]any)
+		if !ok || len(branches) != 2 {
+			t.Fatalf("Expected oneOf with 2 branches, got %v", doc["oneOf"])
+		}
+		okBranch := branches[0].(map[string]any)
+		if okBranch["additionalProperties"] != false || okBranch["properties"].(map[string]any)["ok"] == nil {
+			t.Errorf("Expected closed ok branch, got %v", okBranch)
+		}
+	})
+
+	t.Run("TypeScript", func(t *testing.T) {
+		output, err := typescript.NewGenerator().Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		if !strings.Contains(string(output), "export type UserResult = { ok: User } | { err: NotFound };") {
+			t.Errorf("Unexpected TypeScript output:\n%s", output)
+		}
+	})
+
+	t.Run("Go", func(t *testing.T) {
+		output, err := golang.NewGenerator(golang.DefaultGoOptions()).Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate Go: %v", err)
+		}
+		for _, want := range []string{"type UserResult struct", "Ok *User", "Err *NotFound"} {
+			if !strings.Contains(string(output), want) {
+				t.Errorf("Expected %q in Go output:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("Python", func(t *testing.T) {
+		output, err := python.NewGenerator(python.DefaultPythonOptions()).Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate Python: %v", err)
+		}
+		for _, want := range []string{"class UserResultOk", "class UserResultErr", "UserResult = Union[UserResultOk, UserResultErr]"} {
+			if !strings.Contains(string(output), want) {
+				t.Errorf("Expected %q in Python output:\n%s", want, output)
+			}
+		}
+	})
+
+	// An unnamed result used as a property is declared under a name derived
+	// from its payload types.
+	lookup := builders.NewObjectSchema().
+		Name("Lookup").
+		Property("result", builders.NewResultSchema().Ok(schema.SuccessSchema()).Err(schema.ErrorSchema()).Build()).
+		Required("result").
+		Build()
+
+	t.Run("Go inline", func(t *testing.T) {
+		output, err := golang.NewGenerator(golang.DefaultGoOptions()).Generate(lookup)
+		if err != nil {
+			t.Fatalf("Failed to generate Go: %v", err)
+		}
+		for _, want := range []string{"Result UserNotFoundResult", "type UserNotFoundResult struct", "Ok *User", "Err *NotFound"} {
+			if !strings.Contains(string(output), want) {
+				t.Errorf("Expected %q in Go output:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("Python inline", func(t *testing.T) {
+		output, err := python.NewGenerator(python.DefaultPythonOptions()).Generate(lookup)
+		if err != nil {
+			t.Fatalf("Failed to generate Python: %v", err)
+		}
+		code := string(output)
+		for _, want := range []string{"result: UserNotFoundResult", "UserNotFoundResult = Union[UserNotFoundResultOk, UserNotFoundResultErr]"} {
+			if !strings.Contains(code, want) {
+				t.Errorf("Expected %q in Python output:\n%s", want, output)
+			}
+		}
+		if strings.Index(code, "UserNotFoundResult = ") > strings.Index(code, "class Lookup") {
+			t.Errorf("Expected the result to be defined before its use:\n%s", output)
+		}
+	})
+}
@@ -0,0 +1,304 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"defs.dev/schema/api"
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/construct/document"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/structure"
+	"defs.dev/schema/runtime/broker"
+	"defs.dev/schema/runtime/portal"
+	jsonexport "defs.dev/schema/visit/export/json"
+)
+
+func newOrderTopicSchema() core.TopicSchema {
+	return builders.NewTopicSchema().
+		Name("orders").
+		Description("Placed orders").
+		Key(builders.NewStringSchema().Build()).
+		Payload(builders.NewObjectSchema().
+			Property("id", builders.NewStringSchema().Build()).
+			Property("total", builders.NewNumberSchema().Min(0).Build()).
+			Required("id", "total").
+			Build()).
+		Headers(builders.NewMapSchema().Values(builders.NewStringSchema().Build()).Build()).
+		Build()
+}
+
+func receive(t *testing.T, sub api.Subscription) api.Message {
+	t.Helper()
+	select {
+	case message, ok := <-sub.Messages():
+		if !ok {
+			t.Fatal("subscription closed")
+		}
+		return message
+	case <-time.After(2 * time.Second):
+		t.Fatal("no message received")
+	}
+	return api.Message{}
+}
+
+func TestTopicSchema(t *testing.T) {
+	topic := newOrderTopicSchema()
+
+	if topic.Type() != core.TypeTopic || topic.Name() != "orders" {
+		t.Fatalf("unexpected topic %s %q", topic.Type(), topic.Name())
+	}
+	if topic.Payload() == nil || topic.Key() == nil || topic.Headers() == nil {
+		t.Fatal("expected payload, key and headers schemas")
+	}
+
+	t.Run("validation", func(t *testing.T) {
+		valid := map[string]any{
+			"key":     "eu",
+			"payload": map[string]any{"id": "o-1", "total": 12.5},
+			"headers": map[string]any{"source": "web"},
+		}
+		if result := validation.ValidateValue(topic, valid); !result.Valid {
+			t.Fatalf("expected valid message, got %v", result.Errors)
+		}
+
+		result := validation.ValidateValue(topic, map[string]any{"payload": map[string]any{"id": "o-1", "total": -1}})
+		if result.Valid || len(result.Errors) == 0 || result.Errors[0].Path[0] != "payload" {
+			t.Fatalf("expected payload error, got %v", result.Errors)
+		}
+		if result := validation.ValidateValue(topic, map[string]any{"key": "eu"}); result.Valid {
+			t.Fatal("expected a message without payload to fail")
+		}
+		if result := validation.ValidateValue(topic, map[string]any{"payload": map[string]any{"id": "o-1", "total": 1}, "extra": 1}); result.Valid {
+			t.Fatal("expected unexpected properties to fail")
+		}
+	})
+
+	t.Run("json export", func(t *testing.T) {
+		output, err := jsonexport.NewGenerator().Generate(topic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var exported map[string]any
+		if err := json.Unmarshal(output, &exported); err != nil {
+			t.Fatal(err)
+		}
+		properties, _ := exported["properties"].(map[string]any)
+		if exported["x-topic"] != true || properties["payload"] == nil || properties["key"] == nil {
+			t.Fatalf("unexpected export %s", output)
+		}
+	})
+
+	t.Run("document round trip", func(t *testing.T) {
+		data, err := document.Marshal(topic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := document.Unmarshal(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !structure.Equal(topic, decoded) {
+			t.Fatalf("round trip changed the topic:\n%s", data)
+		}
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		changed := builders.NewTopicSchema().
+			Name("orders").
+			Key(builders.NewStringSchema().Build()).
+			Payload(builders.NewObjectSchema().
+				Property("id", builders.NewStringSchema().Build()).
+				Required("id").
+				Build()).
+			Build()
+		if structure.Diff(topic, changed).Compatibility() == structure.FullyCompatible {
+			t.Fatal("expected changes between topics")
+		}
+	})
+}
+
+func TestTopicBroker(t *testing.T) {
+	ctx := context.Background()
+	order := map[string]any{"id": "o-1", "total": 10}
+
+	t.Run("validates on publish", func(t *testing.T) {
+		b := broker.NewBroker()
+		topic, err := b.CreateTopic(newOrderTopicSchema())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := b.CreateTopic(newOrderTopicSchema()); err == nil {
+			t.Fatal("expected duplicate topic to fail")
+		}
+
+		err = topic.Publish(ctx, api.Message{Key: "eu", Payload: map[string]any{"id": "o-1"}})
+		var validationErr *broker.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Topic != "orders" {
+			t.Fatalf("expected validation error, got %v", err)
+		}
+	})
+
+	t.Run("fan-out and key filters", func(t *testing.T) {
+		b := broker.NewBroker()
+		topic, _ := b.CreateTopic(newOrderTopicSchema())
+
+		all, _ := topic.Subscribe(ctx, api.SubscribeOptions{})
+		defer all.Close()
+		other, _ := topic.Subscribe(ctx, api.SubscribeOptions{})
+		defer other.Close()
+		us, _ := topic.Subscribe(ctx, api.SubscribeOptions{Keys: []any{"us"}})
+		defer us.Close()
+
+		if err := topic.Publish(ctx, api.Message{Key: "eu", Payload: order}); err != nil {
+			t.Fatal(err)
+		}
+		if err := topic.Publish(ctx, api.Message{Key: "us", Payload: order}); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, sub := range []api.Subscription{all, other} {
+			if receive(t, sub).Key != "eu" || receive(t, sub).Key != "us" {
+				t.Fatal("expected both messages in publish order")
+			}
+		}
+		if receive(t, us).Key != "us" || len(us.Messages()) != 0 {
+			t.Fatal("expected only the us message")
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		b := broker.NewBroker()
+		topic, _ := b.CreateTopic(newOrderTopicSchema())
+
+		newest, _ := topic.Subscribe(ctx, api.SubscribeOptions{BufferSize: 2, Overflow: api.OverflowDropNewest})
+		defer newest.Close()
+		oldest, _ := topic.Subscribe(ctx, api.SubscribeOptions{BufferSize: 2, Overflow: api.OverflowDropOldest})
+		defer oldest.Close()
+
+		for _, key := range []string{"a", "b", "c"} {
+			if err := topic.Publish(ctx, api.Message{Key: key, Payload: order}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if newest.Dropped() != 1 || receive(t, newest).Key != "a" || receive(t, newest).Key != "b" {
+			t.Fatal("drop_newest should keep the first messages")
+		}
+		if oldest.Dropped() != 1 || receive(t, oldest).Key != "b" || receive(t, oldest).Key != "c" {
+			t.Fatal("drop_oldest should keep the latest messages")
+		}
+		if _, err := topic.Subscribe(ctx, api.SubscribeOptions{Overflow: "spill"}); err == nil {
+			t.Fatal("expected unknown overflow policy to fail")
+		}
+	})
+
+	t.Run("block applies backpressure", func(t *testing.T) {
+		b := broker.NewBroker()
+		topic, _ := b.CreateTopic(newOrderTopicSchema())
+		sub, _ := topic.Subscribe(ctx, api.SubscribeOptions{BufferSize: 1})
+		defer sub.Close()
+
+		if err := topic.Publish(ctx, api.Message{Payload: order}); err != nil {
+			t.Fatal(err)
+		}
+		timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		if err := topic.Publish(timeout, api.Message{Payload: order}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected publish to block until the deadline, got %v", err)
+		}
+
+		receive(t, sub)
+		if err := topic.Publish(ctx, api.Message{Payload: order}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("subscriptions end", func(t *testing.T) {
+		b := broker.NewBroker()
+		created, _ := b.CreateTopic(newOrderTopicSchema())
+		topic := created.(*broker.Topic)
+
+		subCtx, cancel := context.WithCancel(ctx)
+		cancelled, _ := topic.Subscribe(subCtx, api.SubscribeOptions{})
+		deleted, _ := topic.Subscribe(ctx, api.SubscribeOptions{})
+
+		cancel()
+		if _, ok := <-cancelled.Messages(); ok {
+			t.Fatal("expected cancelled subscription to close")
+		}
+		if topic.Subscribers() != 1 {
+			t.Fatalf("expected 1 subscriber, got %d", topic.Subscribers())
+		}
+
+		if err := b.DeleteTopic("orders"); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := <-deleted.Messages(); ok || b.Exists("orders") {
+			t.Fatal("expected deleting the topic to close its subscriptions")
+		}
+	})
+}
+
+func TestTopicWebSocket(t *testing.T) {
+	ctx := context.Background()
+
+	wsPortal := portal.NewWebSocketPortal(&portal.WebSocketConfig{
+		Host:           "localhost",
+		Port:           8092,
+		Path:           "/ws",
+		PingPeriod:     54 * time.Second,
+		PongWait:       60 * time.Second,
+		WriteTimeout:   10 * time.Second,
+		ReadTimeout:    60 * time.Second,
+		MaxMessageSize: 512 * 1024,
+	}, nil, nil)
+
+	topic, err := broker.NewBroker().CreateTopic(newOrderTopicSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, err := wsPortal.ApplyTopic(ctx, topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address.Path() != "/topic/orders" {
+		t.Fatalf("unexpected address %s", address)
+	}
+
+	if err := wsPortal.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer wsPortal.Stop(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	client := wsPortal.(*portal.WebSocketPortal)
+	sub, err := client.SubscribeTopic(ctx, address, api.SubscribeOptions{Keys: []any{"eu"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	if err := client.PublishTopic(ctx, address, api.Message{Key: "us", Payload: map[string]any{"id": "o-1", "total": 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.PublishTopic(ctx, address, api.Message{Key: "eu", Payload: map[string]any{"id": "o-2", "total": 2}}); err != nil {
+		t.Fatal(err)
+	}
+	message := receive(t, sub)
+	if payload, _ := message.Payload.(map[string]any); message.Key != "eu" || payload["id"] != "o-2" {
+		t.Fatalf("unexpected message %+v", message)
+	}
+
+	if err := client.PublishTopic(ctx, address, api.Message{Payload: "not an order"}); err == nil {
+		t.Fatal("expected invalid message to be rejected")
+	}
+	missing := portal.NewAddressBuilder().Scheme("ws").Host("localhost").Port(8092).Path("/topic/missing").Build()
+	if _, err := client.SubscribeTopic(ctx, missing, api.SubscribeOptions{}); err == nil {
+		t.Fatal("expected unknown topic to fail")
+	}
+}
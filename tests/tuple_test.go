@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	jsonexport "defs.dev/schema/visit/export/json"
+	"defs.dev/schema/visit/export/python"
+	"defs.dev/schema/visit/export/typescript"
+)
+
+func newRecordTuple() core.ArraySchemaBuilder {
+	return builders.NewArraySchema().
+		Name("Record").
+		TupleOf(
+			builders.NewStringSchema().MinLength(1).Build(),
+			builders.NewIntegerSchema().Build(),
+			builders.NewBooleanSchema().Build(),
+		)
+}
+
+func TestTupleSchemaValidation(t *testing.T) {
+	t.Run("Closed tuple", func(t *testing.T) {
+		schema := newRecordTuple().Build()
+
+		if len(schema.PrefixItemSchemas()) != 3 {
+			t.Fatalf("Expected 3 prefix item schemas, got %d", len(schema.PrefixItemSchemas()))
+		}
+
+		if result := validation.ValidateValue(schema, []any{"a", 1, true}); !result.Valid {
+			t.Errorf("Expected tuple to be valid, got errors: %v", result.Errors)
+		}
+
+		result := validation.ValidateValue(schema, []any{"a", "one", true})
+		if result.Valid {
+			t.Fatal("Expected wrong item type to be invalid")
+		}
+		if path := result.Errors[0].Path; len(path) != 1 || path[0] != "[1]" {
+			t.Errorf("Expected error path [1], got %v", path)
+		}
+
+		for _, v := range [][]any{{"a", 1}, {"a", 1, true, "extra"}} {
+			result := validation.ValidateValue(schema, v)
+			if result.Valid || result.Errors[0].Code != "tuple_length_violation" {
+				t.Errorf("Expected tuple_length_violation for %v, got %v", v, result.Errors)
+			}
+		}
+	})
+
+	t.Run("Rest items", func(t *testing.T) {
+		schema := builders.NewArraySchema().
+			PrefixItems(builders.NewStringSchema().Build()).
+			Rest(builders.NewIntegerSchema().Build()).
+			Build()
+
+		if result := validation.ValidateValue(schema, []any{"sum", 1, 2, 3}); !result.Valid {
+			t.Errorf("Expected tuple with rest items to be valid, got errors: %v", result.Errors)
+		}
+
+		result := validation.ValidateValue(schema, []any{"sum", 1, "two"})
+		if result.Valid {
+			t.Fatal("Expected invalid rest item to be rejected")
+		}
+		if path := result.Errors[0].Path; len(path) != 1 || path[0] != "[2]" {
+			t.Errorf("Expected error path [2], got %v", path)
+		}
+	})
+}
+
+func TestTupleSchemaExport(t *testing.T) {
+	t.Run("JSON Schema draft-07", func(t *testing.T) {
+		output, err := jsonexport.NewGenerator().Generate(newRecordTuple().Build())
+		if err != nil {
+			t.Fatalf("Failed to generate JSON Schema: %v", err)
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal(output, &doc); err != nil {
+			t.Fatalf("Failed to parse JSON Schema: %v", err)
+		}
+		items, ok := doc["items"].([]any)
+		if !ok || len(items) != 3 {
+			t.Fatalf("Expected positional items, got %v", doc["items"])
+		}
+		if doc["additionalItems"] != false || doc["minItems"] != float64(3) {
+			t.Errorf("Expected closed tuple of 3 items, got %v", doc)
+		}
+	})
+
+	t.Run("JSON Schema draft-2020-12", func(t *testing.T) {
+		schema := newRecordTuple().Rest(builders.NewStringSchema().Build()).Build()
+		output, err := jsonexport.NewGenerator(jsonexport.WithDraft("draft-2020-12")).Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate JSON Schema: %v", err)
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal(output, &doc); err != nil {
+			t.Fatalf("Failed to parse JSON Schema: %v", err)
+		}
+		if prefixItems, ok := doc["prefixItems"].([]any); !ok || len(prefixItems) != 3 {
+			t.Errorf("Expected prefixItems with 3 entries, got %v", doc["prefixItems"])
+		}
+		if items, ok := doc["items"].(map[string]any); !ok || items["type"] != "string" {
+			t.Errorf("Expected string rest items, got %v", doc["items"])
+		}
+	})
+
+	t.Run("TypeScript", func(t *testing.T) {
+		output, err := typescript.NewGenerator().Generate(newRecordTuple().Build())
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		if !strings.Contains(string(output), "[string, number, boolean]") {
+			t.Errorf("Unexpected TypeScript output:\n%s", output)
+		}
+
+		output, err = typescript.NewGenerator().Generate(newRecordTuple().Rest(builders.NewStringSchema().Build()).Build())
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		if !strings.Contains(string(output), "[string, number, boolean, ...string[]]") {
+			t.Errorf("Unexpected TypeScript output:\n%s", output)
+		}
+	})
+
+	t.Run("Python", func(t *testing.T) {
+		output, err := python.NewGenerator(python.DefaultPythonOptions()).Generate(newRecordTuple().Build())
+		if err != nil {
+			t.Fatalf("Failed to generate Python: %v", err)
+		}
+		for _, want := range []string{"from typing import Tuple", "value: Tuple[str, int, bool]"} {
+			if !strings.Contains(string(output), want) {
+				t.Errorf("Expected %q in Python output:\n%s", want, output)
+			}
+		}
+	})
+}
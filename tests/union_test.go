@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/validation"
+	"defs.dev/schema/core"
+	jsonexport "defs.dev/schema/visit/export/json"
+	"defs.dev/schema/visit/export/python"
+	"defs.dev/schema/visit/export/typescript"
+)
+
+func newShapeUnion() core.UnionSchema {
+	circle := builders.NewObjectSchema().
+		Name("Circle").
+		Property("kind", builders.NewStringSchema().Enum("circle").Build()).
+		Property("radius", builders.NewNumberSchema().Min(0).Build()).
+		Required("kind", "radius").
+		Build()
+	square := builders.NewObjectSchema().
+		Name("Square").
+		Property("kind", builders.NewStringSchema().Enum("square").Build()).
+		Property("side", builders.NewNumberSchema().Min(0).Build()).
+		Required("kind", "side").
+		Build()
+
+	return builders.NewUnionSchema().
+		Name("Shape").
+		Schemas(circle, square).
+		Discriminator("kind").
+		Build()
+}
+
+func TestUnionSchemaValidation(t *testing.T) {
+	t.Run("oneOf primitives", func(t *testing.T) {
+		schema := builders.NewUnionSchema().
+			Schemas(builders.NewStringSchema().Build(), builders.NewBooleanSchema().Build()).
+			Build()
+
+		if schema.Mode() != core.UnionOneOf {
+			t.Errorf("Expected default mode oneOf, got %s", schema.Mode())
+		}
+
+		for _, v := range []any{"hello", true} {
+			if result := validation.ValidateValue(schema, v); !result.Valid {
+				t.Errorf("Expected %v to be valid, got errors: %v", v, result.Errors)
+			}
+		}
+
+		result := validation.ValidateValue(schema, map[string]any{"a": 1})
+		if result.Valid {
+			t.Fatal("Expected object to be invalid for string|boolean union")
+		}
+		if result.Errors[0].Code != "union_no_match" {
+			t.Errorf("Expected union_no_match, got %s", result.Errors[0].Code)
+		}
+		// Branch errors are only reported as causes of the summary error
+		if len(result.Errors) != 1 || len(result.Errors[0].Causes) != 2 {
+			t.Fatalf("Expected one error with a cause per branch, got %v", result.Errors)
+		}
+		for _, cause := range result.Errors[0].Causes {
+			if cause.Path == nil || len(cause.Path) != 0 || cause.Pointer != "" {
+				t.Errorf("Expected causes located at the value, got %+v", cause)
+			}
+		}
+		if detail := result.Problem().Detail; detail != result.Errors[0].Message {
+			t.Errorf("Expected the causes not to be counted, got %q", detail)
+		}
+	})
+
+	t.Run("oneOf rejects multiple matches", func(t *testing.T) {
+		schema := builders.NewUnionSchema().
+			Schemas(builders.NewStringSchema().Build(), builders.NewStringSchema().MinLength(1).Build()).
+			Build()
+
+		result := validation.ValidateValue(schema, "abc")
+		if result.Valid {
+			t.Fatal("Expected value matching two branches to be invalid for oneOf")
+		}
+		if result.Errors[0].Code != "union_multiple_matches" {
+			t.Errorf("Expected union_multiple_matches, got %s", result.Errors[0].Code)
+		}
+
+		anyOf := builders.NewUnionSchema().
+			Schemas(builders.NewStringSchema().Build(), builders.NewStringSchema().MinLength(1).Build()).
+			AnyOf().
+			Build()
+		if result := validation.ValidateValue(anyOf, "abc"); !result.Valid {
+			t.Errorf("Expected anyOf to accept multiple matches, got errors: %v", result.Errors)
+		}
+	})
+
+	t.Run("discriminator selects branch", func(t *testing.T) {
+		schema := newShapeUnion()
+
+		valid := map[string]any{"kind": "circle", "radius": 2.0}
+		if result := validation.ValidateValue(schema, valid); !result.Valid {
+			t.Errorf("Expected circle to be valid, got errors: %v", result.Errors)
+		}
+
+		result := validation.ValidateValue(schema, map[string]any{"kind": "square", "radius": 2.0})
+		if result.Valid {
+			t.Fatal("Expected square without side to be invalid")
+		}
+		found := false
+		for _, err := range result.Errors {
+			if err.Code == "missing_required_property" && strings.Contains(err.Message, "Square") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected error attributed to Square branch, got %v", result.Errors)
+		}
+
+		result = validation.ValidateValue(schema, map[string]any{"kind": "triangle"})
+		if result.Valid || result.Errors[0].Code != "unknown_discriminator" {
+			t.Errorf("Expected unknown_discriminator, got %v", result.Errors)
+		}
+
+		result = validation.ValidateValue(schema, map[string]any{"radius": 2.0})
+		if result.Valid || result.Errors[0].Code != "missing_discriminator" {
+			t.Errorf("Expected missing_discriminator, got %v", result.Errors)
+		}
+	})
+}
+
+func TestUnionBuilderImmutability(t *testing.T) {
+	base := builders.NewUnionSchema().Schemas(builders.NewStringSchema().Build())
+	extended := base.Schemas(builders.NewBooleanSchema().Build())
+
+	if len(base.Build().Schemas()) != 1 {
+		t.Errorf("Expected base builder to keep 1 member, got %d", len(base.Build().Schemas()))
+	}
+	if len(extended.Build().Schemas()) != 2 {
+		t.Errorf("Expected extended builder to have 2 members, got %d", len(extended.Build().Schemas()))
+	}
+
+	clone := extended.Build().Clone().(core.UnionSchema)
+	if len(clone.Schemas()) != 2 || clone.Type() != core.TypeUnion {
+		t.Errorf("Expected clone to preserve members and type")
+	}
+}
+
+func TestUnionSchemaExport(t *testing.T) {
+	schema := newShapeUnion()
+
+	t.Run("JSON Schema", func(t *testing.T) {
+		output, err := jsonexport.NewGenerator().Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate JSON Schema: %v", err)
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal(output, &doc); err != nil {
+			t.Fatalf("Failed to parse JSON Schema: %v", err)
+		}
+		branches, ok := doc["oneOf"].([]any)
+		if !ok || len(branches) != 2 {
+			t.Errorf("Expected oneOf with 2 branches, got %v", doc["oneOf"])
+		}
+		discriminator, ok := doc["discriminator"].(map[string]any)
+		if !ok || discriminator["propertyName"] != "kind" {
+			t.Errorf("Expected discriminator propertyName kind, got %v", doc["discriminator"])
+		}
+	})
+
+	t.Run("TypeScript", func(t *testing.T) {
+		output, err := typescript.NewGenerator().Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate TypeScript: %v", err)
+		}
+		if !strings.Contains(string(output), "export type Shape = Circle | Square;") {
+			t.Errorf("Unexpected TypeScript output:\n%s", output)
+		}
+	})
+
+	t.Run("Python", func(t *testing.T) {
+		output, err := python.NewGenerator(python.DefaultPythonOptions()).Generate(schema)
+		if err != nil {
+			t.Fatalf("Failed to generate Python: %v", err)
+		}
+		if !strings.Contains(string(output), "Shape = Union[Circle, Square]") {
+			t.Errorf("Unexpected Python output:\n%s", output)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/consume/validation"
+)
+
+func TestValidationIssueDetails(t *testing.T) {
+	inventory := newInventory(2)
+	inventory[1] = map[string]any{"id": float64(0), "sku": "abc"}
+	result := validation.Compile(newInventorySchema()).Validate(inventory)
+
+	groups := result.GroupByPath()
+	if len(groups) != 2 || len(groups["/1/id"]) != 1 || len(groups["/1/sku"]) != 1 {
+		t.Fatalf("unexpected groups %+v", groups)
+	}
+
+	id := groups["/1/id"][0]
+	expected := validation.ValidationIssue{
+		Path:           []string{"[1]", "id"},
+		Code:           "number_too_small",
+		Message:        id.Message,
+		Pointer:        "/1/id",
+		SchemaLocation: "/items/properties/id/minimum",
+		Keyword:        "minimum",
+		Params:         map[string]any{"min": int64(1)},
+		Value:          float64(0),
+	}
+	if !reflect.DeepEqual(id, expected) {
+		t.Fatalf("unexpected issue:\n%+v\nexpected:\n%+v", id, expected)
+	}
+	if sku := groups["/1/sku"][0]; sku.Keyword != "pattern" || sku.Params["pattern"] != `^[A-Z]{3}-[0-9]{4}$` || sku.Value != "abc" {
+		t.Fatalf("unexpected issue %+v", sku)
+	}
+
+	t.Run("uncompiled", func(t *testing.T) {
+		uncompiled := validation.ValidateValue(newInventorySchema(), inventory).GroupByPath()
+		if !reflect.DeepEqual(uncompiled, groups) {
+			t.Fatalf("unexpected issues %+v, expected %+v", uncompiled, groups)
+		}
+	})
+
+	t.Run("shared schemas", func(t *testing.T) {
+		name := builders.NewStringSchema().MinLength(2).Build()
+		schema := builders.NewObjectSchema().
+			Property("name", name).
+			Property("tags", builders.NewArraySchema().Items(name).Build()).
+			Property("alias", builders.NewUnionSchema().Schemas(name, builders.NewIntegerSchema().Build()).Build()).
+			Build()
+		value := map[string]any{"name": "ok", "tags": []any{"ok", "x"}, "alias": true}
+
+		for label, result := range map[string]validation.ValidationResult{
+			"compiled":   validation.Compile(schema).Validate(value),
+			"uncompiled": validation.ValidateValue(schema, value),
+		} {
+			groups := result.GroupByPath()
+			if tag := groups["/tags/1"]; len(tag) != 1 || tag[0].SchemaLocation != "/properties/tags/items/minLength" {
+				t.Errorf("%s: unexpected tag issues %+v", label, tag)
+			}
+			var alias *validation.ValidationIssue
+			for i, issue := range groups["/alias"] {
+				if issue.Code == "union_no_match" {
+					alias = &groups["/alias"][i]
+				}
+			}
+			if alias == nil || len(alias.Causes) != 2 || alias.Causes[0].SchemaLocation != "/properties/alias/oneOf/0/type" {
+				t.Errorf("%s: unexpected alias issues %+v", label, groups["/alias"])
+			}
+		}
+	})
+
+	t.Run("union causes", func(t *testing.T) {
+		schema := builders.NewObjectSchema().
+			Property("port", builders.NewUnionSchema().Schemas(
+				builders.NewIntegerSchema().Min(1).Build(),
+				builders.NewStringSchema().MinLength(1).Build(),
+			).Build()).
+			Build()
+		result := validation.Compile(schema).Validate(map[string]any{"port": float64(0)})
+		if len(result.Errors) != 1 {
+			t.Fatalf("expected branch errors only as causes, got %+v", result.Errors)
+		}
+		issue := result.Errors[0]
+		if issue.Code != "union_no_match" || issue.Pointer != "/port" || issue.SchemaLocation != "/properties/port/"+issue.Keyword {
+			t.Fatalf("unexpected issue %+v", issue)
+		}
+		if len(issue.Causes) != 2 {
+			t.Fatalf("expected a cause per branch, got %+v", issue.Causes)
+		}
+		if cause := issue.Causes[0]; cause.Keyword != "minimum" || cause.Pointer != "/port" || !reflect.DeepEqual(cause.Path, []string{"port"}) || cause.SchemaLocation != "/properties/port/"+issue.Keyword+"/0/minimum" {
+			t.Fatalf("unexpected cause %+v", cause)
+		}
+		if cause := issue.Causes[1]; cause.Keyword != "type" || cause.Params["expected"] != "string" {
+			t.Fatalf("unexpected cause %+v", cause)
+		}
+	})
+
+	t.Run("redaction", func(t *testing.T) {
+		redacted := result.Redacted()
+		for _, issue := range redacted.Errors {
+			if issue.Value != nil {
+				t.Fatalf("expected no value, got %+v", issue)
+			}
+		}
+		if result.Errors[0].Value == nil {
+			t.Fatal("expected redaction to leave the result unchanged")
+		}
+	})
+
+	t.Run("problem", func(t *testing.T) {
+		data, err := json.Marshal(result.Redacted().Problem())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var problem map[string]any
+		if err := json.Unmarshal(data, &problem); err != nil {
+			t.Fatal(err)
+		}
+		if problem["status"] != float64(422) || problem["detail"] != "2 validation errors" {
+			t.Fatalf("unexpected problem %s", data)
+		}
+		if issues := problem["errors"].([]any); len(issues) != 2 || issues[0].(map[string]any)["pointer"] == nil {
+			t.Fatalf("unexpected problem errors %s", data)
+		}
+	})
+}
+
+func TestJSONPointer(t *testing.T) {
+	tests := map[string][]string{
+		"":              nil,
+		"/items/3/name": {"items", "[3]", "name"},
+		"/a~1b/m~0n":    {"a/b", "m~n"},
+		"/[x]":          {"[x]"},
+	}
+	for expected, path := range tests {
+		if pointer := validation.JSONPointer(path); pointer != expected {
+			t.Errorf("JSONPointer(%q) = %q, expected %q", path, pointer, expected)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/core"
+)
+
+// recordingVisitor records the values it visits and descends into composites.
+type recordingVisitor struct {
+	visited []string
+}
+
+func (v *recordingVisitor) VisitString(value core.StringValue) error {
+	v.visited = append(v.visited, "string:"+value.Value())
+	return nil
+}
+
+func (v *recordingVisitor) VisitNumber(value core.NumberValue) error {
+	v.visited = append(v.visited, "number:"+value.String())
+	return nil
+}
+
+func (v *recordingVisitor) VisitInteger(value core.IntegerValue) error {
+	v.visited = append(v.visited, "integer:"+value.String())
+	return nil
+}
+
+func (v *recordingVisitor) VisitBoolean(value core.BooleanValue) error {
+	v.visited = append(v.visited, "boolean:"+value.String())
+	return nil
+}
+
+func (v *recordingVisitor) VisitArray(value core.ArrayValue[any]) error {
+	v.visited = append(v.visited, "array")
+	return v.visitEntries(entryValues(value.Values()))
+}
+
+func (v *recordingVisitor) VisitObject(value core.StructureValue[any]) error {
+	v.visited = append(v.visited, "object")
+	return v.visitEntries(entryValues(value.Values()))
+}
+
+func (v *recordingVisitor) VisitMap(value core.MapValue[any, any]) error {
+	v.visited = append(v.visited, "map")
+	return v.visitEntries(entryValues(value.Values()))
+}
+
+func (v *recordingVisitor) visitEntries(values []core.Value[any]) error {
+	for _, value := range values {
+		if err := value.(core.ValueAccepter).AcceptValue(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func entryValues[K any](entries []core.CompositeEntry[K, any]) []core.Value[any] {
+	values := make([]core.Value[any], len(entries))
+	for i, entry := range entries {
+		values[i] = entry.Value
+	}
+	return values
+}
+
+func newOrderSchema() core.ObjectSchema {
+	return builders.NewObjectSchema().
+		Name("Order").
+		Property("id", builders.NewIntegerSchema().Build()).
+		Property("total", builders.NewNumberSchema().Build()).
+		Property("paid", builders.NewBooleanSchema().Build()).
+		Property("items", builders.NewArraySchema().Items(builders.NewStringSchema().Build()).Build()).
+		Property("tags", builders.NewMapSchema().Values(builders.NewStringSchema().Build()).Build()).
+		Property("note", builders.NewOptionalSchema().Of(builders.NewStringSchema().Build()).Build()).
+		Build()
+}
+
+func TestScalarValues(t *testing.T) {
+	s := core.NewStringValue("hello")
+	if s.Value() != "hello" || s.String() != "hello" || s.IsNull() || s.IsComposite() {
+		t.Fatalf("unexpected string value %v", s)
+	}
+	if n := core.NewNumberValue(1.5); n.Value() != 1.5 || n.String() != "1.5" {
+		t.Fatalf("unexpected number value %v", n)
+	}
+	if i := core.NewIntegerValue(42); i.Value() != 42 || i.IsNull() {
+		t.Fatalf("unexpected integer value %v", i)
+	}
+	if b := core.NewBooleanValue(false); b.Value() || b.IsNull() {
+		t.Fatalf("unexpected boolean value %v", b)
+	}
+}
+
+func TestNewValue(t *testing.T) {
+	order := map[string]any{
+		"id":    json.Number("7"),
+		"total": 12.5,
+		"paid":  true,
+		"items": []any{"apple", "pear"},
+		"tags":  map[string]any{"channel": "web"},
+		"note":  nil,
+		"extra": 3.0,
+	}
+
+	value, err := core.NewValue(newOrderSchema(), order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(value.Value(), order) || !value.IsComposite() {
+		t.Fatalf("expected the value to hold the input, got %v", value.Value())
+	}
+
+	visitor := &recordingVisitor{}
+	if err := value.(core.ValueAccepter).AcceptValue(visitor); err != nil {
+		t.Fatal(err)
+	}
+	expected := "object number:3 integer:7 array string:apple string:pear boolean:true map string:web number:12.5"
+	if got := strings.Join(visitor.visited, " "); got != expected {
+		t.Fatalf("unexpected visits %q", got)
+	}
+
+	t.Run("paths", func(t *testing.T) {
+		var object core.StructureValue[any]
+		value.(core.ValueAccepter).AcceptValue(&structureCapture{capture: &object})
+		if object == nil {
+			t.Fatal("expected a structure value")
+		}
+		if !reflect.DeepEqual(object.Keys(), []string{"extra", "id", "items", "note", "paid", "tags", "total"}) {
+			t.Fatalf("unexpected keys %v", object.Keys())
+		}
+
+		items, err := object.Get("items")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var array core.ArrayValue[any]
+		items.(core.ValueAccepter).AcceptValue(&structureCapture{array: &array})
+		entries := array.Values()
+		if len(entries) != 2 || entries[1].PathFragment != "1" || entries[1].Value.Value() != "pear" {
+			t.Fatalf("unexpected array entries %+v", entries)
+		}
+		if path := entries[1].Value.(core.PathAware).Path(); !reflect.DeepEqual(path, []string{"items", "1"}) {
+			t.Fatalf("unexpected path %v", path)
+		}
+
+		note, _ := object.Get("note")
+		if !note.IsNull() {
+			t.Fatal("expected a null note")
+		}
+		if _, err := object.Get("missing"); err == nil {
+			t.Fatal("expected an error for a missing field")
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		_, err := core.NewValue(newOrderSchema(), map[string]any{"items": []any{"apple", 3}})
+		if err == nil || !strings.Contains(err.Error(), "/items/1") {
+			t.Fatalf("expected a mismatch error at /items/1, got %v", err)
+		}
+	})
+
+	t.Run("union", func(t *testing.T) {
+		union := builders.NewUnionSchema().Schemas(
+			builders.NewBooleanSchema().Build(),
+			builders.NewIntegerSchema().Build(),
+		).Build()
+		value, err := core.NewValue(union, 4.0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		visitor := &recordingVisitor{}
+		value.(core.ValueAccepter).AcceptValue(visitor)
+		if !reflect.DeepEqual(visitor.visited, []string{"integer:4"}) {
+			t.Fatalf("expected the integer branch, got %v", visitor.visited)
+		}
+	})
+}
+
+func TestValueImmutability(t *testing.T) {
+	input := map[string]any{"items": []any{"a"}}
+	value := core.ValueOf(input)
+
+	copied := value.Copy().(map[string]any)
+	copied["items"].([]any)[0] = "b"
+	if input["items"].([]any)[0] != "a" {
+		t.Fatal("expected Copy to return a deep copy")
+	}
+
+	// Changing the input after construction does not change the value
+	input["items"].([]any)[0] = "c"
+	input["tags"] = []string{"new"}
+	if !reflect.DeepEqual(value.Value(), map[string]any{"items": []any{"a"}}) {
+		t.Fatalf("expected the value to keep its own copy, got %v", value.Value())
+	}
+	typed := map[string][]string{"tags": {"a", "b"}}
+	built, err := core.NewValue(nil, typed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	typed["tags"][0] = "z"
+	if got := built.Value().(map[string][]string); got["tags"][0] != "a" {
+		t.Fatalf("expected typed slices and maps to be copied, got %v", got)
+	}
+
+	entries := map[string]core.Value[any]{"a": core.ValueOf(1)}
+	m := core.NewMapValue(entries)
+	entries["b"] = core.ValueOf(2)
+	if m.Size() != 1 || m.Has("b") {
+		t.Fatal("expected the map value not to change with its entries")
+	}
+	if v, err := m.Get("a"); err != nil || v != 1 {
+		t.Fatalf("unexpected entry %v: %v", v, err)
+	}
+}
+
+// structureCapture captures the composite value a value dispatches to.
+type structureCapture struct {
+	recordingVisitor
+	capture *core.StructureValue[any]
+	array   *core.ArrayValue[any]
+}
+
+func (c *structureCapture) VisitObject(value core.StructureValue[any]) error {
+	*c.capture = value
+	return nil
+}
+
+func (c *structureCapture) VisitArray(value core.ArrayValue[any]) error {
+	*c.array = value
+	return nil
+}
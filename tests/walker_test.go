@@ -0,0 +1,270 @@
+package tests
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"defs.dev/schema/construct/builders"
+	"defs.dev/schema/core"
+	"defs.dev/schema/schemas"
+	"defs.dev/schema/visit/walker"
+)
+
+// newWalkedServiceSchema builds a service whose method takes an object with
+// nested strings.
+func newWalkedServiceSchema() core.ServiceSchema {
+	address := builders.NewObjectSchema().
+		Description("Postal address").
+		Property("street", builders.NewStringSchema().Description("Street and number").Build()).
+		Property("zip/code", builders.NewStringSchema().MaxLength(10).Build()).
+		Build()
+	user := builders.NewObjectSchema().
+		Description("A user").
+		Property("name", builders.NewStringSchema().Description("Full name").Build()).
+		Property("tags", builders.NewArraySchema().Items(builders.NewStringSchema().Build()).Build()).
+		Property("address", address).
+		Build()
+	update := builders.NewFunctionSchema().
+		RequiredInput("user", user).
+		RequiredOutput("ok", builders.NewBooleanSchema().Build()).
+		Description("Updates a user").
+		Build()
+	return builders.NewServiceSchema().Name("users").Method("update", update).Build()
+}
+
+func walkedPaths(t *testing.T, schema core.Schema) []string {
+	t.Helper()
+	var paths []string
+	err := walker.Walk(schema, walker.Hooks{Pre: func(node *walker.Node) error {
+		paths = append(paths, node.Path)
+		return nil
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return paths
+}
+
+func descriptions(t *testing.T, schema core.Schema) []string {
+	t.Helper()
+	var found []string
+	walker.Walk(schema, walker.Hooks{Pre: func(node *walker.Node) error {
+		if description := node.Schema.Metadata().Description; description != "" {
+			found = append(found, description)
+		}
+		return nil
+	}})
+	return found
+}
+
+// walkedObject is an object schema defined outside the schemas package.
+type walkedObject struct {
+	core.ObjectSchema
+}
+
+func TestWalk(t *testing.T) {
+	service := newWalkedServiceSchema()
+
+	t.Run("paths and parents", func(t *testing.T) {
+		paths := walkedPaths(t, service)
+		for _, expected := range []string{
+			"",
+			"/methods/update",
+			"/methods/update/inputs/user",
+			"/methods/update/inputs/user/properties/tags/items",
+			"/methods/update/inputs/user/properties/address/properties/zip~1code",
+			"/methods/update/outputs/ok",
+		} {
+			if !containsPath(paths, expected) {
+				t.Errorf("expected path %q in %v", expected, paths)
+			}
+		}
+
+		walker.Walk(service, walker.Hooks{Pre: func(node *walker.Node) error {
+			if node.Path == "/methods/update/inputs/user/properties/address/properties/street" {
+				if node.Depth() != 4 || node.Parent.Path != "/methods/update/inputs/user/properties/address" {
+					t.Errorf("unexpected parent %q at depth %d", node.Parent.Path, node.Depth())
+				}
+			}
+			return nil
+		}})
+	})
+
+	t.Run("pre and post order", func(t *testing.T) {
+		var order []string
+		array := builders.NewArraySchema().Items(builders.NewStringSchema().Build()).Build()
+		walker.Walk(array, walker.Hooks{
+			Pre:  func(node *walker.Node) error { order = append(order, "pre "+node.Path); return nil },
+			Post: func(node *walker.Node) error { order = append(order, "post "+node.Path); return nil },
+		})
+		if got := strings.Join(order, ","); got != "pre ,pre /items,post /items,post " {
+			t.Fatalf("unexpected order %s", got)
+		}
+	})
+
+	t.Run("skip, stop and errors", func(t *testing.T) {
+		var visited int
+		walker.Walk(service, walker.Hooks{Pre: func(node *walker.Node) error {
+			visited++
+			if node.Schema.Type() == core.TypeStructure {
+				return walker.SkipChildren
+			}
+			return nil
+		}})
+		// Service, method, user object and the ok output
+		if visited != 4 {
+			t.Fatalf("expected 4 visited schemas, got %d", visited)
+		}
+
+		visited = 0
+		err := walker.Walk(service, walker.Hooks{Pre: func(node *walker.Node) error {
+			visited++
+			if node.Schema.Type() == core.TypeString {
+				return walker.Stop
+			}
+			return nil
+		}})
+		// Service, method, user object, address and street, properties being walked in name order
+		if err != nil || visited != 5 {
+			t.Fatalf("expected stop after the first string, got %d visits: %v", visited, err)
+		}
+
+		failure := errors.New("no arrays")
+		err = walker.Walk(service, walker.Hooks{Post: func(node *walker.Node) error {
+			if node.Schema.Type() == core.TypeArray {
+				return failure
+			}
+			return nil
+		}})
+		if !errors.Is(err, failure) || !strings.HasPrefix(err.Error(), "/methods/update/inputs/user/properties/tags:") {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("schemas defined elsewhere", func(t *testing.T) {
+		object := walkedObject{builders.NewObjectSchema().
+			Property("tags", builders.NewArraySchema().Items(builders.NewStringSchema().Build()).Build()).
+			Build()}
+		paths := walkedPaths(t, builders.NewArraySchema().Items(object).Build())
+		if got := strings.Join(paths, ","); got != ",/items,/items/properties/tags,/items/properties/tags/items" {
+			t.Fatalf("unexpected paths %s", got)
+		}
+
+		_, err := walker.Rewrite(object, func(node *walker.Node) (core.Schema, error) {
+			if node.Schema.Type() == core.TypeString {
+				return builders.NewStringSchema().MaxLength(10).Build(), nil
+			}
+			return nil, nil
+		})
+		if err == nil || !strings.HasPrefix(err.Error(), "/: cannot rebuild") {
+			t.Fatalf("expected an error rebuilding the object, got %v", err)
+		}
+	})
+}
+
+func TestRewrite(t *testing.T) {
+	service := newWalkedServiceSchema()
+
+	t.Run("strip descriptions", func(t *testing.T) {
+		stripped, err := walker.Rewrite(service, func(node *walker.Node) (core.Schema, error) {
+			metadata := node.Schema.Metadata()
+			if metadata.Description == "" {
+				return nil, nil
+			}
+			metadata.Description = ""
+			return schemas.WithMetadata(node.Schema, metadata), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found := descriptions(t, stripped); len(found) != 0 {
+			t.Fatalf("expected no descriptions, got %v", found)
+		}
+		if found := descriptions(t, service); len(found) != 5 {
+			t.Fatalf("expected the original to keep its 5 descriptions, got %v", found)
+		}
+		if len(walkedPaths(t, stripped)) != len(walkedPaths(t, service)) {
+			t.Fatal("expected the same tree shape")
+		}
+	})
+
+	t.Run("cap string lengths", func(t *testing.T) {
+		capped, err := walker.Rewrite(service, func(node *walker.Node) (core.Schema, error) {
+			s, ok := node.Schema.(core.StringSchema)
+			if !ok || (s.MaxLength() != nil && *s.MaxLength() <= 1024) {
+				return nil, nil
+			}
+			maxLength := 1024
+			config := schemas.StringSchemaConfig{
+				Metadata:    s.Metadata(),
+				MinLength:   s.MinLength(),
+				MaxLength:   &maxLength,
+				Format:      s.Format(),
+				EnumValues:  s.EnumValues(),
+				DefaultVal:  s.DefaultValue(),
+				Annotations: s.Annotations(),
+			}
+			if s.Pattern() != "" {
+				config.Pattern = regexp.MustCompile(s.Pattern())
+			}
+			return schemas.NewStringSchema(config), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		limits := map[string]int{}
+		walker.Walk(capped, walker.Hooks{Pre: func(node *walker.Node) error {
+			if s, ok := node.Schema.(core.StringSchema); ok {
+				limits[node.Path] = *s.MaxLength()
+			}
+			return nil
+		}})
+		if len(limits) != 4 || limits["/methods/update/inputs/user/properties/tags/items"] != 1024 ||
+			limits["/methods/update/inputs/user/properties/address/properties/zip~1code"] != 10 {
+			t.Fatalf("unexpected limits %v", limits)
+		}
+	})
+
+	t.Run("unchanged trees are shared", func(t *testing.T) {
+		result, err := walker.Rewrite(service, func(node *walker.Node) (core.Schema, error) {
+			return node.Schema, nil
+		})
+		if err != nil || result != core.Schema(service) {
+			t.Fatalf("expected the original schema back: %v", err)
+		}
+	})
+
+	t.Run("stop keeps earlier rewrites", func(t *testing.T) {
+		rewritten := 0
+		result, err := walker.Rewrite(service, func(node *walker.Node) (core.Schema, error) {
+			if node.Schema.Metadata().Description == "" {
+				return nil, nil
+			}
+			if rewritten == 2 {
+				return nil, walker.Stop
+			}
+			rewritten++
+			metadata := node.Schema.Metadata()
+			metadata.Description = ""
+			return schemas.WithMetadata(node.Schema, metadata), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found := descriptions(t, result); len(found) != 3 {
+			t.Fatalf("expected 3 descriptions left, got %v", found)
+		}
+	})
+}
+
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
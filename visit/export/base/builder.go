@@ -348,6 +348,21 @@ func ToPascalCase(s string) string {
 	return result.String()
 }
 
+// ToTypeIdentifier converts a type expression such as List[UserProfile] to an
+// identifier made of its words, capitalizing each word but otherwise keeping
+// its case (ListUserProfile).
+func ToTypeIdentifier(typeExpr string) string {
+	var result strings.Builder
+	for _, word := range regexp.MustCompile(`[^a-zA-Z0-9]+`).Split(typeExpr, -1) {
+		if word == "" {
+			continue
+		}
+		result.WriteRune(unicode.ToUpper(rune(word[0])))
+		result.WriteString(word[1:])
+	}
+	return result.String()
+}
+
 // ToSnakeCase converts a string to snake_case.
 func ToSnakeCase(s string) string {
 	if s == "" {
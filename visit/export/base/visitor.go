@@ -48,6 +48,18 @@ func (v *BaseVisitor) VisitBoolean(schema core.BooleanSchema) error {
 	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeBoolean, "boolean schema not implemented")
 }
 
+// VisitNull provides a default implementation for null schema visitation.
+// Concrete generators should override this method.
+func (v *BaseVisitor) VisitNull(schema core.NullSchema) error {
+	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeNull, "null schema not implemented")
+}
+
+// VisitAny provides a default implementation for any schema visitation.
+// Concrete generators should override this method.
+func (v *BaseVisitor) VisitAny(schema core.AnySchema) error {
+	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeAny, "any schema not implemented")
+}
+
 // VisitArray provides a default implementation for array schema visitation.
 // Concrete generators should override this method.
 func (v *BaseVisitor) VisitArray(schema core.ArraySchema) error {
@@ -60,6 +72,18 @@ func (v *BaseVisitor) VisitObject(schema core.ObjectSchema) error {
 	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeStructure, "object schema not implemented")
 }
 
+// VisitMap provides a default implementation for map schema visitation.
+// Concrete generators should override this method.
+func (v *BaseVisitor) VisitMap(schema core.MapSchema) error {
+	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeMap, "map schema not implemented")
+}
+
+// VisitOptional provides a default implementation for optional schema visitation.
+// Concrete generators should override this method.
+func (v *BaseVisitor) VisitOptional(schema core.OptionalSchema) error {
+	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeOptional, "optional schema not implemented")
+}
+
 // VisitFunction provides a default implementation for function schema visitation.
 // Concrete generators should override this method.
 func (v *BaseVisitor) VisitFunction(schema core.FunctionSchema) error {
@@ -72,12 +96,48 @@ func (v *BaseVisitor) VisitService(schema core.ServiceSchema) error {
 	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeService, "service schema not implemented")
 }
 
+// VisitTopic provides a default implementation for topic schema visitation.
+// Concrete generators should override this method.
+func (v *BaseVisitor) VisitTopic(schema core.TopicSchema) error {
+	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeTopic, "topic schema not implemented")
+}
+
+// VisitComponent provides a default implementation for component schema visitation.
+// Concrete generators should override this method.
+func (v *BaseVisitor) VisitComponent(schema core.ComponentSchema) error {
+	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeComponent, "component schema not implemented")
+}
+
 // VisitUnion provides a default implementation for union schema visitation.
 // Concrete generators should override this method.
 func (v *BaseVisitor) VisitUnion(schema core.UnionSchema) error {
 	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeUnion, "union schema not implemented")
 }
 
+// VisitRef provides a default implementation for reference schema visitation.
+// Concrete generators should override this method.
+func (v *BaseVisitor) VisitRef(schema core.RefSchema) error {
+	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeRef, "ref schema not implemented")
+}
+
+// VisitResult provides a default implementation for result schema visitation.
+// Concrete generators should override this method.
+func (v *BaseVisitor) VisitResult(schema core.ResultSchema) error {
+	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeResult, "result schema not implemented")
+}
+
+// VisitParameter provides a default implementation for type parameter visitation.
+// Concrete generators should override this method.
+func (v *BaseVisitor) VisitParameter(schema core.TypeParameterSchema) error {
+	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeParameter, "type parameter not implemented")
+}
+
+// VisitGeneric provides a default implementation for generic schema visitation.
+// Concrete generators should override this method.
+func (v *BaseVisitor) VisitGeneric(schema core.GenericSchema) error {
+	return NewUnsupportedSchemaError(v.GeneratorName, core.TypeGeneric, "generic schema not implemented")
+}
+
 // Helper methods for common visitor patterns
 
 // VisitWithPath visits a schema with path tracking for better error reporting.
@@ -142,15 +202,25 @@ func NewNoOpVisitor(generatorName string) *NoOpVisitor {
 
 // Override all visit methods to do nothing and return nil
 
-func (v *NoOpVisitor) VisitString(schema core.StringSchema) error     { return nil }
-func (v *NoOpVisitor) VisitNumber(schema core.NumberSchema) error     { return nil }
-func (v *NoOpVisitor) VisitInteger(schema core.IntegerSchema) error   { return nil }
-func (v *NoOpVisitor) VisitBoolean(schema core.BooleanSchema) error   { return nil }
-func (v *NoOpVisitor) VisitArray(schema core.ArraySchema) error       { return nil }
-func (v *NoOpVisitor) VisitObject(schema core.ObjectSchema) error     { return nil }
-func (v *NoOpVisitor) VisitFunction(schema core.FunctionSchema) error { return nil }
-func (v *NoOpVisitor) VisitService(schema core.ServiceSchema) error   { return nil }
-func (v *NoOpVisitor) VisitUnion(schema core.UnionSchema) error       { return nil }
+func (v *NoOpVisitor) VisitString(schema core.StringSchema) error           { return nil }
+func (v *NoOpVisitor) VisitNumber(schema core.NumberSchema) error           { return nil }
+func (v *NoOpVisitor) VisitInteger(schema core.IntegerSchema) error         { return nil }
+func (v *NoOpVisitor) VisitBoolean(schema core.BooleanSchema) error         { return nil }
+func (v *NoOpVisitor) VisitNull(schema core.NullSchema) error               { return nil }
+func (v *NoOpVisitor) VisitAny(schema core.AnySchema) error                 { return nil }
+func (v *NoOpVisitor) VisitArray(schema core.ArraySchema) error             { return nil }
+func (v *NoOpVisitor) VisitObject(schema core.ObjectSchema) error           { return nil }
+func (v *NoOpVisitor) VisitMap(schema core.MapSchema) error                 { return nil }
+func (v *NoOpVisitor) VisitOptional(schema core.OptionalSchema) error       { return nil }
+func (v *NoOpVisitor) VisitFunction(schema core.FunctionSchema) error       { return nil }
+func (v *NoOpVisitor) VisitService(schema core.ServiceSchema) error         { return nil }
+func (v *NoOpVisitor) VisitTopic(schema core.TopicSchema) error             { return nil }
+func (v *NoOpVisitor) VisitComponent(schema core.ComponentSchema) error     { return nil }
+func (v *NoOpVisitor) VisitUnion(schema core.UnionSchema) error             { return nil }
+func (v *NoOpVisitor) VisitRef(schema core.RefSchema) error                 { return nil }
+func (v *NoOpVisitor) VisitResult(schema core.ResultSchema) error           { return nil }
+func (v *NoOpVisitor) VisitParameter(schema core.TypeParameterSchema) error { return nil }
+func (v *NoOpVisitor) VisitGeneric(schema core.GenericSchema) error         { return nil }
 
 // CountingVisitor counts the number of schemas of each type visited.
 // This is useful for analysis and testing.
@@ -194,6 +264,16 @@ func (v *CountingVisitor) VisitBoolean(schema core.BooleanSchema) error {
 	return nil
 }
 
+func (v *CountingVisitor) VisitNull(schema core.NullSchema) error {
+	v.count(core.TypeNull)
+	return nil
+}
+
+func (v *CountingVisitor) VisitAny(schema core.AnySchema) error {
+	v.count(core.TypeAny)
+	return nil
+}
+
 func (v *CountingVisitor) VisitArray(schema core.ArraySchema) error {
 	v.count(core.TypeArray)
 	return nil
@@ -204,6 +284,16 @@ func (v *CountingVisitor) VisitObject(schema core.ObjectSchema) error {
 	return nil
 }
 
+func (v *CountingVisitor) VisitMap(schema core.MapSchema) error {
+	v.count(core.TypeMap)
+	return nil
+}
+
+func (v *CountingVisitor) VisitOptional(schema core.OptionalSchema) error {
+	v.count(core.TypeOptional)
+	return nil
+}
+
 func (v *CountingVisitor) VisitFunction(schema core.FunctionSchema) error {
 	v.count(core.TypeFunction)
 	return nil
@@ -214,11 +304,41 @@ func (v *CountingVisitor) VisitService(schema core.ServiceSchema) error {
 	return nil
 }
 
+func (v *CountingVisitor) VisitTopic(schema core.TopicSchema) error {
+	v.count(core.TypeTopic)
+	return nil
+}
+
+func (v *CountingVisitor) VisitComponent(schema core.ComponentSchema) error {
+	v.count(core.TypeComponent)
+	return nil
+}
+
 func (v *CountingVisitor) VisitUnion(schema core.UnionSchema) error {
 	v.count(core.TypeUnion)
 	return nil
 }
 
+func (v *CountingVisitor) VisitRef(schema core.RefSchema) error {
+	v.count(core.TypeRef)
+	return nil
+}
+
+func (v *CountingVisitor) VisitResult(schema core.ResultSchema) error {
+	v.count(core.TypeResult)
+	return nil
+}
+
+func (v *CountingVisitor) VisitParameter(schema core.TypeParameterSchema) error {
+	v.count(core.TypeParameter)
+	return nil
+}
+
+func (v *CountingVisitor) VisitGeneric(schema core.GenericSchema) error {
+	v.count(core.TypeGeneric)
+	return nil
+}
+
 // GetCount returns the count for a specific schema type.
 func (v *CountingVisitor) GetCount(schemaType core.SchemaType) int {
 	return v.Counts[schemaType]
@@ -18,6 +18,14 @@ type Generator struct {
 	enumFormatter *EnumFormatter
 	importManager *ImportManager
 	output        strings.Builder
+	results       []inlineResult // unnamed results used as types, in order of first use
+}
+
+// inlineResult is an unnamed result schema used as a type, declared under a
+// name derived from its payload types.
+type inlineResult struct {
+	name   string
+	schema core.ResultSchema
 }
 
 // NewGenerator creates a new Go generator with the given options.
@@ -38,6 +46,7 @@ func NewGenerator(options GoOptions) *Generator {
 func (g *Generator) Generate(schema core.Schema) ([]byte, error) {
 	// Reset output
 	g.output.Reset()
+	g.results = nil
 
 	// Validate options
 	if err := g.options.Validate(); err != nil {
@@ -68,6 +77,13 @@ func (g *Generator) Generate(schema core.Schema) ([]byte, error) {
 		return nil, fmt.Errorf("schema does not implement Accepter interface")
 	}
 
+	// Declare the inline results, including those used by other inline results
+	for i := 0; i < len(g.results); i++ {
+		if err := g.generateResultStruct(g.results[i].name, g.results[i].schema, ""); err != nil {
+			return nil, fmt.Errorf("generation failed: %w", err)
+		}
+	}
+
 	return []byte(g.output.String()), nil
 }
 
@@ -177,6 +193,37 @@ func (g *Generator) VisitBoolean(schema core.BooleanSchema) error {
 	}
 }
 
+// VisitNull generates Go code for a null schema as a type alias.
+func (g *Generator) VisitNull(schema core.NullSchema) error {
+	return g.generateTypeAlias(schema.Metadata(), g.typeMapper.MapSchemaType(core.TypeNull))
+}
+
+// VisitAny generates Go code for an any schema as a type alias.
+func (g *Generator) VisitAny(schema core.AnySchema) error {
+	return g.generateTypeAlias(schema.Metadata(), g.typeMapper.MapSchemaType(core.TypeAny))
+}
+
+// generateTypeAlias writes a commented type alias for a named schema.
+func (g *Generator) generateTypeAlias(metadata core.SchemaMetadata, goType string) error {
+	typeName := g.typeMapper.FormatTypeName(metadata.Name)
+
+	// Add comment
+	if g.options.IncludeComments && metadata.Description != "" {
+		commentLines := g.formatter.FormatComment(metadata.Description)
+		for _, line := range commentLines {
+			g.output.WriteString(line + "\n")
+		}
+	}
+
+	aliasLines := g.formatter.FormatTypeAlias(typeName, goType)
+	for _, line := range aliasLines {
+		g.output.WriteString(line + "\n")
+	}
+
+	g.output.WriteString("\n")
+	return nil
+}
+
 // VisitArray generates Go code for an array schema.
 func (g *Generator) VisitArray(schema core.ArraySchema) error {
 	metadata := schema.Metadata()
@@ -199,6 +246,208 @@ func (g *Generator) VisitObject(schema core.ObjectSchema) error {
 	return g.generateObjectStruct(schema)
 }
 
+// VisitMap generates Go code for a map schema.
+// Maps are always emitted as a named map type, since wrapping them in a struct adds nothing.
+func (g *Generator) VisitMap(schema core.MapSchema) error {
+	metadata := schema.Metadata()
+	typeName := g.typeMapper.FormatTypeName(metadata.Name)
+
+	// Add comment
+	if g.options.IncludeComments && metadata.Description != "" {
+		commentLines := g.formatter.FormatComment(metadata.Description)
+		for _, line := range commentLines {
+			g.output.WriteString(line + "\n")
+		}
+	}
+
+	g.output.WriteString(fmt.Sprintf("type %s %s\n", typeName, g.mapTypeName(schema)))
+	g.output.WriteString("\n")
+	return nil
+}
+
+// VisitOptional generates Go code for an optional (nullable) schema as a pointer type alias.
+func (g *Generator) VisitOptional(schema core.OptionalSchema) error {
+	metadata := schema.Metadata()
+	typeName := g.typeMapper.FormatTypeName(metadata.Name)
+
+	// Add comment
+	if g.options.IncludeComments && metadata.Description != "" {
+		commentLines := g.formatter.FormatComment(metadata.Description)
+		for _, line := range commentLines {
+			g.output.WriteString(line + "\n")
+		}
+	}
+
+	aliasLines := g.formatter.FormatTypeAlias(typeName, g.optionalTypeName(schema))
+	for _, line := range aliasLines {
+		g.output.WriteString(line + "\n")
+	}
+
+	g.output.WriteString("\n")
+	return nil
+}
+
+// VisitRef generates Go code for a reference schema as a type alias of the referenced type.
+func (g *Generator) VisitRef(schema core.RefSchema) error {
+	if schema.ReferenceName() == "" {
+		return fmt.Errorf("reference schema has no target name")
+	}
+
+	metadata := schema.Metadata()
+	typeName := g.typeMapper.FormatTypeName(metadata.Name)
+
+	// Add comment
+	if g.options.IncludeComments && metadata.Description != "" {
+		commentLines := g.formatter.FormatComment(metadata.Description)
+		for _, line := range commentLines {
+			g.output.WriteString(line + "\n")
+		}
+	}
+
+	targetType := g.typeMapper.FormatTypeName(schema.ReferenceName())
+	if instance, ok := schema.(core.InstanceSchema); ok {
+		targetType = g.instanceTypeName(instance)
+	}
+
+	aliasLines := g.formatter.FormatTypeAlias(typeName, targetType)
+	for _, line := range aliasLines {
+		g.output.WriteString(line + "\n")
+	}
+
+	g.output.WriteString("\n")
+	return nil
+}
+
+// VisitParameter rejects type parameters outside of a generic schema, since
+// Go can only declare them as part of a generic type.
+func (g *Generator) VisitParameter(schema core.TypeParameterSchema) error {
+	return fmt.Errorf("type parameter %s can only be generated as part of a generic schema", schema.ParameterName())
+}
+
+// VisitGeneric generates Go code for a generic template. With UseGenerics the
+// template becomes a generic type such as Page[T any]; otherwise the type
+// parameters are replaced by their constraints, or any.
+func (g *Generator) VisitGeneric(schema core.GenericSchema) error {
+	metadata := schema.Metadata()
+	typeName := g.typeMapper.FormatTypeName(metadata.Name)
+
+	declaredName := typeName
+	if g.options.UseGenerics {
+		params := schema.TypeParameters()
+		declarations := make([]string, len(params))
+		for i, param := range params {
+			declarations[i] = param.ParameterName() + " " + g.parameterConstraintName(param)
+		}
+		declaredName = fmt.Sprintf("%s[%s]", typeName, strings.Join(declarations, ", "))
+	}
+
+	// Add comment
+	if g.options.IncludeComments && metadata.Description != "" {
+		commentLines := g.formatter.FormatComment(metadata.Description)
+		for _, line := range commentLines {
+			g.output.WriteString(line + "\n")
+		}
+	}
+
+	// Constructors and validators are not generated for generic types
+	if objectSchema, ok := schema.Template().(core.ObjectSchema); ok {
+		for _, line := range g.formatter.FormatStruct(declaredName, g.objectFields(objectSchema)) {
+			g.output.WriteString(line + "\n")
+		}
+	} else {
+		templateType := g.typeMapper.MapSchemaType(core.TypeAny)
+		if template := schema.Template(); template != nil {
+			templateType = g.getSchemaTypeName(template)
+		}
+		g.output.WriteString(fmt.Sprintf("type %s %s\n", declaredName, templateType))
+	}
+
+	g.output.WriteString("\n")
+	return nil
+}
+
+// parameterConstraintName returns the Go constraint of a type parameter.
+func (g *Generator) parameterConstraintName(param core.TypeParameterSchema) string {
+	if constraint := param.Constraint(); constraint != nil {
+		return g.getSchemaTypeName(constraint)
+	}
+	return g.typeMapper.MapSchemaType(core.TypeAny)
+}
+
+// instanceTypeName returns the Go type of a generic instance: Page[User] with
+// UseGenerics, or the concrete type name PageUser otherwise.
+func (g *Generator) instanceTypeName(instance core.InstanceSchema) string {
+	if !g.options.UseGenerics {
+		return g.typeMapper.FormatTypeName(instance.ReferenceName())
+	}
+
+	args := instance.TypeArguments()
+	argTypes := make([]string, len(args))
+	for i, arg := range args {
+		argTypes[i] = g.getSchemaTypeName(arg)
+	}
+	return fmt.Sprintf("%s[%s]", g.typeMapper.FormatTypeName(instance.GenericName()), strings.Join(argTypes, ", "))
+}
+
+// VisitResult generates Go code for a result schema as a struct in which
+// exactly one of the Ok and Err pointers is set.
+func (g *Generator) VisitResult(schema core.ResultSchema) error {
+	metadata := schema.Metadata()
+	return g.generateResultStruct(g.typeMapper.FormatTypeName(metadata.Name), schema, metadata.Description)
+}
+
+// generateResultStruct generates the struct of a result schema.
+func (g *Generator) generateResultStruct(typeName string, schema core.ResultSchema, description string) error {
+	fields := []Field{
+		g.resultField("ok", schema.SuccessSchema()),
+		g.resultField("err", schema.ErrorSchema()),
+	}
+
+	return g.generateStruct(typeName, fields, description)
+}
+
+// inlineResultTypeName returns the name of an unnamed result schema, e.g.
+// UserNotFoundResult for a result of User or NotFound, and records the result
+// to be declared after the generated code.
+func (g *Generator) inlineResultTypeName(schema core.ResultSchema) string {
+	okType, errType := g.resultField("ok", schema.SuccessSchema()).Type, g.resultField("err", schema.ErrorSchema()).Type
+	name := g.typeMapper.FormatTypeName(resultTypeIdentifier(okType) + resultTypeIdentifier(errType) + "Result")
+	for _, result := range g.results {
+		if result.name == name {
+			return name
+		}
+	}
+	g.results = append(g.results, inlineResult{name: name, schema: schema})
+	return name
+}
+
+// resultTypeIdentifier converts the Go type of a result payload to words of an
+// identifier, spelling out the slices and maps its punctuation denotes.
+func resultTypeIdentifier(goType string) string {
+	goType = strings.TrimPrefix(goType, "*")
+	goType = strings.ReplaceAll(goType, "[]", "Slice ")
+	return base.ToTypeIdentifier(goType)
+}
+
+// resultField returns the pointer field holding one side of a result.
+func (g *Generator) resultField(name string, payload core.Schema) Field {
+	fieldType := "any"
+	if payload != nil {
+		fieldType = g.getSchemaTypeName(payload)
+	}
+	if !isNillableType(fieldType) {
+		fieldType = g.typeMapper.FormatPointerType(fieldType)
+	}
+
+	return Field{
+		Name:         g.typeMapper.FormatFieldName(name),
+		Type:         fieldType,
+		OriginalName: name,
+		Required:     false,
+		JSONTag:      g.typeMapper.FormatJSONTag(name),
+	}
+}
+
 // VisitNumber generates Go code for a number schema.
 func (g *Generator) VisitNumber(schema core.NumberSchema) error {
 	metadata := schema.Metadata()
@@ -315,6 +564,12 @@ func (g *Generator) generateObjectStruct(schema core.ObjectSchema) error {
 	metadata := schema.Metadata()
 	typeName := g.typeMapper.FormatTypeName(metadata.Name)
 
+	// Generate struct
+	return g.generateStruct(typeName, g.objectFields(schema), metadata.Description)
+}
+
+// objectFields converts the properties of an object schema to struct fields.
+func (g *Generator) objectFields(schema core.ObjectSchema) []Field {
 	// Convert properties to fields
 	var fields []Field
 	properties := schema.Properties()
@@ -332,7 +587,12 @@ func (g *Generator) generateObjectStruct(schema core.ObjectSchema) error {
 		}
 
 		// Handle optional fields with pointers
-		if !field.Required && g.options.UsePointers {
+		if !field.Required && g.options.UsePointers && !isNillableType(field.Type) {
+			field.Type = g.typeMapper.FormatPointerType(field.Type)
+		}
+
+		// Referenced types may be recursive, so they are always held by pointer
+		if propSchema.Type() == core.TypeRef && !isNillableType(field.Type) {
 			field.Type = g.typeMapper.FormatPointerType(field.Type)
 		}
 
@@ -344,8 +604,7 @@ func (g *Generator) generateObjectStruct(schema core.ObjectSchema) error {
 		fields = append(fields, field)
 	}
 
-	// Generate struct
-	return g.generateStruct(typeName, fields, metadata.Description)
+	return fields
 }
 
 // generateStruct generates a struct with the given fields.
@@ -639,15 +898,83 @@ func (g *Generator) generateStringEnum(schema core.StringSchema) error {
 
 // getSchemaTypeName returns the Go type name for a schema.
 func (g *Generator) getSchemaTypeName(schema core.Schema) string {
+	// Type parameters are only meaningful inside generic types
+	if param, ok := schema.(core.TypeParameterSchema); ok {
+		if g.options.UseGenerics {
+			return param.ParameterName()
+		}
+		return g.parameterConstraintName(param)
+	}
+	if instance, ok := schema.(core.InstanceSchema); ok {
+		return g.instanceTypeName(instance)
+	}
+
 	metadata := schema.Metadata()
 	if metadata.Name != "" {
 		return g.typeMapper.FormatTypeName(metadata.Name)
 	}
 
+	switch schema.Type() {
+	case core.TypeArray:
+		if arraySchema, ok := schema.(core.ArraySchema); ok && arraySchema.ItemSchema() != nil {
+			return g.typeMapper.FormatSliceType(g.getSchemaTypeName(arraySchema.ItemSchema()))
+		}
+	case core.TypeMap:
+		if mapSchema, ok := schema.(core.MapSchema); ok {
+			return g.mapTypeName(mapSchema)
+		}
+	case core.TypeOptional:
+		if optionalSchema, ok := schema.(core.OptionalSchema); ok {
+			return g.optionalTypeName(optionalSchema)
+		}
+	case core.TypeRef:
+		if refSchema, ok := schema.(core.RefSchema); ok && refSchema.ReferenceName() != "" {
+			return g.typeMapper.FormatTypeName(refSchema.ReferenceName())
+		}
+	case core.TypeResult:
+		if resultSchema, ok := schema.(core.ResultSchema); ok {
+			return g.inlineResultTypeName(resultSchema)
+		}
+	}
+
 	// Fallback to basic type mapping
 	return g.typeMapper.MapSchemaType(schema.Type())
 }
 
+// optionalTypeName returns the Go type for an optional schema.
+// Types that are already nillable (slices, maps, any) are not wrapped in a pointer.
+func (g *Generator) optionalTypeName(schema core.OptionalSchema) string {
+	itemType := "any"
+	if itemSchema := schema.ItemSchema(); itemSchema != nil {
+		itemType = g.getSchemaTypeName(itemSchema)
+	}
+	if isNillableType(itemType) {
+		return itemType
+	}
+	return g.typeMapper.FormatPointerType(itemType)
+}
+
+// isNillableType reports whether a Go type expression can already hold nil.
+func isNillableType(goType string) bool {
+	return goType == "any" || strings.HasPrefix(goType, "*") ||
+		strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[")
+}
+
+// mapTypeName returns the Go map type for a map schema's key and value schemas.
+func (g *Generator) mapTypeName(schema core.MapSchema) string {
+	keyType := "string"
+	if keySchema := schema.KeySchema(); keySchema != nil {
+		keyType = g.getSchemaTypeName(keySchema)
+	}
+
+	valueType := "any"
+	if valueSchema := schema.ValueSchema(); valueSchema != nil {
+		valueType = g.getSchemaTypeName(valueSchema)
+	}
+
+	return g.typeMapper.FormatMapType(keyType, valueType)
+}
+
 // getSchemaDescription returns the description of a schema.
 func (g *Generator) getSchemaDescription(schema core.Schema) string {
 	return schema.Metadata().Description
@@ -830,6 +1157,16 @@ func (g *Generator) VisitService(schema core.ServiceSchema) error {
 	return fmt.Errorf("service schemas are not supported by the Go generator")
 }
 
+// VisitTopic handles topic schemas (not supported in Go generator).
+func (g *Generator) VisitTopic(schema core.TopicSchema) error {
+	return fmt.Errorf("topic schemas are not supported by the Go generator")
+}
+
+// VisitComponent handles component schemas (not supported in Go generator).
+func (g *Generator) VisitComponent(schema core.ComponentSchema) error {
+	return fmt.Errorf("component schemas are not supported by the Go generator")
+}
+
 // VisitUnion handles union schemas.
 func (g *Generator) VisitUnion(schema core.UnionSchema) error {
 	if !g.options.GenerateUnions {
@@ -42,6 +42,15 @@ func (tm *TypeMapper) MapSchemaType(schemaType core.SchemaType) string {
 		return "[]any" // Will be specialized based on item type
 	case core.TypeStructure:
 		return "any" // Will be specialized to struct
+	case core.TypeMap:
+		return "map[string]any" // Will be specialized based on key and value types
+	case core.TypeNull:
+		return "*struct{}" // Only ever nil
+	case core.TypeAny:
+		if tm.isGoVersion118Plus() {
+			return "any"
+		}
+		return "interface{}"
 	default:
 		return "any"
 	}
@@ -3,8 +3,10 @@ package json
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"defs.dev/schema/core"
+	"defs.dev/schema/core/structure"
 	"defs.dev/schema/visit/export"
 	"defs.dev/schema/visit/export/base"
 )
@@ -14,6 +16,30 @@ type Generator struct {
 	*base.BaseVisitor
 	options JSONSchemaOptions
 	result  map[string]any
+
+	// definitions collects the targets of reference schemas. It is shared with
+	// nested generators so references at any depth end up in the root document.
+	definitions *definitionSet
+}
+
+// definitionSet tracks referenced schemas that still need to be emitted under
+// the definitions key, and the ones that already have been. Pending entries are
+// either reference schemas, resolved on emission, or named schemas emitted as is.
+// Every definition is keyed by the fingerprint of its schema, so a type
+// referenced many times is emitted once and distinct types sharing a name are
+// not merged.
+type definitionSet struct {
+	pending      map[string]core.Schema
+	emitted      map[string]any
+	fingerprints map[string]string
+}
+
+func newDefinitionSet() *definitionSet {
+	return &definitionSet{
+		pending:      make(map[string]core.Schema),
+		emitted:      make(map[string]any),
+		fingerprints: make(map[string]string),
+	}
 }
 
 // NewGenerator creates a new JSON Schema generator with the given options.
@@ -47,6 +73,13 @@ func (g *Generator) Generate(s core.Schema) ([]byte, error) {
 	// Reset result
 	g.result = make(map[string]any)
 
+	// The outermost generator owns the definitions collected during generation
+	ownsDefinitions := g.definitions == nil
+	if ownsDefinitions {
+		g.definitions = newDefinitionSet()
+		defer func() { g.definitions = nil }()
+	}
+
 	// Accept the visitor pattern
 	if accepter, ok := s.(core.Accepter); ok {
 		if err := accepter.Accept(g); err != nil {
@@ -56,6 +89,13 @@ func (g *Generator) Generate(s core.Schema) ([]byte, error) {
 		return nil, base.NewGenerationError("json", string(s.Type()), "schema does not implement Accepter interface")
 	}
 
+	// Emit referenced schemas once, at the root
+	if ownsDefinitions {
+		if err := g.addDefinitions(); err != nil {
+			return nil, base.NewGenerationError("json", string(s.Type()), err.Error())
+		}
+	}
+
 	// Add schema metadata
 	g.addSchemaMetadata()
 
@@ -97,6 +137,44 @@ func (g *Generator) addSchemaMetadata() {
 	}
 }
 
+// addDefinitions generates every schema collected by reference and adds them
+// under the configured definitions key. Generating a definition may reference
+// further schemas, so this runs until nothing is pending.
+func (g *Generator) addDefinitions() error {
+	for len(g.definitions.pending) > 0 {
+		names := make([]string, 0, len(g.definitions.pending))
+		for name := range g.definitions.pending {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		name := names[0]
+		target := g.definitions.pending[name]
+		delete(g.definitions.pending, name)
+
+		if ref, ok := target.(core.RefSchema); ok {
+			resolved, err := ref.Resolve()
+			if err != nil {
+				return fmt.Errorf("failed to resolve reference %s: %w", name, err)
+			}
+			target = resolved
+		}
+
+		// Mark as emitted before generating so self references are not queued again
+		g.definitions.emitted[name] = nil
+		targetSchema, err := g.generateNested(target)
+		if err != nil {
+			return fmt.Errorf("failed to generate definition %s: %w", name, err)
+		}
+		g.definitions.emitted[name] = targetSchema
+	}
+
+	if len(g.definitions.emitted) > 0 {
+		g.result[g.options.DefinitionsKey] = g.definitions.emitted
+	}
+	return nil
+}
+
 // marshalResult converts the result to JSON string with appropriate formatting.
 func (g *Generator) marshalResult() (string, error) {
 	var data []byte
@@ -197,17 +275,40 @@ func (g *Generator) VisitBoolean(s core.BooleanSchema) error {
 	return nil
 }
 
+// VisitNull generates JSON Schema for null types.
+func (g *Generator) VisitNull(s core.NullSchema) error {
+	jsonSchema := map[string]any{
+		"type": "null",
+	}
+
+	g.addCommonMetadata(jsonSchema, s)
+	g.result = jsonSchema
+	return nil
+}
+
+// VisitAny generates JSON Schema for any types. The empty schema accepts every value.
+func (g *Generator) VisitAny(s core.AnySchema) error {
+	jsonSchema := map[string]any{}
+
+	g.addCommonMetadata(jsonSchema, s)
+	g.result = jsonSchema
+	return nil
+}
+
 // VisitArray generates JSON Schema for array types.
 func (g *Generator) VisitArray(s core.ArraySchema) error {
 	jsonSchema := map[string]any{
 		"type": "array",
 	}
 
-	// Generate schema for items if present
-	if itemSchema := s.ItemSchema(); itemSchema != nil {
-		itemsGenerator := NewGenerator(
-			WithSchemaURI(""), // Don't add $schema to nested schemas
-		)
+	// Tuples describe each position separately
+	if prefixItems := s.PrefixItemSchemas(); len(prefixItems) > 0 {
+		if err := g.addTupleItems(jsonSchema, s, prefixItems); err != nil {
+			return err
+		}
+	} else if itemSchema := s.ItemSchema(); itemSchema != nil {
+		// Generate schema for items if present
+		itemsGenerator := g.newNestedGenerator()
 		itemsJSON, err := itemsGenerator.Generate(itemSchema)
 		if err != nil {
 			return fmt.Errorf("failed to generate items schema: %w", err)
@@ -236,8 +337,149 @@ func (g *Generator) VisitArray(s core.ArraySchema) error {
 	return nil
 }
 
+// addTupleItems adds the positional item keywords for a tuple array. Draft
+// 2020-12 uses prefixItems with items for the rest; older drafts use the
+// array form of items with additionalItems. Every position is required.
+func (g *Generator) addTupleItems(jsonSchema map[string]any, s core.ArraySchema, prefixItems []core.Schema) error {
+	positions := make([]any, len(prefixItems))
+	for i, itemSchema := range prefixItems {
+		itemJSON, err := g.generateNested(itemSchema)
+		if err != nil {
+			return fmt.Errorf("failed to generate tuple item %d schema: %w", i, err)
+		}
+		positions[i] = itemJSON
+	}
+
+	var rest any = false
+	if restSchema := s.RestItemSchema(); restSchema != nil {
+		restJSON, err := g.generateNested(restSchema)
+		if err != nil {
+			return fmt.Errorf("failed to generate tuple rest schema: %w", err)
+		}
+		rest = restJSON
+	}
+
+	if g.options.Draft == "draft-2020-12" {
+		jsonSchema["prefixItems"] = positions
+		jsonSchema["items"] = rest
+	} else {
+		jsonSchema["items"] = positions
+		jsonSchema["additionalItems"] = rest
+	}
+
+	if s.MinItems() == nil {
+		jsonSchema["minItems"] = len(prefixItems)
+	}
+	return nil
+}
+
+// VisitMap generates JSON Schema for map types.
+func (g *Generator) VisitMap(s core.MapSchema) error {
+	jsonSchema := map[string]any{
+		"type": "object",
+	}
+
+	// Values are described by additionalProperties
+	if valueSchema := s.ValueSchema(); valueSchema != nil {
+		valueJSON, err := g.generateNested(valueSchema)
+		if err != nil {
+			return fmt.Errorf("failed to generate map value schema: %w", err)
+		}
+		jsonSchema["additionalProperties"] = valueJSON
+	}
+
+	// Keys are described by propertyNames; JSON object keys are always strings,
+	// so integer keys are expressed as a pattern
+	if keySchema := s.KeySchema(); keySchema != nil {
+		if keySchema.Type() == core.TypeInteger {
+			jsonSchema["propertyNames"] = map[string]any{
+				"pattern": "^-?[0-9]+$",
+			}
+		} else {
+			keyJSON, err := g.generateNested(keySchema)
+			if err != nil {
+				return fmt.Errorf("failed to generate map key schema: %w", err)
+			}
+			jsonSchema["propertyNames"] = keyJSON
+		}
+	}
+
+	if minItems := s.MinItems(); minItems != nil {
+		jsonSchema["minProperties"] = *minItems
+	}
+	if maxItems := s.MaxItems(); maxItems != nil {
+		jsonSchema["maxProperties"] = *maxItems
+	}
+
+	g.addCommonMetadata(jsonSchema, s)
+	g.result = jsonSchema
+	return nil
+}
+
+// VisitOptional generates JSON Schema for optional (nullable) types.
+func (g *Generator) VisitOptional(s core.OptionalSchema) error {
+	var itemJSON any = map[string]any{}
+	if itemSchema := s.ItemSchema(); itemSchema != nil {
+		var err error
+		itemJSON, err = g.generateNested(itemSchema)
+		if err != nil {
+			return fmt.Errorf("failed to generate optional item schema: %w", err)
+		}
+	}
+
+	// Simple typed schemas without enumerations can widen their type to include null;
+	// anything else is expressed as an anyOf with the null type
+	var jsonSchema map[string]any
+	if item, ok := itemJSON.(map[string]any); ok {
+		_, hasEnum := item["enum"]
+		if itemType, ok := item["type"].(string); ok && !hasEnum {
+			item["type"] = []any{itemType, "null"}
+			jsonSchema = item
+		}
+	}
+	if jsonSchema == nil {
+		jsonSchema = map[string]any{
+			"anyOf": []any{itemJSON, map[string]any{"type": "null"}},
+		}
+	}
+
+	g.addCommonMetadata(jsonSchema, s)
+	g.result = jsonSchema
+	return nil
+}
+
+// newNestedGenerator creates a generator for a nested schema. It does not add a
+// $schema header and shares the definitions of this generator.
+func (g *Generator) newNestedGenerator() *Generator {
+	nested := NewGenerator(
+		WithDraft(g.options.Draft),
+		WithSchemaURI(""), // Don't add $schema to nested schemas
+	)
+	nested.definitions = g.definitions
+	return nested
+}
+
+// generateNested generates the JSON Schema for a nested schema without a $schema header.
+func (g *Generator) generateNested(s core.Schema) (any, error) {
+	nestedGenerator := g.newNestedGenerator()
+	nestedJSON, err := nestedGenerator.Generate(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var nestedSchema any
+	if err := json.Unmarshal(nestedJSON, &nestedSchema); err != nil {
+		return nil, err
+	}
+	return nestedSchema, nil
+}
+
 // VisitObject generates JSON Schema for object types.
 func (g *Generator) VisitObject(s core.ObjectSchema) error {
+	if sources := composedSources(s); sources != nil {
+		return g.visitComposedObject(s, sources)
+	}
+
 	jsonSchema := map[string]any{
 		"type": "object",
 	}
@@ -247,9 +489,7 @@ func (g *Generator) VisitObject(s core.ObjectSchema) error {
 	if len(properties) > 0 {
 		propsJSON := make(map[string]any)
 		for name, prop := range properties {
-			propGenerator := NewGenerator(
-				WithSchemaURI(""), // Don't add $schema to nested schemas
-			)
+			propGenerator := g.newNestedGenerator()
 			propJSON, err := propGenerator.Generate(prop)
 			if err != nil {
 				return fmt.Errorf("failed to generate property %s: %w", name, err)
@@ -264,6 +504,24 @@ func (g *Generator) VisitObject(s core.ObjectSchema) error {
 		jsonSchema["properties"] = propsJSON
 	}
 
+	// Generate pattern properties; "*" matches every property name
+	if p, ok := s.(interface {
+		PatternProperties() map[string]core.Schema
+	}); ok && len(p.PatternProperties()) > 0 {
+		patternsJSON := make(map[string]any)
+		for pattern, prop := range p.PatternProperties() {
+			propJSON, err := g.generateNested(prop)
+			if err != nil {
+				return fmt.Errorf("failed to generate pattern property %s: %w", pattern, err)
+			}
+			if pattern == "*" {
+				pattern = ".*"
+			}
+			patternsJSON[pattern] = propJSON
+		}
+		jsonSchema["patternProperties"] = patternsJSON
+	}
+
 	// Add required fields
 	if required := s.Required(); len(required) > 0 {
 		jsonSchema["required"] = required
@@ -279,6 +537,103 @@ func (g *Generator) VisitObject(s core.ObjectSchema) error {
 	return nil
 }
 
+// composedSources returns the schemas an object was composed from if it can be
+// expressed as an allOf of them. This is not the case when a source forbids
+// additional properties, since each allOf branch is checked on its own, or when
+// an extension redefines a property of its base; such objects are flattened.
+func composedSources(s core.ObjectSchema) []core.Schema {
+	composition := s.Metadata().Composition
+	if composition == nil {
+		return nil
+	}
+	switch composition.Kind {
+	case core.CompositionExtend, core.CompositionMerge, core.CompositionAllOf:
+	default:
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for _, source := range composition.Sources {
+		object, ok := source.(core.ObjectSchema)
+		if !ok || !object.AdditionalProperties() {
+			return nil
+		}
+		for name := range object.Properties() {
+			if seen[name] && composition.Kind == core.CompositionExtend {
+				return nil
+			}
+			seen[name] = true
+		}
+	}
+	return composition.Sources
+}
+
+// visitComposedObject generates an allOf over the sources of a composed object.
+// Named sources are emitted once under the definitions key and referenced.
+func (g *Generator) visitComposedObject(s core.ObjectSchema, sources []core.Schema) error {
+	branches := make([]any, 0, len(sources))
+	for i, source := range sources {
+		_, isRef := source.(core.RefSchema)
+		if name := source.Metadata().Name; name != "" && !isRef {
+			branches = append(branches, g.reference(name, source))
+			continue
+		}
+
+		sourceJSON, err := g.generateNested(source)
+		if err != nil {
+			return fmt.Errorf("failed to generate composition source %d: %w", i, err)
+		}
+		branches = append(branches, sourceJSON)
+	}
+
+	jsonSchema := map[string]any{
+		"allOf": branches,
+	}
+
+	g.addCommonMetadata(jsonSchema, s)
+	g.result = jsonSchema
+	return nil
+}
+
+// reference queues s for emission under the definitions key and returns a
+// $ref to it. A different schema already defined under the same name makes
+// this one take a numbered name, such as User2.
+func (g *Generator) reference(name string, s core.Schema) map[string]any {
+	if g.definitions == nil {
+		g.definitions = newDefinitionSet()
+	}
+
+	fingerprint := definitionFingerprint(s)
+	key := name
+	for i := 2; ; i++ {
+		existing, defined := g.definitions.fingerprints[key]
+		if !defined {
+			g.definitions.fingerprints[key] = fingerprint
+			g.definitions.pending[key] = s
+			break
+		}
+		if existing == fingerprint {
+			break
+		}
+		key = fmt.Sprintf("%s%d", name, i)
+	}
+
+	return map[string]any{
+		"$ref": "#/" + g.options.DefinitionsKey + "/" + key,
+	}
+}
+
+// definitionFingerprint identifies the schema a definition stands for,
+// looking through references so a reference and its target match.
+func definitionFingerprint(s core.Schema) string {
+	if ref, ok := s.(core.RefSchema); ok {
+		if target, err := ref.Resolve(); err == nil {
+			s = target
+		}
+	}
+	return structure.Fingerprint(s)
+}
+
 // addCommonMetadata adds common metadata from schema to JSON Schema.
 func (g *Generator) addCommonMetadata(jsonSchema map[string]any, s core.Schema) {
 	metadata := s.Metadata()
@@ -310,9 +665,7 @@ func (g *Generator) VisitFunction(s core.FunctionSchema) error {
 
 		// Convert each input argument to a property
 		for _, arg := range inputs.Args() {
-			argGenerator := NewGenerator(
-				WithSchemaURI(""), // Don't add $schema to nested schemas
-			)
+			argGenerator := g.newNestedGenerator()
 			argJSON, err := argGenerator.Generate(arg.Schema())
 			if err != nil {
 				return fmt.Errorf("failed to generate input %s: %w", arg.Name(), err)
@@ -355,9 +708,7 @@ func (g *Generator) VisitFunction(s core.FunctionSchema) error {
 
 	// Add error schema if present
 	if errorSchema := s.Errors(); errorSchema != nil {
-		errorGenerator := NewGenerator(
-			WithSchemaURI(""), // Don't add $schema to nested schemas
-		)
+		errorGenerator := g.newNestedGenerator()
 		errorJSON, err := errorGenerator.Generate(errorSchema)
 		if err != nil {
 			return fmt.Errorf("failed to generate error schema: %w", err)
@@ -399,9 +750,7 @@ func (g *Generator) VisitService(s core.ServiceSchema) error {
 	methodNames := make([]string, 0)
 
 	for _, method := range s.Methods() {
-		methodGenerator := NewGenerator(
-			WithSchemaURI(""), // Don't add $schema to nested schemas
-		)
+		methodGenerator := g.newNestedGenerator()
 
 		// Generate schema for the method's function
 		methodJSON, err := methodGenerator.Generate(method.Function())
@@ -435,15 +784,228 @@ func (g *Generator) VisitService(s core.ServiceSchema) error {
 	return nil
 }
 
-// VisitUnion generates JSON Schema for union types (placeholder).
+// VisitTopic generates JSON Schema for the messages of a topic: an object
+// with the payload and, when the topic declares them, the key and headers.
+func (g *Generator) VisitTopic(s core.TopicSchema) error {
+	properties := make(map[string]any)
+	parts := []struct {
+		name   string
+		schema core.Schema
+	}{
+		{"key", s.Key()},
+		{"payload", s.Payload()},
+		{"headers", s.Headers()},
+	}
+	for _, part := range parts {
+		if part.schema == nil {
+			continue
+		}
+		partSchema, err := g.generateNested(part.schema)
+		if err != nil {
+			return fmt.Errorf("failed to generate topic %s schema: %w", part.name, err)
+		}
+		properties[part.name] = partSchema
+	}
+	if _, ok := properties["payload"]; !ok {
+		properties["payload"] = map[string]any{}
+	}
+
+	jsonSchema := map[string]any{
+		"type":       "object",
+		"x-topic":    true, // Mark as topic schema
+		"properties": properties,
+		"required":   []string{"payload"},
+	}
+	if s.Name() != "" {
+		jsonSchema["title"] = s.Name()
+	}
+
+	g.addCommonMetadata(jsonSchema, s)
+	g.result = jsonSchema
+	return nil
+}
+
+// VisitComponent generates JSON Schema for the configuration of a component.
+// The services and topics the component declares are listed by name under
+// "x-component".
+func (g *Generator) VisitComponent(s core.ComponentSchema) error {
+	jsonSchema := map[string]any{"type": "object"}
+	if s.Config() != nil {
+		configSchema, err := g.generateNested(s.Config())
+		if err != nil {
+			return fmt.Errorf("failed to generate component config schema: %w", err)
+		}
+		if configMap, ok := configSchema.(map[string]any); ok {
+			jsonSchema = configMap
+		}
+	}
+
+	serviceNames := func(services []core.ServiceSchema) []string {
+		names := make([]string, len(services))
+		for i, service := range services {
+			names[i] = service.Name()
+		}
+		return names
+	}
+	topicNames := func(topics []core.TopicSchema) []string {
+		names := make([]string, len(topics))
+		for i, topic := range topics {
+			names[i] = topic.Name()
+		}
+		return names
+	}
+	jsonSchema["x-component"] = map[string]any{
+		"provides":   serviceNames(s.Provides()),
+		"requires":   serviceNames(s.Requires()),
+		"publishes":  topicNames(s.Publishes()),
+		"subscribes": topicNames(s.Subscribes()),
+	}
+	if s.Name() != "" {
+		jsonSchema["title"] = s.Name()
+	}
+
+	g.addCommonMetadata(jsonSchema, s)
+	g.result = jsonSchema
+	return nil
+}
+
+// VisitUnion generates JSON Schema for union types.
 func (g *Generator) VisitUnion(s core.UnionSchema) error {
-	// Union schemas are not yet fully implemented in the core system
-	// This is a placeholder for future implementation
+	branches := make([]any, 0, len(s.Schemas()))
+	for i, member := range s.Schemas() {
+		memberGenerator := g.newNestedGenerator()
+		memberJSON, err := memberGenerator.Generate(member)
+		if err != nil {
+			return fmt.Errorf("failed to generate union branch %d: %w", i, err)
+		}
+
+		var memberSchema any
+		if err := json.Unmarshal([]byte(memberJSON), &memberSchema); err != nil {
+			return fmt.Errorf("failed to parse union branch %d schema: %w", i, err)
+		}
+		branches = append(branches, memberSchema)
+	}
+
+	keyword := "oneOf"
+	if s.Mode() == core.UnionAnyOf {
+		keyword = "anyOf"
+	}
+
 	jsonSchema := map[string]any{
-		"anyOf": []any{}, // Empty anyOf for now
+		keyword: branches,
+	}
+
+	if discriminator := s.Discriminator(); discriminator != "" {
+		jsonSchema["discriminator"] = map[string]any{
+			"propertyName": discriminator,
+		}
 	}
 
 	g.addCommonMetadata(jsonSchema, s)
 	g.result = jsonSchema
 	return nil
 }
+
+// VisitRef generates a $ref to the referenced schema. The target itself is
+// emitted once under the definitions key of the root document.
+func (g *Generator) VisitRef(s core.RefSchema) error {
+	name := s.ReferenceName()
+	if name == "" {
+		return fmt.Errorf("reference schema has no target name")
+	}
+
+	jsonSchema := g.reference(name, s)
+
+	g.addCommonMetadata(jsonSchema, s)
+	g.result = jsonSchema
+	return nil
+}
+
+// VisitParameter generates JSON Schema for an unbound type parameter. JSON
+// Schema has no generics, so the parameter accepts anything its constraint
+// accepts and is marked with an x-type-parameter extension.
+func (g *Generator) VisitParameter(s core.TypeParameterSchema) error {
+	jsonSchema := map[string]any{}
+	if constraint := s.Constraint(); constraint != nil {
+		constraintJSON, err := g.generateNested(constraint)
+		if err != nil {
+			return fmt.Errorf("failed to generate type parameter constraint: %w", err)
+		}
+		if constraintMap, ok := constraintJSON.(map[string]any); ok {
+			jsonSchema = constraintMap
+		}
+	}
+	jsonSchema["x-type-parameter"] = s.ParameterName()
+
+	if g.options.IncludeDescription && s.Metadata().Description != "" {
+		jsonSchema["description"] = s.Metadata().Description
+	}
+	g.result = jsonSchema
+	return nil
+}
+
+// VisitGeneric generates JSON Schema for a generic template. The template is
+// emitted with its parameters unbound and listed in x-type-parameters.
+func (g *Generator) VisitGeneric(s core.GenericSchema) error {
+	jsonSchema := map[string]any{}
+	if template := s.Template(); template != nil {
+		templateJSON, err := g.generateNested(template)
+		if err != nil {
+			return fmt.Errorf("failed to generate generic template: %w", err)
+		}
+		if templateMap, ok := templateJSON.(map[string]any); ok {
+			jsonSchema = templateMap
+		}
+	}
+
+	params := s.TypeParameters()
+	names := make([]any, len(params))
+	for i, param := range params {
+		names[i] = param.ParameterName()
+	}
+	jsonSchema["x-type-parameters"] = names
+
+	g.addCommonMetadata(jsonSchema, s)
+	g.result = jsonSchema
+	return nil
+}
+
+// VisitResult generates JSON Schema for result types as a oneOf of a closed
+// {"ok": ...} object and a closed {"err": ...} object.
+func (g *Generator) VisitResult(s core.ResultSchema) error {
+	okBranch, err := g.generateResultBranch("ok", s.SuccessSchema())
+	if err != nil {
+		return fmt.Errorf("failed to generate result success schema: %w", err)
+	}
+	errBranch, err := g.generateResultBranch("err", s.ErrorSchema())
+	if err != nil {
+		return fmt.Errorf("failed to generate result error schema: %w", err)
+	}
+
+	jsonSchema := map[string]any{
+		"oneOf": []any{okBranch, errBranch},
+	}
+
+	g.addCommonMetadata(jsonSchema, s)
+	g.result = jsonSchema
+	return nil
+}
+
+// generateResultBranch generates the closed object schema for one side of a result.
+func (g *Generator) generateResultBranch(key string, payload core.Schema) (map[string]any, error) {
+	var payloadSchema any = map[string]any{}
+	if payload != nil {
+		var err error
+		payloadSchema, err = g.generateNested(payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           map[string]any{key: payloadSchema},
+		"required":             []string{key},
+		"additionalProperties": false,
+	}, nil
+}
@@ -18,6 +18,14 @@ type Generator struct {
 	enumFormatter *EnumFormatter
 	importManager *ImportManager
 	output        strings.Builder
+	results       []inlineResult // unnamed results used as types, in order of first use
+}
+
+// inlineResult is an unnamed result schema used as a type, defined under a
+// name derived from its payload types.
+type inlineResult struct {
+	name   string
+	schema core.ResultSchema
 }
 
 // NewGenerator creates a new Python generator with the given options.
@@ -38,6 +46,7 @@ func NewGenerator(options PythonOptions) *Generator {
 func (g *Generator) Generate(schema core.Schema) ([]byte, error) {
 	// Reset output
 	g.output.Reset()
+	g.results = nil
 
 	// Validate options
 	if err := g.options.Validate(); err != nil {
@@ -48,6 +57,7 @@ func (g *Generator) Generate(schema core.Schema) ([]byte, error) {
 	if g.options.FileHeader != "" {
 		g.writeFileHeader()
 	}
+	header := g.output.Len()
 
 	// Generate the schema using visitor pattern
 	if accepter, ok := schema.(core.Accepter); ok {
@@ -58,8 +68,11 @@ func (g *Generator) Generate(schema core.Schema) ([]byte, error) {
 		return nil, fmt.Errorf("schema does not support visitor pattern")
 	}
 
-	// Add imports at the beginning
+	// Define inline results ahead of the code using them
 	result := g.output.String()
+	result = result[:header] + g.inlineResultDefinitions() + result[header:]
+
+	// Add imports at the beginning
 	if g.options.IncludeImports {
 		result = g.generateImports() + "\n\n" + result
 	}
@@ -271,18 +284,46 @@ func (g *Generator) VisitBoolean(schema core.BooleanSchema) error {
 	return nil
 }
 
+// VisitNull generates Python code for a null schema as a None alias.
+func (g *Generator) VisitNull(schema core.NullSchema) error {
+	metadata := schema.Metadata()
+	typeName := g.typeMapper.FormatClassName(metadata.Name)
+
+	// Add constraints as comments if enabled
+	if g.options.IncludeComments {
+		g.writeSchemaComments(metadata.Description, metadata.Examples, nil)
+	}
+
+	g.output.WriteString(fmt.Sprintf("%s = %s\n", typeName, g.typeMapper.MapSchemaType(core.TypeNull)))
+	return nil
+}
+
+// VisitAny generates Python code for an any schema as an Any alias.
+func (g *Generator) VisitAny(schema core.AnySchema) error {
+	metadata := schema.Metadata()
+	typeName := g.typeMapper.FormatClassName(metadata.Name)
+
+	// Add constraints as comments if enabled
+	if g.options.IncludeComments {
+		g.writeSchemaComments(metadata.Description, metadata.Examples, nil)
+	}
+
+	g.output.WriteString(fmt.Sprintf("%s = %s\n", typeName, g.anyTypeName()))
+	return nil
+}
+
+// anyTypeName returns the Python Any type and records its import.
+func (g *Generator) anyTypeName() string {
+	g.importManager.AddImport("from typing import Any")
+	return g.typeMapper.MapSchemaType(core.TypeAny)
+}
+
 // VisitArray generates Python code for an array schema.
 func (g *Generator) VisitArray(schema core.ArraySchema) error {
 	metadata := schema.Metadata()
 	className := g.typeMapper.FormatClassName(metadata.Name)
 
-	// Determine element type
-	elementType := "Any"
-	if itemSchema := schema.ItemSchema(); itemSchema != nil {
-		elementType = g.getSchemaTypeName(itemSchema)
-	}
-
-	pythonType := g.typeMapper.FormatListType(elementType)
+	pythonType := g.arrayTypeName(schema)
 
 	// Create a field representing this array type
 	field := Field{
@@ -335,11 +376,120 @@ func (g *Generator) VisitObject(schema core.ObjectSchema) error {
 	return g.generateObjectModel(schema)
 }
 
+// VisitMap generates Python code for a map schema.
+func (g *Generator) VisitMap(schema core.MapSchema) error {
+	metadata := schema.Metadata()
+	typeName := g.typeMapper.FormatClassName(metadata.Name)
+
+	// Add constraints as comments if enabled
+	if g.options.IncludeComments {
+		g.writeSchemaComments(metadata.Description, metadata.Examples, nil)
+	}
+
+	g.output.WriteString(fmt.Sprintf("%s = %s\n", typeName, g.mapTypeName(schema)))
+	return nil
+}
+
+// VisitOptional generates Python code for an optional (nullable) schema.
+func (g *Generator) VisitOptional(schema core.OptionalSchema) error {
+	metadata := schema.Metadata()
+	typeName := g.typeMapper.FormatClassName(metadata.Name)
+
+	// Add constraints as comments if enabled
+	if g.options.IncludeComments {
+		g.writeSchemaComments(metadata.Description, metadata.Examples, nil)
+	}
+
+	g.output.WriteString(fmt.Sprintf("%s = %s\n", typeName, g.optionalTypeName(schema)))
+	return nil
+}
+
+// optionalTypeName returns the Python type for an optional schema.
+func (g *Generator) optionalTypeName(schema core.OptionalSchema) string {
+	itemType := "Any"
+	if itemSchema := schema.ItemSchema(); itemSchema != nil {
+		itemType = g.getSchemaTypeName(itemSchema)
+	}
+	return g.typeMapper.FormatOptionalType(itemType)
+}
+
+// arrayTypeName returns the Python type for an array schema: a list of the
+// item type, or a tuple for schemas with positional items.
+func (g *Generator) arrayTypeName(schema core.ArraySchema) string {
+	prefixItems := schema.PrefixItemSchemas()
+	if len(prefixItems) == 0 {
+		elementType := "Any"
+		if itemSchema := schema.ItemSchema(); itemSchema != nil {
+			elementType = g.getSchemaTypeName(itemSchema)
+		}
+		return g.typeMapper.FormatListType(elementType)
+	}
+
+	// Type hints cannot mix fixed positions with a variadic tail, so open
+	// tuples degrade to a variadic tuple of Any
+	var elementTypes []string
+	if schema.RestItemSchema() != nil {
+		elementTypes = []string{g.anyTypeName(), "..."}
+	} else {
+		for _, itemSchema := range prefixItems {
+			elementTypes = append(elementTypes, g.getSchemaTypeName(itemSchema))
+		}
+	}
+
+	tupleType := g.typeMapper.FormatTupleType(elementTypes)
+	if strings.HasPrefix(tupleType, "Tuple[") {
+		g.importManager.AddImport("from typing import Tuple")
+	}
+	return tupleType
+}
+
+// mapTypeName returns the Python dict type for a map schema's key and value schemas.
+func (g *Generator) mapTypeName(schema core.MapSchema) string {
+	keyType := g.typeMapper.MapSchemaType(core.TypeString)
+	if keySchema := schema.KeySchema(); keySchema != nil {
+		keyType = g.getSchemaTypeName(keySchema)
+	}
+
+	var valueType string
+	if valueSchema := schema.ValueSchema(); valueSchema != nil {
+		valueType = g.getSchemaTypeName(valueSchema)
+	} else {
+		valueType = g.anyTypeName()
+	}
+
+	return g.dictTypeName(keyType, valueType)
+}
+
+// dictTypeName returns the Python dict type for key and value types and
+// records the import of Dict where the typing form is used.
+func (g *Generator) dictTypeName(keyType, valueType string) string {
+	dictType := g.typeMapper.FormatDictType(keyType, valueType)
+	if strings.HasPrefix(dictType, "Dict[") {
+		g.importManager.AddImport("from typing import Dict")
+	}
+	return dictType
+}
+
 // generateObjectModel generates a Python model for an object schema.
 func (g *Generator) generateObjectModel(schema core.ObjectSchema) error {
 	metadata := schema.Metadata()
 	className := g.typeMapper.FormatClassName(metadata.Name)
+	fields := g.objectFields(schema)
 
+	// Add class docstring if enabled
+	if g.options.IncludeDocstrings && metadata.Description != "" {
+		docLines := g.formatter.FormatDocstring(metadata.Description, metadata.Examples, nil)
+		for _, line := range docLines {
+			g.output.WriteString(line + "\n")
+		}
+	}
+
+	g.writeModel(className, fields)
+	return nil
+}
+
+// objectFields converts the properties of an object schema to model fields.
+func (g *Generator) objectFields(schema core.ObjectSchema) []Field {
 	// Convert properties to fields
 	var fields []Field
 	properties := schema.Properties()
@@ -355,35 +505,36 @@ func (g *Generator) generateObjectModel(schema core.ObjectSchema) error {
 			DefaultValue: g.getSchemaDefault(propSchema),
 		}
 
-		// Handle optional fields
-		if !field.Required {
+		// Handle optional fields; nullable schemas are already wrapped
+		if !field.Required && propSchema.Type() != core.TypeOptional {
 			field.Type = g.typeMapper.FormatOptionalType(field.Type)
 		}
 
 		fields = append(fields, field)
 	}
 
-	// Add class docstring if enabled
-	if g.options.IncludeDocstrings && metadata.Description != "" {
-		docLines := g.formatter.FormatDocstring(metadata.Description, metadata.Examples, nil)
-		for _, line := range docLines {
-			g.output.WriteString(line + "\n")
-		}
-	}
+	return fields
+}
 
-	// Generate the model based on output style
+// writeModel writes a model class with the given fields in the configured output style.
+func (g *Generator) writeModel(className string, fields []Field) {
+	g.writeModelWithBase(className, fields, g.options.BaseClass)
+}
+
+// writeModelWithBase writes a model class deriving from the given base classes.
+func (g *Generator) writeModelWithBase(className string, fields []Field, baseClass string) {
 	var modelLines []string
 	switch g.options.OutputStyle {
 	case "pydantic":
-		modelLines = g.formatter.FormatPydanticModel(className, fields, g.options.BaseClass)
+		modelLines = g.formatter.FormatPydanticModel(className, fields, baseClass)
 	case "dataclass":
-		modelLines = g.formatter.FormatDataclass(className, fields, g.options.BaseClass)
+		modelLines = g.formatter.FormatDataclass(className, fields, baseClass)
 	case "class":
-		modelLines = g.generatePlainClass(className, fields, g.options.BaseClass)
+		modelLines = g.generatePlainClass(className, fields, baseClass)
 	case "namedtuple":
 		modelLines = g.generateNamedTuple(className, fields)
 	default:
-		modelLines = g.formatter.FormatPydanticModel(className, fields, g.options.BaseClass)
+		modelLines = g.formatter.FormatPydanticModel(className, fields, baseClass)
 	}
 
 	for _, line := range modelLines {
@@ -391,7 +542,6 @@ func (g *Generator) generateObjectModel(schema core.ObjectSchema) error {
 	}
 
 	g.output.WriteString("\n")
-	return nil
 }
 
 // generateStringEnum generates a Python enum for string values.
@@ -493,11 +643,26 @@ func (g *Generator) generateNamedTuple(name string, fields []Field) []string {
 
 // getSchemaTypeName returns the Python type name for a schema.
 func (g *Generator) getSchemaTypeName(schema core.Schema) string {
+	// Null and Any schemas have no distinguishing methods, so dispatch on the type
+	switch schema.Type() {
+	case core.TypeNull:
+		return g.typeMapper.MapSchemaType(core.TypeNull)
+	case core.TypeAny:
+		return g.anyTypeName()
+	}
+
+	// OptionalSchema's method set is a subset of ArraySchema's, so dispatch on the type
+	if optionalSchema, ok := schema.(core.OptionalSchema); ok && schema.Type() == core.TypeOptional {
+		if metadata := schema.Metadata(); metadata.Name != "" {
+			return g.typeMapper.FormatClassName(metadata.Name)
+		}
+		return g.optionalTypeName(optionalSchema)
+	}
+
 	switch s := schema.(type) {
 	case core.StringSchema:
-		enumValues := s.EnumValues()
-		if len(enumValues) > 0 && g.options.UseEnums {
-			metadata := s.Metadata()
+		// Only named enums have a generated enum class to refer to
+		if metadata := s.Metadata(); len(s.EnumValues()) > 0 && g.options.UseEnums && metadata.Name != "" {
 			return g.typeMapper.FormatClassName(metadata.Name)
 		}
 		return g.typeMapper.MapSchemaType(core.TypeString)
@@ -505,17 +670,39 @@ func (g *Generator) getSchemaTypeName(schema core.Schema) string {
 		return g.typeMapper.MapSchemaType(core.TypeInteger)
 	case core.NumberSchema:
 		return g.typeMapper.MapSchemaType(core.TypeNumber)
-	case core.BooleanSchema:
-		return g.typeMapper.MapSchemaType(core.TypeBoolean)
 	case core.ArraySchema:
-		elementType := "Any"
-		if itemSchema := s.ItemSchema(); itemSchema != nil {
-			elementType = g.getSchemaTypeName(itemSchema)
-		}
-		return g.typeMapper.FormatListType(elementType)
+		return g.arrayTypeName(s)
 	case core.ObjectSchema:
 		metadata := s.Metadata()
 		return g.typeMapper.FormatClassName(metadata.Name)
+	case core.MapSchema:
+		if metadata := s.Metadata(); metadata.Name != "" {
+			return g.typeMapper.FormatClassName(metadata.Name)
+		}
+		return g.mapTypeName(s)
+	case core.UnionSchema:
+		if metadata := s.Metadata(); metadata.Name != "" {
+			return g.typeMapper.FormatClassName(metadata.Name)
+		}
+		return g.unionTypeName(s)
+	case core.ResultSchema:
+		if metadata := s.Metadata(); metadata.Name != "" {
+			return g.typeMapper.FormatClassName(metadata.Name)
+		}
+		return g.inlineResultTypeName(s)
+	case core.TypeParameterSchema:
+		return s.ParameterName()
+	case core.GenericSchema:
+		return g.typeMapper.FormatClassName(s.Metadata().Name)
+	case core.InstanceSchema:
+		// Quoted as a forward reference, like plain references
+		return fmt.Sprintf("%q", g.instanceTypeName(s))
+	case core.RefSchema:
+		// Quoted as a forward reference, since the target may be the class being defined
+		return fmt.Sprintf("%q", g.typeMapper.FormatClassName(s.ReferenceName()))
+	case core.BooleanSchema:
+		// BooleanSchema has no distinguishing methods, so it must be matched last
+		return g.typeMapper.MapSchemaType(core.TypeBoolean)
 	default:
 		return "Any"
 	}
@@ -609,22 +796,227 @@ func (g *Generator) VisitService(schema core.ServiceSchema) error {
 	return nil
 }
 
+// VisitTopic handles topic schemas.
+func (g *Generator) VisitTopic(schema core.TopicSchema) error {
+	// Topic schemas are not directly supported in Python generation, like services
+	return nil
+}
+
+// VisitComponent handles component schemas.
+func (g *Generator) VisitComponent(schema core.ComponentSchema) error {
+	// Component schemas are not directly supported in Python generation, like services
+	return nil
+}
+
 // VisitUnion generates Python code for a union schema.
 func (g *Generator) VisitUnion(schema core.UnionSchema) error {
-	// Union schemas could be represented as Union types in Python
-	// For now, we'll generate a type alias to Any
 	metadata := schema.Metadata()
 	typeName := g.typeMapper.FormatClassName(metadata.Name)
 
 	// Add constraints as comments if enabled
 	if g.options.IncludeComments {
 		g.writeSchemaComments(metadata.Description, metadata.Examples, nil)
+		if discriminator := schema.Discriminator(); discriminator != "" {
+			g.output.WriteString(fmt.Sprintf("# Discriminator: %s\n", discriminator))
+		}
+	}
+
+	g.output.WriteString(fmt.Sprintf("%s = %s\n", typeName, g.unionTypeName(schema)))
+	return nil
+}
+
+// VisitRef generates Python code for a reference schema as an alias of the referenced type.
+func (g *Generator) VisitRef(schema core.RefSchema) error {
+	if schema.ReferenceName() == "" {
+		return fmt.Errorf("reference schema has no target name")
+	}
+
+	metadata := schema.Metadata()
+	typeName := g.typeMapper.FormatClassName(metadata.Name)
+
+	// Add constraints as comments if enabled
+	if g.options.IncludeComments {
+		g.writeSchemaComments(metadata.Description, metadata.Examples, nil)
+	}
+
+	targetType := g.typeMapper.FormatClassName(schema.ReferenceName())
+	if instance, ok := schema.(core.InstanceSchema); ok {
+		targetType = g.instanceTypeName(instance)
+	}
+
+	g.output.WriteString(fmt.Sprintf("%s = %s\n", typeName, targetType))
+	return nil
+}
+
+// VisitParameter generates Python code for a type parameter as a TypeVar.
+func (g *Generator) VisitParameter(schema core.TypeParameterSchema) error {
+	g.writeTypeVar(schema)
+	return nil
+}
+
+// VisitGeneric generates Python code for a generic template. Object templates
+// become models deriving from Generic[T]; other templates become generic aliases.
+func (g *Generator) VisitGeneric(schema core.GenericSchema) error {
+	metadata := schema.Metadata()
+	className := g.typeMapper.FormatClassName(metadata.Name)
+
+	params := schema.TypeParameters()
+	paramNames := make([]string, len(params))
+	for i, param := range params {
+		g.writeTypeVar(param)
+		paramNames[i] = param.ParameterName()
+	}
+	g.output.WriteString("\n")
+
+	objectSchema, ok := schema.Template().(core.ObjectSchema)
+	if !ok {
+		templateType := "Any"
+		if template := schema.Template(); template != nil {
+			templateType = g.getSchemaTypeName(template)
+		}
+		if g.options.IncludeComments {
+			g.writeSchemaComments(metadata.Description, metadata.Examples, nil)
+		}
+		g.output.WriteString(fmt.Sprintf("%s = %s\n", className, templateType))
+		return nil
+	}
+
+	// Add class docstring if enabled
+	if g.options.IncludeDocstrings && metadata.Description != "" {
+		docLines := g.formatter.FormatDocstring(metadata.Description, metadata.Examples, nil)
+		for _, line := range docLines {
+			g.output.WriteString(line + "\n")
+		}
 	}
 
-	g.output.WriteString(fmt.Sprintf("%s = Any  # Union type\n", typeName))
+	g.importManager.AddImport("from typing import Generic")
+	genericBase := fmt.Sprintf("Generic[%s]", strings.Join(paramNames, ", "))
+
+	baseClass := g.options.BaseClass
+	if baseClass == "" && (g.options.OutputStyle == "pydantic" || g.options.OutputStyle == "") {
+		baseClass = "BaseModel"
+	}
+	if baseClass != "" {
+		baseClass += ", "
+	}
+	g.writeModelWithBase(className, g.objectFields(objectSchema), baseClass+genericBase)
 	return nil
 }
 
+// writeTypeVar declares a type parameter as a TypeVar, bound to its constraint.
+func (g *Generator) writeTypeVar(param core.TypeParameterSchema) {
+	g.importManager.AddImport("from typing import TypeVar")
+	name := param.ParameterName()
+	if constraint := param.Constraint(); constraint != nil {
+		g.output.WriteString(fmt.Sprintf("%s = TypeVar(%q, bound=%s)\n", name, name, g.getSchemaTypeName(constraint)))
+		return
+	}
+	g.output.WriteString(fmt.Sprintf("%s = TypeVar(%q)\n", name, name))
+}
+
+// instanceTypeName returns the Python type of a generic instance, e.g. Page[User].
+func (g *Generator) instanceTypeName(instance core.InstanceSchema) string {
+	args := instance.TypeArguments()
+	argTypes := make([]string, len(args))
+	for i, arg := range args {
+		argTypes[i] = strings.Trim(g.getSchemaTypeName(arg), `"`)
+	}
+	return fmt.Sprintf("%s[%s]", g.typeMapper.FormatClassName(instance.GenericName()), strings.Join(argTypes, ", "))
+}
+
+// VisitResult generates Python code for a result schema as one model per side
+// ({Name}Ok holding "ok" and {Name}Err holding "err") and a Union of the two.
+func (g *Generator) VisitResult(schema core.ResultSchema) error {
+	metadata := schema.Metadata()
+	typeName := g.typeMapper.FormatClassName(metadata.Name)
+
+	// Add class docstring if enabled
+	if g.options.IncludeDocstrings && metadata.Description != "" {
+		docLines := g.formatter.FormatDocstring(metadata.Description, metadata.Examples, nil)
+		for _, line := range docLines {
+			g.output.WriteString(line + "\n")
+		}
+	}
+
+	g.writeResult(typeName, schema)
+	return nil
+}
+
+// writeResult writes the models of a result schema and the Union naming them.
+func (g *Generator) writeResult(typeName string, schema core.ResultSchema) {
+	okName := typeName + "Ok"
+	errName := typeName + "Err"
+	g.writeModel(okName, []Field{g.resultField("ok", schema.SuccessSchema())})
+	g.writeModel(errName, []Field{g.resultField("err", schema.ErrorSchema())})
+
+	unionType := g.typeMapper.FormatUnionType([]string{okName, errName})
+	if strings.HasPrefix(unionType, "Union[") {
+		g.importManager.AddImport("from typing import Union")
+	}
+	g.output.WriteString(fmt.Sprintf("%s = %s\n", typeName, unionType))
+}
+
+// inlineResultTypeName returns the name of an unnamed result schema, e.g.
+// UserNotFoundResult for a result of User or NotFound, and records the result
+// to be defined by inlineResultDefinitions.
+func (g *Generator) inlineResultTypeName(schema core.ResultSchema) string {
+	okType, errType := g.resultField("ok", schema.SuccessSchema()).Type, g.resultField("err", schema.ErrorSchema()).Type
+	name := g.typeMapper.FormatClassName(base.ToTypeIdentifier(okType) + base.ToTypeIdentifier(errType) + "Result")
+	for _, result := range g.results {
+		if result.name == name {
+			return name
+		}
+	}
+	g.results = append(g.results, inlineResult{name: name, schema: schema})
+	return name
+}
+
+// inlineResultDefinitions returns the code defining the inline results. The
+// payload types of a result are named before the result itself, so each result
+// is recorded after the inline results it uses.
+func (g *Generator) inlineResultDefinitions() string {
+	var definitions strings.Builder
+	for _, result := range g.results {
+		g.output.Reset()
+		g.writeResult(result.name, result.schema)
+		definitions.WriteString(g.output.String() + "\n")
+	}
+	g.output.Reset()
+	return definitions.String()
+}
+
+// resultField returns the required field holding one side of a result.
+func (g *Generator) resultField(name string, payload core.Schema) Field {
+	fieldType := "Any"
+	if payload != nil {
+		fieldType = g.getSchemaTypeName(payload)
+	}
+	return Field{
+		Name:     g.typeMapper.FormatFieldName(name),
+		Type:     fieldType,
+		Required: true,
+	}
+}
+
+// unionTypeName returns the Python type expression for a union schema's members.
+func (g *Generator) unionTypeName(schema core.UnionSchema) string {
+	members := schema.Schemas()
+	if len(members) == 0 {
+		return "Any"
+	}
+
+	memberTypes := make([]string, len(members))
+	for i, member := range members {
+		memberTypes[i] = g.getSchemaTypeName(member)
+	}
+
+	unionType := g.typeMapper.FormatUnionType(memberTypes)
+	if strings.HasPrefix(unionType, "Union[") {
+		g.importManager.AddImport("from typing import Union")
+	}
+	return unionType
+}
+
 // Clone creates a copy of the generator with new options.
 func (g *Generator) Clone(options map[string]any) (*Generator, error) {
 	newOptions := g.options.Clone()
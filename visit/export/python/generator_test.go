@@ -105,6 +105,9 @@ func (s *mockArraySchema) ContainsSchema() core.Schema {
 	panic("implement me")
 }
 
+func (s *mockArraySchema) PrefixItemSchemas() []core.Schema { return nil }
+func (s *mockArraySchema) RestItemSchema() core.Schema      { return nil }
+
 func (s *mockArraySchema) ItemSchema() core.Schema   { return s.items }
 func (s *mockArraySchema) MinItems() *int            { return s.minItems }
 func (s *mockArraySchema) MaxItems() *int            { return s.maxItems }
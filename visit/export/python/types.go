@@ -66,6 +66,14 @@ func (tm *TypeMapper) FormatListType(elementType string) string {
 	return fmt.Sprintf("List[%s]", elementType)
 }
 
+// FormatTupleType formats a tuple type according to the configured style.
+func (tm *TypeMapper) FormatTupleType(elementTypes []string) string {
+	if tm.options.TypeHintStyle == "builtin" && tm.isPython39Plus() {
+		return fmt.Sprintf("tuple[%s]", strings.Join(elementTypes, ", "))
+	}
+	return fmt.Sprintf("Tuple[%s]", strings.Join(elementTypes, ", "))
+}
+
 // FormatDictType formats a dict type according to the configured style.
 func (tm *TypeMapper) FormatDictType(keyType, valueType string) string {
 	if tm.options.TypeHintStyle == "builtin" && tm.isPython39Plus() {
@@ -85,6 +93,17 @@ func (tm *TypeMapper) FormatOptionalType(baseType string) string {
 	return fmt.Sprintf("Union[%s, None]", baseType)
 }
 
+// FormatUnionType formats a union of member types according to the configured style.
+func (tm *TypeMapper) FormatUnionType(memberTypes []string) string {
+	if len(memberTypes) == 1 {
+		return memberTypes[0]
+	}
+	if tm.options.TypeHintStyle == "builtin" && tm.isPython310Plus() {
+		return strings.Join(memberTypes, " | ")
+	}
+	return fmt.Sprintf("Union[%s]", strings.Join(memberTypes, ", "))
+}
+
 // FormatClassName formats a class name according to the naming convention.
 func (tm *TypeMapper) FormatClassName(name string) string {
 	if name == "" {
@@ -2,6 +2,7 @@ package typescript
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"defs.dev/schema/core"
@@ -147,25 +148,44 @@ func (g *Generator) VisitBoolean(s core.BooleanSchema) error {
 	return g.generateBooleanType(typeName, metadata)
 }
 
+// VisitNull generates TypeScript for null types.
+func (g *Generator) VisitNull(s core.NullSchema) error {
+	return g.generateAliasOrInline(s.Metadata(), g.mapper.MapSchemaType(core.TypeNull))
+}
+
+// VisitAny generates TypeScript for any types (unknown or any, depending on options).
+func (g *Generator) VisitAny(s core.AnySchema) error {
+	return g.generateAliasOrInline(s.Metadata(), g.mapper.MapSchemaType(core.TypeAny))
+}
+
 // VisitArray generates TypeScript for array types.
 func (g *Generator) VisitArray(s core.ArraySchema) error {
 	metadata := s.Metadata()
 	typeName := g.mapper.FormatTypeName(metadata.Name)
 
-	// Generate the item type
-	var itemType string
-	if itemSchema := s.ItemSchema(); itemSchema != nil {
-		itemGenerator := NewGenerator()
-		itemOutput, err := itemGenerator.Generate(itemSchema)
+	var arrayType string
+	if prefixItems := s.PrefixItemSchemas(); len(prefixItems) > 0 {
+		tupleType, err := g.tupleType(prefixItems, s.RestItemSchema())
 		if err != nil {
-			return fmt.Errorf("failed to generate item type: %w", err)
+			return err
 		}
-		itemType = strings.TrimSpace(string(itemOutput))
+		arrayType = tupleType
 	} else {
-		itemType = g.mapper.MapSchemaType(core.TypeAny)
-	}
+		// Generate the item type
+		var itemType string
+		if itemSchema := s.ItemSchema(); itemSchema != nil {
+			itemGenerator := NewGenerator()
+			itemOutput, err := itemGenerator.Generate(itemSchema)
+			if err != nil {
+				return fmt.Errorf("failed to generate item type: %w", err)
+			}
+			itemType = strings.TrimSpace(string(itemOutput))
+		} else {
+			itemType = g.mapper.MapSchemaType(core.TypeAny)
+		}
 
-	arrayType := g.mapper.FormatArrayType(itemType)
+		arrayType = g.mapper.FormatArrayType(itemType)
+	}
 
 	if typeName == "" || typeName == "UnnamedType" {
 		g.addSimpleType(arrayType)
@@ -188,6 +208,246 @@ func (g *Generator) VisitObject(s core.ObjectSchema) error {
 	return g.generateObjectType(typeName, s, metadata)
 }
 
+// VisitMap generates TypeScript for map types.
+func (g *Generator) VisitMap(s core.MapSchema) error {
+	metadata := s.Metadata()
+	typeName := g.mapper.FormatTypeName(metadata.Name)
+
+	valueType := g.mapper.MapSchemaType(core.TypeAny)
+	if valueSchema := s.ValueSchema(); valueSchema != nil {
+		var err error
+		valueType, err = g.generateMemberType(valueSchema)
+		if err != nil {
+			return fmt.Errorf("failed to generate map value type: %w", err)
+		}
+	}
+
+	recordType := fmt.Sprintf("Record<%s, %s>", g.mapKeyType(s.KeySchema()), valueType)
+
+	if typeName == "" || typeName == "UnnamedType" {
+		g.addSimpleType(recordType)
+		return nil
+	}
+
+	// Add JSDoc if enabled
+	if g.options.IncludeJSDoc && metadata.Description != "" {
+		jsdocLines := g.formatter.FormatJSDoc(metadata.Description, metadata.Examples, nil)
+		g.result = append(g.result, jsdocLines...)
+	}
+
+	typeLines := g.formatter.FormatType(typeName, recordType, true)
+	g.result = append(g.result, typeLines...)
+
+	return nil
+}
+
+// VisitOptional generates TypeScript for optional (nullable) types.
+func (g *Generator) VisitOptional(s core.OptionalSchema) error {
+	metadata := s.Metadata()
+	typeName := g.mapper.FormatTypeName(metadata.Name)
+
+	itemType := g.mapper.MapSchemaType(core.TypeAny)
+	if itemSchema := s.ItemSchema(); itemSchema != nil {
+		var err error
+		itemType, err = g.generateMemberType(itemSchema)
+		if err != nil {
+			return fmt.Errorf("failed to generate optional item type: %w", err)
+		}
+	}
+
+	nullableType := fmt.Sprintf("%s | null", itemType)
+
+	if typeName == "" || typeName == "UnnamedType" {
+		g.addSimpleType(nullableType)
+		return nil
+	}
+
+	// Add JSDoc if enabled
+	if g.options.IncludeJSDoc && metadata.Description != "" {
+		jsdocLines := g.formatter.FormatJSDoc(metadata.Description, metadata.Examples, nil)
+		g.result = append(g.result, jsdocLines...)
+	}
+
+	typeLines := g.formatter.FormatType(typeName, nullableType, true)
+	g.result = append(g.result, typeLines...)
+
+	return nil
+}
+
+// VisitUnion generates TypeScript for union types.
+func (g *Generator) VisitUnion(s core.UnionSchema) error {
+	metadata := s.Metadata()
+	typeName := g.mapper.FormatTypeName(metadata.Name)
+
+	members := s.Schemas()
+	memberTypes := make([]string, 0, len(members))
+	for i, member := range members {
+		memberType, err := g.generateMemberType(member)
+		if err != nil {
+			return fmt.Errorf("failed to generate union branch %d: %w", i, err)
+		}
+		memberTypes = append(memberTypes, memberType)
+	}
+
+	unionType := "never"
+	if len(memberTypes) > 0 {
+		unionType = strings.Join(memberTypes, " | ")
+	}
+
+	if typeName == "" || typeName == "UnnamedType" {
+		g.addSimpleType(unionType)
+		return nil
+	}
+
+	// Add JSDoc if enabled
+	if g.options.IncludeJSDoc && metadata.Description != "" {
+		jsdocLines := g.formatter.FormatJSDoc(metadata.Description, metadata.Examples, nil)
+		g.result = append(g.result, jsdocLines...)
+	}
+
+	typeLines := g.formatter.FormatType(typeName, unionType, true)
+	g.result = append(g.result, typeLines...)
+
+	return nil
+}
+
+// VisitRef generates TypeScript for reference types.
+// References are emitted by type name so recursive types are never inlined.
+func (g *Generator) VisitRef(s core.RefSchema) error {
+	metadata := s.Metadata()
+	typeName := g.mapper.FormatTypeName(metadata.Name)
+
+	if s.ReferenceName() == "" {
+		return fmt.Errorf("reference schema has no target name")
+	}
+	refType := g.mapper.FormatTypeName(s.ReferenceName())
+	if instance, ok := s.(core.InstanceSchema); ok {
+		instanceType, err := g.instanceType(instance)
+		if err != nil {
+			return err
+		}
+		refType = instanceType
+	}
+
+	if typeName == "" || typeName == "UnnamedType" {
+		g.addSimpleType(refType)
+		return nil
+	}
+
+	// Add JSDoc if enabled
+	if g.options.IncludeJSDoc && metadata.Description != "" {
+		jsdocLines := g.formatter.FormatJSDoc(metadata.Description, metadata.Examples, nil)
+		g.result = append(g.result, jsdocLines...)
+	}
+
+	typeLines := g.formatter.FormatType(typeName, refType, true)
+	g.result = append(g.result, typeLines...)
+
+	return nil
+}
+
+// VisitParameter generates TypeScript for a type parameter, which is referred
+// to by its name inside a generic declaration.
+func (g *Generator) VisitParameter(s core.TypeParameterSchema) error {
+	g.addSimpleType(s.ParameterName())
+	return nil
+}
+
+// VisitGeneric generates TypeScript for a generic template as a generic
+// interface, or a generic type alias when the template is not an object.
+func (g *Generator) VisitGeneric(s core.GenericSchema) error {
+	metadata := s.Metadata()
+	typeName := g.mapper.FormatTypeName(metadata.Name)
+	if typeName == "" || typeName == "UnnamedType" {
+		return fmt.Errorf("generic schema must be named")
+	}
+
+	params := s.TypeParameters()
+	declarations := make([]string, len(params))
+	for i, param := range params {
+		declarations[i] = param.ParameterName()
+		if constraint := param.Constraint(); constraint != nil {
+			constraintType, err := g.generateMemberType(constraint)
+			if err != nil {
+				return fmt.Errorf("failed to generate constraint of %s: %w", param.ParameterName(), err)
+			}
+			declarations[i] += " extends " + constraintType
+		}
+	}
+	declaredName := fmt.Sprintf("%s<%s>", typeName, strings.Join(declarations, ", "))
+
+	template := s.Template()
+	if objectSchema, ok := template.(core.ObjectSchema); ok {
+		return g.generateObjectType(declaredName, objectSchema, metadata)
+	}
+
+	templateType := g.mapper.MapSchemaType(core.TypeAny)
+	if template != nil {
+		var err error
+		if templateType, err = g.generatePropertyType(template); err != nil {
+			return fmt.Errorf("failed to generate generic template: %w", err)
+		}
+	}
+
+	// Add JSDoc if enabled
+	if g.options.IncludeJSDoc && metadata.Description != "" {
+		jsdocLines := g.formatter.FormatJSDoc(metadata.Description, metadata.Examples, nil)
+		g.result = append(g.result, jsdocLines...)
+	}
+
+	typeLines := g.formatter.FormatType(declaredName, templateType, true)
+	g.result = append(g.result, typeLines...)
+	return nil
+}
+
+// instanceType generates the TypeScript type for a generic instance, e.g. Page<User>.
+func (g *Generator) instanceType(instance core.InstanceSchema) (string, error) {
+	args := instance.TypeArguments()
+	argTypes := make([]string, len(args))
+	for i, arg := range args {
+		argType, err := g.generateMemberType(arg)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate type argument %d of %s: %w", i, instance.GenericName(), err)
+		}
+		argTypes[i] = argType
+	}
+	return fmt.Sprintf("%s<%s>", g.mapper.FormatTypeName(instance.GenericName()), strings.Join(argTypes, ", ")), nil
+}
+
+// VisitResult generates TypeScript for result types as a union of
+// { ok: T } and { err: E }, narrowed with the "ok" in / "err" in checks.
+func (g *Generator) VisitResult(s core.ResultSchema) error {
+	metadata := s.Metadata()
+	typeName := g.mapper.FormatTypeName(metadata.Name)
+
+	okType, err := g.resultPayloadType(s.SuccessSchema())
+	if err != nil {
+		return fmt.Errorf("failed to generate result success type: %w", err)
+	}
+	errType, err := g.resultPayloadType(s.ErrorSchema())
+	if err != nil {
+		return fmt.Errorf("failed to generate result error type: %w", err)
+	}
+
+	resultType := fmt.Sprintf("{ ok: %s } | { err: %s }", okType, errType)
+
+	if typeName == "" || typeName == "UnnamedType" {
+		g.addSimpleType(resultType)
+		return nil
+	}
+
+	// Add JSDoc if enabled
+	if g.options.IncludeJSDoc && metadata.Description != "" {
+		jsdocLines := g.formatter.FormatJSDoc(metadata.Description, metadata.Examples, nil)
+		g.result = append(g.result, jsdocLines...)
+	}
+
+	typeLines := g.formatter.FormatType(typeName, resultType, true)
+	g.result = append(g.result, typeLines...)
+
+	return nil
+}
+
 // Helper methods for generating different TypeScript constructs
 
 // addSimpleType adds a simple type without declaration.
@@ -280,6 +540,39 @@ func (g *Generator) generateObjectType(name string, s core.ObjectSchema, metadat
 		g.result = append(g.result, jsdocLines...)
 	}
 
+	composed, ok, err := g.composedObjectType(name, s)
+	if err != nil {
+		return err
+	}
+	if ok {
+		g.result = append(g.result, composed...)
+		return nil
+	}
+
+	props, err := g.objectProperties(s)
+	if err != nil {
+		return err
+	}
+
+	// Generate interface or type based on options
+	switch g.options.OutputStyle {
+	case "interface":
+		interfaceLines := g.formatter.FormatInterface(name, props, true)
+		g.result = append(g.result, interfaceLines...)
+	case "type":
+		typeDefinition := g.formatObjectAsType(props)
+		typeLines := g.formatter.FormatType(name, typeDefinition, true)
+		g.result = append(g.result, typeLines...)
+	default:
+		interfaceLines := g.formatter.FormatInterface(name, props, true)
+		g.result = append(g.result, interfaceLines...)
+	}
+
+	return nil
+}
+
+// objectProperties generates the TypeScript properties of an object schema.
+func (g *Generator) objectProperties(s core.ObjectSchema) ([]Property, error) {
 	properties := s.Properties()
 	required := s.Required()
 	requiredMap := make(map[string]bool)
@@ -292,7 +585,7 @@ func (g *Generator) generateObjectType(name string, s core.ObjectSchema, metadat
 	for propName, propSchema := range properties {
 		propType, err := g.generatePropertyType(propSchema)
 		if err != nil {
-			return fmt.Errorf("failed to generate property %s: %w", propName, err)
+			return nil, fmt.Errorf("failed to generate property %s: %w", propName, err)
 		}
 
 		propMetadata := propSchema.Metadata()
@@ -307,21 +600,114 @@ func (g *Generator) generateObjectType(name string, s core.ObjectSchema, metadat
 		}
 		props = append(props, prop)
 	}
+	return props, nil
+}
 
-	// Generate interface or type based on options
-	switch g.options.OutputStyle {
-	case "interface":
-		interfaceLines := g.formatter.FormatInterface(name, props, true)
-		g.result = append(g.result, interfaceLines...)
-	case "type":
-		typeDefinition := g.formatObjectAsType(props)
-		typeLines := g.formatter.FormatType(name, typeDefinition, true)
-		g.result = append(g.result, typeLines...)
-	default:
-		interfaceLines := g.formatter.FormatInterface(name, props, true)
-		g.result = append(g.result, interfaceLines...)
+// composedObjectType expresses an object built by composition in terms of its
+// named sources: an interface extending its base, Pick<> and Omit<> of a
+// source, or an intersection of merged schemas. It reports false when the
+// sources cannot be referenced by name, and the object is emitted flattened.
+func (g *Generator) composedObjectType(name string, s core.ObjectSchema) ([]string, bool, error) {
+	composition := s.Metadata().Composition
+	if composition == nil || len(composition.Sources) == 0 {
+		return nil, false, nil
+	}
+
+	sourceTypes := make([]string, len(composition.Sources))
+	for i, source := range composition.Sources {
+		sourceName := source.Metadata().Name
+		if ref, ok := source.(core.RefSchema); ok {
+			sourceName = ref.ReferenceName()
+		}
+		if sourceName != "" {
+			sourceTypes[i] = g.mapper.FormatTypeName(sourceName)
+		}
+	}
+
+	switch composition.Kind {
+	case core.CompositionExtend:
+		base, baseIsObject := composition.Sources[0].(core.ObjectSchema)
+		if len(composition.Sources) != 2 {
+			return nil, false, nil
+		}
+		extension, extensionIsObject := composition.Sources[1].(core.ObjectSchema)
+		if sourceTypes[0] == "" || !extensionIsObject {
+			return nil, false, nil
+		}
+		props, err := g.objectProperties(extension)
+		if err != nil {
+			return nil, true, err
+		}
+
+		// Redefined base properties are omitted from the base so the types never clash
+		baseType := sourceTypes[0]
+		if baseIsObject {
+			var overridden []string
+			for propName := range extension.Properties() {
+				if _, exists := base.Properties()[propName]; exists {
+					overridden = append(overridden, propName)
+				}
+			}
+			if len(overridden) > 0 {
+				sort.Strings(overridden)
+				baseType = fmt.Sprintf("Omit<%s, %s>", baseType, g.keyUnion(overridden))
+			}
+		}
+
+		if g.options.OutputStyle == "type" {
+			return g.formatter.FormatType(name, baseType+" & "+g.formatObjectAsType(props), true), true, nil
+		}
+		return g.formatter.FormatInterface(name+" extends "+baseType, props, true), true, nil
+
+	case core.CompositionPick, core.CompositionOmit:
+		if sourceTypes[0] == "" {
+			return nil, false, nil
+		}
+		utility := "Pick"
+		if composition.Kind == core.CompositionOmit {
+			utility = "Omit"
+		}
+		typeDefinition := fmt.Sprintf("%s<%s, %s>", utility, sourceTypes[0], g.keyUnion(composition.Keys))
+		return g.formatter.FormatType(name, typeDefinition, true), true, nil
+
+	case core.CompositionMerge, core.CompositionAllOf:
+		for _, sourceType := range sourceTypes {
+			if sourceType == "" {
+				return nil, false, nil
+			}
+		}
+		return g.formatter.FormatType(name, strings.Join(sourceTypes, " & "), true), true, nil
+	}
+
+	return nil, false, nil
+}
+
+// keyUnion formats property names as a union of string literal types.
+func (g *Generator) keyUnion(names []string) string {
+	literals := make([]string, len(names))
+	for i, name := range names {
+		literals[i] = fmt.Sprintf("%q", name)
+	}
+	return strings.Join(literals, " | ")
+}
+
+// generateAliasOrInline emits a named type alias, or the bare type for unnamed schemas.
+func (g *Generator) generateAliasOrInline(metadata core.SchemaMetadata, typeStr string) error {
+	typeName := g.mapper.FormatTypeName(metadata.Name)
+	if typeName == "" || typeName == "UnnamedType" {
+		g.addSimpleType(typeStr)
+		return nil
+	}
+
+	// Add JSDoc if enabled
+	if g.options.IncludeJSDoc && metadata.Description != "" {
+		jsdocLines := g.formatter.FormatJSDoc(metadata.Description, metadata.Examples, nil)
+		g.result = append(g.result, jsdocLines...)
 	}
 
+	typeLines := g.formatter.FormatType(typeName, typeStr, true)
+	g.result = append(g.result, typeLines...)
+
 	return nil
 }
 
@@ -335,6 +721,65 @@ func (g *Generator) generatePropertyType(propSchema core.Schema) (string, error)
 	return strings.TrimSpace(string(propOutput)), nil
 }
 
+// generateMemberType generates the TypeScript type for a union member.
+// Named members are referenced by type name; unnamed members are inlined.
+func (g *Generator) generateMemberType(member core.Schema) (string, error) {
+	if name := member.Metadata().Name; name != "" {
+		return g.mapper.FormatTypeName(name), nil
+	}
+	return g.generatePropertyType(member)
+}
+
+// tupleType generates the TypeScript tuple type for positional item schemas.
+func (g *Generator) tupleType(prefixItems []core.Schema, restSchema core.Schema) (string, error) {
+	elementTypes := make([]string, len(prefixItems))
+	for i, itemSchema := range prefixItems {
+		elementType, err := g.generateMemberType(itemSchema)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate tuple item %d type: %w", i, err)
+		}
+		elementTypes[i] = elementType
+	}
+
+	restType := ""
+	if restSchema != nil {
+		var err error
+		if restType, err = g.generateMemberType(restSchema); err != nil {
+			return "", fmt.Errorf("failed to generate tuple rest type: %w", err)
+		}
+	}
+
+	return g.mapper.FormatTupleType(elementTypes, restType), nil
+}
+
+// resultPayloadType generates the TypeScript type for one side of a result.
+func (g *Generator) resultPayloadType(payload core.Schema) (string, error) {
+	if payload == nil {
+		return g.mapper.MapSchemaType(core.TypeAny), nil
+	}
+	return g.generateMemberType(payload)
+}
+
+// mapKeyType returns the TypeScript key type for a map key schema.
+// Enumerated string keys become a union of string literals.
+func (g *Generator) mapKeyType(keySchema core.Schema) string {
+	switch k := keySchema.(type) {
+	case core.StringSchema:
+		if enumValues := k.EnumValues(); len(enumValues) > 0 {
+			literals := make([]string, len(enumValues))
+			for i, v := range enumValues {
+				literals[i] = fmt.Sprintf("\"%s\"", v)
+			}
+			return strings.Join(literals, " | ")
+		}
+		return "string"
+	case core.IntegerSchema, core.NumberSchema:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
 // formatObjectAsType formats object properties as a type definition.
 func (g *Generator) formatObjectAsType(props []Property) string {
 	if len(props) == 0 {
@@ -63,6 +63,19 @@ func (tm *TypeMapper) FormatArrayType(elementType string) string {
 	}
 }
 
+// FormatTupleType formats a tuple type from its element types.
+// A non-empty rest type is appended as a spread of an array of that type.
+func (tm *TypeMapper) FormatTupleType(elementTypes []string, restType string) string {
+	elements := append([]string(nil), elementTypes...)
+	if restType != "" {
+		if strings.Contains(restType, "|") && tm.options.ArrayStyle != "Array<T>" {
+			restType = fmt.Sprintf("(%s)", restType)
+		}
+		elements = append(elements, "..."+tm.FormatArrayType(restType))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(elements, ", "))
+}
+
 // FormatOptionalProperty formats a property as optional if needed.
 func (tm *TypeMapper) FormatOptionalProperty(propertyType string, isRequired bool) string {
 	if isRequired || !tm.options.UseOptionalProperties {
@@ -0,0 +1,193 @@
+// Package walker traverses schema trees depth-first.
+//
+// Every schema in the tree is reached with its parent and its JSON Pointer
+// path from the root, such as /properties/address/properties/street or
+// /methods/get/inputs/id. Besides the containers, the walk descends into
+// function inputs, outputs and errors, service methods, topic parts,
+// component services and topics, and generic templates.
+//
+// Walk calls hooks before and after the children of each schema. Rewrite
+// rebuilds the tree bottom-up and returns a new tree, leaving the original
+// untouched:
+//
+//	stripped, err := walker.Rewrite(schema, func(node *walker.Node) (core.Schema, error) {
+//		metadata := node.Schema.Metadata()
+//		metadata.Description = ""
+//		return schemas.WithMetadata(node.Schema, metadata), nil
+//	})
+//
+// Children are found through the core interfaces with structure.Children, so
+// any implementation of them is descended into; references are leaves. Rewrite
+// rebuilds parents with schemas.MapChildren and fails for parents defined
+// outside the schemas package whose children were rewritten.
+package walker
+
+import (
+	"errors"
+	"fmt"
+
+	"defs.dev/schema/core"
+	"defs.dev/schema/core/structure"
+	"defs.dev/schema/schemas"
+)
+
+// SkipChildren can be returned by a pre hook to skip the children of the
+// current schema. Its post hook is still called.
+var SkipChildren = errors.New("skip children")
+
+// Stop can be returned by any hook to end the walk early. Walk and Rewrite
+// then return without an error.
+var Stop = errors.New("stop walk")
+
+// Node is a schema reached during a walk.
+type Node struct {
+	Schema core.Schema
+
+	// Parent is the node of the enclosing schema, or nil at the root
+	Parent *Node
+
+	// Path is the JSON Pointer of the schema from the root, which is ""
+	Path string
+}
+
+// Depth returns the number of ancestors of the node.
+func (n *Node) Depth() int {
+	depth := 0
+	for parent := n.Parent; parent != nil; parent = parent.Parent {
+		depth++
+	}
+	return depth
+}
+
+// Hook is called for each node of a walk.
+type Hook func(node *Node) error
+
+// Hooks are the callbacks of Walk. Either may be nil.
+type Hooks struct {
+	// Pre is called before the children of a schema are walked
+	Pre Hook
+
+	// Post is called after the children of a schema have been walked
+	Post Hook
+}
+
+// Walk traverses schema depth-first. Errors returned by hooks other than
+// SkipChildren and Stop end the walk and are returned with the path of the
+// node they were returned for.
+func Walk(schema core.Schema, hooks Hooks) error {
+	if schema == nil {
+		return nil
+	}
+	err := walk(&Node{Schema: schema}, hooks)
+	if errors.Is(err, Stop) {
+		return nil
+	}
+	return err
+}
+
+func walk(node *Node, hooks Hooks) error {
+	skip := false
+	if hooks.Pre != nil {
+		if err := hooks.Pre(node); err != nil {
+			if !errors.Is(err, SkipChildren) {
+				return nodeError(node, err)
+			}
+			skip = true
+		}
+	}
+
+	if !skip {
+		for _, child := range structure.Children(node.Schema) {
+			if err := walk(&Node{Schema: child.Schema, Parent: node, Path: node.Path + child.Pointer}, hooks); err != nil {
+				return err
+			}
+		}
+	}
+
+	if hooks.Post != nil {
+		if err := hooks.Post(node); err != nil && !errors.Is(err, SkipChildren) {
+			return nodeError(node, err)
+		}
+	}
+	return nil
+}
+
+// RewriteFunc returns the replacement of a schema. The schema of the node
+// already holds the rewritten children; returning it, or nil, keeps it.
+type RewriteFunc func(node *Node) (core.Schema, error)
+
+// Rewrite returns a copy of schema in which every schema has been passed
+// through fn, children before their parents. Parents of rewritten schemas are
+// rebuilt, everything else is shared with the original tree. When fn returns
+// Stop, the rewrites made so far are kept and the rest of the tree is left as
+// is.
+func Rewrite(schema core.Schema, fn RewriteFunc) (core.Schema, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	r := &rewriter{fn: fn}
+	result, err := r.rewrite(&Node{Schema: schema})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type rewriter struct {
+	fn      RewriteFunc
+	stopped bool
+}
+
+func (r *rewriter) rewrite(node *Node) (core.Schema, error) {
+	rewritten := map[string]core.Schema{}
+	for _, child := range structure.Children(node.Schema) {
+		if r.stopped {
+			break
+		}
+		result, err := r.rewrite(&Node{Schema: child.Schema, Parent: node, Path: node.Path + child.Pointer})
+		if err != nil {
+			return nil, err
+		}
+		if result != child.Schema {
+			rewritten[child.Pointer] = result
+		}
+	}
+
+	rebuilt := node.Schema
+	if len(rewritten) > 0 {
+		rebuilt = schemas.MapChildren(node.Schema, func(pointer string, child core.Schema) core.Schema {
+			if result, ok := rewritten[pointer]; ok {
+				delete(rewritten, pointer)
+				return result
+			}
+			return child
+		})
+		if len(rewritten) > 0 {
+			return nil, nodeError(node, fmt.Errorf("cannot rebuild %T with rewritten children", node.Schema))
+		}
+	}
+	if r.stopped {
+		return rebuilt, nil
+	}
+
+	result, err := r.fn(&Node{Schema: rebuilt, Parent: node.Parent, Path: node.Path})
+	switch {
+	case errors.Is(err, Stop):
+		r.stopped = true
+		return rebuilt, nil
+	case err != nil:
+		return nil, nodeError(node, err)
+	case result == nil:
+		return rebuilt, nil
+	default:
+		return result, nil
+	}
+}
+
+func nodeError(node *Node, err error) error {
+	path := node.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}